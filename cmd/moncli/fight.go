@@ -0,0 +1,410 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/tapmon/tapmond/fightmons"
+	"github.com/tapmon/tapmond/tapmonrpc"
+)
+
+var fightCommands = &commandGroup{
+	description: "interact with fights",
+	commands: map[string]*command{
+		"verify": {
+			usage: "verify [--relay url]... [--file dump] " +
+				"<match_id>",
+			description: "independently verify the outcome of a " +
+				"fight",
+			run: fightVerify,
+		},
+		"watch": {
+			usage: "watch [--rpcserver host] [--relay url]... " +
+				"[--file dump] <match_id>",
+			description: "watch a fight live as its rounds are " +
+				"verified by tapmond or ourselves",
+			run: fightWatch,
+		},
+		"practice": {
+			usage: "practice [--difficulty level] " +
+				"[--mon id[:level:nonce]]...",
+			description: "practice a fight offline against a " +
+				"local bot",
+			run: fightPractice,
+		},
+	},
+}
+
+const (
+	// hpBarWidth is the number of characters of a full hp bar.
+	hpBarWidth = 20
+)
+
+func fightVerify(args []string) error {
+	flags := flag.NewFlagSet("fight verify", flag.ContinueOnError)
+	var relays stringSlice
+	flags.Var(&relays, "relay", "relay to fetch the match events from, "+
+		"can be given multiple times")
+	file := flags.String("file", "", "json dump of the match events to "+
+		"verify instead of fetching them from relays")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout for "+
+		"fetching the events from relays")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: moncli fight verify [--relay url]... " +
+			"[--file dump] <match_id>")
+	}
+	matchId := flags.Arg(0)
+
+	var (
+		events []*nostr.Event
+		err    error
+	)
+	switch {
+	case *file != "":
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		events, err = fightmons.ReadMatchEvents(f)
+		if err != nil {
+			return fmt.Errorf("unable to read events: %w", err)
+		}
+
+	case len(relays) > 0:
+		ctx, cancel := context.WithTimeout(
+			context.Background(), *timeout,
+		)
+		defer cancel()
+
+		events, err = fightmons.FetchMatchEvents(ctx, relays, matchId)
+		if err != nil {
+			return fmt.Errorf("unable to fetch events: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("either --relay or --file is required")
+	}
+
+	// There is no way to verify mon ownership from the events alone yet,
+	// so we verify without and report it as unverified.
+	verified, err := fightmons.VerifyMatch(matchId, events, nil)
+	if err != nil {
+		return fmt.Errorf("match verification failed: %w", err)
+	}
+
+	printVerifiedMatch(os.Stdout, verified)
+
+	return nil
+}
+
+func printVerifiedMatch(w io.Writer, verified *fightmons.VerifiedMatch) {
+	fmt.Fprintf(w, "match %s\n", verified.MatchId)
+	for _, side := range []fightmons.Side{
+		fightmons.SideHost, fightmons.SideChallenger,
+	} {
+		stats := verified.Battle().PartyStats()[side]
+		fmt.Fprintf(w, "%-10s %s\n", side, verified.Players[side])
+		for i, mon := range verified.Parties[side] {
+			fmt.Fprintf(w, "%-10s mon %d %s level %d (%v)\n", "", i,
+				mon.Id, mon.Level, stats[i])
+		}
+	}
+	fmt.Fprintln(w)
+
+	for _, round := range verified.Rounds {
+		fmt.Fprintln(w, round)
+	}
+	fmt.Fprintln(w)
+
+	if len(verified.Ignored) > 0 {
+		fmt.Fprintf(w, "ignored %d events that anyone could have "+
+			"published\n", len(verified.Ignored))
+	}
+	if len(verified.Unapplied) > 0 {
+		fmt.Fprintf(w, "%d events could not be applied to the match\n",
+			len(verified.Unapplied))
+	}
+	if len(verified.Disputed) > 0 {
+		fmt.Fprintf(w, "disputed: %d results signed by the players "+
+			"don't follow from the events\n",
+			len(verified.Disputed))
+	}
+	if !verified.OwnershipVerified {
+		fmt.Fprintln(w, "mon ownership: not verified")
+	}
+
+	receipts := verified.Receipts()
+	switch {
+	case verified.CoSigned():
+		fmt.Fprintln(w, "result: signed by both players")
+
+	case receipts[fightmons.SideHost] != nil:
+		fmt.Fprintln(w, "result: only signed by host")
+
+	case receipts[fightmons.SideChallenger] != nil:
+		fmt.Fprintln(w, "result: only signed by challenger")
+
+	default:
+		fmt.Fprintln(w, "result: not signed")
+	}
+
+	printVerifiedResult(w, verified)
+}
+
+// printVerifiedResult prints the verified winner of the match.
+func printVerifiedResult(w io.Writer, verified *fightmons.VerifiedMatch) {
+	if verified.Winner == fightmons.SideNone {
+		fmt.Fprintf(w, "verified result: draw after %d rounds\n",
+			len(verified.Rounds))
+		return
+	}
+	fmt.Fprintf(w, "verified winner: %v %s after %d rounds%s\n",
+		verified.Winner, verified.WinnerPubKey(), len(verified.Rounds),
+		timeoutSuffix(verified.TimedOut()))
+}
+
+// timeoutSuffix returns the suffix of a winner line of a match that was won
+// by timeout.
+func timeoutSuffix(timedOut bool) string {
+	if !timedOut {
+		return ""
+	}
+
+	return " by timeout"
+}
+
+func fightWatch(args []string) error {
+	flags := flag.NewFlagSet("fight watch", flag.ContinueOnError)
+	rpcServer := rpcServerFlag(flags)
+	var relays stringSlice
+	flags.Var(&relays, "relay", "relay to receive the match events from "+
+		"instead of watching the match through tapmond, can be given "+
+		"multiple times")
+	file := flags.String("file", "", "json dump of the match events to "+
+		"replay instead of watching the match through tapmond")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: moncli fight watch [--rpcserver " +
+			"host] [--relay url]... [--file dump] <match_id>")
+	}
+	matchId := flags.Arg(0)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var (
+		updates   <-chan *fightmons.MatchUpdate
+		streamErr = func() error { return nil }
+	)
+	switch {
+	case *file != "":
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		dump, err := fightmons.ReadMatchEvents(f)
+		if err != nil {
+			return fmt.Errorf("unable to read events: %w", err)
+		}
+		replay := make(chan *nostr.Event, len(dump))
+		for _, ev := range dump {
+			replay <- ev
+		}
+		close(replay)
+		updates = fightmons.WatchMatch(ctx, matchId, replay)
+
+	case len(relays) > 0:
+		events, err := fightmons.SubscribeMatchEvents(
+			ctx, relays, matchId,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to subscribe to events: %w",
+				err)
+		}
+		updates = fightmons.WatchMatch(ctx, matchId, events)
+
+	default:
+		client, cleanup, err := connectDaemon(*rpcServer)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		updates, streamErr, err = watchDaemonMatch(ctx, client, matchId)
+		if err != nil {
+			return err
+		}
+	}
+
+	renderer := &matchRenderer{w: os.Stdout}
+	for update := range updates {
+		renderer.render(update)
+	}
+
+	switch {
+	case renderer.finished:
+		return nil
+
+	case ctx.Err() != nil:
+		return ctx.Err()
+
+	case streamErr() != nil:
+		return streamErr()
+
+	default:
+		return fmt.Errorf("match %v didn't finish", matchId)
+	}
+}
+
+// watchDaemonMatch streams the updates of a match that tapmond watches. The
+// returned function returns the error that ended the stream once the
+// updates are closed.
+func watchDaemonMatch(ctx context.Context, client tapmonrpc.TapmonClient,
+	matchId string) (<-chan *fightmons.MatchUpdate, func() error, error) {
+
+	stream, err := client.WatchMatch(ctx, &tapmonrpc.WatchMatchRequest{
+		MatchId: matchId,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to watch match: %w", err)
+	}
+
+	var (
+		updates   = make(chan *fightmons.MatchUpdate)
+		streamErr error
+	)
+	go func() {
+		defer close(updates)
+
+		for {
+			rpcUpdate, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				streamErr = fmt.Errorf("unable to receive match "+
+					"update: %w", err)
+				return
+			}
+
+			select {
+			case updates <- matchUpdateFromRpc(rpcUpdate):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, func() error { return streamErr }, nil
+}
+
+// matchRenderer renders the updates of a watched match as text.
+type matchRenderer struct {
+	w io.Writer
+
+	players [2]string
+	stats   [2][]fightmons.Stats
+
+	finished bool
+}
+
+func (r *matchRenderer) render(update *fightmons.MatchUpdate) {
+	switch update.Type {
+	case fightmons.UpdateLobbyOpened:
+		fmt.Fprintf(r.w, "lobby opened by %s\n", update.Event.PubKey)
+
+	case fightmons.UpdateChallenged:
+		fmt.Fprintf(r.w, "challenged by %s\n", update.Event.PubKey)
+
+	case fightmons.UpdateAccepted:
+		battle, err := fightmons.NewBattle(
+			update.Parties[fightmons.SideHost],
+			update.Parties[fightmons.SideChallenger],
+		)
+		if err != nil {
+			fmt.Fprintf(r.w, "invalid parties: %v\n", err)
+			return
+		}
+		r.players = update.Players
+		r.stats = battle.PartyStats()
+		fmt.Fprintf(r.w, "%s accepted %s\n",
+			r.players[fightmons.SideHost],
+			r.players[fightmons.SideChallenger])
+
+	case fightmons.UpdateStarted:
+		fmt.Fprintln(r.w, "match started")
+		r.renderHP(nil)
+
+	case fightmons.UpdateCommitted:
+		fmt.Fprintf(r.w, "%v committed to round %d\n", update.Side,
+			update.RoundId)
+
+	case fightmons.UpdateRoundResult:
+		fmt.Fprintln(r.w, update.Result)
+		r.renderHP(update.Result)
+
+	case fightmons.UpdateFinished:
+		r.finished = true
+		if update.Winner == fightmons.SideNone {
+			fmt.Fprintf(r.w, "draw after %d rounds\n",
+				update.RoundId)
+			return
+		}
+		fmt.Fprintf(r.w, "winner: %v %s after %d rounds%s\n",
+			update.Winner, r.players[update.Winner], update.RoundId,
+			timeoutSuffix(update.TimedOut))
+
+	case fightmons.UpdateResultSigned:
+		fmt.Fprintf(r.w, "%v signed the result\n", update.Side)
+	}
+}
+
+// renderHP renders the hp bars of all fightmons after the given round, or
+// full bars before the first round. The active fightmons are marked.
+func (r *matchRenderer) renderHP(result *fightmons.RoundResult) {
+	for _, side := range []fightmons.Side{
+		fightmons.SideHost, fightmons.SideChallenger,
+	} {
+		for i, stats := range r.stats[side] {
+			hp, active := stats.HP, i == 0
+			if result != nil {
+				hp = result.PartyHP[side][i]
+				active = i == result.Active[side]
+			}
+
+			marker := " "
+			if active {
+				marker = "*"
+			}
+			fmt.Fprintf(r.w, "  %-10s %s mon %d %s\n", side, marker,
+				i, hpBar(hp, stats.HP))
+		}
+	}
+}
+
+// hpBar renders the hp of a fightmon as a bar.
+func hpBar(hp, maxHP int) string {
+	filled := 0
+	if maxHP > 0 {
+		filled = hp * hpBarWidth / maxHP
+	}
+
+	return fmt.Sprintf("[%s%s] %d/%d", strings.Repeat("#", filled),
+		strings.Repeat("-", hpBarWidth-filled), hp, maxHP)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond"
+	"github.com/tapmon/tapmond/fightmons"
+	"github.com/tapmon/tapmond/tapmonrpc"
+	"google.golang.org/grpc"
+)
+
+const (
+	testMatchFile = "testdata/match.jsonl"
+
+	testMatchId = "660492b203a36f986fa85077c931cab1120041b06d5cfde12e025de" +
+		"774de2082"
+
+	testChallenger = "599923d9258d0e045538934906506e08772d5afa9699de10ecb" +
+		"1d0fbe19ce27b"
+)
+
+// TestFightVerify tests that a match dump is verified by the fight verify
+// command and that the verified result is printed.
+func TestFightVerify(t *testing.T) {
+	err := run([]string{
+		"fight", "verify", "--file", testMatchFile, testMatchId,
+	})
+	require.NoError(t, err)
+
+	// A dump doesn't verify as another match.
+	err = run([]string{
+		"fight", "verify", "--file", testMatchFile, "other",
+	})
+	require.ErrorContains(t, err, "never started")
+
+	f, err := os.Open(testMatchFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	events, err := fightmons.ReadMatchEvents(f)
+	require.NoError(t, err)
+	verified, err := fightmons.VerifyMatch(testMatchId, events, nil)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	printVerifiedMatch(&out, verified)
+	require.Contains(t, out.String(), "match "+testMatchId+"\n")
+	require.Contains(t, out.String(), "round 4: ")
+	require.Contains(t, out.String(), "mon ownership: not verified\n")
+	require.Contains(t, out.String(), "result: not signed\n")
+	require.Contains(t, out.String(), "verified winner: challenger "+
+		testChallenger+" after 4 rounds\n")
+}
+
+// TestFightWatch tests that a match dump is replayed by the fight watch
+// command and that the rounds are rendered with hp bars.
+func TestFightWatch(t *testing.T) {
+	err := run([]string{
+		"fight", "watch", "--file", testMatchFile, testMatchId,
+	})
+	require.NoError(t, err)
+
+	err = run([]string{
+		"fight", "watch", "--file", testMatchFile, "other",
+	})
+	require.ErrorContains(t, err, "didn't finish")
+
+	f, err := os.Open(testMatchFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	events, err := fightmons.ReadMatchEvents(f)
+	require.NoError(t, err)
+	replay := make(chan *nostr.Event, len(events))
+	for _, ev := range events {
+		replay <- ev
+	}
+	close(replay)
+
+	var out bytes.Buffer
+	renderer := &matchRenderer{w: &out}
+	updates := fightmons.WatchMatch(context.Background(), testMatchId, replay)
+	for update := range updates {
+		renderer.render(update)
+	}
+	require.True(t, renderer.finished)
+	require.Contains(t, out.String(), "match started\n")
+	require.Contains(t, out.String(), "round 4: ")
+	require.Contains(t, out.String(), "* mon 0 ["+
+		strings.Repeat("#", hpBarWidth)+"]")
+	require.Contains(t, out.String(), "winner: challenger "+
+		testChallenger+" after 4 rounds\n")
+}
+
+// replayTransport is a fight transport that replays the events of a match
+// dump to every subscription.
+type replayTransport struct {
+	fightmons.Transport
+
+	events []*nostr.Event
+}
+
+func (r *replayTransport) Subscribe(_ context.Context,
+	_ nostr.Filters) (<-chan *nostr.Event, error) {
+
+	events := make(chan *nostr.Event, len(r.events))
+	for _, ev := range r.events {
+		events <- ev
+	}
+	close(events)
+
+	return events, nil
+}
+
+// TestFightWatchDaemon tests that the fight watch command renders the
+// updates of a match that tapmond watches.
+func TestFightWatchDaemon(t *testing.T) {
+	f, err := os.Open(testMatchFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	events, err := fightmons.ReadMatchEvents(f)
+	require.NoError(t, err)
+	manager := fightmons.NewManager(
+		&replayTransport{events: events}, nil, nil, nil,
+	)
+	defer manager.Stop()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	tapmonrpc.RegisterTapmonServer(
+		server, tapmond.NewTapmonRpcServer(nil, manager, nil),
+	)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	rpcServer := listener.Addr().String()
+	err = run([]string{
+		"fight", "watch", "--rpcserver", rpcServer, testMatchId,
+	})
+	require.NoError(t, err)
+
+	client, cleanup, err := connectDaemon(rpcServer)
+	require.NoError(t, err)
+	defer cleanup()
+
+	updates, streamErr, err := watchDaemonMatch(
+		context.Background(), client, testMatchId,
+	)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	renderer := &matchRenderer{w: &out}
+	for update := range updates {
+		renderer.render(update)
+	}
+	require.NoError(t, streamErr())
+	require.True(t, renderer.finished)
+	require.Contains(t, out.String(), "match started\n")
+	require.Contains(t, out.String(), "round 4: ")
+	require.Contains(t, out.String(), "* mon 0 ["+
+		strings.Repeat("#", hpBarWidth)+"]")
+	require.Contains(t, out.String(), "winner: challenger "+
+		testChallenger+" after 4 rounds\n")
+}
+
+// TestFightPractice tests that a practice match is played with the actions
+// read from the input until it is verified, and that invalid actions are
+// asked for again.
+func TestFightPractice(t *testing.T) {
+	ctx := context.Background()
+	party, err := parseParty(nil)
+	require.NoError(t, err)
+
+	input := "dance\nswitch 1\n" +
+		strings.Repeat("special\n", fightmons.MaxRounds)
+	var out bytes.Buffer
+	err = playPractice(
+		ctx, party, &fightmons.RandomStrategy{},
+		strings.NewReader(input), &out,
+	)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "round 1 (attack, special, "+
+		"defend, switch <mon>)> unable to submit action: unknown "+
+		"action \"dance\"\n")
+	require.Contains(t, out.String(), "unable to submit action: switch "+
+		"target 1 out of range\n")
+	require.Contains(t, out.String(), "host uses special")
+	require.Contains(t, out.String(), "verified ")
+
+	// Running out of input abandons the match.
+	err = playPractice(
+		ctx, party, &fightmons.RandomStrategy{},
+		strings.NewReader(""), &out,
+	)
+	require.ErrorContains(t, err, "abandoned in round 1")
+
+	_, err = parseParty([]string{party[0].Id + ":1"})
+	require.ErrorContains(t, err, "expected id[:level:nonce]")
+}
+
+// TestHPBar tests the rendering of hp bars.
+func TestHPBar(t *testing.T) {
+	require.Equal(t, "[##########----------] 50/100", hpBar(50, 100))
+	require.Equal(t, "[--------------------] 0/80", hpBar(0, 80))
+	require.Equal(t, "[####################] 7/7", hpBar(7, 7))
+}
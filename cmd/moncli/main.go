@@ -1 +1,100 @@
 package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// command is a moncli command.
+type command struct {
+	usage       string
+	description string
+	run         func(args []string) error
+}
+
+// commandGroup is a group of commands such as "fight".
+type commandGroup struct {
+	description string
+	commands    map[string]*command
+}
+
+var groups = map[string]*commandGroup{
+	"fight": fightCommands,
+	"mon":   monCommands,
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "[moncli] %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		printUsage()
+		return nil
+	}
+
+	group, ok := groups[args[0]]
+	if !ok {
+		printUsage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	if len(args) < 2 {
+		printGroupUsage(args[0], group)
+		return nil
+	}
+
+	cmd, ok := group.commands[args[1]]
+	if !ok {
+		printGroupUsage(args[0], group)
+		return fmt.Errorf("unknown command %q", strings.Join(args[:2], " "))
+	}
+
+	return cmd.run(args[2:])
+}
+
+func printUsage() {
+	fmt.Println("usage: moncli <command> <subcommand> [flags] [args]")
+	fmt.Println()
+	fmt.Println("commands:")
+	for _, name := range sortedKeys(groups) {
+		fmt.Printf("  %-10s %s\n", name, groups[name].description)
+	}
+}
+
+func printGroupUsage(name string, group *commandGroup) {
+	fmt.Printf("usage: moncli %s <subcommand> [flags] [args]\n", name)
+	fmt.Println()
+	fmt.Println("subcommands:")
+	for _, cmdName := range sortedKeys(group.commands) {
+		cmd := group.commands[cmdName]
+		fmt.Printf("  %-40s %s\n", cmd.usage, cmd.description)
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// stringSlice is a flag that can be given multiple times.
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
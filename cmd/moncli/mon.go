@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tapmon/tapmond/mons"
+)
+
+var monCommands = &commandGroup{
+	description: "interact with mons",
+	commands: map[string]*command{
+		"verify": {
+			usage: "verify --headers file <proof_file>",
+			description: "verify an exported mon proof offline " +
+				"against block headers",
+			run: monVerify,
+		},
+	},
+}
+
+func monVerify(args []string) error {
+	flags := flag.NewFlagSet("mon verify", flag.ContinueOnError)
+	headersFile := flags.String("headers", "", "file with a height and "+
+		"a hex encoded block header per line, taken from a node you "+
+		"trust")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 || *headersFile == "" {
+		return fmt.Errorf("usage: moncli mon verify --headers file " +
+			"<proof_file>")
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	var monProof mons.MonProof
+	if err := json.Unmarshal(data, &monProof); err != nil {
+		return fmt.Errorf("unable to read mon proof: %w", err)
+	}
+
+	f, err := os.Open(*headersFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	headers, err := mons.ReadBlockHeaders(f)
+	if err != nil {
+		return fmt.Errorf("unable to read block headers: %w", err)
+	}
+
+	verified, err := mons.VerifyMonProof(
+		context.Background(), &monProof, headers,
+	)
+	if err != nil {
+		return fmt.Errorf("mon proof verification failed: %w", err)
+	}
+
+	printVerifiedMon(os.Stdout, verified)
+
+	return nil
+}
+
+func printVerifiedMon(w io.Writer, verified *mons.VerifiedMon) {
+	mon := verified.Evolved
+	fmt.Fprintf(w, "mon %x %q\n", mon.Id, verified.Name)
+	fmt.Fprintf(w, "asset %x\n", verified.AssetId)
+	fmt.Fprintf(w, "minted in block %v at height %d\n",
+		verified.BlockHash, verified.Height)
+	fmt.Fprintf(w, "generation %d, level %d (%s)\n",
+		verified.Metadata.Generation, mon.Level, mon.Form.Name)
+	fmt.Fprintf(w, "scores %v\n", mon.Scores)
+	if len(mon.Types) > 0 {
+		fmt.Fprintf(w, "types %v\n", mon.Types)
+	}
+	fmt.Fprintf(w, "owner %x at %v\n", verified.Owner.ScriptKey,
+		verified.Owner.AnchorOutpoint)
+}
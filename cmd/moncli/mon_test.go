@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/mons"
+)
+
+const (
+	testMonProofFile = "testdata/monproof.json"
+
+	testHeadersFile = "testdata/headers.txt"
+
+	testMonId = "91343aa7acca98e823552cf58f40d84a8360d5a8ce8748bb96a342b5" +
+		"6bd7da8e"
+
+	testOwner = "0236c46154cf94b8ca425f9d5b3fd4af31368643c2aecb82ba7b347c" +
+		"599fa59f93"
+)
+
+// TestMonVerify tests that an exported mon proof is verified offline by the
+// mon verify command and that the verified mon is printed.
+func TestMonVerify(t *testing.T) {
+	err := run([]string{
+		"mon", "verify", "--headers", testHeadersFile,
+		testMonProofFile,
+	})
+	require.NoError(t, err)
+
+	err = run([]string{"mon", "verify", testMonProofFile})
+	require.ErrorContains(t, err, "usage")
+
+	// Without the header of a parent the proof doesn't verify.
+	data, err := os.ReadFile(testHeadersFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+
+	partial := filepath.Join(t.TempDir(), "headers.txt")
+	err = os.WriteFile(
+		partial, []byte(lines[0]+"\n"+lines[2]+"\n"), 0600,
+	)
+	require.NoError(t, err)
+	err = run([]string{
+		"mon", "verify", "--headers", partial, testMonProofFile,
+	})
+	require.ErrorContains(t, err, "no block header known at height 101")
+
+	data, err = os.ReadFile(testMonProofFile)
+	require.NoError(t, err)
+	var monProof mons.MonProof
+	require.NoError(t, json.Unmarshal(data, &monProof))
+
+	f, err := os.Open(testHeadersFile)
+	require.NoError(t, err)
+	defer f.Close()
+	headers, err := mons.ReadBlockHeaders(f)
+	require.NoError(t, err)
+
+	verified, err := mons.VerifyMonProof(
+		context.Background(), &monProof, headers,
+	)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	printVerifiedMon(&out, verified)
+	require.Contains(t, out.String(), "mon "+testMonId+" \"ember\"\n")
+	require.Contains(t, out.String(), "generation 1, level 4 (adult)\n")
+	require.Contains(t, out.String(), "types [")
+	require.Contains(t, out.String(), "owner "+testOwner+" at ")
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/tapmon/tapmond/fightmons"
+)
+
+func fightPractice(args []string) error {
+	flags := flag.NewFlagSet("fight practice", flag.ContinueOnError)
+	difficulty := flags.String("difficulty", "greedy", "strategy of the "+
+		"bot, one of "+strings.Join(fightmons.Difficulties, ", "))
+	var mons stringSlice
+	flags.Var(&mons, "mon", "fightmon of our party as id[:level:nonce], "+
+		"can be given multiple times, a random mon if not given")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: moncli fight practice [--difficulty " +
+			"level] [--mon id[:level:nonce]]...")
+	}
+
+	strategy, err := fightmons.NewStrategy(*difficulty)
+	if err != nil {
+		return err
+	}
+	party, err := parseParty(mons)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	fmt.Printf("practicing against a %s bot, you are host\n", *difficulty)
+
+	return playPractice(ctx, party, strategy, os.Stdin, os.Stdout)
+}
+
+// parseParty parses fightmons given as id[:level:nonce]. Without any, the
+// party is a single random fightmon without a level.
+func parseParty(mons []string) (fightmons.Party, error) {
+	if len(mons) == 0 {
+		var id [32]byte
+		if _, err := rand.Read(id[:]); err != nil {
+			return nil, err
+		}
+
+		return fightmons.Party{{Id: hex.EncodeToString(id[:])}}, nil
+	}
+
+	party := make(fightmons.Party, 0, len(mons))
+	for _, mon := range mons {
+		parts := strings.Split(mon, ":")
+		fightMon := fightmons.FightMon{Id: parts[0]}
+		switch len(parts) {
+		case 1:
+
+		case 3:
+			var err error
+			fightMon.Level, err = strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid level of mon %v: %w",
+					parts[0], err)
+			}
+			fightMon.LevelNonce, err = strconv.Atoi(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid level nonce of mon "+
+					"%v: %w", parts[0], err)
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid mon %q, expected "+
+				"id[:level:nonce]", mon)
+		}
+		party = append(party, fightMon)
+	}
+
+	return party, party.Validate()
+}
+
+// playPractice plays a practice match against a bot with the given
+// strategy, reading our actions from in and rendering the match to out.
+func playPractice(ctx context.Context, party fightmons.Party,
+	strategy fightmons.Strategy, in io.Reader, out io.Writer) error {
+
+	practice, err := fightmons.NewPractice(ctx, party, strategy)
+	if err != nil {
+		return err
+	}
+	defer practice.Stop()
+
+	updates, cancel, err := practice.SubscribeMatch()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	var (
+		renderer = &matchRenderer{w: out}
+		input    = bufio.NewScanner(in)
+	)
+	for !renderer.finished {
+		var update *fightmons.MatchUpdate
+		select {
+		case update = <-updates:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		renderer.render(update)
+
+		roundId := 1
+		switch update.Type {
+		case fightmons.UpdateStarted:
+
+		case fightmons.UpdateRoundResult:
+			if update.Result.Final() {
+				continue
+			}
+			roundId = update.Result.RoundId + 1
+
+		default:
+			continue
+		}
+
+		err := practiceRound(ctx, practice, roundId, input, out)
+		if err != nil {
+			return err
+		}
+	}
+
+	verified, err := practice.Verify()
+	if err != nil {
+		return fmt.Errorf("unable to verify practice match: %w", err)
+	}
+	printVerifiedResult(out, verified)
+
+	return nil
+}
+
+// practiceRound prompts for our action in the given round until a valid one
+// was submitted.
+func practiceRound(ctx context.Context, practice *fightmons.Practice,
+	roundId int, input *bufio.Scanner, out io.Writer) error {
+
+	for {
+		fmt.Fprintf(out, "round %d (attack, special, defend, switch "+
+			"<mon>)> ", roundId)
+		if !input.Scan() {
+			if err := input.Err(); err != nil {
+				return err
+			}
+
+			return fmt.Errorf("practice match abandoned in round %d",
+				roundId)
+		}
+
+		var err error
+		fields := strings.Fields(input.Text())
+		switch {
+		case len(fields) == 2 && fields[0] == "switch":
+			var target int
+			target, err = strconv.Atoi(fields[1])
+			if err == nil {
+				_, err = practice.SwitchMon(ctx, target)
+			}
+
+		case len(fields) == 1 && fields[0] != "switch":
+			var action fightmons.Action
+			action, err = parseAction(fields[0])
+			if err == nil {
+				_, err = practice.SubmitAction(ctx, action)
+			}
+
+		default:
+			err = fmt.Errorf("invalid action %q", input.Text())
+		}
+		if err != nil {
+			fmt.Fprintf(out, "unable to submit action: %v\n", err)
+			continue
+		}
+
+		return nil
+	}
+}
+
+// parseAction parses the name of an action that doesn't need a target.
+func parseAction(name string) (fightmons.Action, error) {
+	for _, action := range []fightmons.Action{
+		fightmons.ActionAttack, fightmons.ActionSpecial,
+		fightmons.ActionDefend,
+	} {
+		if name == action.String() {
+			return action, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown action %q", name)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/tapmon/tapmond/fightmons"
+	"github.com/tapmon/tapmond/tapmonrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// defaultRPCServer is the rpc address of tapmond if none is given.
+	defaultRPCServer = "localhost:10039"
+)
+
+// rpcServerFlag adds the flag that sets the rpc address of tapmond.
+func rpcServerFlag(flags *flag.FlagSet) *string {
+	return flags.String("rpcserver", defaultRPCServer, "rpc address of "+
+		"tapmond")
+}
+
+// connectDaemon connects to the rpc server of tapmond at the given address.
+// The rpc server of tapmond is unauthenticated and only listens locally.
+func connectDaemon(rpcServer string) (tapmonrpc.TapmonClient, func(),
+	error) {
+
+	conn, err := grpc.Dial(
+		rpcServer,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to connect to tapmond at "+
+			"%v: %w", rpcServer, err)
+	}
+
+	cleanup := func() {
+		conn.Close()
+	}
+
+	return tapmonrpc.NewTapmonClient(conn), cleanup, nil
+}
+
+// sideFromRpc returns the side of an rpc match update.
+func sideFromRpc(side tapmonrpc.FightSide) fightmons.Side {
+	switch side {
+	case tapmonrpc.FightSide_SIDE_HOST:
+		return fightmons.SideHost
+	case tapmonrpc.FightSide_SIDE_CHALLENGER:
+		return fightmons.SideChallenger
+	default:
+		return fightmons.SideNone
+	}
+}
+
+// partyFromRpc returns the party of an rpc match update.
+func partyFromRpc(rpcParty []*tapmonrpc.FightMon) fightmons.Party {
+	party := make(fightmons.Party, 0, len(rpcParty))
+	for _, mon := range rpcParty {
+		party = append(party, fightmons.FightMon{
+			Id:         hex.EncodeToString(mon.Id),
+			Level:      int(mon.Level),
+			LevelNonce: int(mon.LevelNonce),
+		})
+	}
+
+	return party
+}
+
+// matchUpdateFromRpc returns the match update streamed by tapmond, so that
+// it is rendered like an update of a match we verify ourselves. The event
+// only carries its id and author.
+func matchUpdateFromRpc(rpcUpdate *tapmonrpc.MatchUpdate) *fightmons.MatchUpdate {
+	update := &fightmons.MatchUpdate{
+		Type:     fightmons.UpdateType(rpcUpdate.Type),
+		Side:     sideFromRpc(rpcUpdate.Side),
+		RoundId:  int(rpcUpdate.RoundId),
+		Action:   fightmons.Action(rpcUpdate.Action),
+		Target:   int(rpcUpdate.Target),
+		Winner:   sideFromRpc(rpcUpdate.Winner),
+		TimedOut: rpcUpdate.TimedOut,
+	}
+	if rpcUpdate.EventId != "" || rpcUpdate.Pubkey != "" {
+		update.Event = &nostr.Event{
+			ID:     rpcUpdate.EventId,
+			PubKey: rpcUpdate.Pubkey,
+		}
+	}
+
+	// The host authored the accept event.
+	if update.Type == fightmons.UpdateAccepted {
+		update.Players = [2]string{
+			rpcUpdate.Pubkey, rpcUpdate.Challenger,
+		}
+		update.Parties = [2]fightmons.Party{
+			partyFromRpc(rpcUpdate.HostParty),
+			partyFromRpc(rpcUpdate.ChallengerParty),
+		}
+	}
+
+	rpcResult := rpcUpdate.Result
+	if rpcResult == nil {
+		return update
+	}
+	result := &fightmons.RoundResult{
+		RoundId: int(rpcResult.RoundId),
+	}
+	for i := 0; i < len(rpcResult.Hp) && i < 2; i++ {
+		result.HP[i] = int(rpcResult.Hp[i])
+	}
+	for i := 0; i < len(rpcResult.Active) && i < 2; i++ {
+		result.Active[i] = int(rpcResult.Active[i])
+	}
+	for side, partyHP := range [2][]int32{
+		rpcResult.HostPartyHp, rpcResult.ChallengerPartyHp,
+	} {
+		for _, hp := range partyHP {
+			result.PartyHP[side] = append(
+				result.PartyHP[side], int(hp),
+			)
+		}
+	}
+	for _, move := range rpcResult.Moves {
+		result.Moves = append(result.Moves, fightmons.Move{
+			Side:   sideFromRpc(move.Side),
+			Action: fightmons.Action(move.Action),
+			Damage: int(move.Damage),
+			Missed: move.Missed,
+			Target: int(move.Target),
+			Forced: move.Forced,
+		})
+	}
+	update.Result = result
+
+	return update
+}
@@ -1,11 +1,144 @@
 package main
 
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/tapmon/tapmond"
+)
+
 func main() {
-	// Start the server
-	// This is the entry point of the application
-	// It will start the server and listen for incoming requests
-	// The server will run until it is stopped
-	//server.Start()
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "[mond] %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := parseConfig(args)
+	if err != nil {
+		return err
+	}
+
+	daemon, err := tapmond.InitTapmond(cfg)
+	if err != nil {
+		return err
+	}
+	if err := daemon.Start(); err != nil {
+		return err
+	}
+	defer daemon.Stop()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	<-interrupt
+
+	return nil
 }
 
-// "github.com/ncruces/go-sqlite3"
+// parseConfig returns the default configuration overridden by the given
+// command line flags.
+func parseConfig(args []string) (*tapmond.Config, error) {
+	cfg := tapmond.DefaultConfig()
+
+	flags := flag.NewFlagSet("mond", flag.ContinueOnError)
+	flags.StringVar(&cfg.Network, "network", cfg.Network, "bitcoin "+
+		"network tapd and lnd run on")
+	flags.StringVar(&cfg.DataDir, "datadir", cfg.DataDir, "directory "+
+		"the database and the nostr key are stored in")
+	flags.StringVar(&cfg.RPCListen, "rpclisten", cfg.RPCListen, "address "+
+		"the unauthenticated rpc server listens on")
+
+	flags.StringVar(&cfg.Tapd.Host, "tapd.host", cfg.Tapd.Host, "rpc "+
+		"address of tapd")
+	flags.StringVar(&cfg.Tapd.TLSPath, "tapd.tlspath", "", "tls "+
+		"certificate of tapd, defaults to the one in the tapd "+
+		"directory")
+	flags.StringVar(&cfg.Tapd.MacaroonPath, "tapd.macaroonpath", "",
+		"admin macaroon of tapd, defaults to the one of the network in "+
+			"the tapd directory")
+
+	flags.StringVar(&cfg.Lnd.Host, "lnd.host", cfg.Lnd.Host, "rpc "+
+		"address of lnd")
+	flags.StringVar(&cfg.Lnd.TLSPath, "lnd.tlspath", "", "tls "+
+		"certificate of lnd, defaults to the one in the lnd directory")
+	flags.StringVar(&cfg.Lnd.MacaroonDir, "lnd.macaroondir", "",
+		"macaroon directory of lnd, defaults to the one of the network "+
+			"in the lnd directory")
+
+	flags.Func("mons.mintconfs", "confirmations a mint waits for "+
+		"before the minted mon is derived, defaults to the one of the "+
+		"network", uint32Flag(&cfg.Mons.MintConfirmations))
+	flags.Func("mons.finalconfs", "confirmations from which on a mon is "+
+		"final, defaults to the one of the network",
+		uint32Flag(&cfg.Mons.FinalConfirmations))
+
+	var relays, p2pListen, universes stringSlice
+	flags.Var(&universes, "universe.server", "universe server to "+
+		"discover mons minted on other nodes from, can be given "+
+		"multiple times")
+	flags.BoolVar(&cfg.Universe.SyncIssuances, "universe.syncissuances",
+		false, "allow tapd to insert the issuance proofs of all "+
+			"assets, overriding its global issuance sync config")
+
+	flags.StringVar(&cfg.Nostr.KeyFile, "nostr.keyfile", "", "file with "+
+		"the hex encoded nostr private key, created if it doesn't "+
+		"exist, defaults to a file in the data directory")
+	flags.Var(&relays, "nostr.relay", "nostr relay to play fights on, "+
+		"can be given multiple times")
+
+	flags.DurationVar(&cfg.Fight.WagerTimeout, "fight.wagertimeout",
+		cfg.Fight.WagerTimeout, "time after which wagers of "+
+			"unfinished matches are canceled")
+	flags.DurationVar(&cfg.Fight.AnteTimeout, "fight.antetimeout",
+		cfg.Fight.AnteTimeout, "time after which antes of unfinished "+
+			"matches are returned")
+	flags.BoolVar(&cfg.Fight.Escrow, "fight.escrow", false, "hold the "+
+		"antes of other players as escrow")
+	flags.Var(&p2pListen, "fight.p2plisten", "libp2p multiaddr to "+
+		"listen on for direct streams with opponents, can be given "+
+		"multiple times")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() != 0 {
+		return nil, fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+	cfg.Universe.Servers = universes
+	cfg.Nostr.Relays = relays
+	cfg.Fight.P2PListen = p2pListen
+
+	return cfg, nil
+}
+
+// uint32Flag returns a flag function that parses its value into the given
+// uint32.
+func uint32Flag(value *uint32) func(string) error {
+	return func(s string) error {
+		parsed, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return err
+		}
+		*value = uint32(parsed)
+
+		return nil
+	}
+}
+
+// stringSlice is a flag that can be given multiple times.
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
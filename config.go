@@ -1 +1,244 @@
 package tapmond
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/tapmon/tapmond/fightmons"
+	"github.com/tapmon/tapmond/mons"
+)
+
+const (
+	// defaultNetwork is the network tapmond runs on if none is configured.
+	defaultNetwork = "mainnet"
+
+	// defaultRPCListen is the address the rpc server of tapmond listens
+	// on if none is configured.
+	defaultRPCListen = "localhost:10039"
+
+	// defaultTapdHost is the rpc address of tapd if none is configured.
+	defaultTapdHost = "localhost:10029"
+
+	// defaultLndHost is the rpc address of lnd if none is configured.
+	defaultLndHost = "localhost:10009"
+
+	// dbFileName is the name of the sqlite database in the data
+	// directory.
+	dbFileName = "tapmond.db"
+
+	// nostrKeyFileName is the name of the file in the data directory the
+	// nostr key is stored in if no key file is configured.
+	nostrKeyFileName = "nostr.key"
+)
+
+var (
+	// defaultDataDir is the directory tapmond stores its data in if none
+	// is configured.
+	defaultDataDir = btcutil.AppDataDir("tapmond", false)
+
+	// defaultTapdDir is the directory of tapd the tls certificate and the
+	// macaroon are read from if no paths are configured.
+	defaultTapdDir = btcutil.AppDataDir("tapd", false)
+
+	// defaultLndDir is the directory of lnd the tls certificate and the
+	// macaroons are read from if no paths are configured.
+	defaultLndDir = btcutil.AppDataDir("lnd", false)
+)
+
+// TapdConfig is the configuration of the connection to tapd.
+type TapdConfig struct {
+	// Host is the rpc address of tapd.
+	Host string
+
+	// TLSPath is the path to the tls certificate of tapd.
+	TLSPath string
+
+	// MacaroonPath is the path to the admin macaroon of tapd.
+	MacaroonPath string
+}
+
+// LndConfig is the configuration of the connection to the lnd that backs
+// tapd.
+type LndConfig struct {
+	// Host is the rpc address of lnd.
+	Host string
+
+	// TLSPath is the path to the tls certificate of lnd.
+	TLSPath string
+
+	// MacaroonDir is the directory of the macaroons of lnd.
+	MacaroonDir string
+}
+
+// NostrConfig is the configuration of the nostr relays fights are played
+// on.
+type NostrConfig struct {
+	// KeyFile is the file the hex encoded nostr private key is read from.
+	// A new key is created if the file doesn't exist.
+	KeyFile string
+
+	// Relays are the urls of the relays events are published to and
+	// received from.
+	Relays []string
+}
+
+// MonsConfig is the configuration of the mon indexer.
+type MonsConfig struct {
+	// MintConfirmations is the number of confirmations a mint waits for
+	// before the minted mon is derived. The default of the network is
+	// used if it's zero.
+	MintConfirmations uint32
+
+	// FinalConfirmations is the number of confirmations from which on a
+	// mon is final. The default of the network is used if it's zero.
+	FinalConfirmations uint32
+}
+
+// UniverseConfig is the configuration of the universe servers mons minted
+// on other nodes are discovered from.
+type UniverseConfig struct {
+	// Servers are the hosts of the universe servers tapd syncs with. Mons
+	// are only discovered from the universe of tapd if none are given.
+	Servers []string
+
+	// SyncIssuances allows tapd to insert the issuance proofs of all
+	// assets, which overrides its global issuance sync config. Otherwise,
+	// only the issuances allowed by the config of tapd are discovered.
+	SyncIssuances bool
+}
+
+// FightConfig is the configuration of the fights.
+type FightConfig struct {
+	// WagerTimeout is the time after which wagers of matches that
+	// weren't finished are canceled.
+	WagerTimeout time.Duration
+
+	// AnteTimeout is the time after which the antes of matches that
+	// weren't finished are returned.
+	AnteTimeout time.Duration
+
+	// Escrow enables holding the antes of other players.
+	Escrow bool
+
+	// P2PListen are the libp2p multiaddrs to listen on for direct streams
+	// with opponents. Events are only exchanged through the relays if
+	// none are given.
+	P2PListen []string
+}
+
+// Config is the configuration of tapmond.
+type Config struct {
+	// Network is the bitcoin network tapd and lnd run on.
+	Network string
+
+	// DataDir is the directory the database and the nostr key are
+	// stored in.
+	DataDir string
+
+	// RPCListen is the address the rpc server listens on. The rpc server
+	// is unauthenticated, so it should only listen on local addresses.
+	RPCListen string
+
+	Tapd     TapdConfig
+	Lnd      LndConfig
+	Mons     MonsConfig
+	Universe UniverseConfig
+	Nostr    NostrConfig
+	Fight    FightConfig
+}
+
+// DefaultConfig returns the default configuration. The paths of tapd and
+// lnd depend on the network and are set by Validate if they're left empty.
+func DefaultConfig() *Config {
+	return &Config{
+		Network:   defaultNetwork,
+		DataDir:   defaultDataDir,
+		RPCListen: defaultRPCListen,
+		Tapd: TapdConfig{
+			Host: defaultTapdHost,
+		},
+		Lnd: LndConfig{
+			Host: defaultLndHost,
+		},
+		Fight: FightConfig{
+			WagerTimeout: fightmons.DefaultWagerTimeout,
+			AnteTimeout:  fightmons.DefaultAnteTimeout,
+		},
+	}
+}
+
+// Validate checks the configuration and fills in the paths that depend on
+// the network or the data directory.
+func (c *Config) Validate() error {
+	policy, err := c.finalityPolicy()
+	if err != nil {
+		return err
+	}
+	if policy.MintConfirmations == 0 ||
+		policy.FinalConfirmations < policy.MintConfirmations {
+
+		return fmt.Errorf("mints need at least one confirmation and no " +
+			"more confirmations than final mons")
+	}
+
+	if c.Universe.SyncIssuances && len(c.Universe.Servers) == 0 {
+		return fmt.Errorf("no universe servers to sync issuances with")
+	}
+
+	if c.DataDir == "" {
+		return fmt.Errorf("no data directory configured")
+	}
+	if c.Nostr.KeyFile == "" {
+		c.Nostr.KeyFile = filepath.Join(c.DataDir, nostrKeyFileName)
+	}
+	if len(c.Nostr.Relays) == 0 {
+		return fmt.Errorf("no nostr relays configured")
+	}
+
+	if c.Tapd.TLSPath == "" {
+		c.Tapd.TLSPath = filepath.Join(defaultTapdDir, "tls.cert")
+	}
+	if c.Tapd.MacaroonPath == "" {
+		c.Tapd.MacaroonPath = filepath.Join(
+			defaultTapdDir, "data", c.Network, "admin.macaroon",
+		)
+	}
+	if c.Lnd.TLSPath == "" {
+		c.Lnd.TLSPath = filepath.Join(defaultLndDir, "tls.cert")
+	}
+	if c.Lnd.MacaroonDir == "" {
+		c.Lnd.MacaroonDir = filepath.Join(
+			defaultLndDir, "data", "chain", "bitcoin", c.Network,
+		)
+	}
+
+	if c.Fight.WagerTimeout <= 0 || c.Fight.AnteTimeout <= 0 {
+		return fmt.Errorf("wager and ante timeouts must be positive")
+	}
+
+	return nil
+}
+
+// finalityPolicy returns the finality policy of the network with the
+// configured confirmations.
+func (c *Config) finalityPolicy() (mons.FinalityPolicy, error) {
+	policy, err := mons.FinalityPolicyForNetwork(c.Network)
+	if err != nil {
+		return mons.FinalityPolicy{}, err
+	}
+	if c.Mons.MintConfirmations != 0 {
+		policy.MintConfirmations = c.Mons.MintConfirmations
+	}
+	if c.Mons.FinalConfirmations != 0 {
+		policy.FinalConfirmations = c.Mons.FinalConfirmations
+	}
+
+	return policy, nil
+}
+
+// dbPath returns the path of the sqlite database.
+func (c *Config) dbPath() string {
+	return filepath.Join(c.DataDir, dbFileName)
+}
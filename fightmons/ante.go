@@ -0,0 +1,313 @@
+package fightmons
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+)
+
+const (
+	// DefaultAnteTimeout is the time after which the escrow returns the
+	// antes of a match that didn't finish.
+	DefaultAnteTimeout = time.Hour
+
+	// anteAmount is the amount of a mon asset. Mons are collectibles, so
+	// there's only ever one unit of them.
+	anteAmount = 1
+)
+
+var (
+	// ErrUnknownAnte is returned if there's no ante of a player for a
+	// match.
+	ErrUnknownAnte = errors.New("unknown ante")
+)
+
+// AnteState is the state of the ante of a player.
+type AnteState int
+
+const (
+	// AnteOffered means the ante was offered, but the escrow didn't
+	// receive the mon yet.
+	AnteOffered AnteState = iota
+
+	// AnteDeposited means the escrow holds the mon until the match is
+	// over.
+	AnteDeposited
+
+	// AnteReturned means the escrow returned the mon to its owner,
+	// because the owner won, the match was a draw or it wasn't finished
+	// in time.
+	AnteReturned
+
+	// AnteForfeited means the owner lost the match and the escrow
+	// transferred the mon to the winner.
+	AnteForfeited
+
+	// AnteCanceled means the mon was never deposited and the escrow
+	// stopped waiting for it.
+	AnteCanceled
+)
+
+// String returns the name of the ante state.
+func (s AnteState) String() string {
+	switch s {
+	case AnteOffered:
+		return "offered"
+	case AnteDeposited:
+		return "deposited"
+	case AnteReturned:
+		return "returned"
+	case AnteForfeited:
+		return "forfeited"
+	case AnteCanceled:
+		return "canceled"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Resolved returns true if the ante reached a final state.
+func (s AnteState) Resolved() bool {
+	return s >= AnteReturned
+}
+
+// Ante is a mon a player puts at stake in a match. Both players deposit
+// their mon with an escrow they agreed on by naming it in their ante. Once
+// the match is over, the escrow transfers the mon of the loser to the winner
+// and returns the mon of the winner. If the match is a draw, isn't finished
+// in time or only one player deposited, all mons are returned. Neither
+// player can refuse to pay once their mon is deposited, but both have to
+// trust the escrow.
+type Ante struct {
+	// MatchId is the match the ante is bound to.
+	MatchId string
+
+	// Player is the pubkey of the player that owns the mon.
+	Player string
+
+	// Escrow is the pubkey of the escrow that holds the mon.
+	Escrow string
+
+	// AssetId is the asset id of the mon.
+	AssetId []byte
+
+	// RefundAddr is the address of the player the escrow returns the mon
+	// to.
+	RefundAddr string
+
+	// DepositAddr is the address of the escrow the player deposits the
+	// mon to.
+	DepositAddr string
+
+	// ClaimAddr is the address of the opponent the escrow transfers the
+	// mon to if the player lost.
+	ClaimAddr string
+
+	// State is the state of the ante.
+	State AnteState
+
+	// TransferTxid is the anchor txid of the last transfer of the mon.
+	TransferTxid string
+
+	// Expiry is the time after which the escrow returns the mon if the
+	// match isn't finished.
+	Expiry time.Time
+}
+
+// AnteStore persists antes. Both the players and the escrow keep track of
+// the antes of a match.
+type AnteStore interface {
+	// AddAnte adds a new ante.
+	AddAnte(ctx context.Context, ante *Ante) error
+
+	// UpdateAnte updates the addresses, state and transfer txid of an
+	// ante.
+	UpdateAnte(ctx context.Context, ante *Ante) error
+
+	// GetAnte returns the ante of a player for a match or
+	// ErrUnknownAnte.
+	GetAnte(ctx context.Context, matchId, player string) (*Ante, error)
+
+	// ListAntes returns all antes.
+	ListAntes(ctx context.Context) ([]*Ante, error)
+}
+
+// Antes offers and deposits our antes with tapd.
+type Antes struct {
+	store   AnteStore
+	tap     taprpc.TaprootAssetsClient
+	timeout time.Duration
+
+	mu sync.Mutex
+}
+
+// NewAntes creates a new ante handler.
+func NewAntes(store AnteStore, tap taprpc.TaprootAssetsClient,
+	timeout time.Duration) *Antes {
+
+	return &Antes{
+		store:   store,
+		tap:     tap,
+		timeout: timeout,
+	}
+}
+
+// Offer creates our ante of the mon with the given asset id for a match. The
+// mon isn't deposited before the escrow publishes its deposit address.
+func (a *Antes) Offer(ctx context.Context, matchId, player, escrow string,
+	assetId []byte) (*Ante, error) {
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, err := a.store.GetAnte(ctx, matchId, player)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("ante for match %v already offered",
+			matchId)
+
+	case !errors.Is(err, ErrUnknownAnte):
+		return nil, err
+	}
+
+	refundAddr, err := a.NewAddr(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	ante := &Ante{
+		MatchId:    matchId,
+		Player:     player,
+		Escrow:     escrow,
+		AssetId:    assetId,
+		RefundAddr: refundAddr,
+		State:      AnteOffered,
+		Expiry:     time.Now().Add(a.timeout),
+	}
+	if err := a.store.AddAnte(ctx, ante); err != nil {
+		return nil, err
+	}
+
+	return ante, nil
+}
+
+// Get returns the ante of a player for a match.
+func (a *Antes) Get(ctx context.Context, matchId, player string) (*Ante,
+	error) {
+
+	return a.store.GetAnte(ctx, matchId, player)
+}
+
+// List returns all antes.
+func (a *Antes) List(ctx context.Context) ([]*Ante, error) {
+	return a.store.ListAntes(ctx)
+}
+
+// NewAddr returns a new address of ours to receive the mon with the given
+// asset id.
+func (a *Antes) NewAddr(ctx context.Context, assetId []byte) (string,
+	error) {
+
+	addr, err := a.tap.NewAddr(ctx, &taprpc.NewAddrRequest{
+		AssetId: assetId,
+		Amt:     anteAmount,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to create address: %w", err)
+	}
+
+	return addr.Encoded, nil
+}
+
+// Update applies the state of our ante as published by the escrow. Our mon
+// is deposited the first time the escrow publishes its deposit address.
+func (a *Antes) Update(ctx context.Context, matchId, player string,
+	status *FightMonAnteEscrowEvent) error {
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ante, err := a.store.GetAnte(ctx, matchId, player)
+	if err != nil {
+		return err
+	}
+
+	state := AnteState(status.State)
+	if state < ante.State {
+		return nil
+	}
+
+	if ante.DepositAddr == "" && status.DepositAddr != "" {
+		err := checkAnteAddr(ctx, a.tap, status.DepositAddr, ante.AssetId)
+		if err != nil {
+			return fmt.Errorf("invalid deposit address: %w", err)
+		}
+
+		txid, err := sendAnte(ctx, a.tap, status.DepositAddr)
+		if err != nil {
+			return fmt.Errorf("unable to deposit ante: %w", err)
+		}
+		ante.DepositAddr = status.DepositAddr
+		ante.TransferTxid = txid
+	}
+
+	if state > ante.State {
+		ante.State = state
+		if status.TransferTxid != "" {
+			ante.TransferTxid = status.TransferTxid
+		}
+	}
+
+	return a.store.UpdateAnte(ctx, ante)
+}
+
+// checkAnteAddr checks that the address receives exactly the mon with the
+// given asset id.
+func checkAnteAddr(ctx context.Context, tap taprpc.TaprootAssetsClient,
+	addr string, assetId []byte) error {
+
+	decoded, err := tap.DecodeAddr(ctx, &taprpc.DecodeAddrRequest{
+		Addr: addr,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !bytes.Equal(decoded.AssetId, assetId):
+		return fmt.Errorf("address is for asset %x instead of %x",
+			decoded.AssetId, assetId)
+
+	case decoded.Amount != anteAmount:
+		return fmt.Errorf("address amount %d instead of %d",
+			decoded.Amount, anteAmount)
+	}
+
+	return nil
+}
+
+// sendAnte sends a mon to the address and returns the anchor txid of the
+// transfer.
+func sendAnte(ctx context.Context, tap taprpc.TaprootAssetsClient,
+	addr string) (string, error) {
+
+	resp, err := tap.SendAsset(ctx, &taprpc.SendAssetRequest{
+		TapAddrs: []string{addr},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	txid, err := chainhash.NewHash(resp.Transfer.AnchorTxHash)
+	if err != nil {
+		return "", err
+	}
+
+	return txid.String(), nil
+}
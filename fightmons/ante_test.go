@@ -0,0 +1,357 @@
+package fightmons
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// memAddr is an address of the in-memory asset network.
+type memAddr struct {
+	node    *memTapd
+	assetId []byte
+}
+
+// memAssets is an in-memory asset network of collectibles.
+type memAssets struct {
+	mu        sync.Mutex
+	owners    map[string]*memTapd
+	addrs     map[string]memAddr
+	receives  map[string]int
+	transfers uint32
+}
+
+func newMemAssets() *memAssets {
+	return &memAssets{
+		owners:   make(map[string]*memTapd),
+		addrs:    make(map[string]memAddr),
+		receives: make(map[string]int),
+	}
+}
+
+// node returns a new tapd of the network.
+func (a *memAssets) node() *memTapd {
+	return &memTapd{
+		assets: a,
+	}
+}
+
+// mint mints a new collectible owned by the given node.
+func (a *memAssets) mint(t *testing.T, owner *memTapd) []byte {
+	assetId := make([]byte, sha256.Size)
+	_, err := rand.Read(assetId)
+	require.NoError(t, err)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.owners[hex.EncodeToString(assetId)] = owner
+
+	return assetId
+}
+
+func (a *memAssets) owner(assetId []byte) *memTapd {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.owners[hex.EncodeToString(assetId)]
+}
+
+// memTapd is the tapd of a node of the in-memory asset network.
+type memTapd struct {
+	taprpc.TaprootAssetsClient
+
+	assets *memAssets
+}
+
+func (m *memTapd) NewAddr(_ context.Context, in *taprpc.NewAddrRequest,
+	_ ...grpc.CallOption) (*taprpc.Addr, error) {
+
+	m.assets.mu.Lock()
+	defer m.assets.mu.Unlock()
+
+	encoded := fmt.Sprintf("tapmem%d:%x", len(m.assets.addrs), in.AssetId)
+	m.assets.addrs[encoded] = memAddr{
+		node:    m,
+		assetId: in.AssetId,
+	}
+
+	return &taprpc.Addr{
+		Encoded: encoded,
+		AssetId: in.AssetId,
+		Amount:  in.Amt,
+	}, nil
+}
+
+func (m *memTapd) DecodeAddr(_ context.Context, in *taprpc.DecodeAddrRequest,
+	_ ...grpc.CallOption) (*taprpc.Addr, error) {
+
+	m.assets.mu.Lock()
+	defer m.assets.mu.Unlock()
+
+	addr, ok := m.assets.addrs[in.Addr]
+	if !ok {
+		return nil, errors.New("invalid address")
+	}
+
+	return &taprpc.Addr{
+		Encoded: in.Addr,
+		AssetId: addr.assetId,
+		Amount:  anteAmount,
+	}, nil
+}
+
+func (m *memTapd) SendAsset(_ context.Context, in *taprpc.SendAssetRequest,
+	_ ...grpc.CallOption) (*taprpc.SendAssetResponse, error) {
+
+	m.assets.mu.Lock()
+	defer m.assets.mu.Unlock()
+
+	for _, encoded := range in.TapAddrs {
+		addr, ok := m.assets.addrs[encoded]
+		if !ok {
+			return nil, errors.New("invalid address")
+		}
+
+		assetId := hex.EncodeToString(addr.assetId)
+		if m.assets.owners[assetId] != m {
+			return nil, fmt.Errorf("asset %v not owned", assetId)
+		}
+		m.assets.owners[assetId] = addr.node
+		m.assets.receives[encoded]++
+	}
+
+	m.assets.transfers++
+	var txid [4]byte
+	binary.BigEndian.PutUint32(txid[:], m.assets.transfers)
+	hash := sha256.Sum256(txid[:])
+
+	return &taprpc.SendAssetResponse{
+		Transfer: &taprpc.AssetTransfer{
+			AnchorTxHash: hash[:],
+		},
+	}, nil
+}
+
+func (m *memTapd) AddrReceives(_ context.Context,
+	in *taprpc.AddrReceivesRequest,
+	_ ...grpc.CallOption) (*taprpc.AddrReceivesResponse, error) {
+
+	m.assets.mu.Lock()
+	defer m.assets.mu.Unlock()
+
+	resp := &taprpc.AddrReceivesResponse{}
+	for i := 0; i < m.assets.receives[in.FilterAddr]; i++ {
+		resp.Events = append(resp.Events, &taprpc.AddrEvent{
+			Status: taprpc.AddrEventStatus_ADDR_EVENT_STATUS_COMPLETED,
+		})
+	}
+
+	return resp, nil
+}
+
+func newTestAntes(tap *memTapd) *Antes {
+	return NewAntes(newMemStore(), tap, DefaultAnteTimeout)
+}
+
+// waitForAnte polls the escrow and the player until the ante of the player
+// reached the given state. It doesn't fail the test, so it can be used by
+// the player goroutines.
+func waitForAnte(ctx context.Context, escrow *Escrow, player *Manager,
+	matchId string, state AnteState) error {
+
+	timeout := time.After(5 * time.Second)
+	for {
+		if err := escrow.Poll(ctx, time.Now()); err != nil {
+			return err
+		}
+		if err := player.RefreshAntes(ctx); err != nil {
+			return err
+		}
+
+		ante, err := player.antes.Get(ctx, matchId, player.PubKey())
+		if err != nil {
+			return err
+		}
+		if ante.State == state {
+			return nil
+		}
+
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			return fmt.Errorf("ante state %v instead of %v",
+				ante.State, state)
+		}
+	}
+}
+
+// TestManagerAnte tests that the escrow transfers the mon of the loser to
+// the winner and returns the mon of the winner.
+func TestManagerAnte(t *testing.T) {
+	ctx := context.Background()
+	relay := newMemRelay()
+	assets := newMemAssets()
+
+	escrowTransport := newMemTransport(t, relay)
+	escrow := NewEscrow(
+		escrowTransport, assets.node(), newMemStore(),
+		DefaultAnteTimeout,
+	)
+	require.NoError(t, escrow.Start())
+	defer escrow.Stop()
+
+	hostTap, challengerTap := assets.node(), assets.node()
+	host := NewManager(
+		newMemTransport(t, relay), nil, newTestAntes(hostTap), nil,
+	)
+	defer host.Stop()
+	challenger := NewManager(
+		newMemTransport(t, relay), nil, newTestAntes(challengerTap),
+		nil,
+	)
+	defer challenger.Stop()
+
+	taps := map[*Manager]*memTapd{
+		host:       hostTap,
+		challenger: challengerTap,
+	}
+	mons := map[*Manager][]byte{
+		host:       assets.mint(t, hostTap),
+		challenger: assets.mint(t, challengerTap),
+	}
+
+	// Both players only fight once their mon is held by the escrow.
+	ante := func(player *Manager, matchId string) error {
+		_, err := player.OfferAnte(
+			ctx, matchId, escrowTransport.PubKey(), mons[player],
+		)
+		if err != nil {
+			return err
+		}
+
+		return waitForAnte(ctx, escrow, player, matchId, AnteDeposited)
+	}
+	matchId, winner := playManagerMatch(t, host, challenger, ante)
+	require.NotEqual(t, SideNone, winner)
+
+	winnerMgr, loserMgr := host, challenger
+	if winner == SideChallenger {
+		winnerMgr, loserMgr = challenger, host
+	}
+
+	require.NoError(t, waitForAnte(
+		ctx, escrow, loserMgr, matchId, AnteForfeited,
+	))
+	require.NoError(t, waitForAnte(
+		ctx, escrow, winnerMgr, matchId, AnteReturned,
+	))
+	require.Same(t, taps[winnerMgr], assets.owner(mons[winnerMgr]))
+	require.Same(t, taps[winnerMgr], assets.owner(mons[loserMgr]))
+
+	// The loser learned where their mon went.
+	lost, err := loserMgr.antes.Get(ctx, matchId, loserMgr.PubKey())
+	require.NoError(t, err)
+	require.NotEmpty(t, lost.TransferTxid)
+}
+
+// TestEscrowExpiry tests that the escrow returns deposited mons and cancels
+// antes that were never deposited once an unfinished match expired.
+func TestEscrowExpiry(t *testing.T) {
+	ctx := context.Background()
+	relay := newMemRelay()
+	assets := newMemAssets()
+
+	escrowTransport := newMemTransport(t, relay)
+	escrowStore := newMemStore()
+	escrow := NewEscrow(
+		escrowTransport, assets.node(), escrowStore, DefaultAnteTimeout,
+	)
+	require.NoError(t, escrow.Start())
+	defer escrow.Stop()
+
+	hostTap := assets.node()
+	host := NewManager(
+		newMemTransport(t, relay), nil, newTestAntes(hostTap), nil,
+	)
+	defer host.Stop()
+	challenger := NewManager(
+		newMemTransport(t, relay), nil, newTestAntes(assets.node()),
+		nil,
+	)
+	defer challenger.Stop()
+
+	_, matchId, err := host.FindMatch(ctx, true)
+	require.NoError(t, err)
+	updates, cancel, err := host.SubscribeMatch(matchId)
+	require.NoError(t, err)
+	defer cancel()
+
+	hostMon, challengerMon := newTestPlayer(t).mon, newTestPlayer(t).mon
+	require.NoError(t, challenger.ChallengeMon(
+		ctx, matchId, Party{challengerMon},
+	))
+	waitForUpdate(t, updates, UpdateChallenged)
+
+	// Antes can't be offered before the match was accepted.
+	_, err = host.OfferAnte(ctx, matchId, escrowTransport.PubKey(), nil)
+	require.ErrorContains(t, err, "not accepted")
+
+	challengerUpdates, cancel, err := challenger.SubscribeMatch(matchId)
+	require.NoError(t, err)
+	defer cancel()
+
+	err = host.AcceptChallenge(
+		ctx, matchId, challenger.PubKey(), Party{hostMon},
+	)
+	require.NoError(t, err)
+	waitForUpdate(t, challengerUpdates, UpdateAccepted)
+
+	// The challenger offers a mon they don't own, so it's never
+	// deposited.
+	hostAsset := assets.mint(t, hostTap)
+	_, err = host.OfferAnte(
+		ctx, matchId, escrowTransport.PubKey(), hostAsset,
+	)
+	require.NoError(t, err)
+	_, err = challenger.OfferAnte(
+		ctx, matchId, escrowTransport.PubKey(), assets.mint(t, hostTap),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, waitForAnte(
+		ctx, escrow, host, matchId, AnteDeposited,
+	))
+	require.Eventually(t, func() bool {
+		_, err := escrowStore.GetAnte(ctx, matchId, challenger.PubKey())
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Nothing happens before the antes expired.
+	require.NoError(t, escrow.Poll(ctx, time.Now()))
+	require.NotSame(t, hostTap, assets.owner(hostAsset))
+
+	expired := time.Now().Add(DefaultAnteTimeout)
+	require.NoError(t, escrow.Poll(ctx, expired))
+	require.Same(t, hostTap, assets.owner(hostAsset))
+
+	hostAnte, err := escrowStore.GetAnte(ctx, matchId, host.PubKey())
+	require.NoError(t, err)
+	require.Equal(t, AnteReturned, hostAnte.State)
+	challengerAnte, err := escrowStore.GetAnte(
+		ctx, matchId, challenger.PubKey(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, AnteCanceled, challengerAnte.State)
+}
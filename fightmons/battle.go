@@ -0,0 +1,546 @@
+package fightmons
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// MaxRounds is the number of rounds after which a match is decided by
+	// the share of health the parties have left.
+	MaxRounds = 50
+)
+
+// Action is a move a fightmon can make in a round.
+type Action int
+
+const (
+	// ActionAttack is a regular attack.
+	ActionAttack Action = iota
+
+	// ActionSpecial is a stronger attack that might miss.
+	ActionSpecial
+
+	// ActionDefend halves the damage taken in the round.
+	ActionDefend
+
+	// ActionSwitch replaces the active fightmon with another fightmon of
+	// the party. Switches happen before any attack of the round.
+	ActionSwitch
+)
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case ActionAttack:
+		return "attack"
+	case ActionSpecial:
+		return "special"
+	case ActionDefend:
+		return "defend"
+	case ActionSwitch:
+		return "switch"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(a))
+	}
+}
+
+// Valid returns true if the action is a known action.
+func (a Action) Valid() bool {
+	return a >= ActionAttack && a <= ActionSwitch
+}
+
+// Side is one of the two sides of a match.
+type Side int
+
+const (
+	// SideNone is used when no side won a match, e.g. on a draw.
+	SideNone Side = -1
+
+	// SideHost is the side of the player that was looking for a match.
+	SideHost Side = 0
+
+	// SideChallenger is the side of the player that requested the match.
+	SideChallenger Side = 1
+)
+
+// Opponent returns the opposing side.
+func (s Side) Opponent() Side {
+	return 1 - s
+}
+
+// String returns the name of the side.
+func (s Side) String() string {
+	switch s {
+	case SideHost:
+		return "host"
+	case SideChallenger:
+		return "challenger"
+	default:
+		return "none"
+	}
+}
+
+// NewRoundSeed creates a new random rng seed for a round and returns it
+// together with the hash that commits to both the action and the seed. As
+// the seed is random, the hash doesn't reveal the action before both players
+// committed. The target is the party index of the fightmon to switch in and
+// is only committed to for switches.
+func NewRoundSeed(action Action, target int) (string, string, error) {
+	var seed [32]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return "", "", err
+	}
+	hash := roundCommitment(action, target, seed[:])
+
+	return hex.EncodeToString(seed[:]), hex.EncodeToString(hash[:]), nil
+}
+
+// CheckRoundSeed checks that the revealed action, target and seed match the
+// committed hash.
+func CheckRoundSeed(rngHash string, action Action, target int,
+	rngSeed string) error {
+
+	seed, err := hex.DecodeString(rngSeed)
+	if err != nil {
+		return fmt.Errorf("invalid rng seed: %w", err)
+	}
+	hash := roundCommitment(action, target, seed)
+	if hex.EncodeToString(hash[:]) != rngHash {
+		return fmt.Errorf("revealed action and rng seed do not match " +
+			"committed hash")
+	}
+
+	return nil
+}
+
+// roundCommitment returns sha256(action || seed) with the action encoded as
+// a big endian uint32. For switches the target is committed to as well, as
+// sha256(action || target || seed).
+func roundCommitment(action Action, target int, seed []byte) [32]byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(action))
+
+	h := sha256.New()
+	h.Write(buf[:])
+	if action == ActionSwitch {
+		binary.BigEndian.PutUint32(buf[:], uint32(target))
+		h.Write(buf[:])
+	}
+	h.Write(seed)
+
+	var hash [32]byte
+	copy(hash[:], h.Sum(nil))
+
+	return hash
+}
+
+// intner is a source of the random numbers a round is played with.
+type intner interface {
+	// Intn returns a number in [0, n).
+	Intn(n int) int
+}
+
+// roundRng is a deterministic rng seeded by the seeds of both players.
+type roundRng struct {
+	state   [32]byte
+	counter uint32
+}
+
+func newRoundRng(roundId int, seeds [2]string) *roundRng {
+	h := sha256.New()
+	var round [4]byte
+	binary.BigEndian.PutUint32(round[:], uint32(roundId))
+	h.Write(round[:])
+	h.Write([]byte(seeds[SideHost]))
+	h.Write([]byte(seeds[SideChallenger]))
+
+	r := &roundRng{}
+	copy(r.state[:], h.Sum(nil))
+	return r
+}
+
+// Intn returns a deterministic number in [0, n).
+func (r *roundRng) Intn(n int) int {
+	var buf [36]byte
+	copy(buf[:], r.state[:])
+	binary.BigEndian.PutUint32(buf[32:], r.counter)
+	r.counter++
+	hash := sha256.Sum256(buf[:])
+
+	return int(binary.BigEndian.Uint64(hash[:8]) % uint64(n))
+}
+
+// Move is a single action of a fightmon within a round.
+type Move struct {
+	Side   Side
+	Action Action
+
+	// Target is the party index of the fightmon switched in, only set for
+	// switches.
+	Target int
+
+	// Forced is true if the fightmon was switched in because the active
+	// fightmon was knocked out.
+	Forced bool
+
+	Damage int
+	Missed bool
+}
+
+// RoundResult is the outcome of a single round.
+type RoundResult struct {
+	RoundId int
+	Actions [2]Action
+	Moves   []Move
+
+	// HP is the health of the active fightmons at the end of the round,
+	// before knocked out fightmons are replaced.
+	HP [2]int
+
+	// PartyHP is the health of every fightmon of both parties after the
+	// round.
+	PartyHP [2][]int
+
+	// Active is the party index of the active fightmons after the round.
+	Active [2]int
+
+	// Done is true if the match ended with the round.
+	Done bool
+}
+
+// Final returns true if the match ended with the round.
+func (r *RoundResult) Final() bool {
+	return r.Done
+}
+
+// String returns a human readable log line of the round.
+func (r *RoundResult) String() string {
+	s := fmt.Sprintf("round %d:", r.RoundId)
+	for _, move := range r.Moves {
+		switch {
+		case move.Action == ActionSwitch && move.Forced:
+			s += fmt.Sprintf(" %v sends in mon %d;", move.Side,
+				move.Target)
+		case move.Action == ActionSwitch:
+			s += fmt.Sprintf(" %v switches to mon %d;", move.Side,
+				move.Target)
+		case move.Action == ActionDefend:
+			s += fmt.Sprintf(" %v defends;", move.Side)
+		case move.Missed:
+			s += fmt.Sprintf(" %v uses %v and misses;", move.Side,
+				move.Action)
+		default:
+			s += fmt.Sprintf(" %v uses %v for %d damage;", move.Side,
+				move.Action, move.Damage)
+		}
+	}
+
+	return s + fmt.Sprintf(" hp %d/%d", r.HP[SideHost], r.HP[SideChallenger])
+}
+
+// Battle is the deterministic battle engine. Given the same parties, actions
+// and seeds it always produces the same result, which allows anyone to re-run
+// a battle from the published events.
+type Battle struct {
+	stats  [2][]Stats
+	hp     [2][]int
+	active [2]int
+	round  int
+	winner Side
+	done   bool
+}
+
+// NewBattle creates a new battle between the given parties. The first
+// fightmon of each party starts the fight.
+func NewBattle(host, challenger Party) (*Battle, error) {
+	b := &Battle{
+		winner: SideNone,
+	}
+	for i, party := range []Party{host, challenger} {
+		if len(party) == 0 {
+			return nil, fmt.Errorf("empty party")
+		}
+		for _, mon := range party {
+			stats, err := mon.Stats()
+			if err != nil {
+				return nil, err
+			}
+			b.stats[i] = append(b.stats[i], stats)
+			b.hp[i] = append(b.hp[i], stats.HP)
+		}
+	}
+
+	return b, nil
+}
+
+// Round returns the id of the next round to be played.
+func (b *Battle) Round() int {
+	return b.round + 1
+}
+
+// HP returns the current health of the active fightmons.
+func (b *Battle) HP() [2]int {
+	return [2]int{
+		b.hp[SideHost][b.active[SideHost]],
+		b.hp[SideChallenger][b.active[SideChallenger]],
+	}
+}
+
+// Stats returns the stats of the active fightmons.
+func (b *Battle) Stats() [2]Stats {
+	return [2]Stats{
+		b.stats[SideHost][b.active[SideHost]],
+		b.stats[SideChallenger][b.active[SideChallenger]],
+	}
+}
+
+// PartyHP returns the current health of every fightmon of both parties.
+func (b *Battle) PartyHP() [2][]int {
+	return [2][]int{
+		append([]int(nil), b.hp[SideHost]...),
+		append([]int(nil), b.hp[SideChallenger]...),
+	}
+}
+
+// PartyStats returns the stats of every fightmon of both parties.
+func (b *Battle) PartyStats() [2][]Stats {
+	return [2][]Stats{
+		append([]Stats(nil), b.stats[SideHost]...),
+		append([]Stats(nil), b.stats[SideChallenger]...),
+	}
+}
+
+// Active returns the party index of the active fightmons.
+func (b *Battle) Active() [2]int {
+	return b.active
+}
+
+// Finished returns true if the battle is over and the winning side, which is
+// SideNone on a draw.
+func (b *Battle) Finished() (bool, Side) {
+	return b.done, b.winner
+}
+
+// CheckAction checks that the given side can take the action in the next
+// round. A switch must target a fightmon of the party that isn't active and
+// isn't knocked out.
+func (b *Battle) CheckAction(side Side, action Action, target int) error {
+	if !action.Valid() {
+		return fmt.Errorf("invalid action %d", action)
+	}
+	if action != ActionSwitch {
+		return nil
+	}
+
+	switch {
+	case target < 0 || target >= len(b.hp[side]):
+		return fmt.Errorf("switch target %d out of range", target)
+	case target == b.active[side]:
+		return fmt.Errorf("mon %d is already active", target)
+	case b.hp[side][target] <= 0:
+		return fmt.Errorf("mon %d is knocked out", target)
+	}
+
+	return nil
+}
+
+// PlayRound plays the next round with the given actions, switch targets and
+// revealed seeds.
+func (b *Battle) PlayRound(actions [2]Action, targets [2]int,
+	seeds [2]string) (*RoundResult, error) {
+
+	rng := newRoundRng(b.Round(), seeds)
+
+	return b.playRound(actions, targets, rng)
+}
+
+// playRound plays the next round with the given actions and switch targets,
+// drawing the random numbers of the round from the rng.
+func (b *Battle) playRound(actions [2]Action, targets [2]int,
+	rng intner) (*RoundResult, error) {
+
+	if b.done {
+		return nil, fmt.Errorf("battle already finished")
+	}
+	for _, side := range []Side{SideHost, SideChallenger} {
+		err := b.CheckAction(side, actions[side], targets[side])
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", side, err)
+		}
+	}
+
+	b.round++
+	result := &RoundResult{
+		RoundId: b.round,
+		Actions: actions,
+	}
+
+	// Switches happen first, so the fightmon switched in takes the hits of
+	// the round.
+	for _, side := range []Side{SideHost, SideChallenger} {
+		if actions[side] != ActionSwitch {
+			continue
+		}
+		b.active[side] = targets[side]
+		result.Moves = append(result.Moves, Move{
+			Side:   side,
+			Action: ActionSwitch,
+			Target: targets[side],
+		})
+	}
+
+	// The faster fightmon acts first, ties are broken by the rng.
+	stats := b.Stats()
+	first := SideHost
+	switch {
+	case stats[SideChallenger].Speed > stats[SideHost].Speed:
+		first = SideChallenger
+	case stats[SideChallenger].Speed == stats[SideHost].Speed:
+		first = Side(rng.Intn(2))
+	}
+
+	for _, side := range []Side{first, first.Opponent()} {
+		if b.hp[side][b.active[side]] <= 0 {
+			break
+		}
+		if actions[side] == ActionSwitch {
+			continue
+		}
+		move := b.move(rng, side, actions)
+		result.Moves = append(result.Moves, move)
+	}
+	result.HP = b.HP()
+
+	switch {
+	case b.standing(SideHost) < 0:
+		b.done, b.winner = true, SideChallenger
+	case b.standing(SideChallenger) < 0:
+		b.done, b.winner = true, SideHost
+	case b.round >= MaxRounds:
+		b.done, b.winner = true, b.leadingSide()
+	}
+
+	// A knocked out fightmon is replaced by the next fightmon of its party
+	// that is still standing.
+	for _, side := range []Side{SideHost, SideChallenger} {
+		if b.done || b.hp[side][b.active[side]] > 0 {
+			continue
+		}
+		b.active[side] = b.standing(side)
+		result.Moves = append(result.Moves, Move{
+			Side:   side,
+			Action: ActionSwitch,
+			Target: b.active[side],
+			Forced: true,
+		})
+	}
+	result.PartyHP = b.PartyHP()
+	result.Active = b.active
+	result.Done = b.done
+
+	return result, nil
+}
+
+// standing returns the party index of the first fightmon of the side that
+// isn't knocked out, or -1 if the whole party is knocked out.
+func (b *Battle) standing(side Side) int {
+	for i, hp := range b.hp[side] {
+		if hp > 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// move executes the action of the given side against the active fightmon of
+// its opponent.
+func (b *Battle) move(rng intner, side Side, actions [2]Action) Move {
+	move := Move{
+		Side:   side,
+		Action: actions[side],
+	}
+	if move.Action == ActionDefend {
+		return move
+	}
+
+	target := side.Opponent()
+	damage := baseDamage(
+		b.stats[side][b.active[side]], b.stats[target][b.active[target]],
+	)
+	if move.Action == ActionSpecial {
+		// A special attack misses one out of four times.
+		if rng.Intn(4) == 0 {
+			move.Missed = true
+			return move
+		}
+		damage = damage * 3 / 2
+	}
+
+	// Damage varies between 85% and 100%.
+	damage = damage * (85 + rng.Intn(16)) / 100
+	if actions[target] == ActionDefend {
+		damage /= 2
+	}
+	if damage < 1 {
+		damage = 1
+	}
+
+	hp := &b.hp[target][b.active[target]]
+	*hp -= damage
+	if *hp < 0 {
+		*hp = 0
+	}
+	move.Damage = damage
+
+	return move
+}
+
+// baseDamage returns the damage of a regular attack of the attacker on the
+// defender before it varies.
+func baseDamage(attacker, defender Stats) int {
+	damage := attacker.Attack*2 - defender.Defense
+	if damage < attacker.Attack/2 {
+		damage = attacker.Attack / 2
+	}
+
+	return damage
+}
+
+// clone returns a copy of the battle that can be played on without
+// affecting the battle.
+func (b *Battle) clone() *Battle {
+	clone := *b
+	for _, side := range []Side{SideHost, SideChallenger} {
+		clone.hp[side] = append([]int(nil), b.hp[side]...)
+	}
+
+	return &clone
+}
+
+// leadingSide returns the side with the higher share of its total party
+// health left.
+func (b *Battle) leadingSide() Side {
+	var hp, maxHP [2]int
+	for _, side := range []Side{SideHost, SideChallenger} {
+		for i, stats := range b.stats[side] {
+			hp[side] += b.hp[side][i]
+			maxHP[side] += stats.HP
+		}
+	}
+
+	host := hp[SideHost] * maxHP[SideChallenger]
+	challenger := hp[SideChallenger] * maxHP[SideHost]
+	switch {
+	case host > challenger:
+		return SideHost
+	case challenger > host:
+		return SideChallenger
+	default:
+		return SideNone
+	}
+}
@@ -0,0 +1,227 @@
+package fightmons
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// findTestMon returns a random fightmon whose stats satisfy the given
+// function.
+func findTestMon(t *testing.T, match func(Stats) bool) FightMon {
+	for i := 0; i < 100000; i++ {
+		var id [32]byte
+		_, err := rand.Read(id[:])
+		require.NoError(t, err)
+
+		mon := FightMon{Id: hex.EncodeToString(id[:])}
+		stats, err := mon.Stats()
+		require.NoError(t, err)
+		if match(stats) {
+			return mon
+		}
+	}
+	require.FailNow(t, "no matching fightmon found")
+
+	return FightMon{}
+}
+
+// TestPartyJSON tests that a party of a single fightmon is encoded like the
+// fightmon itself and that larger parties round trip.
+func TestPartyJSON(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+
+	single, err := json.Marshal(Party{host.mon})
+	require.NoError(t, err)
+	mon, err := json.Marshal(host.mon)
+	require.NoError(t, err)
+	require.JSONEq(t, string(mon), string(single))
+
+	var party Party
+	require.NoError(t, json.Unmarshal(mon, &party))
+	require.Equal(t, Party{host.mon}, party)
+
+	team := Party{host.mon, challenger.mon}
+	data, err := json.Marshal(team)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &party))
+	require.Equal(t, team, party)
+	require.Equal(t, &team[0], team.Lead())
+	require.NoError(t, team.Validate())
+
+	require.ErrorContains(t, Party{}.Validate(), "out of range")
+	require.ErrorContains(
+		t, Party{host.mon, host.mon}.Validate(), "duplicate",
+	)
+	tooLarge := make(Party, MaxPartySize+1)
+	for i := range tooLarge {
+		tooLarge[i] = newTestPlayer(t).mon
+	}
+	require.ErrorContains(t, tooLarge.Validate(), "out of range")
+}
+
+// TestTeamBattle tests switching, per fightmon health and that a battle
+// only ends once a whole party is knocked out.
+func TestTeamBattle(t *testing.T) {
+	// The host brings two fragile fightmons against a single strong one
+	// and only defends, so it loses both of them.
+	fragile := func(stats Stats) bool {
+		return stats.HP <= 130 && stats.Defense <= 30
+	}
+	hostParty := Party{findTestMon(t, fragile), findTestMon(t, fragile)}
+	challengerParty := Party{findTestMon(t, func(stats Stats) bool {
+		return stats.Attack >= 40
+	})}
+
+	battle, err := NewBattle(hostParty, challengerParty)
+	require.NoError(t, err)
+	full := battle.PartyHP()
+
+	// Switches must target a standing fightmon that isn't active.
+	require.NoError(t, battle.CheckAction(SideHost, ActionSwitch, 1))
+	require.ErrorContains(
+		t, battle.CheckAction(SideHost, ActionSwitch, 0),
+		"already active",
+	)
+	require.ErrorContains(
+		t, battle.CheckAction(SideHost, ActionSwitch, 2),
+		"out of range",
+	)
+	require.ErrorContains(
+		t, battle.CheckAction(SideChallenger, ActionSwitch, 1),
+		"out of range",
+	)
+	_, err = battle.PlayRound(
+		[2]Action{ActionSwitch, ActionSwitch}, [2]int{1, 0},
+		[2]string{"a", "b"},
+	)
+	require.Error(t, err)
+	require.Equal(t, 1, battle.Round())
+
+	// The fightmon switched in takes the hit of the round.
+	seeds := [2]string{"a", "b"}
+	result, err := battle.PlayRound(
+		[2]Action{ActionSwitch, ActionAttack}, [2]int{1, 0}, seeds,
+	)
+	require.NoError(t, err)
+	require.Equal(t, Move{Side: SideHost, Action: ActionSwitch, Target: 1},
+		result.Moves[0])
+	require.Len(t, result.Moves, 2)
+	require.Equal(t, [2]int{1, 0}, result.Active)
+	require.Equal(t, full[SideHost][0], result.PartyHP[SideHost][0])
+	require.Equal(t, full[SideHost][1]-result.Moves[1].Damage,
+		result.PartyHP[SideHost][1])
+	require.Equal(t, result.PartyHP[SideHost][1], result.HP[SideHost])
+	require.Equal(t, full[SideChallenger], result.PartyHP[SideChallenger])
+	require.False(t, result.Final())
+
+	// Once the active fightmon is knocked out, the remaining one is sent
+	// in and the battle goes on.
+	var forced bool
+	for !result.Final() {
+		result, err = battle.PlayRound(
+			[2]Action{ActionDefend, ActionAttack}, [2]int{}, seeds,
+		)
+		require.NoError(t, err)
+
+		for _, move := range result.Moves {
+			if !move.Forced {
+				continue
+			}
+			require.False(t, forced)
+			forced = true
+			require.Equal(t, Move{
+				Side:   SideHost,
+				Action: ActionSwitch,
+				Target: 0,
+				Forced: true,
+			}, move)
+			require.Zero(t, result.HP[SideHost])
+			require.Zero(t, result.PartyHP[SideHost][1])
+			require.Equal(t, 0, result.Active[SideHost])
+			require.False(t, result.Final())
+		}
+	}
+	require.True(t, forced)
+	require.Less(t, result.RoundId, MaxRounds)
+	require.Equal(t, []int{0, 0}, result.PartyHP[SideHost])
+
+	done, winner := battle.Finished()
+	require.True(t, done)
+	require.Equal(t, SideChallenger, winner)
+}
+
+// TestVerifyTeamMatch tests that a match between parties with switches can
+// be verified from its events and that illegal switches are detected.
+func TestVerifyTeamMatch(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+	parties := [2]Party{
+		{host.mon, newTestPlayer(t).mon},
+		{challenger.mon, newTestPlayer(t).mon, newTestPlayer(t).mon},
+	}
+
+	// Both players switch in their second fightmon in the first round.
+	pick := func(_ Side, roundId int) (Action, int) {
+		if roundId == 1 {
+			return ActionSwitch, 1
+		}
+
+		return ActionSpecial, 0
+	}
+	matchId, events := playTestPartyMatch(
+		t, host, challenger, parties, pick,
+	)
+
+	verified, err := VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+	require.Equal(t, parties, verified.Parties)
+	require.Equal(t, &parties[SideChallenger][0],
+		verified.Mons[SideChallenger])
+	require.Equal(t, [2]int{1, 1}, verified.Rounds[0].Active)
+	require.True(t, verified.Rounds[len(verified.Rounds)-1].Final())
+
+	// Switching to the active fightmon is illegal, even if the reveal
+	// matches the commitment, so the challenger forfeits in round 2.
+	seed, hash, err := NewRoundSeed(ActionSwitch, 1)
+	require.NoError(t, err)
+	for _, ev := range events {
+		if ev.PubKey != challenger.pub {
+			continue
+		}
+
+		var content any
+		switch ev.Kind {
+		case FightMonRound:
+			content = FightMonRoundEvent{RoundId: 2, RngHash: hash}
+
+		case FightMonRoundCommit:
+			content = FightMonRoundCommitEvent{
+				RoundID: 2,
+				Action:  int(ActionSwitch),
+				RngSeed: seed,
+				Target:  1,
+			}
+
+		default:
+			continue
+		}
+
+		var round FightMonRoundEvent
+		require.NoError(t, json.Unmarshal([]byte(ev.Content), &round))
+		if round.RoundId != 2 {
+			continue
+		}
+		data, err := json.Marshal(content)
+		require.NoError(t, err)
+		ev.Content = string(data)
+		challenger.sign(t, ev, ev.CreatedAt)
+	}
+	verified, err = VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, verified.Violation, "already active")
+	require.Equal(t, SideHost, verified.Winner)
+	require.Len(t, verified.Rounds, 1)
+}
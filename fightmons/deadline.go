@@ -0,0 +1,188 @@
+package fightmons
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+const (
+	// DefaultRoundTimeout is the round timeout of matches whose lobby
+	// doesn't set one.
+	DefaultRoundTimeout = 2 * time.Minute
+
+	// MinRoundTimeout is the shortest round timeout a host can set.
+	MinRoundTimeout = time.Second
+
+	// MaxRoundTimeout is the longest round timeout a host can set.
+	MaxRoundTimeout = 24 * time.Hour
+)
+
+var (
+	// ErrLate is returned for round events that were created after the
+	// deadline of their phase. They don't count, so the opponent can
+	// claim the match once the deadline passed.
+	ErrLate = errors.New("event created after deadline")
+
+	// ErrBackdated is returned for round events that were created before
+	// their phase began, i.e. before the opponent event they answer. A
+	// player could otherwise backdate an event to beat a timeout claim.
+	ErrBackdated = errors.New("event created before its phase")
+)
+
+// phase is the part of a round in which both players have to commit, or
+// both players have to reveal, before the round timeout passed. The
+// deadlines are measured from the created_at timestamps of the events, so
+// every verifier agrees on them.
+type phase struct {
+	// start is the time the phase began, which is when the match started
+	// or the last player acted in the previous phase.
+	start nostr.Timestamp
+
+	// acted are the times the players acted in the phase, zero if they
+	// didn't yet.
+	acted [2]nostr.Timestamp
+}
+
+// SetRoundTimeout sets the round timeout of the match of the given lobby
+// event. It must be called before the event is signed.
+func SetRoundTimeout(ev *nostr.Event, timeout time.Duration) {
+	seconds := strconv.Itoa(int(timeout / time.Second))
+	for _, tag := range ev.Tags {
+		if len(tag) >= 2 && tag[0] == RoundTimeoutTag {
+			tag[1] = seconds
+			return
+		}
+	}
+
+	ev.Tags = append(ev.Tags, nostr.Tag{RoundTimeoutTag, seconds})
+}
+
+// EventRoundTimeout returns the round timeout the lobby event sets, or the
+// default round timeout if it doesn't set one.
+func EventRoundTimeout(ev *nostr.Event) (time.Duration, error) {
+	tag := ev.Tags.GetFirst([]string{RoundTimeoutTag})
+	if tag == nil {
+		return DefaultRoundTimeout, nil
+	}
+
+	seconds, err := strconv.Atoi(tag.Value())
+	timeout := time.Duration(seconds) * time.Second
+	if err != nil || timeout < MinRoundTimeout ||
+		timeout > MaxRoundTimeout {
+
+		return 0, fmt.Errorf("%w: invalid round timeout %q",
+			ErrInvalidSchema, tag.Value())
+	}
+
+	return timeout, nil
+}
+
+// deadline returns the deadline of the current phase. There is none if the
+// match is played with a version without deadlines, didn't start yet or is
+// finished.
+func (m *Match) deadline() (nostr.Timestamp, bool) {
+	if m.Version < DeadlineVersion || !m.started {
+		return 0, false
+	}
+	if done, _ := m.Finished(); done {
+		return 0, false
+	}
+
+	return m.phase.start + nostr.Timestamp(m.RoundTimeout/time.Second),
+		true
+}
+
+// Deadline returns the time until which both players have to act in the
+// current phase of the round. There is none if the match is played with a
+// version without deadlines, didn't start yet or is finished.
+func (m *Match) Deadline() (time.Time, bool) {
+	deadline, ok := m.deadline()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return deadline.Time(), true
+}
+
+// Acted returns true if the player of the given side already committed or
+// revealed in the current phase of the round.
+func (m *Match) Acted(side Side) bool {
+	return m.phase.acted[side] != 0
+}
+
+// Stalled returns the side whose opponent missed the deadline of the
+// current phase at the given time, which wins the match once it claims the
+// timeout. If neither player acted in time, nobody can claim the match.
+func (m *Match) Stalled(now nostr.Timestamp) (Side, bool) {
+	deadline, ok := m.deadline()
+	if !ok || now <= deadline {
+		return SideNone, false
+	}
+
+	host, challenger := m.Acted(SideHost), m.Acted(SideChallenger)
+	switch {
+	case host && !challenger:
+		return SideHost, true
+
+	case challenger && !host:
+		return SideChallenger, true
+
+	default:
+		return SideNone, false
+	}
+}
+
+// TimedOut returns true if the match ended because a player missed a
+// deadline.
+func (m *Match) TimedOut() bool {
+	return m.timedOut
+}
+
+// checkDeadline returns ErrLate if the round event was created after the
+// deadline of the current phase and ErrBackdated if it was created before
+// the phase began.
+func (m *Match) checkDeadline(ev *nostr.Event) error {
+	deadline, ok := m.deadline()
+	switch {
+	case !ok:
+		return nil
+
+	case ev.CreatedAt < m.phase.start:
+		return fmt.Errorf("%w: created at %d, phase began at %d",
+			ErrBackdated, ev.CreatedAt, m.phase.start)
+
+	case ev.CreatedAt > deadline:
+		return fmt.Errorf("%w: created at %d, deadline was %d",
+			ErrLate, ev.CreatedAt, deadline)
+	}
+
+	return nil
+}
+
+// phaseStart returns the time the current phase of the round began, which
+// round events of the match must not be created before.
+func (m *Match) phaseStart() nostr.Timestamp {
+	return m.phase.start
+}
+
+// act records that the player of the given side acted in the current phase
+// with the given event. Once both players acted, the next phase begins.
+func (m *Match) act(side Side, ev *nostr.Event) {
+	m.phase.acted[side] = ev.CreatedAt
+
+	host, challenger := m.phase.acted[SideHost],
+		m.phase.acted[SideChallenger]
+	if host == 0 || challenger == 0 {
+		return
+	}
+
+	start := host
+	if challenger > start {
+		start = challenger
+	}
+	m.phase = phase{start: start}
+}
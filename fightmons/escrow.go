@@ -0,0 +1,490 @@
+package fightmons
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/nbd-wtf/go-nostr"
+)
+
+const (
+	// escrowPollInterval is the interval in which the escrow checks for
+	// deposits and settles antes.
+	escrowPollInterval = time.Minute
+)
+
+// escrowMatch is a match the escrow holds antes for.
+type escrowMatch struct {
+	match  *Match
+	cancel func()
+}
+
+// Escrow holds the antes of matches whose players named it as escrow. It
+// follows the events of the matches to learn their outcome and settles the
+// antes with tapd.
+type Escrow struct {
+	transport Transport
+	tap       taprpc.TaprootAssetsClient
+	store     AnteStore
+	timeout   time.Duration
+
+	ctx    context.Context
+	cancel func()
+
+	mu      sync.Mutex
+	matches map[string]*escrowMatch
+}
+
+// NewEscrow creates a new escrow that holds antes with the given tapd until
+// the match is over or the timeout expired.
+func NewEscrow(transport Transport, tap taprpc.TaprootAssetsClient,
+	store AnteStore, timeout time.Duration) *Escrow {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Escrow{
+		transport: transport,
+		tap:       tap,
+		store:     store,
+		timeout:   timeout,
+		ctx:       ctx,
+		cancel:    cancel,
+		matches:   make(map[string]*escrowMatch),
+	}
+}
+
+// Start follows the matches with unresolved antes and starts listening for
+// new antes.
+func (e *Escrow) Start() error {
+	antes, err := e.antes(e.ctx)
+	if err != nil {
+		return err
+	}
+	for _, ante := range antes {
+		if ante.State.Resolved() {
+			continue
+		}
+		if err := e.follow(ante.MatchId); err != nil {
+			return err
+		}
+	}
+
+	events, err := e.transport.Subscribe(e.ctx, nostr.Filters{{
+		Kinds: []int{FightMonAnte},
+		Tags: nostr.TagMap{
+			"p": []string{e.transport.PubKey()},
+		},
+	}})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for ev := range events {
+			matchId := GetMatchId(ev)
+			if matchId == "" {
+				continue
+			}
+			if err := e.follow(matchId); err != nil {
+				log.Printf("Unable to follow match %v: %v",
+					matchId, err)
+			}
+		}
+	}()
+
+	go e.pollAntes()
+
+	return nil
+}
+
+// Stop stops following all matches.
+func (e *Escrow) Stop() {
+	e.cancel()
+}
+
+// pollAntes periodically checks for deposits and settles antes.
+func (e *Escrow) pollAntes() {
+	ticker := time.NewTicker(escrowPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := e.Poll(e.ctx, now); err != nil {
+				log.Printf("Unable to settle antes: %v", err)
+			}
+
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+// antes returns all antes held by us.
+func (e *Escrow) antes(ctx context.Context) ([]*Ante, error) {
+	antes, err := e.store.ListAntes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ours []*Ante
+	for _, ante := range antes {
+		if ante.Escrow == e.transport.PubKey() &&
+			ante.Player != e.transport.PubKey() {
+
+			ours = append(ours, ante)
+		}
+	}
+
+	return ours, nil
+}
+
+// follow starts following the events of a match if we don't already.
+func (e *Escrow) follow(matchId string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.matches[matchId]; ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(e.ctx)
+	events, err := e.transport.Subscribe(
+		ctx, nostr.Filters{MatchFilter(matchId)},
+	)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	em := &escrowMatch{
+		match:  NewMatch(matchId),
+		cancel: cancel,
+	}
+	e.matches[matchId] = em
+
+	go func() {
+		for ev := range events {
+			e.handleEvent(em, ev)
+		}
+	}()
+
+	return nil
+}
+
+// handleEvent applies an event to the match and handles antes and claims.
+func (e *Escrow) handleEvent(em *escrowMatch, ev *nostr.Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	matchId := em.match.MatchId
+	_, err := em.match.AddEvent(ev)
+
+	// An error might have been caused by a pending event that could be
+	// applied now, which doesn't make this event invalid.
+	var evErr *EventError
+	if errors.As(err, &evErr) && evErr.Event.ID == ev.ID {
+		if !IsIgnorable(err) {
+			log.Printf("Invalid event in match %v: %v", matchId,
+				err)
+		}
+		return
+	}
+
+	switch ev.Kind {
+	case FightMonAnte:
+		err = e.addAnte(em, ev)
+
+	case FightMonAnteClaim:
+		err = e.addClaim(em, ev)
+	}
+	if err != nil {
+		log.Printf("Invalid ante event %v in match %v: %v", ev.ID,
+			matchId, err)
+	}
+}
+
+// addAnte creates the deposit address for a new ante that names us as
+// escrow. The caller must hold the lock.
+func (e *Escrow) addAnte(em *escrowMatch, ev *nostr.Event) error {
+	ctx := e.ctx
+	matchId := em.match.MatchId
+
+	var offer FightMonAnteEvent
+	if err := decodeEvent(ev, &offer); err != nil {
+		return err
+	}
+	if offer.Escrow != e.transport.PubKey() {
+		return nil
+	}
+	if ev.PubKey == e.transport.PubKey() {
+		return fmt.Errorf("can't hold our own ante")
+	}
+
+	// Only the first ante of a player counts, later ones and the ones
+	// replayed after a restart are ignored.
+	_, err := e.store.GetAnte(ctx, matchId, ev.PubKey)
+	switch {
+	case err == nil:
+		return nil
+
+	case !errors.Is(err, ErrUnknownAnte):
+		return err
+	}
+
+	assetId, err := hex.DecodeString(offer.AssetId)
+	if err != nil {
+		return fmt.Errorf("invalid asset id: %w", err)
+	}
+	err = checkAnteAddr(ctx, e.tap, offer.RefundAddr, assetId)
+	if err != nil {
+		return fmt.Errorf("invalid refund address: %w", err)
+	}
+
+	depositAddr, err := e.tap.NewAddr(ctx, &taprpc.NewAddrRequest{
+		AssetId: assetId,
+		Amt:     anteAmount,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create deposit address: %w", err)
+	}
+
+	ante := &Ante{
+		MatchId:     matchId,
+		Player:      ev.PubKey,
+		Escrow:      offer.Escrow,
+		AssetId:     assetId,
+		RefundAddr:  offer.RefundAddr,
+		DepositAddr: depositAddr.Encoded,
+		State:       AnteOffered,
+		Expiry:      time.Now().Add(e.timeout),
+	}
+	if err := e.store.AddAnte(ctx, ante); err != nil {
+		return err
+	}
+
+	return e.publishAnte(ctx, ante)
+}
+
+// addClaim stores the claim address of a player for the ante of their
+// opponent. The ante is only transferred to it if the claimant won. The
+// caller must hold the lock.
+func (e *Escrow) addClaim(em *escrowMatch, ev *nostr.Event) error {
+	ctx := e.ctx
+	matchId := em.match.MatchId
+
+	var claim FightMonAnteClaimEvent
+	if err := decodeEvent(ev, &claim); err != nil {
+		return err
+	}
+
+	side, ok := em.match.SideOf(ev.PubKey)
+	if !ok {
+		return fmt.Errorf("claim by %v who isn't a player", ev.PubKey)
+	}
+	opponent := em.match.Players[side.Opponent()]
+
+	ante, err := e.store.GetAnte(ctx, matchId, opponent)
+	if err != nil {
+		return err
+	}
+	if ante.ClaimAddr != "" {
+		return fmt.Errorf("ante of %v already claimed", opponent)
+	}
+
+	err = checkAnteAddr(ctx, e.tap, claim.ClaimAddr, ante.AssetId)
+	if err != nil {
+		return fmt.Errorf("invalid claim address: %w", err)
+	}
+
+	ante.ClaimAddr = claim.ClaimAddr
+
+	return e.store.UpdateAnte(ctx, ante)
+}
+
+// Poll checks for deposits of offered antes and settles the antes of
+// matches that are over or expired at the given time.
+func (e *Escrow) Poll(ctx context.Context, now time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	antes, err := e.antes(ctx)
+	if err != nil {
+		return err
+	}
+
+	byMatch := make(map[string][]*Ante)
+	for _, ante := range antes {
+		if ante.State == AnteOffered {
+			if err := e.checkDeposit(ctx, ante); err != nil {
+				return err
+			}
+		}
+		byMatch[ante.MatchId] = append(byMatch[ante.MatchId], ante)
+	}
+
+	for matchId, antes := range byMatch {
+		em, ok := e.matches[matchId]
+		if !ok {
+			continue
+		}
+		if err := e.settle(ctx, em, antes, now); err != nil {
+			return fmt.Errorf("unable to settle antes of match "+
+				"%v: %w", matchId, err)
+		}
+	}
+
+	return nil
+}
+
+// checkDeposit marks an ante as deposited once its deposit address received
+// the mon. The caller must hold the lock.
+func (e *Escrow) checkDeposit(ctx context.Context, ante *Ante) error {
+	resp, err := e.tap.AddrReceives(ctx, &taprpc.AddrReceivesRequest{
+		FilterAddr:   ante.DepositAddr,
+		FilterStatus: taprpc.AddrEventStatus_ADDR_EVENT_STATUS_COMPLETED,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Events) == 0 {
+		return nil
+	}
+
+	ante.State = AnteDeposited
+	if err := e.store.UpdateAnte(ctx, ante); err != nil {
+		return err
+	}
+
+	return e.publishAnte(ctx, ante)
+}
+
+// settle settles the antes of a match. The caller must hold the lock.
+func (e *Escrow) settle(ctx context.Context, em *escrowMatch,
+	antes []*Ante, now time.Time) error {
+
+	done, winner := em.match.Finished()
+	if !done {
+		for _, ante := range antes {
+			if ante.State.Resolved() || now.Before(ante.Expiry) {
+				continue
+			}
+
+			log.Printf("Returning expired ante of %v in match %v",
+				ante.Player, ante.MatchId)
+			if err := e.returnAnte(ctx, ante); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// A mon is only forfeited if both players deposited theirs, so
+	// there's nothing to lose by depositing first. The mon of the winner
+	// might already have been returned by an earlier call.
+	deposited := 0
+	for _, ante := range antes {
+		_, ok := em.match.SideOf(ante.Player)
+		if ok && ante.State != AnteOffered &&
+			ante.State != AnteCanceled {
+
+			deposited++
+		}
+	}
+
+	for _, ante := range antes {
+		side, ok := em.match.SideOf(ante.Player)
+		lost := ok && winner != SideNone && side != winner
+		if !lost || deposited < 2 {
+			if err := e.returnAnte(ctx, ante); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The winner has to tell us where to transfer the mon of the
+		// loser to.
+		if ante.ClaimAddr == "" {
+			continue
+		}
+		err := e.transferAnte(ctx, ante, ante.ClaimAddr, AnteForfeited)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, ante := range antes {
+		if !ante.State.Resolved() {
+			return nil
+		}
+	}
+
+	// All antes are settled, so there's nothing left to follow.
+	em.cancel()
+	delete(e.matches, em.match.MatchId)
+
+	return nil
+}
+
+// returnAnte returns a deposited mon to its owner or cancels an ante that
+// was never deposited. The caller must hold the lock.
+func (e *Escrow) returnAnte(ctx context.Context, ante *Ante) error {
+	if ante.State == AnteOffered {
+		ante.State = AnteCanceled
+		if err := e.store.UpdateAnte(ctx, ante); err != nil {
+			return err
+		}
+
+		return e.publishAnte(ctx, ante)
+	}
+
+	return e.transferAnte(ctx, ante, ante.RefundAddr, AnteReturned)
+}
+
+// transferAnte transfers a deposited mon to the address. The caller must
+// hold the lock.
+func (e *Escrow) transferAnte(ctx context.Context, ante *Ante, addr string,
+	state AnteState) error {
+
+	if ante.State != AnteDeposited {
+		return nil
+	}
+
+	txid, err := sendAnte(ctx, e.tap, addr)
+	if err != nil {
+		return err
+	}
+	ante.State = state
+	ante.TransferTxid = txid
+	if err := e.store.UpdateAnte(ctx, ante); err != nil {
+		return err
+	}
+	log.Printf("Ante of %v in match %v %v in %v", ante.Player,
+		ante.MatchId, state, txid)
+
+	return e.publishAnte(ctx, ante)
+}
+
+// publishAnte publishes the state of an ante for its player.
+func (e *Escrow) publishAnte(ctx context.Context, ante *Ante) error {
+	ev, err := GetFightMonAnteEscrowEvent(
+		ante.MatchId, FightMonAnteEscrowEvent{
+			Player:       ante.Player,
+			DepositAddr:  ante.DepositAddr,
+			State:        int(ante.State),
+			TransferTxid: ante.TransferTxid,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return e.transport.Publish(ctx, ev)
+}
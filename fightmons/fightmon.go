@@ -1,10 +1,183 @@
 package fightmons
 
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tapmon/tapmond/mons"
+)
+
 const (
-	// FightMonVersion is the version of the fightmon
-	FightMonVersion = 1
+	// FightMonVersion is the newest version of the fightmon protocol
+	// we speak.
+	FightMonVersion = 2
+
+	// DeadlineVersion is the first protocol version in which the players
+	// have to commit and reveal within the round timeout of the match.
+	DeadlineVersion = 2
+
+	// MaxLevel is the highest level a fightmon can prove.
+	MaxLevel = mons.MaxLevel
+
+	// MaxPartySize is the maximum number of fightmons a player can bring
+	// to a match.
+	MaxPartySize = 6
 )
 
+// FightMon is a mon as it is presented to an opponent in a fight. Everything
+// needed to derive the fighting stats of the mon can be verified from these
+// fields alone. That the presenting player owns the mon can't be, which is
+// left to an OwnershipVerifier.
 type FightMon struct {
-	Id string
+	// Id is the hex encoded id of the mon.
+	Id string `json:"id"`
+
+	// Level is the level of the mon.
+	Level int `json:"level"`
+
+	// LevelNonce is the nonce proving the level of the mon.
+	LevelNonce int `json:"level_nonce"`
+}
+
+// Mon returns the mon that backs the fightmon.
+func (f *FightMon) Mon() (*mons.Mon, error) {
+	id, err := hex.DecodeString(f.Id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mon id: %w", err)
+	}
+	if len(id) != sha256.Size {
+		return nil, fmt.Errorf("invalid mon id length %d", len(id))
+	}
+
+	mon := mons.MonsterFromId(id)
+	mon.Level = f.Level
+	mon.Nonce = f.LevelNonce
+
+	return mon, nil
+}
+
+// Validate checks that the id of the fightmon is well formed and that the
+// level is backed by a valid level proof.
+func (f *FightMon) Validate() error {
+	mon, err := f.Mon()
+	if err != nil {
+		return err
+	}
+
+	if f.Level < 0 || f.Level > MaxLevel {
+		return fmt.Errorf("level %d out of range", f.Level)
+	}
+
+	if f.Level > 0 && !mon.VerifyLevelUp(f.Level, f.LevelNonce) {
+		return fmt.Errorf("invalid level proof for level %d", f.Level)
+	}
+
+	return nil
+}
+
+// Stats are the fighting stats of a fightmon.
+type Stats struct {
+	HP      int
+	Attack  int
+	Defense int
+	Speed   int
+}
+
+// String returns a string representation of the stats.
+func (s Stats) String() string {
+	return fmt.Sprintf("HP: %d, Attack: %d, Defense: %d, Speed: %d",
+		s.HP, s.Attack, s.Defense, s.Speed)
+}
+
+// Stats derives the fighting stats of the fightmon from the scores of the
+// backing mon and its level.
+func (f *FightMon) Stats() (Stats, error) {
+	mon, err := f.Mon()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	score := func(i int) int {
+		return int(mon.Scores[i%len(mon.Scores)])
+	}
+
+	return Stats{
+		HP:      100 + score(0)/2 + f.Level*10,
+		Attack:  20 + score(1)/8 + f.Level*2,
+		Defense: 20 + score(2)/8 + f.Level*2,
+		Speed:   10 + score(3)/4 + f.Level,
+	}, nil
+}
+
+// Party is the team of fightmons a player brings to a match. The first
+// fightmon of the party leads the fight, the others can be switched in or
+// replace it once it's knocked out.
+type Party []FightMon
+
+// Lead returns the fightmon that starts the fight.
+func (p Party) Lead() *FightMon {
+	if len(p) == 0 {
+		return nil
+	}
+
+	return &p[0]
+}
+
+// Validate checks the size of the party, that every fightmon is valid and
+// that no fightmon is in the party twice.
+func (p Party) Validate() error {
+	if len(p) == 0 || len(p) > MaxPartySize {
+		return fmt.Errorf("party size %d out of range [1, %d]", len(p),
+			MaxPartySize)
+	}
+
+	ids := make(map[string]struct{}, len(p))
+	for i := range p {
+		if err := p[i].Validate(); err != nil {
+			return fmt.Errorf("invalid fightmon %d: %w", i, err)
+		}
+		if _, ok := ids[p[i].Id]; ok {
+			return fmt.Errorf("duplicate fightmon %v", p[i].Id)
+		}
+		ids[p[i].Id] = struct{}{}
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes a party of a single fightmon as the fightmon itself,
+// so events of single fightmon matches keep the format they had before
+// parties were introduced. Larger parties are encoded as an array.
+func (p Party) MarshalJSON() ([]byte, error) {
+	if len(p) == 1 {
+		return json.Marshal(p[0])
+	}
+
+	return json.Marshal([]FightMon(p))
+}
+
+// UnmarshalJSON decodes a party from either a single fightmon or an array of
+// fightmons. Unknown fields are rejected.
+func (p *Party) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var mon FightMon
+		if err := strictUnmarshal(data, &mon); err != nil {
+			return err
+		}
+		*p = Party{mon}
+
+		return nil
+	}
+
+	var mons []FightMon
+	if err := strictUnmarshal(data, &mons); err != nil {
+		return err
+	}
+	*p = mons
+
+	return nil
 }
@@ -0,0 +1,1377 @@
+package fightmons
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/nbd-wtf/go-nostr"
+)
+
+const (
+	// LobbyExpiry is the time after which a lobby that was looking for a
+	// match is no longer considered open.
+	LobbyExpiry = 10 * time.Minute
+
+	// subscriberBuffer is the number of updates buffered per match
+	// subscriber.
+	subscriberBuffer = 100
+
+	// wagerExpiryInterval is the interval in which expired wagers are
+	// canceled.
+	wagerExpiryInterval = time.Minute
+
+	// receiptTimeout is the time we keep following a finished match for
+	// the opponent to sign its result, which our wager is resolved by.
+	receiptTimeout = DefaultWagerTimeout
+
+	// anteRefreshInterval is the interval in which the state of our
+	// unresolved antes is queried from the escrows.
+	anteRefreshInterval = time.Minute
+
+	// rateTimeout is the time we wait for the relays to return the
+	// events of a finished match we rate.
+	rateTimeout = time.Minute
+)
+
+var (
+	// ErrUnknownMatch is returned for matches the manager doesn't take
+	// part in.
+	ErrUnknownMatch = errors.New("unknown match")
+)
+
+// Transport publishes and receives fightmon events.
+type Transport interface {
+	// PubKey returns the pubkey the transport publishes events with.
+	PubKey() string
+
+	// Publish signs the event with the key of the transport and
+	// publishes it.
+	Publish(ctx context.Context, ev *nostr.Event) error
+
+	// Subscribe streams all events matching the filters until the
+	// context is cancelled.
+	Subscribe(ctx context.Context,
+		filters nostr.Filters) (<-chan *nostr.Event, error)
+
+	// Query returns the stored events matching the filters.
+	Query(ctx context.Context,
+		filters nostr.Filters) ([]*nostr.Event, error)
+}
+
+// Lobby is a match that is looking for a challenger.
+type Lobby struct {
+	MatchId   string
+	Host      string
+	CreatedAt time.Time
+
+	// HostRating is the rating of the host if ratings are tracked.
+	HostRating float64
+
+	// Version is the protocol version a match with the host would be
+	// played with, the highest version both of us speak.
+	Version int
+
+	// RoundTimeout is the time the players have to commit and to reveal
+	// in each round of the match.
+	RoundTimeout time.Duration
+
+	// Private is true if the host invited us to a private match.
+	Private bool
+}
+
+// roundSecret is what we committed to in a round and reveal once both
+// players committed.
+type roundSecret struct {
+	action Action
+	target int
+	seed   string
+}
+
+// activeMatch is a match the manager takes part in.
+type activeMatch struct {
+	sync.Mutex
+
+	match *Match
+	side  Side
+
+	// secrets are our actions and rng seeds by round.
+	secrets map[int]roundSecret
+
+	// revealed are the rounds we revealed our seed for.
+	revealed map[int]bool
+
+	updates     []*MatchUpdate
+	subscribers map[chan *MatchUpdate]struct{}
+
+	// wagerOffers are the invoices of the wager events by pubkey.
+	wagerOffers map[string]string
+
+	// wagerPaid is true once we paid the wager invoice of the opponent.
+	wagerPaid bool
+
+	// startOnLock is true if we host a wagered match that is started
+	// once the challenger's payment locked our wager.
+	startOnLock bool
+
+	// anteOffers are the ante events by pubkey.
+	anteOffers map[string]FightMonAnteEvent
+
+	// resolved is true once the outcome of the match was handed to the
+	// antes.
+	resolved bool
+
+	// wagerResolved is true once the result of the match signed by both
+	// players was handed to the wagers.
+	wagerResolved bool
+
+	// peer is the pubkey of the opponent of a private match, whose events
+	// are sealed for the opponent instead of being published. It is empty
+	// for public matches.
+	peer string
+
+	// events are the events applied to the match, which are attached to
+	// our result as evidence and published as a bundle once a private
+	// match is finished or when the match is disputed.
+	events []*nostr.Event
+
+	// claimTimer claims the match once the opponent missed the deadline
+	// of the current phase.
+	claimTimer *time.Timer
+
+	cancel func()
+}
+
+// Manager plays fights on behalf of the daemon. It speaks the nostr fight
+// protocol, so clients only have to pick their actions.
+type Manager struct {
+	transport Transport
+	wagers    *Wagers
+	antes     *Antes
+	ratings   *Ratings
+
+	// roundTimeout is the round timeout of the matches we host.
+	roundTimeout time.Duration
+
+	ctx    context.Context
+	cancel func()
+
+	mu      sync.Mutex
+	matches map[string]*activeMatch
+
+	// invites are the private matches we were invited to by match id.
+	invites map[string]*invite
+}
+
+// NewManager creates a new fight manager using the given transport. If
+// wagers or antes are nil, wagers or antes can't be offered. If ratings is
+// nil, our finished matches aren't rated. Private matches can only be played
+// if the transport is a PrivateTransport.
+func NewManager(transport Transport, wagers *Wagers, antes *Antes,
+	ratings *Ratings) *Manager {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := &Manager{
+		transport:    transport,
+		wagers:       wagers,
+		antes:        antes,
+		ratings:      ratings,
+		roundTimeout: DefaultRoundTimeout,
+		ctx:          ctx,
+		cancel:       cancel,
+		matches:      make(map[string]*activeMatch),
+		invites:      make(map[string]*invite),
+	}
+
+	if private, ok := transport.(PrivateTransport); ok {
+		go m.receiveSealed(private)
+	}
+
+	if wagers != nil {
+		go m.expireWagers()
+		go m.resumeWagers()
+	}
+	if antes != nil {
+		go m.refreshAntes()
+	}
+
+	return m
+}
+
+// expireWagers periodically cancels wagers of matches that weren't finished
+// in time.
+func (m *Manager) expireWagers() {
+	ticker := time.NewTicker(wagerExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			err := m.wagers.CancelExpired(m.ctx, now)
+			if err != nil {
+				log.Printf("Unable to cancel expired wagers: %v",
+					err)
+			}
+
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// resumeWagers waits again for the opponent's payments of the wagers we
+// offered before a restart, so that they are locked, and settled if we
+// already won, once the payments are held.
+func (m *Manager) resumeWagers() {
+	wagers, err := m.wagers.List(m.ctx)
+	if err != nil {
+		log.Printf("Unable to resume wagers: %v", err)
+		return
+	}
+
+	for _, wager := range wagers {
+		if wager.State == WagerOffered {
+			go m.waitForLock(wager.MatchId)
+		}
+	}
+}
+
+// refreshAntes periodically queries the state of our unresolved antes, as
+// the escrow settles them after we stopped following the match.
+func (m *Manager) refreshAntes() {
+	ticker := time.NewTicker(anteRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.RefreshAntes(m.ctx); err != nil {
+				log.Printf("Unable to refresh antes: %v", err)
+			}
+
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop stops following all matches.
+func (m *Manager) Stop() {
+	m.cancel()
+}
+
+// PubKey returns the pubkey the manager plays with.
+func (m *Manager) PubKey() string {
+	return m.transport.PubKey()
+}
+
+// FindMatch returns the open lobbies of other players, including the
+// private matches we were invited to. If ratings are tracked, the lobbies of
+// the hosts closest to our rating come first. If openLobby is set, it also
+// opens a lobby of our own and returns its match id.
+func (m *Manager) FindMatch(ctx context.Context, openLobby bool) ([]*Lobby,
+	string, error) {
+
+	lobbies, err := m.openLobbies(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	lobbies = append(lobbies, m.invitations()...)
+
+	if m.ratings != nil {
+		if err := m.sortLobbies(ctx, lobbies); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if !openLobby {
+		return lobbies, "", nil
+	}
+
+	_, matchNonce, err := NewMatchId(m.transport.PubKey())
+	if err != nil {
+		return nil, "", err
+	}
+
+	matchId, err := m.OpenMatch(ctx, matchNonce)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return lobbies, matchId, nil
+}
+
+// OpenMatch opens a lobby for the match whose id is derived from our pubkey
+// and the given nonce and returns the match id. This allows to host matches
+// that were scheduled with a known nonce, like the matches of a tournament.
+func (m *Manager) OpenMatch(ctx context.Context, matchNonce string) (string,
+	error) {
+
+	matchId, err := deriveMatchId(m.transport.PubKey(), matchNonce)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := m.follow(matchId, SideHost, ""); err != nil {
+		return "", err
+	}
+
+	err = m.publish(ctx, matchId, m.lobbyEvent(matchId, matchNonce))
+	if err != nil {
+		return "", err
+	}
+
+	return matchId, nil
+}
+
+// lobbyEvent returns the lobby event of a match we host.
+func (m *Manager) lobbyEvent(matchId, matchNonce string) *nostr.Event {
+	ev := GetFightMonLookingForMatchEvent(matchId, matchNonce)
+	SetRoundTimeout(ev, m.roundTimeout)
+
+	return ev
+}
+
+// openLobbies queries the lobbies of other players that haven't been
+// accepted yet.
+func (m *Manager) openLobbies(ctx context.Context) ([]*Lobby, error) {
+	since := nostr.Timestamp(time.Now().Add(-LobbyExpiry).Unix())
+	events, err := m.transport.Query(ctx, nostr.Filters{{
+		Kinds: []int{FightMonLookingForMatch, FightMonAcceptMatch},
+		Since: &since,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		lobbies  = make(map[string]*Lobby)
+		accepted = make(map[string]struct{})
+	)
+	for _, ev := range events {
+		matchId := GetMatchId(ev)
+		if matchId == "" {
+			continue
+		}
+
+		switch ev.Kind {
+		case FightMonAcceptMatch:
+			accepted[matchId] = struct{}{}
+
+		case FightMonLookingForMatch:
+			if ev.PubKey == m.transport.PubKey() {
+				continue
+			}
+			if ok, err := ev.CheckSignature(); err != nil || !ok {
+				continue
+			}
+
+			// Skip lobbies for match ids that weren't derived from
+			// the pubkey of the author, the match would never
+			// verify.
+			nonceTag := ev.Tags.GetFirst([]string{MatchNonceTag})
+			if nonceTag == nil {
+				continue
+			}
+			err := CheckMatchId(matchId, ev.PubKey, nonceTag.Value())
+			if err != nil {
+				continue
+			}
+			if _, ok := lobbies[matchId]; ok {
+				continue
+			}
+
+			// Skip lobbies of hosts we share no protocol version
+			// with.
+			if err := ValidateEvent(ev); err != nil {
+				continue
+			}
+			versions, err := EventVersions(ev)
+			if err != nil {
+				continue
+			}
+			version, err := NegotiateVersion(
+				SupportedVersions(), versions,
+			)
+			if err != nil {
+				continue
+			}
+			roundTimeout, err := EventRoundTimeout(ev)
+			if err != nil {
+				continue
+			}
+			lobbies[matchId] = &Lobby{
+				MatchId:      matchId,
+				Host:         ev.PubKey,
+				CreatedAt:    ev.CreatedAt.Time(),
+				Version:      version,
+				RoundTimeout: roundTimeout,
+			}
+		}
+	}
+
+	open := make([]*Lobby, 0, len(lobbies))
+	for matchId, lobby := range lobbies {
+		if _, ok := accepted[matchId]; ok {
+			continue
+		}
+		open = append(open, lobby)
+	}
+
+	return open, nil
+}
+
+// sortLobbies sorts the lobbies by the distance of the rating of their host
+// to our rating.
+func (m *Manager) sortLobbies(ctx context.Context, lobbies []*Lobby) error {
+	ours, err := m.ratings.Get(ctx, RatingPlayer, m.transport.PubKey())
+	if err != nil {
+		return err
+	}
+
+	for _, lobby := range lobbies {
+		host, err := m.ratings.Get(ctx, RatingPlayer, lobby.Host)
+		if err != nil {
+			return err
+		}
+		lobby.HostRating = host.Rating
+	}
+
+	sort.SliceStable(lobbies, func(i, j int) bool {
+		return math.Abs(lobbies[i].HostRating-ours.Rating) <
+			math.Abs(lobbies[j].HostRating-ours.Rating)
+	})
+
+	return nil
+}
+
+// ChallengeMon requests to fight the host of the given match with our party.
+// If the host invited us to a private match, the request is only sent to the
+// host.
+func (m *Manager) ChallengeMon(ctx context.Context, matchId string,
+	party Party) error {
+
+	if err := party.Validate(); err != nil {
+		return err
+	}
+
+	invite := m.takeInvite(matchId)
+	var peer string
+	if invite != nil {
+		peer = invite.lobby.Host
+	}
+
+	active, err := m.follow(matchId, SideChallenger, peer)
+	if err != nil {
+		return err
+	}
+
+	// The lobby of a private match is never published, so it is applied
+	// right away.
+	if invite != nil {
+		m.handleEvent(active, invite.event)
+	}
+
+	ev, err := GetFightMonRequestMatchEvent(matchId, party)
+	if err != nil {
+		return err
+	}
+
+	return m.publish(ctx, matchId, ev)
+}
+
+// AcceptChallenge accepts the match request of the given challenger with
+// our party and starts the match.
+func (m *Manager) AcceptChallenge(ctx context.Context, matchId,
+	challenger string, party Party) error {
+
+	if err := m.accept(ctx, matchId, challenger, party); err != nil {
+		return err
+	}
+
+	return m.publish(ctx, matchId, GetFightMonStartMatchEvent(matchId))
+}
+
+// AcceptWageredChallenge accepts the match request of the given challenger
+// with our party and offers a wager of the given amount. The match is only
+// started once both stakes are locked: we pay the challenger's invoice as
+// soon as they offered the same amount, the challenger pays ours once their
+// own invoice is locked, and our invoice being locked starts the match.
+func (m *Manager) AcceptWageredChallenge(ctx context.Context, matchId,
+	challenger string, party Party, amount btcutil.Amount) error {
+
+	if m.wagers == nil {
+		return fmt.Errorf("wagers not supported")
+	}
+
+	if err := m.accept(ctx, matchId, challenger, party); err != nil {
+		return err
+	}
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return err
+	}
+	active.Lock()
+	active.startOnLock = true
+	active.Unlock()
+
+	_, err = m.OfferWager(ctx, matchId, amount)
+
+	return err
+}
+
+// accept publishes our accept event for the match request of the given
+// challenger.
+func (m *Manager) accept(ctx context.Context, matchId, challenger string,
+	party Party) error {
+
+	if err := party.Validate(); err != nil {
+		return err
+	}
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return err
+	}
+
+	active.Lock()
+	_, requested := active.match.Requests[challenger]
+	side := active.side
+	version, versionErr := active.match.NegotiatedVersion(challenger)
+	peer := active.peer
+	active.Unlock()
+
+	switch {
+	case side != SideHost:
+		return fmt.Errorf("only the host can accept a challenge")
+
+	case peer != "" && challenger != peer:
+		return fmt.Errorf("only %v was invited to the match", peer)
+
+	case !requested:
+		return fmt.Errorf("no match request by %v", challenger)
+
+	case versionErr != nil:
+		return versionErr
+	}
+
+	ev, err := GetFightMonAcceptMatchEvent(matchId, challenger, party)
+	if err != nil {
+		return err
+	}
+	SetEventVersion(ev, version)
+
+	return m.publish(ctx, matchId, ev)
+}
+
+// SubmitAction commits to our action for the current round of the match.
+// The rng seed is revealed automatically once the opponent committed too.
+// It returns the id of the round the action was submitted for.
+func (m *Manager) SubmitAction(ctx context.Context, matchId string,
+	action Action) (int, error) {
+
+	if action == ActionSwitch {
+		return 0, fmt.Errorf("switches need a target, use SwitchMon")
+	}
+
+	return m.submit(ctx, matchId, action, 0)
+}
+
+// SwitchMon commits to switching our active fightmon for the fightmon with
+// the given index in our party in the current round of the match. It returns
+// the id of the round the switch was submitted for.
+func (m *Manager) SwitchMon(ctx context.Context, matchId string,
+	target int) (int, error) {
+
+	return m.submit(ctx, matchId, ActionSwitch, target)
+}
+
+// submit commits to the given action and switch target for the current round
+// of the match.
+func (m *Manager) submit(ctx context.Context, matchId string, action Action,
+	target int) (int, error) {
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return 0, err
+	}
+
+	active.Lock()
+	if !active.match.Started() {
+		active.Unlock()
+		return 0, fmt.Errorf("match not started")
+	}
+	if done, _ := active.match.Finished(); done {
+		active.Unlock()
+		return 0, fmt.Errorf("match already finished")
+	}
+	roundId := active.match.Battle().Round()
+	deadline, ok := active.match.Deadline()
+	if ok && time.Now().After(deadline) {
+		active.Unlock()
+		return 0, fmt.Errorf("deadline of round %d passed", roundId)
+	}
+	if _, ok := active.secrets[roundId]; ok {
+		active.Unlock()
+		return 0, fmt.Errorf("action for round %d already submitted",
+			roundId)
+	}
+
+	// Revealing an illegal action would invalidate the match, so we never
+	// commit to one.
+	err = active.match.Battle().CheckAction(active.side, action, target)
+	if err != nil {
+		active.Unlock()
+		return 0, err
+	}
+
+	seed, hash, err := NewRoundSeed(action, target)
+	if err != nil {
+		active.Unlock()
+		return 0, err
+	}
+	active.secrets[roundId] = roundSecret{
+		action: action,
+		target: target,
+		seed:   seed,
+	}
+	active.Unlock()
+
+	ev, err := GetFightMonRoundEvent(matchId, FightMonRoundEvent{
+		RoundId: roundId,
+		RngHash: hash,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := m.publish(ctx, matchId, ev); err != nil {
+		active.Lock()
+		delete(active.secrets, roundId)
+		active.Unlock()
+
+		return 0, err
+	}
+
+	return roundId, nil
+}
+
+// OfferWager offers a wager of the given amount on a match that was accepted
+// but isn't started yet, see AcceptWageredChallenge. Our hold invoice is
+// published for the opponent to pay. The host pays the challenger's invoice
+// as soon as they offered the same amount, the challenger only pays once
+// their own invoice is locked. Wagers are trust-based, see Wager.
+func (m *Manager) OfferWager(ctx context.Context, matchId string,
+	amount btcutil.Amount) (*Wager, error) {
+
+	if m.wagers == nil {
+		return nil, fmt.Errorf("wagers not supported")
+	}
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return nil, err
+	}
+
+	active.Lock()
+	accepted := active.match.Players[SideChallenger] != ""
+	started := active.match.Started()
+	active.Unlock()
+
+	switch {
+	case !accepted:
+		return nil, fmt.Errorf("match not accepted yet")
+
+	case started:
+		return nil, fmt.Errorf("match already started")
+	}
+
+	wager, err := m.wagers.Offer(ctx, matchId, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	go m.waitForLock(matchId)
+
+	ev, err := GetFightMonWagerEvent(matchId, FightMonWagerEvent{
+		AmountSat: int64(amount),
+		Invoice:   wager.Invoice,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := m.publish(ctx, matchId, ev); err != nil {
+		return nil, err
+	}
+
+	return wager, nil
+}
+
+// ListWagers returns all our wagers.
+func (m *Manager) ListWagers(ctx context.Context) ([]*Wager, error) {
+	if m.wagers == nil {
+		return nil, nil
+	}
+
+	return m.wagers.List(ctx)
+}
+
+// OfferAnte offers the mon with the given asset id as ante of a match that
+// was accepted but isn't finished yet. The mon is deposited with the escrow
+// as soon as it publishes its deposit address. The opponent has to name the
+// same escrow, otherwise both mons are returned after the match.
+func (m *Manager) OfferAnte(ctx context.Context, matchId, escrow string,
+	assetId []byte) (*Ante, error) {
+
+	if m.antes == nil {
+		return nil, fmt.Errorf("antes not supported")
+	}
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return nil, err
+	}
+
+	active.Lock()
+	_, isPlayer := active.match.SideOf(escrow)
+	accepted := active.match.Players[SideChallenger] != ""
+	done, _ := active.match.Finished()
+	private := active.peer != ""
+	active.Unlock()
+
+	switch {
+	case private:
+		return nil, fmt.Errorf("the escrow can't follow private matches")
+
+	case !accepted:
+		return nil, fmt.Errorf("match not accepted yet")
+
+	case done:
+		return nil, fmt.Errorf("match already finished")
+
+	case isPlayer:
+		return nil, fmt.Errorf("escrow can't be a player of the match")
+	}
+
+	ante, err := m.antes.Offer(
+		ctx, matchId, m.transport.PubKey(), escrow, assetId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ev, err := GetFightMonAnteEvent(matchId, FightMonAnteEvent{
+		Escrow:     escrow,
+		AssetId:    hex.EncodeToString(assetId),
+		RefundAddr: ante.RefundAddr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := m.publish(ctx, matchId, ev); err != nil {
+		return nil, err
+	}
+
+	return ante, nil
+}
+
+// ListAntes returns all our antes.
+func (m *Manager) ListAntes(ctx context.Context) ([]*Ante, error) {
+	if m.antes == nil {
+		return nil, nil
+	}
+
+	antes, err := m.antes.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we also act as escrow, the store holds the antes of others too.
+	ours := make([]*Ante, 0, len(antes))
+	for _, ante := range antes {
+		if ante.Player == m.transport.PubKey() {
+			ours = append(ours, ante)
+		}
+	}
+
+	return ours, nil
+}
+
+// RefreshAntes queries the escrows for the state of our unresolved antes.
+func (m *Manager) RefreshAntes(ctx context.Context) error {
+	antes, err := m.ListAntes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ante := range antes {
+		if ante.State.Resolved() {
+			continue
+		}
+
+		events, err := m.transport.Query(ctx, nostr.Filters{{
+			Kinds:   []int{FightMonAnteEscrow},
+			Authors: []string{ante.Escrow},
+			Tags: nostr.TagMap{
+				MatchIndexTag: []string{ante.MatchId},
+				"p":           []string{ante.Player},
+			},
+		}})
+		if err != nil {
+			return err
+		}
+
+		for _, ev := range events {
+			m.updateAnte(ante.MatchId, ev)
+		}
+	}
+
+	return nil
+}
+
+// updateAnte applies the state of our ante published by the escrow.
+func (m *Manager) updateAnte(matchId string, ev *nostr.Event) {
+	ante, err := m.antes.Get(m.ctx, matchId, m.transport.PubKey())
+	if err != nil || ev.PubKey != ante.Escrow {
+		return
+	}
+	if ok, err := ev.CheckSignature(); err != nil || !ok {
+		return
+	}
+
+	var status FightMonAnteEscrowEvent
+	if err := decodeEvent(ev, &status); err != nil {
+		return
+	}
+	if status.Player != m.transport.PubKey() {
+		return
+	}
+
+	err = m.antes.Update(m.ctx, matchId, m.transport.PubKey(), &status)
+	if err != nil {
+		log.Printf("Unable to update ante of match %v: %v", matchId,
+			err)
+	}
+}
+
+// claimAnte claims the ante of the opponent from the escrow if we won the
+// match and both players used the same escrow.
+func (m *Manager) claimAnte(active *activeMatch, winner Side) {
+	matchId := active.match.MatchId
+	if winner == SideNone || winner != active.side {
+		return
+	}
+
+	ante, err := m.antes.Get(m.ctx, matchId, m.transport.PubKey())
+	if err != nil {
+		return
+	}
+
+	active.Lock()
+	opponent := active.match.Players[active.side.Opponent()]
+	offer, ok := active.anteOffers[opponent]
+	active.Unlock()
+	if !ok || offer.Escrow != ante.Escrow {
+		return
+	}
+
+	assetId, err := hex.DecodeString(offer.AssetId)
+	if err != nil {
+		return
+	}
+
+	claimAddr, err := m.antes.NewAddr(m.ctx, assetId)
+	if err == nil {
+		var ev *nostr.Event
+		ev, err = GetFightMonAnteClaimEvent(
+			matchId, ante.Escrow, FightMonAnteClaimEvent{
+				ClaimAddr: claimAddr,
+			},
+		)
+		if err == nil {
+			err = m.transport.Publish(m.ctx, ev)
+		}
+	}
+	if err != nil {
+		log.Printf("Unable to claim ante of match %v: %v", matchId,
+			err)
+	}
+}
+
+// Leaderboard returns the ratings of the given kind from the highest to the
+// lowest, skipping the first offset ratings.
+func (m *Manager) Leaderboard(ctx context.Context, kind RatingKind, offset,
+	limit int) ([]*Rating, error) {
+
+	if m.ratings == nil {
+		return nil, ErrRatingsNotTracked
+	}
+
+	return m.ratings.Leaderboard(ctx, kind, offset, limit)
+}
+
+// RatingHistory returns the ratings of a subject after each of its rated
+// matches in the order they were rated.
+func (m *Manager) RatingHistory(ctx context.Context, kind RatingKind,
+	subject string) ([]*RatingHistoryEntry, error) {
+
+	if m.ratings == nil {
+		return nil, ErrRatingsNotTracked
+	}
+
+	return m.ratings.History(ctx, kind, subject)
+}
+
+// rateMatch verifies a finished match from the events published to the
+// relays and updates our local ratings of its players, and of its mons if
+// their ownership can be verified.
+func (m *Manager) rateMatch(matchId string) {
+	ctx, cancel := context.WithTimeout(m.ctx, rateTimeout)
+	defer cancel()
+
+	events, err := m.transport.Query(
+		ctx, nostr.Filters{MatchFilter(matchId)},
+	)
+	if err == nil {
+		var verified *VerifiedMatch
+		verified, err = VerifyMatch(matchId, events, nil)
+		if err == nil {
+			err = m.ratings.RateMatch(ctx, verified, time.Now())
+		}
+	}
+	if err != nil && !errors.Is(err, ErrMatchRated) {
+		log.Printf("Unable to rate match %v: %v", matchId, err)
+	}
+}
+
+// waitForLock waits until the opponent's payment locked our wager on a match
+// and continues the match if we still follow it.
+func (m *Manager) waitForLock(matchId string) {
+	if err := m.wagers.WaitForLock(m.ctx, matchId); err != nil {
+		log.Printf("Unable to lock wager of match %v: %v", matchId,
+			err)
+		return
+	}
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return
+	}
+	m.wagerLocked(active)
+}
+
+// wagerLocked is called once the opponent's payment locked our wager. The
+// challenger pays the host's wager in return, while the host starts the
+// match, as the challenger only paid after their own wager was locked.
+func (m *Manager) wagerLocked(active *activeMatch) {
+	matchId := active.match.MatchId
+
+	wager, err := m.wagers.Get(m.ctx, matchId)
+	if err != nil || wager.State != WagerLocked {
+		return
+	}
+
+	active.Lock()
+	start := active.startOnLock && !active.match.Started()
+	active.startOnLock = false
+	active.Unlock()
+
+	if !start {
+		m.payWager(active)
+		return
+	}
+
+	err = m.publish(m.ctx, matchId, GetFightMonStartMatchEvent(matchId))
+	if err != nil {
+		log.Printf("Unable to start wagered match %v: %v", matchId,
+			err)
+	}
+}
+
+// payWager pays the wager invoice of the opponent once both players offered
+// a wager. The host pays first, the challenger only pays once the host's
+// payment locked their own wager, so that the host can't start the match
+// before both stakes are locked. Nothing is paid into a started match.
+func (m *Manager) payWager(active *activeMatch) {
+	matchId := active.match.MatchId
+
+	active.Lock()
+	opponent := active.match.Players[active.side.Opponent()]
+	invoice, ok := active.wagerOffers[opponent]
+	started := active.match.Started()
+	side := active.side
+	active.Unlock()
+	if opponent == "" || !ok || started {
+		return
+	}
+
+	// We only pay if we offered a wager ourselves.
+	wager, err := m.wagers.Get(m.ctx, matchId)
+	if err != nil {
+		return
+	}
+	if side == SideChallenger && wager.State != WagerLocked {
+		return
+	}
+
+	active.Lock()
+	if active.wagerPaid {
+		active.Unlock()
+		return
+	}
+	active.wagerPaid = true
+	active.Unlock()
+
+	results, err := m.wagers.Pay(m.ctx, matchId, invoice)
+	if err != nil {
+		log.Printf("Unable to pay wager of match %v: %v", matchId, err)
+		return
+	}
+
+	go func() {
+		select {
+		case result := <-results:
+			if result.Err != nil {
+				log.Printf("Wager payment of match %v refunded: "+
+					"%v", matchId, result.Err)
+				return
+			}
+			log.Printf("Wager payment of match %v settled by "+
+				"opponent", matchId)
+
+		case <-m.ctx.Done():
+		}
+	}()
+}
+
+// resolveWager settles or cancels our wager on a match whose result both
+// players signed.
+func (m *Manager) resolveWager(matchId string, side, winner Side) {
+	var err error
+	switch winner {
+	case SideNone:
+		err = m.wagers.Cancel(m.ctx, matchId)
+	default:
+		err = m.wagers.Resolve(m.ctx, matchId, winner == side)
+	}
+	if err != nil && !errors.Is(err, ErrUnknownWager) {
+		log.Printf("Unable to resolve wager of match %v: %v", matchId,
+			err)
+	}
+}
+
+// SubscribeMatch returns a channel with all past and future updates of the
+// match. The subscription ends when the returned cancel function is called.
+func (m *Manager) SubscribeMatch(matchId string) (<-chan *MatchUpdate,
+	func(), error) {
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	active.Lock()
+	defer active.Unlock()
+
+	updates := make(
+		chan *MatchUpdate, subscriberBuffer+len(active.updates),
+	)
+	for _, update := range active.updates {
+		updates <- update
+	}
+	active.subscribers[updates] = struct{}{}
+
+	cancel := func() {
+		active.Lock()
+		defer active.Unlock()
+
+		if _, ok := active.subscribers[updates]; ok {
+			delete(active.subscribers, updates)
+			close(updates)
+		}
+	}
+
+	return updates, cancel, nil
+}
+
+func (m *Manager) activeMatch(matchId string) (*activeMatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active, ok := m.matches[matchId]
+	if !ok {
+		return nil, ErrUnknownMatch
+	}
+
+	return active, nil
+}
+
+// follow starts following the events of a match we take part in. If peer is
+// set, the match is private and its events are only exchanged with the peer.
+func (m *Manager) follow(matchId string, side Side,
+	peer string) (*activeMatch, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.matches[matchId]; ok {
+		return nil, fmt.Errorf("already taking part in match %v",
+			matchId)
+	}
+
+	// The events of private matches are received sealed, so there's
+	// nothing to subscribe to.
+	ctx, cancel := context.WithCancel(m.ctx)
+	var (
+		events <-chan *nostr.Event
+		err    error
+	)
+	if peer == "" {
+		events, err = m.transport.Subscribe(
+			ctx, nostr.Filters{MatchFilter(matchId)},
+		)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	active := &activeMatch{
+		match:       NewMatch(matchId),
+		side:        side,
+		secrets:     make(map[int]roundSecret),
+		revealed:    make(map[int]bool),
+		subscribers: make(map[chan *MatchUpdate]struct{}),
+		wagerOffers: make(map[string]string),
+		anteOffers:  make(map[string]FightMonAnteEvent),
+		peer:        peer,
+		cancel:      cancel,
+	}
+	m.matches[matchId] = active
+	if events == nil {
+		return active, nil
+	}
+
+	go func() {
+		for ev := range events {
+			m.handleEvent(active, ev)
+		}
+	}()
+
+	return active, nil
+}
+
+// publish publishes an event of a match and applies it to the match right
+// away instead of waiting for the relays to echo it. The events of private
+// matches are sealed for the opponent instead.
+func (m *Manager) publish(ctx context.Context, matchId string,
+	ev *nostr.Event) error {
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return err
+	}
+
+	// Once a match was accepted, all events are encoded with the version
+	// negotiated for it.
+	active.Lock()
+	if version := active.match.Version; version != 0 {
+		SetEventVersion(ev, version)
+	}
+
+	// Round events created before their phase began are rejected, so
+	// they're never dated before the opponent event they answer, even if
+	// our clock is behind the opponent's.
+	isRound := ev.Kind == FightMonRound || ev.Kind == FightMonRoundCommit
+	if isRound && ev.CreatedAt == 0 {
+		ev.CreatedAt = nostr.Now()
+		if start := active.match.phaseStart(); ev.CreatedAt < start {
+			ev.CreatedAt = start
+		}
+	}
+	peer := active.peer
+	active.Unlock()
+
+	if peer != "" {
+		// The transport was checked to be private when the match was
+		// opened or we were invited to it.
+		transport := m.transport.(PrivateTransport)
+		sealed, err := sealEvent(transport, peer, ev)
+		if err != nil {
+			return err
+		}
+		if err := transport.Publish(ctx, sealed); err != nil {
+			return err
+		}
+	} else if err := m.transport.Publish(ctx, ev); err != nil {
+		return err
+	}
+	m.handleEvent(active, ev)
+
+	return nil
+}
+
+// handleEvent applies an event to a match, notifies the subscribers and
+// reveals our seed once both players committed.
+func (m *Manager) handleEvent(active *activeMatch, ev *nostr.Event) {
+	active.Lock()
+
+	// Even if the event or an event that was pending on it is invalid,
+	// the updates that happened before are valid.
+	_, seen := active.match.seen[ev.ID]
+	updates, err := active.match.AddEvent(ev)
+	if err != nil && !IsIgnorable(err) {
+		log.Printf("Invalid event in match %v: %v",
+			active.match.MatchId, err)
+	}
+	if err == nil && !seen {
+		active.events = append(active.events, ev)
+	}
+
+	if err == nil && ev.Kind == FightMonWager &&
+		ev.PubKey != m.transport.PubKey() {
+
+		var offer FightMonWagerEvent
+		if err := decodeEvent(ev, &offer); err == nil {
+			active.wagerOffers[ev.PubKey] = offer.Invoice
+		}
+	}
+
+	if err == nil && ev.Kind == FightMonAnte &&
+		ev.PubKey != m.transport.PubKey() {
+
+		var offer FightMonAnteEvent
+		if err := decodeEvent(ev, &offer); err == nil {
+			active.anteOffers[ev.PubKey] = offer
+		}
+	}
+
+	for _, update := range updates {
+		active.updates = append(active.updates, update)
+		for subscriber := range active.subscribers {
+			select {
+			case subscriber <- update:
+			default:
+				log.Printf("Dropping update for slow subscriber "+
+					"of match %v", active.match.MatchId)
+			}
+		}
+	}
+
+	reveal := m.pendingReveal(active)
+	m.scheduleTimeoutClaim(active)
+	done, winner := active.match.Finished()
+	resolve := done && !active.resolved
+	if resolve {
+		active.resolved = true
+	}
+
+	// Our wager is only resolved by the result both players signed, so
+	// a finished match is followed until the opponent signed it too.
+	coSigned := active.match.CoSigned()
+	resolveWager := coSigned && !active.wagerResolved
+	if resolveWager {
+		active.wagerResolved = true
+	}
+	switch {
+	case coSigned:
+		active.cancel()
+
+	case resolve:
+		time.AfterFunc(receiptTimeout, active.cancel)
+	}
+	private := active.peer != ""
+	active.Unlock()
+
+	if m.wagers != nil {
+		m.payWager(active)
+		if resolveWager {
+			m.resolveWager(active.match.MatchId, active.side, winner)
+		}
+	}
+
+	// The result of a private match can only be verified by others once
+	// its bundle is published, which includes rating it ourselves. The
+	// bundle includes our signed result.
+	if resolve {
+		go func() {
+			m.signResult(active)
+			if private {
+				m.publishBundle(active)
+			}
+			if m.ratings != nil {
+				m.rateMatch(active.match.MatchId)
+			}
+		}()
+	}
+
+	if m.antes != nil {
+		if ev.Kind == FightMonAnteEscrow {
+			m.updateAnte(active.match.MatchId, ev)
+		}
+		if resolve {
+			m.claimAnte(active, winner)
+		}
+	}
+
+	if reveal == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, time.Minute)
+	defer cancel()
+	revealEv, err := GetFightMonRoundCommitEvent(
+		active.match.MatchId, *reveal,
+	)
+	if err == nil {
+		err = m.publish(ctx, active.match.MatchId, revealEv)
+	}
+	if err != nil {
+		log.Printf("Unable to reveal seed for round %d of match %v: %v",
+			reveal.RoundID, active.match.MatchId, err)
+
+		active.Lock()
+		delete(active.revealed, reveal.RoundID)
+		active.Unlock()
+	}
+}
+
+// pendingReveal returns our reveal for the current round if both players
+// committed and we didn't reveal yet. The caller must hold the lock.
+func (m *Manager) pendingReveal(active *activeMatch) *FightMonRoundCommitEvent {
+	battle := active.match.Battle()
+	if battle == nil {
+		return nil
+	}
+	if done, _ := active.match.Finished(); done {
+		return nil
+	}
+
+	roundId := battle.Round()
+	ours := active.match.Commit(roundId, active.side)
+	theirs := active.match.Commit(roundId, active.side.Opponent())
+	if ours == nil || theirs == nil || active.revealed[roundId] {
+		return nil
+	}
+
+	secret, ok := active.secrets[roundId]
+	if !ok {
+		return nil
+	}
+	active.revealed[roundId] = true
+
+	return &FightMonRoundCommitEvent{
+		RoundID: roundId,
+		Action:  int(secret.action),
+		RngSeed: secret.seed,
+		Target:  secret.target,
+	}
+}
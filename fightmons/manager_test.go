@@ -0,0 +1,338 @@
+package fightmons
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/nbd-wtf/go-nostr/nip44"
+	"github.com/stretchr/testify/require"
+)
+
+// memRelay is an in-memory relay for tests.
+type memRelay struct {
+	mu     sync.Mutex
+	events []*nostr.Event
+	subs   map[*memSub]struct{}
+}
+
+type memSub struct {
+	filters nostr.Filters
+	events  chan *nostr.Event
+}
+
+func newMemRelay() *memRelay {
+	return &memRelay{
+		subs: make(map[*memSub]struct{}),
+	}
+}
+
+func (r *memRelay) add(ev *nostr.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, ev)
+	for sub := range r.subs {
+		if sub.filters.Match(ev) {
+			sub.events <- ev
+		}
+	}
+}
+
+// memTransport is a transport that publishes to a memRelay.
+type memTransport struct {
+	relay *memRelay
+	sk    string
+	pub   string
+}
+
+func newMemTransport(t *testing.T, relay *memRelay) *memTransport {
+	sk := nostr.GeneratePrivateKey()
+	pub, err := nostr.GetPublicKey(sk)
+	require.NoError(t, err)
+
+	return &memTransport{
+		relay: relay,
+		sk:    sk,
+		pub:   pub,
+	}
+}
+
+func (m *memTransport) PubKey() string {
+	return m.pub
+}
+
+func (m *memTransport) Publish(_ context.Context, ev *nostr.Event) error {
+	ev.CreatedAt = nostr.Now()
+	if err := ev.Sign(m.sk); err != nil {
+		return err
+	}
+
+	// Publish a copy so that the receivers can't modify our event.
+	published := *ev
+	m.relay.add(&published)
+
+	return nil
+}
+
+func (m *memTransport) Sign(ev *nostr.Event) error {
+	if ev.CreatedAt == 0 {
+		ev.CreatedAt = nostr.Now()
+	}
+
+	return ev.Sign(m.sk)
+}
+
+func (m *memTransport) Encrypt(pubKey, plaintext string) (string, error) {
+	key, err := nip44.GenerateConversationKey(pubKey, m.sk)
+	if err != nil {
+		return "", err
+	}
+
+	// The nonce is passed explicitly, as the nip44 version we use doesn't
+	// generate a random one by itself.
+	var nonce [32]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+
+	return nip44.Encrypt(plaintext, key, nip44.WithCustomNonce(nonce[:]))
+}
+
+func (m *memTransport) Decrypt(pubKey, ciphertext string) (string, error) {
+	key, err := nip44.GenerateConversationKey(pubKey, m.sk)
+	if err != nil {
+		return "", err
+	}
+
+	return nip44.Decrypt(ciphertext, key)
+}
+
+func (m *memTransport) Subscribe(ctx context.Context,
+	filters nostr.Filters) (<-chan *nostr.Event, error) {
+
+	sub := &memSub{
+		filters: filters,
+		events:  make(chan *nostr.Event, 1000),
+	}
+
+	m.relay.mu.Lock()
+	for _, ev := range m.relay.events {
+		if filters.Match(ev) {
+			sub.events <- ev
+		}
+	}
+	m.relay.subs[sub] = struct{}{}
+	m.relay.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.relay.mu.Lock()
+		delete(m.relay.subs, sub)
+		close(sub.events)
+		m.relay.mu.Unlock()
+	}()
+
+	return sub.events, nil
+}
+
+func (m *memTransport) Query(_ context.Context,
+	filters nostr.Filters) ([]*nostr.Event, error) {
+
+	m.relay.mu.Lock()
+	defer m.relay.mu.Unlock()
+
+	var events []*nostr.Event
+	for _, ev := range m.relay.events {
+		if filters.Match(ev) {
+			events = append(events, ev)
+		}
+	}
+
+	return events, nil
+}
+
+// waitForUpdate waits for an update of the given type, or any update if
+// the type is negative.
+func waitForUpdate(t *testing.T, updates <-chan *MatchUpdate,
+	updateType UpdateType) *MatchUpdate {
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case update := <-updates:
+			if updateType < 0 || update.Type == updateType {
+				return update
+			}
+
+		case <-timeout:
+			t.Fatalf("timeout waiting for update %v", updateType)
+		}
+	}
+}
+
+// playManagerMatch plays a full match between the two managers, where the
+// host attacks and the challenger uses special attacks, and returns the
+// match id and the winner. The optional onStarted function is called by
+// both players when the match started, before they submit their first
+// action.
+func playManagerMatch(t *testing.T, host, challenger *Manager,
+	onStarted func(player *Manager, matchId string) error) (string, Side) {
+
+	return playWageredMatch(t, host, challenger, onStarted, 0)
+}
+
+// playWageredMatch plays a full match like playManagerMatch. If a wager is
+// given, the host accepts the challenge with a wager of that amount and the
+// challenger offers the same amount once the challenge was accepted.
+func playWageredMatch(t *testing.T, host, challenger *Manager,
+	onStarted func(player *Manager, matchId string) error,
+	wager btcutil.Amount) (string, Side) {
+
+	ctx := context.Background()
+	hostMon, challengerMon := newTestPlayer(t).mon, newTestPlayer(t).mon
+
+	lobbies, matchId, err := host.FindMatch(ctx, true)
+	require.NoError(t, err)
+	require.Empty(t, lobbies)
+	require.NotEmpty(t, matchId)
+
+	hostUpdates, cancel, err := host.SubscribeMatch(matchId)
+	require.NoError(t, err)
+	defer cancel()
+
+	lobbies, _, err = challenger.FindMatch(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, lobbies, 1)
+	require.Equal(t, matchId, lobbies[0].MatchId)
+	require.Equal(t, host.PubKey(), lobbies[0].Host)
+
+	require.NoError(t, challenger.ChallengeMon(
+		ctx, matchId, Party{challengerMon},
+	))
+	challengerUpdates, cancel, err := challenger.SubscribeMatch(matchId)
+	require.NoError(t, err)
+	defer cancel()
+
+	update := waitForUpdate(t, hostUpdates, UpdateChallenged)
+	require.Equal(t, challenger.PubKey(), update.Event.PubKey)
+
+	// Both players submit their action whenever a new round starts until
+	// the match is finished. The players report back the winner or the
+	// first error, as only the test goroutine may fail the test.
+	type outcome struct {
+		winner Side
+		err    error
+	}
+	play := func(player *Manager, updates <-chan *MatchUpdate,
+		action Action, outcomes chan<- outcome) {
+
+		winner, err := playMatch(
+			ctx, player, matchId, updates, action, onStarted,
+		)
+		outcomes <- outcome{winner: winner, err: err}
+	}
+	hostOutcome, challengerOutcome := make(chan outcome, 1),
+		make(chan outcome, 1)
+	go play(host, hostUpdates, ActionAttack, hostOutcome)
+	go play(challenger, challengerUpdates, ActionSpecial, challengerOutcome)
+
+	if wager == 0 {
+		err = host.AcceptChallenge(
+			ctx, matchId, challenger.PubKey(), Party{hostMon},
+		)
+		require.NoError(t, err)
+	} else {
+		err = host.AcceptWageredChallenge(
+			ctx, matchId, challenger.PubKey(), Party{hostMon},
+			wager,
+		)
+		require.NoError(t, err)
+
+		// The challenger can offer its wager once it received the
+		// accept event.
+		require.Eventually(t, func() bool {
+			_, err := challenger.OfferWager(ctx, matchId, wager)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	hostResult, challengerResult := <-hostOutcome, <-challengerOutcome
+	require.NoError(t, hostResult.err)
+	require.NoError(t, challengerResult.err)
+	require.Equal(t, hostResult.winner, challengerResult.winner)
+
+	return matchId, hostResult.winner
+}
+
+// playMatch submits the action whenever a new round of the match starts
+// until the match is finished and returns the winner. The optional onStarted
+// function is called when the match started, before the first action is
+// submitted.
+func playMatch(ctx context.Context, player *Manager, matchId string,
+	updates <-chan *MatchUpdate, action Action,
+	onStarted func(player *Manager, matchId string) error) (Side, error) {
+
+	timeout := time.After(10 * time.Second)
+	for {
+		var update *MatchUpdate
+		select {
+		case update = <-updates:
+		case <-timeout:
+			return SideNone, errors.New("timeout")
+		}
+
+		if update.Type == UpdateStarted && onStarted != nil {
+			if err := onStarted(player, matchId); err != nil {
+				return SideNone, err
+			}
+		}
+
+		switch {
+		case update.Type == UpdateStarted,
+			update.Type == UpdateRoundResult &&
+				!update.Result.Final():
+
+			_, err := player.SubmitAction(ctx, matchId, action)
+			if err != nil {
+				return SideNone, err
+			}
+
+		case update.Type == UpdateFinished:
+			return update.Winner, nil
+		}
+	}
+}
+
+// TestManagerFight tests that two managers can play a full fight against
+// each other.
+func TestManagerFight(t *testing.T) {
+	ctx := context.Background()
+	relay := newMemRelay()
+
+	host := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer host.Stop()
+	challenger := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer challenger.Stop()
+
+	matchId, winner := playManagerMatch(t, host, challenger, nil)
+
+	// Submitting after the match finished must fail.
+	_, err := host.SubmitAction(ctx, matchId, ActionAttack)
+	require.Error(t, err)
+
+	// The published events verify to the same winner.
+	events, err := host.transport.Query(
+		ctx, nostr.Filters{MatchFilter(matchId)},
+	)
+	require.NoError(t, err)
+	verified, err := VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+	require.Equal(t, winner, verified.Winner)
+}
@@ -0,0 +1,755 @@
+package fightmons
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+var (
+	// ErrNotParticipant is returned when an event is authored by someone
+	// that is not a player of the match. Such events can be published by
+	// anyone and don't affect the match.
+	ErrNotParticipant = errors.New("event not authored by a participant")
+
+	// ErrWrongMatch is returned when an event belongs to another match.
+	ErrWrongMatch = errors.New("event belongs to another match")
+
+	// ErrInvalidRequest is returned for match requests that can't be
+	// accepted. As anyone can request a match, such requests don't affect
+	// the match.
+	ErrInvalidRequest = errors.New("invalid match request")
+
+	// ErrNotHost is returned for lobby events of a match whose id was not
+	// derived from the pubkey of the author. As anyone can publish such
+	// events, they don't affect the match.
+	ErrNotHost = errors.New("match id not derived from author")
+
+	// ErrInvalidResult is returned for result events that don't follow
+	// from the events of the match. Results are only claims of their
+	// authors, so such events don't affect the match.
+	ErrInvalidResult = errors.New("invalid match result")
+
+	// errNotReady is returned when an event can't be applied yet because
+	// the events it builds upon haven't been seen yet.
+	errNotReady = errors.New("event not ready")
+)
+
+// EventError is an error caused by applying a specific event to a match.
+type EventError struct {
+	// Event is the event that couldn't be applied.
+	Event *nostr.Event
+
+	// Err is the reason the event couldn't be applied.
+	Err error
+
+	// Violation is true if the event was authored by a player of the
+	// started match and breaks the protocol, e.g. a second commit in a
+	// round or a reveal that doesn't match its commit.
+	Violation bool
+}
+
+// Error returns the error string.
+func (e *EventError) Error() string {
+	return fmt.Sprintf("event %v: %v", e.Event.ID, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *EventError) Unwrap() error {
+	return e.Err
+}
+
+// UpdateType describes what changed in a match after an event was applied.
+type UpdateType int
+
+const (
+	// UpdateLobbyOpened is sent when the host opened the match.
+	UpdateLobbyOpened UpdateType = iota
+
+	// UpdateChallenged is sent when a player requested the match.
+	UpdateChallenged
+
+	// UpdateAccepted is sent when the host accepted a challenger.
+	UpdateAccepted
+
+	// UpdateStarted is sent when the match started.
+	UpdateStarted
+
+	// UpdateCommitted is sent when a player committed to an action.
+	UpdateCommitted
+
+	// UpdateRevealed is sent when a player revealed their rng seed.
+	UpdateRevealed
+
+	// UpdateRoundResult is sent when a round has been resolved.
+	UpdateRoundResult
+
+	// UpdateFinished is sent when the match is over.
+	UpdateFinished
+
+	// UpdateResultSigned is sent when a player signed the result of the
+	// finished match.
+	UpdateResultSigned
+)
+
+// MatchUpdate is a change of a match caused by an event.
+type MatchUpdate struct {
+	Type    UpdateType
+	Event   *nostr.Event
+	Side    Side
+	RoundId int
+	Action  Action
+
+	// Target is the party index of the fightmon a revealed switch
+	// targets.
+	Target int
+
+	// Players and Parties are the pubkeys and parties of the host and
+	// the challenger, only set for accepted updates.
+	Players [2]string
+	Parties [2]Party
+
+	Result *RoundResult
+	Winner Side
+
+	// TimedOut is true if the match finished because the opponent of the
+	// winner missed a deadline, only set for finished updates.
+	TimedOut bool
+}
+
+// Match tracks the state of a single match by applying its nostr events. It
+// validates every event, so a match that was built from a set of events is
+// a verification of those events. Events may be added in any order, events
+// that can't be applied yet are kept until they can.
+type Match struct {
+	MatchId string
+
+	// Players are the pubkeys of the host and the challenger.
+	Players [2]string
+
+	// Parties are the parties of the host and the challenger.
+	Parties [2]Party
+
+	// Mons are the lead fightmons of the host and the challenger.
+	Mons [2]*FightMon
+
+	// Requests are the parties of the match requests by challenger pubkey.
+	Requests map[string]Party
+
+	// Rounds are the results of all resolved rounds.
+	Rounds []*RoundResult
+
+	// Version is the protocol version negotiated for the match, which all
+	// events after the lobby and the match requests are encoded with. It
+	// is set once the host accepted a challenger.
+	Version int
+
+	// RoundTimeout is the time the players have to commit and to reveal
+	// in each round, set by the lobby of the host. It is only enforced
+	// from DeadlineVersion on.
+	RoundTimeout time.Duration
+
+	hostVersions    []int
+	requestVersions map[string][]int
+
+	started bool
+	battle  *Battle
+	phase   phase
+
+	// timedOut is true if the match was won by timeoutWinner because
+	// the opponent missed a deadline.
+	timedOut      bool
+	timeoutWinner Side
+
+	// receipts are the result events signed by the host and the
+	// challenger.
+	receipts [2]*nostr.Event
+
+	commits map[int]*[2]*FightMonRoundEvent
+	reveals map[int]*[2]*FightMonRoundCommitEvent
+
+	seen    map[string]struct{}
+	pending []*nostr.Event
+	ignored []*nostr.Event
+}
+
+// IsIgnorable returns true if the error was caused by an event that anyone
+// could have published and that therefore must not invalidate a match. Late
+// or backdated round events and invalid results of the players don't count
+// either, they are answered by a timeout claim or a dispute instead.
+func IsIgnorable(err error) bool {
+	return errors.Is(err, ErrNotParticipant) ||
+		errors.Is(err, ErrWrongMatch) ||
+		errors.Is(err, ErrNotHost) ||
+		errors.Is(err, ErrInvalidRequest) ||
+		errors.Is(err, ErrLate) ||
+		errors.Is(err, ErrBackdated) ||
+		errors.Is(err, ErrInvalidResult)
+}
+
+// NewMatch creates a new empty match.
+func NewMatch(matchId string) *Match {
+	return &Match{
+		MatchId:         matchId,
+		Requests:        make(map[string]Party),
+		requestVersions: make(map[string][]int),
+		commits:         make(map[int]*[2]*FightMonRoundEvent),
+		reveals:         make(map[int]*[2]*FightMonRoundCommitEvent),
+		seen:            make(map[string]struct{}),
+	}
+}
+
+// Started returns true if the match has been started by the host.
+func (m *Match) Started() bool {
+	return m.started
+}
+
+// Battle returns the battle of the match or nil if it didn't start yet.
+func (m *Match) Battle() *Battle {
+	return m.battle
+}
+
+// Finished returns true if the match is over, together with the winner.
+func (m *Match) Finished() (bool, Side) {
+	if m.timedOut {
+		return true, m.timeoutWinner
+	}
+	if m.battle == nil {
+		return false, SideNone
+	}
+
+	return m.battle.Finished()
+}
+
+// SideOf returns the side of the given pubkey in the match.
+func (m *Match) SideOf(pubKey string) (Side, bool) {
+	switch {
+	case pubKey == "":
+		return SideNone, false
+	case pubKey == m.Players[SideHost]:
+		return SideHost, true
+	case pubKey == m.Players[SideChallenger]:
+		return SideChallenger, true
+	default:
+		return SideNone, false
+	}
+}
+
+// Ignored returns the events of the match that were rejected because anyone
+// could have published them, e.g. events by third parties or invalid match
+// requests.
+func (m *Match) Ignored() []*nostr.Event {
+	return m.ignored
+}
+
+// Pending returns the events that were added but couldn't be applied yet
+// because the events they build upon are missing.
+func (m *Match) Pending() []*nostr.Event {
+	return m.pending
+}
+
+// NegotiatedVersion returns the protocol version a match with the given
+// challenger is played with, the highest version both the host and the
+// challenger speak.
+func (m *Match) NegotiatedVersion(challenger string) (int, error) {
+	versions, ok := m.requestVersions[challenger]
+	if !ok {
+		return 0, fmt.Errorf("no match request by %v", challenger)
+	}
+
+	return NegotiateVersion(m.hostVersions, versions)
+}
+
+// Commit returns the commit of the given side for the given round.
+func (m *Match) Commit(roundId int, side Side) *FightMonRoundEvent {
+	commits, ok := m.commits[roundId]
+	if !ok {
+		return nil
+	}
+
+	return commits[side]
+}
+
+// AddEvent validates and applies an event to the match. It returns the
+// updates that happened because of the event, which might include updates
+// caused by previously added events that could be applied now. Errors are
+// returned as an EventError naming the event that caused them, which is not
+// necessarily the added event. Updates that happened before such an error
+// are returned together with it.
+func (m *Match) AddEvent(ev *nostr.Event) ([]*MatchUpdate, error) {
+	if _, ok := m.seen[ev.ID]; ok {
+		return nil, nil
+	}
+
+	if GetMatchId(ev) != m.MatchId {
+		return nil, &EventError{Event: ev, Err: ErrWrongMatch}
+	}
+
+	if ev.ID != ev.GetID() {
+		return nil, &EventError{
+			Event: ev,
+			Err:   fmt.Errorf("invalid event id %v", ev.ID),
+		}
+	}
+	ok, err := ev.CheckSignature()
+	if err != nil {
+		return nil, &EventError{
+			Event: ev,
+			Err:   fmt.Errorf("unable to check signature: %w", err),
+		}
+	}
+	if !ok {
+		return nil, &EventError{
+			Event: ev,
+			Err:   fmt.Errorf("invalid signature"),
+		}
+	}
+	m.seen[ev.ID] = struct{}{}
+
+	updates, err := m.apply(ev)
+	switch {
+	case errors.Is(err, errNotReady):
+		m.pending = append(m.pending, ev)
+		return nil, nil
+
+	case IsIgnorable(err):
+		m.ignored = append(m.ignored, ev)
+		return nil, &EventError{Event: ev, Err: err}
+
+	case err != nil:
+		return nil, m.eventError(ev, err)
+	}
+
+	pendingUpdates, err := m.applyPending()
+
+	return append(updates, pendingUpdates...), err
+}
+
+// applyPending applies pending events until no more progress is made.
+// Pending events that turn out to be ignorable are moved to the ignored
+// events.
+func (m *Match) applyPending() ([]*MatchUpdate, error) {
+	var updates []*MatchUpdate
+	for progress := true; progress; {
+		progress = false
+		for i := 0; i < len(m.pending); i++ {
+			ev := m.pending[i]
+			update, err := m.apply(ev)
+			if errors.Is(err, errNotReady) {
+				continue
+			}
+
+			m.pending = append(m.pending[:i], m.pending[i+1:]...)
+			i--
+			switch {
+			case IsIgnorable(err):
+				m.ignored = append(m.ignored, ev)
+				continue
+
+			case err != nil:
+				return updates, m.eventError(ev, err)
+			}
+
+			updates = append(updates, update...)
+			progress = true
+		}
+	}
+
+	return updates, nil
+}
+
+// eventError returns the error of an event that couldn't be applied. Only
+// the players can author valid events of a started match, so any other
+// error of their events is a violation of the protocol.
+func (m *Match) eventError(ev *nostr.Event, err error) *EventError {
+	_, player := m.SideOf(ev.PubKey)
+
+	return &EventError{
+		Event:     ev,
+		Err:       err,
+		Violation: player && m.started,
+	}
+}
+
+// apply applies a single event whose signature has already been checked.
+func (m *Match) apply(ev *nostr.Event) ([]*MatchUpdate, error) {
+	switch ev.Kind {
+	case FightMonLookingForMatch:
+		return m.applyLookingForMatch(ev)
+
+	case FightMonRequestMatch:
+		return m.applyRequestMatch(ev)
+
+	case FightMonAcceptMatch:
+		return m.applyAcceptMatch(ev)
+
+	case FightMonStartMatch:
+		return m.applyStartMatch(ev)
+
+	case FightMonRound:
+		return m.applyRound(ev)
+
+	case FightMonRoundCommit:
+		return m.applyRoundCommit(ev)
+
+	case FightMonResult:
+		return m.applyResult(ev)
+
+	// Wagers and antes are settled outside of the match and don't affect
+	// it. Bundles only repeat the events of private matches and are
+	// expanded by VerifyMatch, peers and relays are only used by the
+	// transport.
+	case FightMonWager, FightMonAnte, FightMonAnteEscrow,
+		FightMonAnteClaim, FightMonMatchBundle, FightMonPeer,
+		FightMonTurnRelay:
+
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("unknown event kind %d", ev.Kind)
+	}
+}
+
+func (m *Match) applyLookingForMatch(ev *nostr.Event) ([]*MatchUpdate,
+	error) {
+
+	// Only the lobby of the player the match id was derived from opens the
+	// match, so nobody can take over the match with a backdated lobby.
+	nonceTag := ev.Tags.GetFirst([]string{MatchNonceTag})
+	if nonceTag == nil {
+		return nil, fmt.Errorf("%w: lobby without match nonce",
+			ErrNotHost)
+	}
+	err := CheckMatchId(m.MatchId, ev.PubKey, nonceTag.Value())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotHost, err)
+	}
+
+	if m.Players[SideHost] == ev.PubKey {
+		return nil, nil
+	}
+
+	if err := decodeEvent(ev, nil); err != nil {
+		return nil, err
+	}
+	versions, err := EventVersions(ev)
+	if err != nil {
+		return nil, err
+	}
+	roundTimeout, err := EventRoundTimeout(ev)
+	if err != nil {
+		return nil, err
+	}
+	m.Players[SideHost] = ev.PubKey
+	m.hostVersions = versions
+	m.RoundTimeout = roundTimeout
+
+	return []*MatchUpdate{{
+		Type:  UpdateLobbyOpened,
+		Event: ev,
+		Side:  SideHost,
+	}}, nil
+}
+
+func (m *Match) applyRequestMatch(ev *nostr.Event) ([]*MatchUpdate, error) {
+	if m.Players[SideHost] == "" {
+		return nil, errNotReady
+	}
+	if ev.PubKey == m.Players[SideHost] {
+		return nil, fmt.Errorf("%w: host can't request own match",
+			ErrInvalidRequest)
+	}
+	if _, ok := m.Requests[ev.PubKey]; ok {
+		return nil, fmt.Errorf("%w: duplicate request by %v",
+			ErrInvalidRequest, ev.PubKey)
+	}
+
+	var party Party
+	if err := decodeEvent(ev, &party); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	versions, err := EventVersions(ev)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	if _, err := NegotiateVersion(m.hostVersions, versions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	m.Requests[ev.PubKey] = party
+	m.requestVersions[ev.PubKey] = versions
+
+	return []*MatchUpdate{{
+		Type:  UpdateChallenged,
+		Event: ev,
+		Side:  SideChallenger,
+	}}, nil
+}
+
+func (m *Match) applyAcceptMatch(ev *nostr.Event) ([]*MatchUpdate, error) {
+	if m.Players[SideHost] == "" {
+		return nil, errNotReady
+	}
+	if ev.PubKey != m.Players[SideHost] {
+		return nil, ErrNotParticipant
+	}
+	if m.Players[SideChallenger] != "" {
+		return nil, fmt.Errorf("match already accepted")
+	}
+
+	challengerTag := ev.Tags.GetFirst([]string{"p"})
+	if challengerTag == nil {
+		return nil, fmt.Errorf("match accept without challenger")
+	}
+	challenger := challengerTag.Value()
+	request, ok := m.Requests[challenger]
+	if !ok {
+		return nil, errNotReady
+	}
+
+	var party Party
+	if err := decodeEvent(ev, &party); err != nil {
+		return nil, fmt.Errorf("invalid match accept: %w", err)
+	}
+
+	// The host must accept with the highest version both players speak.
+	version, err := m.NegotiatedVersion(challenger)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEventVersion(ev, version); err != nil {
+		return nil, err
+	}
+
+	m.Version = version
+	m.Players[SideChallenger] = challenger
+	m.Parties[SideHost] = party
+	m.Parties[SideChallenger] = request
+	m.Mons[SideHost] = party.Lead()
+	m.Mons[SideChallenger] = request.Lead()
+
+	return []*MatchUpdate{{
+		Type:    UpdateAccepted,
+		Event:   ev,
+		Side:    SideHost,
+		Players: m.Players,
+		Parties: m.Parties,
+	}}, nil
+}
+
+func (m *Match) applyStartMatch(ev *nostr.Event) ([]*MatchUpdate, error) {
+	if m.Players[SideHost] == "" {
+		return nil, errNotReady
+	}
+	if ev.PubKey != m.Players[SideHost] {
+		return nil, ErrNotParticipant
+	}
+	if m.Players[SideChallenger] == "" {
+		return nil, errNotReady
+	}
+	if m.started {
+		return nil, nil
+	}
+	if err := decodeEvent(ev, nil); err != nil {
+		return nil, err
+	}
+	if err := checkEventVersion(ev, m.Version); err != nil {
+		return nil, err
+	}
+
+	battle, err := NewBattle(
+		m.Parties[SideHost], m.Parties[SideChallenger],
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.battle = battle
+	m.started = true
+	m.phase = phase{start: ev.CreatedAt}
+
+	return []*MatchUpdate{{
+		Type:  UpdateStarted,
+		Event: ev,
+		Side:  SideHost,
+	}}, nil
+}
+
+// playerSide returns the side of the author of a round event, or
+// errNotReady if the players aren't known yet.
+func (m *Match) playerSide(ev *nostr.Event) (Side, error) {
+	side, ok := m.SideOf(ev.PubKey)
+	switch {
+	case ok && m.started:
+		return side, nil
+
+	// Until the match started, we can't tell whether the author will
+	// become a player or not.
+	case !m.started:
+		return SideNone, errNotReady
+
+	default:
+		return SideNone, ErrNotParticipant
+	}
+}
+
+// checkRoundId makes sure round events are only applied for the round that
+// is currently played. Events for rounds after the end of the match are
+// kept pending, so they are reported as never applied regardless of the
+// order the events were added in.
+func (m *Match) checkRoundId(roundId int) error {
+	if done, _ := m.Finished(); done {
+		return errNotReady
+	}
+
+	switch current := m.battle.Round(); {
+	case roundId < current:
+		return fmt.Errorf("round event for already resolved round %d",
+			roundId)
+
+	case roundId > current:
+		return errNotReady
+	}
+
+	return nil
+}
+
+func (m *Match) applyRound(ev *nostr.Event) ([]*MatchUpdate, error) {
+	side, err := m.playerSide(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	var round FightMonRoundEvent
+	if err := decodeEvent(ev, &round); err != nil {
+		return nil, fmt.Errorf("invalid round event: %w", err)
+	}
+	if err := checkEventVersion(ev, m.Version); err != nil {
+		return nil, err
+	}
+	if err := m.checkRoundId(round.RoundId); err != nil {
+		return nil, err
+	}
+	if err := m.checkDeadline(ev); err != nil {
+		return nil, err
+	}
+
+	commits, ok := m.commits[round.RoundId]
+	if !ok {
+		commits = &[2]*FightMonRoundEvent{}
+		m.commits[round.RoundId] = commits
+	}
+	if commits[side] != nil {
+		return nil, fmt.Errorf("%v committed twice in round %d", side,
+			round.RoundId)
+	}
+	commits[side] = &round
+	m.act(side, ev)
+
+	return []*MatchUpdate{{
+		Type:    UpdateCommitted,
+		Event:   ev,
+		Side:    side,
+		RoundId: round.RoundId,
+	}}, nil
+}
+
+func (m *Match) applyRoundCommit(ev *nostr.Event) ([]*MatchUpdate, error) {
+	side, err := m.playerSide(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	var reveal FightMonRoundCommitEvent
+	if err := decodeEvent(ev, &reveal); err != nil {
+		return nil, fmt.Errorf("invalid round commit event: %w", err)
+	}
+	if err := checkEventVersion(ev, m.Version); err != nil {
+		return nil, err
+	}
+	if err := m.checkRoundId(reveal.RoundID); err != nil {
+		return nil, err
+	}
+
+	// Seeds are only revealed once both players committed, which is when
+	// the phase of the reveals begins.
+	commit := m.Commit(reveal.RoundID, side)
+	if commit == nil || m.Commit(reveal.RoundID, side.Opponent()) == nil {
+		return nil, errNotReady
+	}
+	if err := m.checkDeadline(ev); err != nil {
+		return nil, err
+	}
+	action := Action(reveal.Action)
+	err = m.battle.CheckAction(side, action, reveal.Target)
+	if err != nil {
+		return nil, fmt.Errorf("illegal action %d by %v in round %d: "+
+			"%w", reveal.Action, side, reveal.RoundID, err)
+	}
+	err = CheckRoundSeed(
+		commit.RngHash, action, reveal.Target, reveal.RngSeed,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%v in round %d: %w", side,
+			reveal.RoundID, err)
+	}
+
+	reveals, ok := m.reveals[reveal.RoundID]
+	if !ok {
+		reveals = &[2]*FightMonRoundCommitEvent{}
+		m.reveals[reveal.RoundID] = reveals
+	}
+	if reveals[side] != nil {
+		return nil, fmt.Errorf("%v revealed twice in round %d", side,
+			reveal.RoundID)
+	}
+	reveals[side] = &reveal
+	m.act(side, ev)
+
+	updates := []*MatchUpdate{{
+		Type:    UpdateRevealed,
+		Event:   ev,
+		Side:    side,
+		RoundId: reveal.RoundID,
+		Action:  action,
+		Target:  reveal.Target,
+	}}
+	if reveals[SideHost] == nil || reveals[SideChallenger] == nil {
+		return updates, nil
+	}
+
+	result, err := m.battle.PlayRound(
+		[2]Action{
+			Action(reveals[SideHost].Action),
+			Action(reveals[SideChallenger].Action),
+		},
+		[2]int{reveals[SideHost].Target, reveals[SideChallenger].Target},
+		[2]string{
+			reveals[SideHost].RngSeed,
+			reveals[SideChallenger].RngSeed,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.Rounds = append(m.Rounds, result)
+	updates = append(updates, &MatchUpdate{
+		Type:    UpdateRoundResult,
+		Event:   ev,
+		Side:    SideNone,
+		RoundId: result.RoundId,
+		Result:  result,
+	})
+
+	if done, winner := m.battle.Finished(); done {
+		updates = append(updates, &MatchUpdate{
+			Type:    UpdateFinished,
+			Event:   ev,
+			Side:    SideNone,
+			RoundId: result.RoundId,
+			Winner:  winner,
+		})
+	}
+
+	return updates, nil
+}
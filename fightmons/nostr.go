@@ -1,7 +1,11 @@
 package fightmons
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 
 	"github.com/nbd-wtf/go-nostr"
 )
@@ -34,47 +38,258 @@ const (
 	// FightMonRoundCommit is the kind for FightMon events where a player
 	// commits their move for a round by publishing their rng seed.
 	FightMonRoundCommit
+
+	// FightMonWager is the kind for FightMon events where a player
+	// publishes the hold invoice their opponent pays to lock their stake
+	// of a wager on the match.
+	FightMonWager
+
+	// FightMonAnte is the kind for FightMon events where a player offers
+	// a mon as ante that is held by an escrow until the match is over.
+	FightMonAnte
+
+	// FightMonAnteEscrow is the kind for FightMon events where the escrow
+	// of an ante publishes where to deposit the mon and the state of the
+	// ante.
+	FightMonAnteEscrow
+
+	// FightMonAnteClaim is the kind for FightMon events where the winner
+	// of a match claims the ante of the loser from the escrow.
+	FightMonAnteClaim
+
+	// FightMonTournament is the kind for FightMon events where an
+	// organizer announces a tournament and its entry rules.
+	FightMonTournament
+
+	// FightMonTournamentRegister is the kind for FightMon events where a
+	// player registers a mon for a tournament.
+	FightMonTournamentRegister
+
+	// FightMonTournamentEntry is the kind for FightMon events where the
+	// organizer publishes the invoice a player pays as entry fee.
+	FightMonTournamentEntry
+
+	// FightMonTournamentRound is the kind for FightMon events where the
+	// organizer publishes the pairings of a tournament round.
+	FightMonTournamentRound
+
+	// FightMonTournamentStandings is the kind for FightMon events where
+	// the organizer publishes the results of a tournament round and the
+	// standings after it.
+	FightMonTournamentStandings
+
+	// FightMonSealed is the kind for FightMon events that carry a signed
+	// fightmon event encrypted with NIP-44 for the opponent in a private
+	// match. Only the recipient can tell which match it belongs to.
+	FightMonSealed
+
+	// FightMonMatchBundle is the kind for FightMon events that publish
+	// all signed events of a finished private match, so anyone can verify
+	// its result.
+	FightMonMatchBundle
+
+	// FightMonPeer is the kind for FightMon events that announce the
+	// libp2p peer a player of a match can be reached at directly, so the
+	// events of the match can be exchanged without waiting for the relays.
+	FightMonPeer
+
+	// FightMonTurnRelay is the kind for FightMon events that announce the
+	// TURN relay address a player of a match can be reached at if neither
+	// player can be connected to directly.
+	FightMonTurnRelay
+
+	// FightMonResult is the kind for FightMon events where a player signs
+	// the result of a match, or claims that the opponent missed a
+	// deadline.
+	FightMonResult
+
+	// FightMonOwnership is the kind for FightMon events where a player
+	// publishes the proof of a mon they own, so that opponents can verify
+	// that they fought with their own mon.
+	FightMonOwnership
 )
 
-func GetFightMonLookingForMatchEvent(matchId string) *nostr.Event {
+const (
+	// MatchIdTag is the tag that binds a fightmon event to a match.
+	MatchIdTag = "match_id"
+
+	// MatchIndexTag is a single letter tag that also carries the match id.
+	// Relays only index single letter tags, so this is the tag to query
+	// the events of a match by.
+	MatchIndexTag = "t"
+
+	// MatchNonceTag is the tag of the lobby event that carries the nonce
+	// the match id was derived from.
+	MatchNonceTag = "match_nonce"
+
+	// RoundTimeoutTag is the tag of the lobby event that carries the
+	// round timeout of the match in seconds.
+	RoundTimeoutTag = "round_timeout"
+
+	// TournamentIdTag is the tag that binds a fightmon event to a
+	// tournament.
+	TournamentIdTag = "tournament_id"
+
+	// TournamentIndexTag is the single letter tag to query the events of
+	// a tournament by.
+	TournamentIndexTag = "t"
+
+	// TournamentNonceTag is the tag of the tournament event that carries
+	// the nonce the tournament id was derived from.
+	TournamentNonceTag = "tournament_nonce"
+)
+
+// FightMonKinds are all kinds of fightmon match events.
+var FightMonKinds = []int{
+	FightMonLookingForMatch,
+	FightMonRequestMatch,
+	FightMonAcceptMatch,
+	FightMonStartMatch,
+	FightMonRound,
+	FightMonRoundCommit,
+	FightMonWager,
+	FightMonAnte,
+	FightMonAnteEscrow,
+	FightMonAnteClaim,
+	FightMonMatchBundle,
+	FightMonPeer,
+	FightMonTurnRelay,
+	FightMonResult,
+}
+
+// TournamentKinds are all kinds of fightmon tournament events.
+var TournamentKinds = []int{
+	FightMonTournament,
+	FightMonTournamentRegister,
+	FightMonTournamentEntry,
+	FightMonTournamentRound,
+	FightMonTournamentStandings,
+}
+
+// GetMatchId returns the match id an event is tagged with.
+func GetMatchId(ev *nostr.Event) string {
+	tag := ev.Tags.GetFirst([]string{MatchIdTag})
+	if tag == nil {
+		return ""
+	}
+
+	return tag.Value()
+}
+
+// NewMatchId creates a new match id for the given host. The match id commits
+// to the pubkey of the host, so nobody else can open a lobby for it.
+func NewMatchId(hostPubKey string) (string, string, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", "", err
+	}
+	matchNonce := hex.EncodeToString(nonce[:])
+
+	matchId, err := deriveMatchId(hostPubKey, matchNonce)
+	if err != nil {
+		return "", "", err
+	}
+
+	return matchId, matchNonce, nil
+}
+
+// CheckMatchId checks that the match id was derived from the pubkey of the
+// host and the given nonce.
+func CheckMatchId(matchId, hostPubKey, matchNonce string) error {
+	expected, err := deriveMatchId(hostPubKey, matchNonce)
+	if err != nil {
+		return err
+	}
+	if expected != matchId {
+		return fmt.Errorf("match id %v was not derived from host %v",
+			matchId, hostPubKey)
+	}
+
+	return nil
+}
+
+func deriveMatchId(hostPubKey, matchNonce string) (string, error) {
+	pubKey, err := hex.DecodeString(hostPubKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid host pubkey: %w", err)
+	}
+	nonce, err := hex.DecodeString(matchNonce)
+	if err != nil {
+		return "", fmt.Errorf("invalid match nonce: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(pubKey)
+	h.Write(nonce)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// matchTags returns the tags that bind an event to the given match.
+func matchTags(matchId string) nostr.Tags {
+	return append(nostr.Tags{
+		nostr.Tag{MatchIdTag, matchId},
+		nostr.Tag{MatchIndexTag, matchId},
+	}, versionTags()...)
+}
+
+// GetFightMonLookingForMatchEvent returns the lobby event of a match. The
+// match id must have been created by NewMatchId for the author of the event.
+// The lobby lists the protocol versions we speak.
+func GetFightMonLookingForMatchEvent(matchId,
+	matchNonce string) *nostr.Event {
+
+	tags := append(
+		nostr.Tags{
+			nostr.Tag{MatchIdTag, matchId},
+			nostr.Tag{MatchIndexTag, matchId},
+			nostr.Tag{MatchNonceTag, matchNonce},
+		}, handshakeTags()...,
+	)
+
 	return &nostr.Event{
 		Kind: FightMonLookingForMatch,
-		Tags: nostr.Tags{
-			nostr.Tag{"match_id", matchId},
-		},
+		Tags: tags,
 	}
 }
 
-func GetFightMonRequestMatchEvent(matchId string, fightmon FightMon,
+// GetFightMonRequestMatchEvent returns the event that requests a match with
+// the given party. The request lists the protocol versions we speak.
+func GetFightMonRequestMatchEvent(matchId string, party Party,
 ) (*nostr.Event, error) {
 
-	data, err := json.Marshal(fightmon)
+	data, err := json.Marshal(party)
 	if err != nil {
 		return nil, err
 	}
 
+	tags := append(
+		nostr.Tags{
+			nostr.Tag{MatchIdTag, matchId},
+			nostr.Tag{MatchIndexTag, matchId},
+		}, handshakeTags()...,
+	)
+
 	return &nostr.Event{
-		Kind: FightMonRequestMatch,
-		Tags: nostr.Tags{
-			nostr.Tag{"match_id", matchId},
-		},
+		Kind:    FightMonRequestMatch,
+		Tags:    tags,
 		Content: string(data),
 	}, nil
 }
 
-func GetFightMonAcceptMatchEvent(matchId string, fightMons FightMon,
-) (*nostr.Event, error) {
+// GetFightMonAcceptMatchEvent returns the event that accepts the match
+// request of the challenger with the given pubkey with the party of the host.
+func GetFightMonAcceptMatchEvent(matchId, challenger string,
+	party Party) (*nostr.Event, error) {
 
-	data, err := json.Marshal(fightMons)
+	data, err := json.Marshal(party)
 	if err != nil {
 		return nil, err
 	}
 
 	return &nostr.Event{
-		Kind: FightMonAcceptMatch,
-		Tags: nostr.Tags{
-			nostr.Tag{"match_id", matchId},
-		},
+		Kind:    FightMonAcceptMatch,
+		Tags:    append(matchTags(matchId), nostr.Tag{"p", challenger}),
 		Content: string(data),
 	}, nil
 }
@@ -82,15 +297,14 @@ func GetFightMonAcceptMatchEvent(matchId string, fightMons FightMon,
 func GetFightMonStartMatchEvent(matchId string) *nostr.Event {
 	return &nostr.Event{
 		Kind: FightMonStartMatch,
-		Tags: nostr.Tags{
-			nostr.Tag{"match_id", matchId},
-		},
+		Tags: matchTags(matchId),
 	}
 }
 
+// FightMonRoundEvent commits to the action and rng seed of a player for a
+// round without revealing either of them.
 type FightMonRoundEvent struct {
 	RoundId int    `json:"round_id"`
-	Action  int    `json:"action"`
 	RngHash string `json:"rng_hash"`
 }
 
@@ -103,10 +317,8 @@ func GetFightMonRoundEvent(matchId string, round FightMonRoundEvent,
 	}
 
 	return &nostr.Event{
-		Kind: FightMonRound,
-		Tags: nostr.Tags{
-			nostr.Tag{"match_id", matchId},
-		},
+		Kind:    FightMonRound,
+		Tags:    matchTags(matchId),
 		Content: string(data),
 	}, nil
 }
@@ -115,6 +327,10 @@ type FightMonRoundCommitEvent struct {
 	RoundID int    `json:"round_id"`
 	Action  int    `json:"action"`
 	RngSeed string `json:"rng_seed"`
+
+	// Target is the party index of the fightmon to switch in, only set
+	// for switches.
+	Target int `json:"target,omitempty"`
 }
 
 func GetFightMonRoundCommitEvent(matchId string, commit FightMonRoundCommitEvent,
@@ -126,10 +342,390 @@ func GetFightMonRoundCommitEvent(matchId string, commit FightMonRoundCommitEvent
 	}
 
 	return &nostr.Event{
-		Kind: FightMonRoundCommit,
-		Tags: nostr.Tags{
-			nostr.Tag{"match_id", matchId},
-		},
+		Kind:    FightMonRoundCommit,
+		Tags:    matchTags(matchId),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonWagerEvent offers a wager on a match. The opponent locks their
+// stake by paying the hold invoice.
+type FightMonWagerEvent struct {
+	AmountSat int64  `json:"amount_sat"`
+	Invoice   string `json:"invoice"`
+}
+
+// GetFightMonWagerEvent returns the event that offers a wager on a match.
+func GetFightMonWagerEvent(matchId string, wager FightMonWagerEvent,
+) (*nostr.Event, error) {
+
+	data, err := json.Marshal(wager)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonWager,
+		Tags:    matchTags(matchId),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonAnteEvent offers a mon as ante of a match. The mon is deposited
+// with the escrow, which returns it to the refund address unless the player
+// loses the match.
+type FightMonAnteEvent struct {
+	Escrow     string `json:"escrow"`
+	AssetId    string `json:"asset_id"`
+	RefundAddr string `json:"refund_addr"`
+}
+
+// GetFightMonAnteEvent returns the event that offers a mon as ante of a
+// match to the given escrow.
+func GetFightMonAnteEvent(matchId string, ante FightMonAnteEvent,
+) (*nostr.Event, error) {
+
+	data, err := json.Marshal(ante)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonAnte,
+		Tags:    append(matchTags(matchId), nostr.Tag{"p", ante.Escrow}),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonAnteEscrowEvent is the state of the ante of a player as seen by
+// the escrow.
+type FightMonAnteEscrowEvent struct {
+	Player       string `json:"player"`
+	DepositAddr  string `json:"deposit_addr"`
+	State        int    `json:"state"`
+	TransferTxid string `json:"transfer_txid,omitempty"`
+}
+
+// GetFightMonAnteEscrowEvent returns the event that publishes the state of
+// the ante of a player.
+func GetFightMonAnteEscrowEvent(matchId string,
+	escrow FightMonAnteEscrowEvent) (*nostr.Event, error) {
+
+	data, err := json.Marshal(escrow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonAnteEscrow,
+		Tags:    append(matchTags(matchId), nostr.Tag{"p", escrow.Player}),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonAnteClaimEvent claims the ante of the loser of a match. The claim
+// address must be an address for the mon of the loser.
+type FightMonAnteClaimEvent struct {
+	ClaimAddr string `json:"claim_addr"`
+}
+
+// GetFightMonAnteClaimEvent returns the event that claims the ante of the
+// loser of a match from the escrow.
+func GetFightMonAnteClaimEvent(matchId, escrow string,
+	claim FightMonAnteClaimEvent) (*nostr.Event, error) {
+
+	data, err := json.Marshal(claim)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonAnteClaim,
+		Tags:    append(matchTags(matchId), nostr.Tag{"p", escrow}),
+		Content: string(data),
+	}, nil
+}
+
+// GetTournamentId returns the tournament id an event is tagged with.
+func GetTournamentId(ev *nostr.Event) string {
+	tag := ev.Tags.GetFirst([]string{TournamentIdTag})
+	if tag == nil {
+		return ""
+	}
+
+	return tag.Value()
+}
+
+// NewTournamentId creates a new tournament id for the given organizer. Like
+// a match id, it commits to the pubkey of the organizer, so nobody else can
+// announce the tournament.
+func NewTournamentId(organizer string) (string, string, error) {
+	return NewMatchId(organizer)
+}
+
+// CheckTournamentId checks that the tournament id was derived from the
+// pubkey of the organizer and the given nonce.
+func CheckTournamentId(tournamentId, organizer, nonce string) error {
+	expected, err := deriveMatchId(organizer, nonce)
+	if err != nil {
+		return err
+	}
+	if expected != tournamentId {
+		return fmt.Errorf("tournament id %v was not derived from "+
+			"organizer %v", tournamentId, organizer)
+	}
+
+	return nil
+}
+
+// tournamentTags returns the tags that bind an event to the given
+// tournament.
+func tournamentTags(tournamentId string) nostr.Tags {
+	return append(nostr.Tags{
+		nostr.Tag{TournamentIdTag, tournamentId},
+		nostr.Tag{TournamentIndexTag, tournamentId},
+	}, versionTags()...)
+}
+
+// GetFightMonTournamentEvent returns the event that announces a tournament
+// with the given rules. The tournament id must have been created by
+// NewTournamentId for the author of the event.
+func GetFightMonTournamentEvent(tournamentId, nonce string,
+	rules TournamentRules) (*nostr.Event, error) {
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind: FightMonTournament,
+		Tags: append(
+			tournamentTags(tournamentId),
+			nostr.Tag{TournamentNonceTag, nonce},
+		),
+		Content: string(data),
+	}, nil
+}
+
+// GetFightMonTournamentRegisterEvent returns the event that registers a
+// fightmon for the tournament of the given organizer.
+func GetFightMonTournamentRegisterEvent(tournamentId, organizer string,
+	fightMon FightMon) (*nostr.Event, error) {
+
+	data, err := json.Marshal(fightMon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind: FightMonTournamentRegister,
+		Tags: append(
+			tournamentTags(tournamentId), nostr.Tag{"p", organizer},
+		),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonTournamentEntryEvent is the invoice a registered player pays to
+// enter a tournament with an entry fee.
+type FightMonTournamentEntryEvent struct {
+	Player  string `json:"player"`
+	Invoice string `json:"invoice"`
+}
+
+// GetFightMonTournamentEntryEvent returns the event that publishes the entry
+// fee invoice of a player.
+func GetFightMonTournamentEntryEvent(tournamentId string,
+	entry FightMonTournamentEntryEvent) (*nostr.Event, error) {
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind: FightMonTournamentEntry,
+		Tags: append(
+			tournamentTags(tournamentId),
+			nostr.Tag{"p", entry.Player},
+		),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonTournamentRoundEvent starts a round of a tournament. The first
+// round also fixes the entrants of the tournament in the order of their
+// seeds.
+type FightMonTournamentRoundEvent struct {
+	Round    int                 `json:"round"`
+	Entrants []string            `json:"entrants,omitempty"`
+	Pairings []TournamentPairing `json:"pairings"`
+	Bye      string              `json:"bye,omitempty"`
+}
+
+// GetFightMonTournamentRoundEvent returns the event that starts a round of
+// a tournament.
+func GetFightMonTournamentRoundEvent(tournamentId string,
+	round FightMonTournamentRoundEvent) (*nostr.Event, error) {
+
+	data, err := json.Marshal(round)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonTournamentRound,
+		Tags:    tournamentTags(tournamentId),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonTournamentStandingsEvent closes a round of a tournament with the
+// results of its matches and the standings after it.
+type FightMonTournamentStandingsEvent struct {
+	Round     int                  `json:"round"`
+	Results   []TournamentResult   `json:"results"`
+	Standings []TournamentStanding `json:"standings"`
+	Final     bool                 `json:"final"`
+}
+
+// GetFightMonTournamentStandingsEvent returns the event that closes a round
+// of a tournament.
+func GetFightMonTournamentStandingsEvent(tournamentId string,
+	standings FightMonTournamentStandingsEvent) (*nostr.Event, error) {
+
+	data, err := json.Marshal(standings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonTournamentStandings,
+		Tags:    tournamentTags(tournamentId),
+		Content: string(data),
+	}, nil
+}
+
+// GetFightMonSealedEvent returns the event that carries an encrypted
+// fightmon event for the given recipient.
+func GetFightMonSealedEvent(recipient, ciphertext string) *nostr.Event {
+	return &nostr.Event{
+		Kind: FightMonSealed,
+		Tags: append(
+			nostr.Tags{nostr.Tag{"p", recipient}}, versionTags()...,
+		),
+		Content: ciphertext,
+	}
+}
+
+// GetFightMonMatchBundleEvent returns the event that publishes the given
+// signed events of a private match.
+func GetFightMonMatchBundleEvent(matchId string,
+	events []*nostr.Event) (*nostr.Event, error) {
+
+	data, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonMatchBundle,
+		Tags:    matchTags(matchId),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonPeerEvent announces the libp2p peer id and addresses of a player.
+type FightMonPeerEvent struct {
+	PeerId string   `json:"peer_id"`
+	Addrs  []string `json:"addrs"`
+}
+
+// GetFightMonPeerEvent returns the event that announces the libp2p peer we
+// can be reached at for the given match.
+func GetFightMonPeerEvent(matchId string,
+	peer FightMonPeerEvent) (*nostr.Event, error) {
+
+	data, err := json.Marshal(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonPeer,
+		Tags:    matchTags(matchId),
+		Content: string(data),
+	}, nil
+}
+
+// FightMonTurnRelayEvent announces the address a TURN server relays to a
+// player.
+type FightMonTurnRelayEvent struct {
+	RelayAddr string `json:"relay_addr"`
+}
+
+// GetFightMonTurnRelayEvent returns the event that announces our TURN relay
+// address for the given match.
+func GetFightMonTurnRelayEvent(matchId string,
+	relay FightMonTurnRelayEvent) (*nostr.Event, error) {
+
+	data, err := json.Marshal(relay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonTurnRelay,
+		Tags:    matchTags(matchId),
+		Content: string(data),
+	}, nil
+}
+
+// ResultReason is the reason a match ended.
+type ResultReason string
+
+const (
+	// ResultFinished is the reason of matches that were played until one
+	// side had no fightmons left or the round limit was reached.
+	ResultFinished ResultReason = "finished"
+
+	// ResultTimeout is the reason of matches that ended because a player
+	// didn't commit or reveal before the deadline.
+	ResultTimeout ResultReason = "timeout"
+)
+
+// FightMonResultEvent is the result of a match as signed by one of its
+// players. If both players sign the same result, it is a receipt neither of
+// them can dispute. The winner attaches all events of the match as evidence,
+// so the result can be verified even if the loser never signs it.
+type FightMonResultEvent struct {
+	// Winner is the pubkey of the winner, empty on a draw.
+	Winner string `json:"winner,omitempty"`
+
+	Reason ResultReason `json:"reason"`
+
+	// Rounds is the number of rounds that were resolved.
+	Rounds int `json:"rounds"`
+
+	// Evidence are the signed events of the match the result follows
+	// from.
+	Evidence []*nostr.Event `json:"evidence,omitempty"`
+}
+
+// GetFightMonResultEvent returns the event that signs the result of a
+// match.
+func GetFightMonResultEvent(matchId string,
+	result FightMonResultEvent) (*nostr.Event, error) {
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind:    FightMonResult,
+		Tags:    matchTags(matchId),
 		Content: string(data),
 	}, nil
 }
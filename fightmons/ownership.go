@@ -0,0 +1,178 @@
+package fightmons
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/tapmon/tapmond/mons"
+)
+
+const (
+	// MonIdTag is the tag of ownership events that carries the id of the
+	// proven mon.
+	MonIdTag = "mon_id"
+
+	// MonIndexTag is the single letter tag to query the ownership events
+	// of a mon by.
+	MonIndexTag = "m"
+
+	// ownershipTimeout is the time the ownership proofs of a fightmon
+	// are looked up and verified in.
+	ownershipTimeout = time.Minute
+)
+
+// MonProofVerifier verifies mon proofs against the main chain.
+type MonProofVerifier interface {
+	// VerifyMonProof verifies a mon proof against the headers of the
+	// main chain.
+	VerifyMonProof(ctx context.Context,
+		monProof *mons.MonProof) (*mons.VerifiedMon, error)
+}
+
+// GetFightMonOwnershipEvent returns the event that publishes the proof of a
+// mon we own.
+func GetFightMonOwnershipEvent(monProof *mons.MonProof) (*nostr.Event,
+	error) {
+
+	if monProof.Stats == nil {
+		return nil, fmt.Errorf("mon proof has no stats")
+	}
+
+	data, err := json.Marshal(monProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nostr.Event{
+		Kind: FightMonOwnership,
+		Tags: append(nostr.Tags{
+			nostr.Tag{MonIdTag, monProof.Stats.Id},
+			nostr.Tag{MonIndexTag, monProof.Stats.Id},
+		}, versionTags()...),
+		Content: string(data),
+	}, nil
+}
+
+// PublishOwnership publishes the proof of a mon we own, so that our
+// opponents can rate the mon by the matches we fight with it.
+func (m *Manager) PublishOwnership(ctx context.Context,
+	monProof *mons.MonProof) error {
+
+	ev, err := GetFightMonOwnershipEvent(monProof)
+	if err != nil {
+		return err
+	}
+
+	return m.transport.Publish(ctx, ev)
+}
+
+// ProofOwnership verifies that players own their fightmons by the mon proofs
+// they published to the relays. The ownership proofs of tapd are signed by
+// the script key of the owner, but don't commit to the nostr key of the
+// player, so anyone can republish a proof. Of all valid proofs of a mon, the
+// one proving its latest owner counts, and of the proofs of the same owner
+// the one published first. The owner is expected to publish their proof
+// before anyone else gets hold of it.
+type ProofOwnership struct {
+	transport Transport
+	proofs    MonProofVerifier
+}
+
+// NewProofOwnership creates an ownership verifier that looks up the proofs
+// of mons on the relays of the transport and verifies them with the given
+// verifier.
+func NewProofOwnership(transport Transport,
+	proofs MonProofVerifier) *ProofOwnership {
+
+	return &ProofOwnership{
+		transport: transport,
+		proofs:    proofs,
+	}
+}
+
+// VerifyOwnership returns an error if the player with the given pubkey
+// didn't publish the proof of the latest owner of the fightmon first.
+func (o *ProofOwnership) VerifyOwnership(pubKey string, mon *FightMon) error {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), ownershipTimeout,
+	)
+	defer cancel()
+
+	events, err := o.transport.Query(ctx, nostr.Filters{{
+		Kinds: []int{FightMonOwnership},
+		Tags: nostr.TagMap{
+			MonIndexTag: []string{mon.Id},
+		},
+	}})
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt < events[j].CreatedAt
+	})
+
+	var (
+		claimant string
+		latest   *mons.MonOwner
+	)
+	for _, ev := range events {
+		owner, err := o.verifyClaim(ctx, ev, mon.Id)
+		if err != nil {
+			log.Printf("Ignoring ownership proof %v of mon %v: %v",
+				ev.ID, mon.Id, err)
+
+			continue
+		}
+		if latest == nil || owner.Height > latest.Height {
+			latest, claimant = owner, ev.PubKey
+		}
+	}
+
+	switch {
+	case latest == nil:
+		return fmt.Errorf("no valid ownership proof of mon %v "+
+			"published", mon.Id)
+
+	case claimant != pubKey:
+		return fmt.Errorf("mon %v was last proven to be owned by %v",
+			mon.Id, claimant)
+	}
+
+	return nil
+}
+
+// verifyClaim verifies an ownership event of the mon with the given id and
+// returns the owner it proves.
+func (o *ProofOwnership) verifyClaim(ctx context.Context, ev *nostr.Event,
+	monId string) (*mons.MonOwner, error) {
+
+	if ok, err := ev.CheckSignature(); err != nil || !ok {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	tag := ev.Tags.GetFirst([]string{MonIdTag})
+	if ev.Kind != FightMonOwnership || tag == nil ||
+		tag.Value() != monId {
+
+		return nil, fmt.Errorf("not an ownership proof of the mon")
+	}
+
+	var monProof mons.MonProof
+	if err := strictUnmarshal([]byte(ev.Content), &monProof); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	verified, err := o.proofs.VerifyMonProof(ctx, &monProof)
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(verified.Mon.Id) != monId {
+		return nil, fmt.Errorf("proof of mon %x", verified.Mon.Id)
+	}
+
+	return verified.Owner, nil
+}
@@ -0,0 +1,100 @@
+package fightmons
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/mons"
+)
+
+// memProofs verifies the mon proofs whose proof files it knows.
+type memProofs map[string]*mons.VerifiedMon
+
+func (p memProofs) VerifyMonProof(_ context.Context,
+	monProof *mons.MonProof) (*mons.VerifiedMon, error) {
+
+	verified, ok := p[monProof.ProofFile]
+	if !ok {
+		return nil, fmt.Errorf("invalid proof file")
+	}
+
+	return verified, nil
+}
+
+// TestProofOwnership tests that a fightmon is owned by the player that first
+// published the proof of its latest owner.
+func TestProofOwnership(t *testing.T) {
+	relay := newMemRelay()
+	owner := newMemTransport(t, relay)
+	thief := newMemTransport(t, relay)
+	buyer := newMemTransport(t, relay)
+
+	id := make([]byte, 32)
+	id[0] = 1
+	mon := &FightMon{Id: hex.EncodeToString(id)}
+	proven := func(height uint32) *mons.VerifiedMon {
+		return &mons.VerifiedMon{
+			IndexedMon: &mons.IndexedMon{
+				Mon: &mons.Mon{Id: id},
+			},
+			Owner: &mons.MonOwner{Height: height},
+		}
+	}
+	proofs := memProofs{
+		"minted": proven(100),
+		"bought": proven(200),
+	}
+	verifier := NewProofOwnership(owner, proofs)
+
+	publish := func(player *memTransport, proofFile string,
+		createdAt nostr.Timestamp) {
+
+		ev, err := GetFightMonOwnershipEvent(&mons.MonProof{
+			Version:   mons.MonProofVersion,
+			ProofFile: proofFile,
+			Stats:     &mons.MonStats{Id: mon.Id},
+		})
+		require.NoError(t, err)
+		ev.CreatedAt = createdAt
+		require.NoError(t, player.Sign(ev))
+		relay.add(ev)
+	}
+
+	err := verifier.VerifyOwnership(owner.pub, mon)
+	require.ErrorContains(t, err, "no valid ownership proof")
+
+	// Invalid proofs don't count, even if they were published first.
+	publish(thief, "forged", 1)
+	publish(owner, "minted", 2)
+	require.NoError(t, verifier.VerifyOwnership(owner.pub, mon))
+
+	// Republishing the proof of the owner doesn't make anyone the owner.
+	publish(thief, "minted", 3)
+	require.NoError(t, verifier.VerifyOwnership(owner.pub, mon))
+	err = verifier.VerifyOwnership(thief.pub, mon)
+	require.ErrorContains(t, err, "last proven to be owned by "+owner.pub)
+
+	// Once the mon was sent to a new owner, the proof of the new owner
+	// counts.
+	publish(buyer, "bought", 4)
+	require.NoError(t, verifier.VerifyOwnership(buyer.pub, mon))
+	err = verifier.VerifyOwnership(owner.pub, mon)
+	require.ErrorContains(t, err, "last proven to be owned by "+buyer.pub)
+
+	// Proofs of other mons don't count.
+	other := &FightMon{Id: hex.EncodeToString(make([]byte, 32))}
+	proofs["other"] = proven(300)
+	ev, err := GetFightMonOwnershipEvent(&mons.MonProof{
+		ProofFile: "other",
+		Stats:     &mons.MonStats{Id: other.Id},
+	})
+	require.NoError(t, err)
+	require.NoError(t, thief.Sign(ev))
+	relay.add(ev)
+	err = verifier.VerifyOwnership(thief.pub, other)
+	require.ErrorContains(t, err, "no valid ownership proof")
+}
@@ -0,0 +1,399 @@
+package fightmons
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+const (
+	// localSubBuffer is the number of events buffered per subscription
+	// of a local relay, which holds every event of a practice match.
+	localSubBuffer = 1000
+)
+
+// localRelay is a relay in memory that practice matches are played through,
+// so they don't need any relays.
+type localRelay struct {
+	mu     sync.Mutex
+	events []*nostr.Event
+	subs   map[*localSub]struct{}
+}
+
+// localSub is a subscription to a local relay.
+type localSub struct {
+	filters nostr.Filters
+	events  chan *nostr.Event
+}
+
+func newLocalRelay() *localRelay {
+	return &localRelay{
+		subs: make(map[*localSub]struct{}),
+	}
+}
+
+// add stores the event and sends it to the matching subscriptions.
+func (r *localRelay) add(ev *nostr.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, ev)
+	for sub := range r.subs {
+		if sub.filters.Match(ev) {
+			sub.events <- ev
+		}
+	}
+}
+
+// query returns the stored events matching the filters.
+func (r *localRelay) query(filters nostr.Filters) []*nostr.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []*nostr.Event
+	for _, ev := range r.events {
+		if filters.Match(ev) {
+			events = append(events, ev)
+		}
+	}
+
+	return events
+}
+
+// localTransport is a transport that publishes to a local relay with a key
+// that is thrown away after the practice match.
+type localTransport struct {
+	relay *localRelay
+	sk    string
+	pub   string
+}
+
+func newLocalTransport(relay *localRelay) (*localTransport, error) {
+	sk := nostr.GeneratePrivateKey()
+	pub, err := nostr.GetPublicKey(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localTransport{
+		relay: relay,
+		sk:    sk,
+		pub:   pub,
+	}, nil
+}
+
+// PubKey returns the pubkey the transport publishes events with.
+func (l *localTransport) PubKey() string {
+	return l.pub
+}
+
+// Publish signs the event and adds it to the local relay.
+func (l *localTransport) Publish(_ context.Context, ev *nostr.Event) error {
+	ev.CreatedAt = nostr.Now()
+	if err := ev.Sign(l.sk); err != nil {
+		return err
+	}
+
+	// Publish a copy so that the receivers can't modify our event.
+	published := *ev
+	l.relay.add(&published)
+
+	return nil
+}
+
+// Subscribe streams the stored and future events of the local relay that
+// match the filters until the context is cancelled.
+func (l *localTransport) Subscribe(ctx context.Context,
+	filters nostr.Filters) (<-chan *nostr.Event, error) {
+
+	sub := &localSub{
+		filters: filters,
+		events:  make(chan *nostr.Event, localSubBuffer),
+	}
+
+	l.relay.mu.Lock()
+	for _, ev := range l.relay.events {
+		if filters.Match(ev) {
+			sub.events <- ev
+		}
+	}
+	l.relay.subs[sub] = struct{}{}
+	l.relay.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		l.relay.mu.Lock()
+		delete(l.relay.subs, sub)
+		close(sub.events)
+		l.relay.mu.Unlock()
+	}()
+
+	return sub.events, nil
+}
+
+// Query returns the events of the local relay matching the filters.
+func (l *localTransport) Query(_ context.Context,
+	filters nostr.Filters) ([]*nostr.Event, error) {
+
+	return l.relay.query(filters), nil
+}
+
+// battle returns a copy of the battle of the match and our side in it. The
+// battle is nil if the match didn't start yet.
+func (m *Manager) battle(matchId string) (*Battle, Side, error) {
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return nil, SideNone, err
+	}
+
+	active.Lock()
+	defer active.Unlock()
+
+	battle := active.match.Battle()
+	if battle == nil {
+		return nil, active.side, nil
+	}
+
+	return battle.clone(), active.side, nil
+}
+
+// Bot is an opponent that plays its matches on its own, picking its actions
+// with a strategy.
+type Bot struct {
+	manager  *Manager
+	strategy Strategy
+}
+
+// NewBot creates a bot that plays through the given transport.
+func NewBot(transport Transport, strategy Strategy) *Bot {
+	return &Bot{
+		manager:  NewManager(transport, nil, nil, nil),
+		strategy: strategy,
+	}
+}
+
+// PubKey returns the pubkey the bot plays with.
+func (b *Bot) PubKey() string {
+	return b.manager.PubKey()
+}
+
+// Challenge requests the given match with the party and plays it in the
+// background until it is finished or the bot is stopped.
+func (b *Bot) Challenge(ctx context.Context, matchId string,
+	party Party) error {
+
+	if err := b.manager.ChallengeMon(ctx, matchId, party); err != nil {
+		return err
+	}
+
+	updates, cancel, err := b.manager.SubscribeMatch(matchId)
+	if err != nil {
+		return err
+	}
+	go b.play(matchId, updates, cancel)
+
+	return nil
+}
+
+// play submits an action whenever a new round of the match begins.
+func (b *Bot) play(matchId string, updates <-chan *MatchUpdate,
+	cancel func()) {
+
+	defer cancel()
+
+	var played int
+	for {
+		var update *MatchUpdate
+		select {
+		case update = <-updates:
+		case <-b.manager.ctx.Done():
+			return
+		}
+
+		switch update.Type {
+		case UpdateStarted, UpdateRoundResult:
+
+		case UpdateFinished:
+			return
+
+		default:
+			continue
+		}
+
+		battle, side, err := b.manager.battle(matchId)
+		if err != nil {
+			log.Printf("Bot unable to play match %v: %v", matchId, err)
+			return
+		}
+		if battle == nil || battle.Round() <= played {
+			continue
+		}
+		if done, _ := battle.Finished(); done {
+			return
+		}
+		played = battle.Round()
+
+		action, target := b.strategy.Action(battle, side)
+		ctx := b.manager.ctx
+		if action == ActionSwitch {
+			_, err = b.manager.SwitchMon(ctx, matchId, target)
+		} else {
+			_, err = b.manager.SubmitAction(ctx, matchId, action)
+		}
+		if err != nil {
+			log.Printf("Bot unable to submit action for round %d of "+
+				"match %v: %v", played, matchId, err)
+		}
+	}
+}
+
+// Stop stops the bot.
+func (b *Bot) Stop() {
+	b.manager.Stop()
+}
+
+// randomParty returns a party of the given size of random fightmons without
+// levels.
+func randomParty(size int) (Party, error) {
+	party := make(Party, size)
+	for i := range party {
+		var id [32]byte
+		if _, err := rand.Read(id[:]); err != nil {
+			return nil, err
+		}
+		party[i] = FightMon{Id: hex.EncodeToString(id[:])}
+	}
+
+	return party, nil
+}
+
+// Practice is a match against a bot that is played offline. Both players
+// speak the full fight protocol through a local relay, so a practice match
+// is played and verified like any other match.
+type Practice struct {
+	// MatchId is the id of the practice match.
+	MatchId string
+
+	relay  *localRelay
+	player *Manager
+	bot    *Bot
+}
+
+// NewPractice starts a practice match of our party against a bot that plays
+// with the given strategy and a random party of the same size. We host the
+// match, so there's no deadline for our actions that matters in practice.
+func NewPractice(ctx context.Context, party Party,
+	strategy Strategy) (*Practice, error) {
+
+	if err := party.Validate(); err != nil {
+		return nil, err
+	}
+	botParty, err := randomParty(len(party))
+	if err != nil {
+		return nil, err
+	}
+
+	relay := newLocalRelay()
+	playerTransport, err := newLocalTransport(relay)
+	if err != nil {
+		return nil, err
+	}
+	botTransport, err := newLocalTransport(relay)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Practice{
+		relay:  relay,
+		player: NewManager(playerTransport, nil, nil, nil),
+		bot:    NewBot(botTransport, strategy),
+	}
+	p.player.roundTimeout = MaxRoundTimeout
+
+	if err := p.start(ctx, party, botParty); err != nil {
+		p.Stop()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// start opens the match, lets the bot challenge us and accepts it.
+func (p *Practice) start(ctx context.Context, party, botParty Party) error {
+	_, matchNonce, err := NewMatchId(p.player.PubKey())
+	if err != nil {
+		return err
+	}
+	p.MatchId, err = p.player.OpenMatch(ctx, matchNonce)
+	if err != nil {
+		return err
+	}
+
+	updates, cancel, err := p.player.SubscribeMatch(p.MatchId)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	err = p.bot.Challenge(ctx, p.MatchId, botParty)
+	if err != nil {
+		return fmt.Errorf("bot unable to challenge: %w", err)
+	}
+
+	for {
+		select {
+		case update := <-updates:
+			if update.Type != UpdateChallenged {
+				continue
+			}
+
+			return p.player.AcceptChallenge(
+				ctx, p.MatchId, p.bot.PubKey(), party,
+			)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// SubscribeMatch returns a channel with all past and future updates of the
+// practice match. The subscription ends when the returned cancel function is
+// called.
+func (p *Practice) SubscribeMatch() (<-chan *MatchUpdate, func(), error) {
+	return p.player.SubscribeMatch(p.MatchId)
+}
+
+// SubmitAction commits to our action for the current round. It returns the
+// id of the round the action was submitted for.
+func (p *Practice) SubmitAction(ctx context.Context,
+	action Action) (int, error) {
+
+	return p.player.SubmitAction(ctx, p.MatchId, action)
+}
+
+// SwitchMon commits to switching our active fightmon for the fightmon with
+// the given index in our party in the current round. It returns the id of
+// the round the switch was submitted for.
+func (p *Practice) SwitchMon(ctx context.Context, target int) (int, error) {
+	return p.player.SwitchMon(ctx, p.MatchId, target)
+}
+
+// Verify verifies the practice match from the events of the local relay.
+func (p *Practice) Verify() (*VerifiedMatch, error) {
+	events := p.relay.query(nostr.Filters{MatchFilter(p.MatchId)})
+
+	return VerifyMatch(p.MatchId, events, nil)
+}
+
+// Stop stops playing the practice match.
+func (p *Practice) Stop() {
+	p.bot.Stop()
+	p.player.Stop()
+}
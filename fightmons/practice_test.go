@@ -0,0 +1,56 @@
+package fightmons
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPractice tests that a practice match against a bot is played through
+// to the end and verifies like any other match.
+func TestPractice(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	party, err := randomParty(2)
+	require.NoError(t, err)
+	practice, err := NewPractice(ctx, party, &GreedyStrategy{})
+	require.NoError(t, err)
+	defer practice.Stop()
+
+	updates, cancelUpdates, err := practice.SubscribeMatch()
+	require.NoError(t, err)
+	defer cancelUpdates()
+
+	var (
+		rounds   int
+		finished *MatchUpdate
+	)
+	for finished == nil {
+		update := waitForUpdate(t, updates, -1)
+		switch update.Type {
+		case UpdateStarted:
+			_, err := practice.SubmitAction(ctx, ActionSpecial)
+			require.NoError(t, err)
+
+		case UpdateRoundResult:
+			rounds++
+			if update.Result.Final() {
+				continue
+			}
+			_, err := practice.SubmitAction(ctx, ActionSpecial)
+			require.NoError(t, err)
+
+		case UpdateFinished:
+			finished = update
+		}
+	}
+
+	verified, err := practice.Verify()
+	require.NoError(t, err)
+	require.Equal(t, finished.Winner, verified.Winner)
+	require.Len(t, verified.Rounds, rounds)
+	require.Equal(t, party, verified.Parties[SideHost])
+}
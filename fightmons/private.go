@@ -0,0 +1,280 @@
+package fightmons
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+// PrivateTransport is a transport that can also seal events for a single
+// recipient, which is needed to play private matches.
+type PrivateTransport interface {
+	Transport
+
+	// Sign signs the event with the key of the transport without
+	// publishing it.
+	Sign(ev *nostr.Event) error
+
+	// Encrypt encrypts the plaintext for the given pubkey with NIP-44.
+	Encrypt(pubKey, plaintext string) (string, error)
+
+	// Decrypt decrypts a NIP-44 ciphertext that the given pubkey
+	// encrypted for us.
+	Decrypt(pubKey, ciphertext string) (string, error)
+}
+
+// invite is the lobby of a private match we were invited to.
+type invite struct {
+	lobby *Lobby
+	event *nostr.Event
+}
+
+// sealEvent signs the event and returns the sealed event that carries it
+// encrypted for the recipient.
+func sealEvent(transport PrivateTransport, recipient string,
+	ev *nostr.Event) (*nostr.Event, error) {
+
+	if err := transport.Sign(ev); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := transport.Encrypt(recipient, string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return GetFightMonSealedEvent(recipient, ciphertext), nil
+}
+
+// openSealedEvent decrypts a sealed event for us and returns the fightmon
+// event it carries. The carried event must be signed by the author of the
+// sealed event, so nobody can pass on the events of others.
+func openSealedEvent(transport PrivateTransport,
+	sealed *nostr.Event) (*nostr.Event, error) {
+
+	if sealed.Kind != FightMonSealed {
+		return nil, fmt.Errorf("not a sealed event")
+	}
+	recipient := sealed.Tags.GetFirst([]string{"p"})
+	if recipient == nil || recipient.Value() != transport.PubKey() {
+		return nil, fmt.Errorf("sealed event not for us")
+	}
+	version, err := EventVersion(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if version < MinFightMonVersion || version > FightMonVersion {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, version)
+	}
+	if ok, err := sealed.CheckSignature(); err != nil || !ok {
+		return nil, fmt.Errorf("invalid signature of sealed event")
+	}
+
+	plaintext, err := transport.Decrypt(sealed.PubKey, sealed.Content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt sealed event: %w",
+			err)
+	}
+	var ev nostr.Event
+	if err := json.Unmarshal([]byte(plaintext), &ev); err != nil {
+		return nil, fmt.Errorf("invalid sealed event: %w", err)
+	}
+	if ev.PubKey != sealed.PubKey {
+		return nil, fmt.Errorf("sealed event of %v carries event of %v",
+			sealed.PubKey, ev.PubKey)
+	}
+	if ok, err := ev.CheckSignature(); err != nil || !ok {
+		return nil, fmt.Errorf("invalid signature of sealed event %v",
+			ev.ID)
+	}
+
+	return &ev, nil
+}
+
+// OpenPrivateMatch opens a lobby that only the given opponent can see and
+// returns the match id. All events of the match are encrypted between the
+// two players until the match is finished, when both publish the bundle of
+// all events for anyone to verify the result.
+func (m *Manager) OpenPrivateMatch(ctx context.Context,
+	opponent string) (string, error) {
+
+	if _, ok := m.transport.(PrivateTransport); !ok {
+		return "", fmt.Errorf("private matches not supported")
+	}
+	if opponent == m.transport.PubKey() {
+		return "", fmt.Errorf("can't invite ourselves")
+	}
+
+	matchId, matchNonce, err := NewMatchId(m.transport.PubKey())
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := m.follow(matchId, SideHost, opponent); err != nil {
+		return "", err
+	}
+
+	err = m.publish(ctx, matchId, m.lobbyEvent(matchId, matchNonce))
+	if err != nil {
+		return "", err
+	}
+
+	return matchId, nil
+}
+
+// invitations returns the private lobbies we were invited to and didn't
+// answer yet.
+func (m *Manager) invitations() []*Lobby {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiry := time.Now().Add(-LobbyExpiry)
+	lobbies := make([]*Lobby, 0, len(m.invites))
+	for _, invite := range m.invites {
+		if invite.lobby.CreatedAt.Before(expiry) {
+			continue
+		}
+		lobbies = append(lobbies, invite.lobby)
+	}
+
+	return lobbies
+}
+
+// takeInvite removes and returns the invite to the given match.
+func (m *Manager) takeInvite(matchId string) *invite {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	invite, ok := m.invites[matchId]
+	if !ok {
+		return nil
+	}
+	delete(m.invites, matchId)
+
+	return invite
+}
+
+// receiveSealed receives the sealed events for us and hands them to the
+// private matches they belong to.
+func (m *Manager) receiveSealed(transport PrivateTransport) {
+	events, err := transport.Subscribe(m.ctx, nostr.Filters{{
+		Kinds: []int{FightMonSealed},
+		Tags: nostr.TagMap{
+			"p": []string{transport.PubKey()},
+		},
+	}})
+	if err != nil {
+		log.Printf("Unable to subscribe to sealed events: %v", err)
+		return
+	}
+
+	for sealed := range events {
+		// Anyone can send us sealed events, so invalid ones are
+		// dropped silently.
+		ev, err := openSealedEvent(transport, sealed)
+		if err != nil {
+			continue
+		}
+		m.handleSealed(ev)
+	}
+}
+
+// handleSealed applies an event received in a private match.
+func (m *Manager) handleSealed(ev *nostr.Event) {
+	matchId := GetMatchId(ev)
+	if ev.Kind == FightMonLookingForMatch {
+		m.addInvite(matchId, ev)
+		return
+	}
+
+	active, err := m.activeMatch(matchId)
+	if err != nil {
+		return
+	}
+
+	// Only our opponent seals events of a private match for us.
+	active.Lock()
+	peer := active.peer
+	active.Unlock()
+	if peer != ev.PubKey {
+		return
+	}
+
+	m.handleEvent(active, ev)
+}
+
+// addInvite adds the private lobby of the given event to our invitations.
+func (m *Manager) addInvite(matchId string, ev *nostr.Event) {
+	nonceTag := ev.Tags.GetFirst([]string{MatchNonceTag})
+	if nonceTag == nil {
+		return
+	}
+	if CheckMatchId(matchId, ev.PubKey, nonceTag.Value()) != nil {
+		return
+	}
+	if ValidateEvent(ev) != nil {
+		return
+	}
+	versions, err := EventVersions(ev)
+	if err != nil {
+		return
+	}
+	version, err := NegotiateVersion(SupportedVersions(), versions)
+	if err != nil {
+		return
+	}
+	roundTimeout, err := EventRoundTimeout(ev)
+	if err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.matches[matchId]; ok {
+		return
+	}
+	if _, ok := m.invites[matchId]; ok {
+		return
+	}
+	m.invites[matchId] = &invite{
+		lobby: &Lobby{
+			MatchId:      matchId,
+			Host:         ev.PubKey,
+			CreatedAt:    ev.CreatedAt.Time(),
+			Version:      version,
+			RoundTimeout: roundTimeout,
+			Private:      true,
+		},
+		event: ev,
+	}
+}
+
+// publishBundle publishes all events of a finished private match, so
+// anyone can verify its result.
+func (m *Manager) publishBundle(active *activeMatch) {
+	active.Lock()
+	events := make([]*nostr.Event, len(active.events))
+	copy(events, active.events)
+	active.Unlock()
+
+	ctx, cancel := context.WithTimeout(m.ctx, time.Minute)
+	defer cancel()
+
+	matchId := active.match.MatchId
+	bundle, err := GetFightMonMatchBundleEvent(matchId, events)
+	if err == nil {
+		err = m.transport.Publish(ctx, bundle)
+	}
+	if err != nil {
+		log.Printf("Unable to publish bundle of match %v: %v", matchId,
+			err)
+	}
+}
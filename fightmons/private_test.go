@@ -0,0 +1,162 @@
+package fightmons
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSealedEvent tests that sealed events can only be opened by their
+// recipient and only carry events of their author.
+func TestSealedEvent(t *testing.T) {
+	relay := newMemRelay()
+	alice, bob := newMemTransport(t, relay), newMemTransport(t, relay)
+	eve := newMemTransport(t, relay)
+
+	matchId, matchNonce, err := NewMatchId(alice.pub)
+	require.NoError(t, err)
+	ev := GetFightMonLookingForMatchEvent(matchId, matchNonce)
+
+	sealed, err := sealEvent(alice, bob.pub, ev)
+	require.NoError(t, err)
+	require.NotContains(t, sealed.Content, matchId)
+	require.NoError(t, alice.Sign(sealed))
+
+	opened, err := openSealedEvent(bob, sealed)
+	require.NoError(t, err)
+	require.Equal(t, ev.ID, opened.ID)
+	require.Equal(t, matchId, GetMatchId(opened))
+
+	_, err = openSealedEvent(eve, sealed)
+	require.ErrorContains(t, err, "not for us")
+
+	// Eve can't pass on the event of alice as her own.
+	data, err := json.Marshal(opened)
+	require.NoError(t, err)
+	ciphertext, err := eve.Encrypt(bob.pub, string(data))
+	require.NoError(t, err)
+	forged := GetFightMonSealedEvent(bob.pub, ciphertext)
+	require.NoError(t, eve.Sign(forged))
+	_, err = openSealedEvent(bob, forged)
+	require.ErrorContains(t, err, "carries event of")
+
+	tampered := *sealed
+	tampered.Content = sealed.Content[:len(sealed.Content)-4] + "AAAA"
+	require.NoError(t, alice.Sign(&tampered))
+	_, err = openSealedEvent(bob, &tampered)
+	require.ErrorContains(t, err, "unable to decrypt")
+}
+
+// TestPrivateMatch tests that a private match is only visible to the invited
+// opponent while it is played and that its result can be verified by anyone
+// from the published bundles once it is finished.
+func TestPrivateMatch(t *testing.T) {
+	ctx := context.Background()
+	relay := newMemRelay()
+
+	host := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer host.Stop()
+	challenger := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer challenger.Stop()
+	other := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer other.Stop()
+
+	_, err := host.OpenPrivateMatch(ctx, host.PubKey())
+	require.Error(t, err)
+
+	matchId, err := host.OpenPrivateMatch(ctx, challenger.PubKey())
+	require.NoError(t, err)
+	hostUpdates, cancel, err := host.SubscribeMatch(matchId)
+	require.NoError(t, err)
+	defer cancel()
+
+	// Only the invited opponent sees the lobby.
+	require.Eventually(t, func() bool {
+		lobbies, _, err := challenger.FindMatch(ctx, false)
+		return err == nil && len(lobbies) == 1 &&
+			lobbies[0].MatchId == matchId && lobbies[0].Private
+	}, 5*time.Second, 10*time.Millisecond)
+	lobbies, _, err := other.FindMatch(ctx, false)
+	require.NoError(t, err)
+	require.Empty(t, lobbies)
+
+	require.NoError(t, challenger.ChallengeMon(
+		ctx, matchId, Party{newTestPlayer(t).mon},
+	))
+	challengerUpdates, cancel, err := challenger.SubscribeMatch(matchId)
+	require.NoError(t, err)
+	defer cancel()
+
+	update := waitForUpdate(t, hostUpdates, UpdateChallenged)
+	require.Equal(t, challenger.PubKey(), update.Event.PubKey)
+
+	err = host.AcceptChallenge(
+		ctx, matchId, other.PubKey(), Party{newTestPlayer(t).mon},
+	)
+	require.Error(t, err)
+
+	type outcome struct {
+		winner Side
+		err    error
+	}
+	play := func(player *Manager, updates <-chan *MatchUpdate,
+		action Action, outcomes chan<- outcome) {
+
+		winner, err := playMatch(
+			ctx, player, matchId, updates, action, nil,
+		)
+		outcomes <- outcome{winner: winner, err: err}
+	}
+	hostOutcome, challengerOutcome := make(chan outcome, 1),
+		make(chan outcome, 1)
+	go play(host, hostUpdates, ActionAttack, hostOutcome)
+	go play(challenger, challengerUpdates, ActionSpecial, challengerOutcome)
+
+	err = host.AcceptChallenge(
+		ctx, matchId, challenger.PubKey(), Party{newTestPlayer(t).mon},
+	)
+	require.NoError(t, err)
+
+	hostResult, challengerResult := <-hostOutcome, <-challengerOutcome
+	require.NoError(t, hostResult.err)
+	require.NoError(t, challengerResult.err)
+	require.Equal(t, hostResult.winner, challengerResult.winner)
+
+	// Nothing but the bundles of both players was published in the clear.
+	var events []*nostr.Event
+	require.Eventually(t, func() bool {
+		events, err = other.transport.Query(
+			ctx, nostr.Filters{MatchFilter(matchId)},
+		)
+		return err == nil && len(events) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	// The sealed results carry no evidence, as the bundles publish all
+	// events of the match anyway.
+	var results int
+	for _, ev := range events {
+		require.Equal(t, FightMonMatchBundle, ev.Kind)
+
+		var bundled []*nostr.Event
+		require.NoError(t, json.Unmarshal([]byte(ev.Content), &bundled))
+		for _, bundledEv := range bundled {
+			if bundledEv.Kind != FightMonResult {
+				continue
+			}
+
+			var result FightMonResultEvent
+			require.NoError(t, decodeEvent(bundledEv, &result))
+			require.Empty(t, result.Evidence)
+			results++
+		}
+	}
+	require.NotZero(t, results)
+
+	verified, err := VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+	require.Equal(t, hostResult.winner, verified.Winner)
+	require.Empty(t, verified.Ignored)
+}
@@ -0,0 +1,284 @@
+package fightmons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// InitialRating is the rating of players and mons that haven't been
+	// rated yet.
+	InitialRating = 1500.0
+
+	// ratingK is the maximum rating change of a single match.
+	ratingK = 32.0
+
+	// ratingScale is the rating difference at which the higher rated
+	// side is expected to win ten times as often.
+	ratingScale = 400.0
+)
+
+var (
+	// ErrMatchRated is returned if the ratings of a match were already
+	// stored.
+	ErrMatchRated = errors.New("match already rated")
+
+	// ErrRatingsNotTracked is returned if ratings are looked up while
+	// they aren't tracked.
+	ErrRatingsNotTracked = errors.New("ratings not tracked")
+)
+
+// RatingKind is the kind of subject that is rated.
+type RatingKind int
+
+const (
+	// RatingPlayer rates players by their nostr pubkey.
+	RatingPlayer RatingKind = iota
+
+	// RatingMon rates mons by their id.
+	RatingMon
+)
+
+// String returns the name of the rating kind.
+func (k RatingKind) String() string {
+	switch k {
+	case RatingPlayer:
+		return "player"
+	case RatingMon:
+		return "mon"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(k))
+	}
+}
+
+// Rating is the Elo rating of a player or a mon.
+type Rating struct {
+	// Kind is the kind of the rated subject.
+	Kind RatingKind
+
+	// Subject is the nostr pubkey of a player or the id of a mon.
+	Subject string
+
+	// Rating is the current rating.
+	Rating float64
+
+	// Games is the number of rated matches.
+	Games int
+
+	// Wins is the number of rated matches won.
+	Wins int
+
+	// Losses is the number of rated matches lost. The remaining games
+	// were draws.
+	Losses int
+}
+
+// RatingHistoryEntry is the rating of a subject after a match.
+type RatingHistoryEntry struct {
+	// MatchId is the id of the rated match.
+	MatchId string
+
+	// Rating is the rating of the subject after the match.
+	Rating float64
+
+	// PlayedAt is the time the match was rated.
+	PlayedAt time.Time
+}
+
+// RatingStore persists ratings and their history.
+type RatingStore interface {
+	// GetRating returns the rating of a subject or nil if it wasn't
+	// rated yet.
+	GetRating(ctx context.Context, kind RatingKind,
+		subject string) (*Rating, error)
+
+	// AddMatchRatings atomically stores the new ratings of the subjects
+	// of a match and adds them to the rating history. ErrMatchRated is
+	// returned if the match was already rated.
+	AddMatchRatings(ctx context.Context, matchId string,
+		playedAt time.Time, ratings []*Rating) error
+
+	// ListRatings returns the ratings of the given kind from the highest
+	// to the lowest, skipping the first offset ratings.
+	ListRatings(ctx context.Context, kind RatingKind, offset,
+		limit int) ([]*Rating, error)
+
+	// GetRatingHistory returns the ratings of a subject after each of
+	// its rated matches in the order they were rated.
+	GetRatingHistory(ctx context.Context, kind RatingKind,
+		subject string) ([]*RatingHistoryEntry, error)
+}
+
+// Ratings rates players and mons by the results of verified matches.
+// Ratings are local: only the matches we played ourselves are rated, after
+// verifying them from the events on the relays, and the ratings of other
+// nodes are never imported. Players are identified by the keys that signed
+// the events, while mons are only rated once their players proved to own
+// them, so that a mon can't be rated by matches it didn't fight in.
+type Ratings struct {
+	store RatingStore
+
+	// ownership verifies that the players own the mons they fought with.
+	// Without it, only the players are rated.
+	ownership OwnershipVerifier
+
+	// mu serializes rating updates, as the new ratings of a match depend
+	// on the current ones.
+	mu sync.Mutex
+}
+
+// NewRatings creates a new rating ladder. The mons of a match are only
+// rated if the ownership verifier is given and accepts them.
+func NewRatings(store RatingStore, ownership OwnershipVerifier) *Ratings {
+	return &Ratings{
+		store:     store,
+		ownership: ownership,
+	}
+}
+
+// Get returns the rating of a subject, which is the initial rating if it
+// wasn't rated yet.
+func (r *Ratings) Get(ctx context.Context, kind RatingKind,
+	subject string) (*Rating, error) {
+
+	rating, err := r.store.GetRating(ctx, kind, subject)
+	if err != nil {
+		return nil, err
+	}
+	if rating == nil {
+		rating = &Rating{
+			Kind:    kind,
+			Subject: subject,
+			Rating:  InitialRating,
+		}
+	}
+
+	return rating, nil
+}
+
+// Leaderboard returns the ratings of the given kind from the highest to the
+// lowest, skipping the first offset ratings.
+func (r *Ratings) Leaderboard(ctx context.Context, kind RatingKind, offset,
+	limit int) ([]*Rating, error) {
+
+	return r.store.ListRatings(ctx, kind, offset, limit)
+}
+
+// History returns the ratings of a subject after each of its rated matches
+// in the order they were rated.
+func (r *Ratings) History(ctx context.Context, kind RatingKind,
+	subject string) ([]*RatingHistoryEntry, error) {
+
+	return r.store.GetRatingHistory(ctx, kind, subject)
+}
+
+// RateMatch updates the ratings of both players of a verified match, and
+// those of both mons if the players own them. Rating a match twice returns
+// ErrMatchRated.
+func (r *Ratings) RateMatch(ctx context.Context, match *VerifiedMatch,
+	playedAt time.Time) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subjects := map[RatingKind][2]string{
+		RatingPlayer: match.Players,
+		RatingMon: {
+			match.Mons[SideHost].Id, match.Mons[SideChallenger].Id,
+		},
+	}
+	kinds := []RatingKind{RatingPlayer}
+	if r.ownsMons(match) {
+		kinds = append(kinds, RatingMon)
+	}
+
+	var ratings []*Rating
+	for _, kind := range kinds {
+		host, challenger := subjects[kind][SideHost],
+			subjects[kind][SideChallenger]
+
+		// A mon fighting itself doesn't tell anything about its
+		// strength.
+		if host == challenger {
+			continue
+		}
+
+		hostRating, err := r.Get(ctx, kind, host)
+		if err != nil {
+			return err
+		}
+		challengerRating, err := r.Get(ctx, kind, challenger)
+		if err != nil {
+			return err
+		}
+
+		rate(hostRating, challengerRating, match.Winner)
+		ratings = append(ratings, hostRating, challengerRating)
+	}
+
+	return r.store.AddMatchRatings(ctx, match.MatchId, playedAt, ratings)
+}
+
+// ownsMons returns true if the ownership of the rated mons of the match was
+// verified, either by VerifyMatch or by our ownership verifier.
+func (r *Ratings) ownsMons(match *VerifiedMatch) bool {
+	if match.OwnershipVerified {
+		return true
+	}
+	if r.ownership == nil {
+		return false
+	}
+
+	for _, side := range []Side{SideHost, SideChallenger} {
+		err := r.ownership.VerifyOwnership(
+			match.Players[side], match.Mons[side],
+		)
+		if err != nil {
+			log.Printf("Not rating mons of match %v: unable to "+
+				"verify ownership of %v mon %v: %v",
+				match.MatchId, side, match.Mons[side].Id, err)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// rate updates the ratings of the host and the challenger by the outcome of
+// their match.
+func rate(host, challenger *Rating, winner Side) {
+	var score float64
+	switch winner {
+	case SideHost:
+		score = 1
+		host.Wins++
+		challenger.Losses++
+
+	case SideChallenger:
+		score = 0
+		host.Losses++
+		challenger.Wins++
+
+	default:
+		score = 0.5
+	}
+	host.Games++
+	challenger.Games++
+
+	expected := ExpectedScore(host.Rating, challenger.Rating)
+	delta := ratingK * (score - expected)
+	host.Rating += delta
+	challenger.Rating -= delta
+}
+
+// ExpectedScore returns the expected score of a subject with the given
+// rating against an opponent, where a win scores 1 and a draw 0.5.
+func ExpectedScore(rating, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/ratingScale))
+}
@@ -0,0 +1,176 @@
+package fightmons
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRate tests the Elo rating updates.
+func TestRate(t *testing.T) {
+	require.InDelta(t, 0.5, ExpectedScore(1500, 1500), 1e-9)
+	require.InDelta(t, 10.0/11, ExpectedScore(1900, 1500), 1e-9)
+
+	// Equally rated players win or lose half of the maximum change.
+	host := &Rating{Rating: 1500}
+	challenger := &Rating{Rating: 1500}
+	rate(host, challenger, SideHost)
+	require.InDelta(t, 1516, host.Rating, 1e-9)
+	require.InDelta(t, 1484, challenger.Rating, 1e-9)
+	require.Equal(t, &Rating{Rating: host.Rating, Games: 1, Wins: 1}, host)
+	require.Equal(
+		t, &Rating{Rating: challenger.Rating, Games: 1, Losses: 1},
+		challenger,
+	)
+
+	// A draw moves the ratings towards each other.
+	rate(host, challenger, SideNone)
+	require.Less(t, host.Rating, 1516.0)
+	require.Greater(t, challenger.Rating, 1484.0)
+	require.InDelta(t, 3000, host.Rating+challenger.Rating, 1e-9)
+	require.Equal(t, 2, host.Games)
+	require.Equal(t, 1, host.Wins)
+
+	// Beating a much stronger opponent gains almost the maximum change.
+	underdog := &Rating{Rating: 1100}
+	favorite := &Rating{Rating: 1900}
+	rate(underdog, favorite, SideHost)
+	require.Greater(t, underdog.Rating, 1100+0.99*ratingK)
+}
+
+// ownershipFunc is an ownership verifier backed by a function.
+type ownershipFunc func(pubKey string, mon *FightMon) error
+
+func (f ownershipFunc) VerifyOwnership(pubKey string, mon *FightMon) error {
+	return f(pubKey, mon)
+}
+
+// TestManagerRatings tests that both players rate their verified matches,
+// that mons are only rated if their ownership is verified and that lobbies
+// of hosts with a similar rating are preferred.
+func TestManagerRatings(t *testing.T) {
+	ctx := context.Background()
+	relay := newMemRelay()
+
+	// Only the host can verify that the players own their mons.
+	owned := ownershipFunc(func(string, *FightMon) error {
+		return nil
+	})
+	unowned := ownershipFunc(func(string, *FightMon) error {
+		return errors.New("not owned")
+	})
+
+	hostStore, challengerStore := newMemStore(), newMemStore()
+	host := NewManager(
+		newMemTransport(t, relay), nil, nil,
+		NewRatings(hostStore, owned),
+	)
+	defer host.Stop()
+	challenger := NewManager(
+		newMemTransport(t, relay), nil, nil,
+		NewRatings(challengerStore, unowned),
+	)
+	defer challenger.Stop()
+
+	matchId, winner := playManagerMatch(t, host, challenger, nil)
+	require.NotEqual(t, SideNone, winner)
+
+	winnerKey, loserKey := host.PubKey(), challenger.PubKey()
+	if winner == SideChallenger {
+		winnerKey, loserKey = loserKey, winnerKey
+	}
+
+	// Both players rate the match the same way.
+	for _, store := range []*memStore{hostStore, challengerStore} {
+		require.Eventually(t, func() bool {
+			rating, err := store.GetRating(
+				ctx, RatingPlayer, winnerKey,
+			)
+			return err == nil && rating != nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		ratings, err := NewRatings(store, nil).Leaderboard(
+			ctx, RatingPlayer, 0, 10,
+		)
+		require.NoError(t, err)
+		require.Len(t, ratings, 2)
+		require.Equal(t, winnerKey, ratings[0].Subject)
+		require.InDelta(t, InitialRating+ratingK/2, ratings[0].Rating,
+			1e-9)
+		require.Equal(t, loserKey, ratings[1].Subject)
+		require.Equal(t, 1, ratings[1].Losses)
+
+		mons, err := NewRatings(store, nil).Leaderboard(
+			ctx, RatingMon, 0, 10,
+		)
+		require.NoError(t, err)
+		if store == hostStore {
+			require.Len(t, mons, 2)
+		} else {
+			require.Empty(t, mons)
+		}
+	}
+
+	// A match is only rated once.
+	events, err := host.transport.Query(
+		ctx, nostr.Filters{MatchFilter(matchId)},
+	)
+	require.NoError(t, err)
+	verified, err := VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+	err = host.ratings.RateMatch(ctx, verified, time.Now())
+	require.ErrorIs(t, err, ErrMatchRated)
+
+	// The match is in the history of the mons that fought in it.
+	hostMon := verified.Mons[SideHost].Id
+	history, err := host.RatingHistory(ctx, RatingMon, hostMon)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, matchId, history[0].MatchId)
+
+	unrated := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer unrated.Stop()
+	_, err = unrated.RatingHistory(ctx, RatingMon, hostMon)
+	require.ErrorIs(t, err, ErrRatingsNotTracked)
+
+	// A newcomer prefers the lobby of the host closest to its own
+	// rating.
+	seekerStore := newMemStore()
+	seeker := NewManager(
+		newMemTransport(t, relay), nil, nil,
+		NewRatings(seekerStore, nil),
+	)
+	defer seeker.Stop()
+
+	strong := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer strong.Stop()
+	near := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer near.Stop()
+
+	err = seekerStore.AddMatchRatings(ctx, "rated", time.Now(), []*Rating{{
+		Kind:    RatingPlayer,
+		Subject: strong.PubKey(),
+		Rating:  1900,
+	}, {
+		Kind:    RatingPlayer,
+		Subject: near.PubKey(),
+		Rating:  1520,
+	}})
+	require.NoError(t, err)
+
+	_, _, err = strong.FindMatch(ctx, true)
+	require.NoError(t, err)
+	_, _, err = near.FindMatch(ctx, true)
+	require.NoError(t, err)
+
+	lobbies, _, err := seeker.FindMatch(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, lobbies, 2)
+	require.Equal(t, near.PubKey(), lobbies[0].Host)
+	require.Equal(t, 1520.0, lobbies[0].HostRating)
+	require.Equal(t, strong.PubKey(), lobbies[1].Host)
+}
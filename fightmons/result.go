@@ -0,0 +1,381 @@
+package fightmons
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+const (
+	// resultTimeout is the time we wait for the relays to accept the
+	// result of a match or the bundle of a disputed match.
+	resultTimeout = time.Minute
+)
+
+// Receipts returns the result events the host and the challenger signed.
+func (m *Match) Receipts() [2]*nostr.Event {
+	return m.receipts
+}
+
+// CoSigned returns true if both players signed the result of the match.
+func (m *Match) CoSigned() bool {
+	return m.receipts[SideHost] != nil && m.receipts[SideChallenger] != nil
+}
+
+// result returns the result of the finished match.
+func (m *Match) result() FightMonResultEvent {
+	result := FightMonResultEvent{
+		Reason: ResultFinished,
+		Rounds: len(m.Rounds),
+	}
+	if m.timedOut {
+		result.Reason = ResultTimeout
+	}
+	if _, winner := m.Finished(); winner != SideNone {
+		result.Winner = m.Players[winner]
+	}
+
+	return result
+}
+
+func (m *Match) applyResult(ev *nostr.Event) ([]*MatchUpdate, error) {
+	side, err := m.playerSide(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	var result FightMonResultEvent
+	if err := decodeEvent(ev, &result); err != nil {
+		return nil, fmt.Errorf("invalid result event: %w", err)
+	}
+	if err := checkEventVersion(ev, m.Version); err != nil {
+		return nil, err
+	}
+	if m.receipts[side] != nil {
+		return nil, fmt.Errorf("%w: %v signed a result twice",
+			ErrInvalidResult, side)
+	}
+
+	var updates []*MatchUpdate
+	done, _ := m.Finished()
+	if !done && result.Reason == ResultTimeout {
+		updates, err = m.applyTimeoutClaim(ev, side, &result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Results of matches that are still played are kept until the
+	// events they follow from were applied.
+	done, winner := m.Finished()
+	if !done {
+		return nil, errNotReady
+	}
+
+	expected := m.result()
+	if result.Winner != expected.Winner ||
+		result.Reason != expected.Reason ||
+		result.Rounds != expected.Rounds {
+
+		return nil, fmt.Errorf("%w: %v signed %v win of %v after %d "+
+			"rounds", ErrInvalidResult, side, result.Reason,
+			result.Winner, result.Rounds)
+	}
+	m.receipts[side] = ev
+
+	return append(updates, &MatchUpdate{
+		Type:    UpdateResultSigned,
+		Event:   ev,
+		Side:    side,
+		RoundId: len(m.Rounds),
+		Winner:  winner,
+	}), nil
+}
+
+// applyTimeoutClaim ends the match if the player of the given side claims
+// the win after the opponent missed the deadline of the current phase. The
+// claim is measured against the time it was created at, so it is only valid
+// if the opponent didn't act before the claim was created. Once applied, the
+// claim is final, as the events of a finished match are never applied.
+func (m *Match) applyTimeoutClaim(ev *nostr.Event, side Side,
+	claim *FightMonResultEvent) ([]*MatchUpdate, error) {
+
+	switch {
+	// The claim can't be judged until the rounds before it and our own
+	// action in the current phase were applied.
+	case claim.Rounds > len(m.Rounds) || !m.started:
+		return nil, errNotReady
+
+	case claim.Rounds < len(m.Rounds):
+		return nil, fmt.Errorf("%w: timeout claimed for round %d, "+
+			"which was resolved", ErrInvalidResult, claim.Rounds+1)
+
+	case !m.Acted(side):
+		return nil, errNotReady
+
+	case claim.Winner != m.Players[side]:
+		return nil, fmt.Errorf("%w: %v claimed a timeout for its "+
+			"opponent", ErrInvalidResult, side)
+	}
+
+	winner, ok := m.Stalled(ev.CreatedAt)
+	if !ok || winner != side {
+		return nil, fmt.Errorf("%w: %v claimed a timeout before the "+
+			"deadline", ErrInvalidResult, side)
+	}
+	m.timedOut = true
+	m.timeoutWinner = side
+
+	return []*MatchUpdate{{
+		Type:     UpdateFinished,
+		Event:    ev,
+		Side:     SideNone,
+		RoundId:  len(m.Rounds),
+		Winner:   side,
+		TimedOut: true,
+	}}, nil
+}
+
+// forfeit ends the match with a win of the opponent of the given side after
+// the player of the side violated the protocol. The offending event doesn't
+// count, so the match ends as if the player missed the deadline of the
+// current phase and a timeout claim of the opponent is valid. Results that
+// were kept until the end of the match are applied.
+func (m *Match) forfeit(side Side) ([]*MatchUpdate, error) {
+	if done, _ := m.Finished(); done {
+		return nil, nil
+	}
+	m.timedOut = true
+	m.timeoutWinner = side.Opponent()
+
+	updates := []*MatchUpdate{{
+		Type:     UpdateFinished,
+		Side:     SideNone,
+		RoundId:  len(m.Rounds),
+		Winner:   side.Opponent(),
+		TimedOut: true,
+	}}
+	pendingUpdates, err := m.applyPending()
+
+	return append(updates, pendingUpdates...), err
+}
+
+// isEvidence returns true if events of the given kind are needed to verify
+// the result of a match.
+func isEvidence(kind int) bool {
+	switch kind {
+	case FightMonLookingForMatch, FightMonRequestMatch,
+		FightMonAcceptMatch, FightMonStartMatch, FightMonRound,
+		FightMonRoundCommit:
+
+		return true
+
+	default:
+		return false
+	}
+}
+
+// evidence returns the events of the match the result follows from. The
+// results of private matches carry no evidence, as a sealed event can't
+// hold more than the nip-44 plaintext limit of 65535 bytes. Their events
+// are published in the bundle of the match instead. The caller must hold
+// the lock.
+func (a *activeMatch) evidence() []*nostr.Event {
+	if a.peer != "" {
+		return nil
+	}
+
+	var evidence []*nostr.Event
+	for _, ev := range a.events {
+		if isEvidence(ev.Kind) {
+			evidence = append(evidence, ev)
+		}
+	}
+
+	return evidence
+}
+
+// claimEvidence returns the events a timeout claim carries. They tell
+// verifiers which events of the claimed round we had seen when we claimed
+// the timeout. Sealed claims only carry the round events of the claimed
+// round to stay within the size limit of sealed events. The caller must
+// hold the lock.
+func (a *activeMatch) claimEvidence() []*nostr.Event {
+	if a.peer == "" {
+		return a.evidence()
+	}
+
+	var evidence []*nostr.Event
+	for _, ev := range a.events {
+		roundId, ok := eventRoundId(ev)
+		if ok && roundId == len(a.match.Rounds)+1 {
+			evidence = append(evidence, ev)
+		}
+	}
+
+	return evidence
+}
+
+// eventRoundId returns the round of a round commit or reveal event.
+func eventRoundId(ev *nostr.Event) (int, bool) {
+	switch ev.Kind {
+	case FightMonRound:
+		var round FightMonRoundEvent
+		if err := decodeEvent(ev, &round); err != nil {
+			return 0, false
+		}
+
+		return round.RoundId, true
+
+	case FightMonRoundCommit:
+		var reveal FightMonRoundCommitEvent
+		if err := decodeEvent(ev, &reveal); err != nil {
+			return 0, false
+		}
+
+		return reveal.RoundID, true
+
+	default:
+		return 0, false
+	}
+}
+
+// signResult publishes our result of the finished match unless we already
+// signed it by claiming a timeout. The winner of a public match attaches the
+// events of the match as evidence, so the result can be verified from the
+// result alone.
+func (m *Manager) signResult(active *activeMatch) {
+	active.Lock()
+	if active.match.Receipts()[active.side] != nil {
+		active.Unlock()
+		return
+	}
+	result := active.match.result()
+	if result.Winner == m.transport.PubKey() {
+		result.Evidence = active.evidence()
+	}
+	active.Unlock()
+
+	ctx, cancel := context.WithTimeout(m.ctx, resultTimeout)
+	defer cancel()
+
+	matchId := active.match.MatchId
+	ev, err := GetFightMonResultEvent(matchId, result)
+	if err == nil {
+		err = m.publish(ctx, matchId, ev)
+	}
+	if err != nil {
+		log.Printf("Unable to sign result of match %v: %v", matchId,
+			err)
+	}
+}
+
+// scheduleTimeoutClaim arms the timer that claims the match once the
+// deadline of the current phase passed, if we acted in the phase and the
+// opponent didn't. The caller must hold the lock.
+func (m *Manager) scheduleTimeoutClaim(active *activeMatch) {
+	if active.claimTimer != nil {
+		active.claimTimer.Stop()
+		active.claimTimer = nil
+	}
+
+	deadline, ok := active.match.Deadline()
+	if !ok || !active.match.Acted(active.side) ||
+		active.match.Acted(active.side.Opponent()) {
+
+		return
+	}
+
+	// Events are timestamped in seconds, so the claim is only valid
+	// from the second after the deadline on.
+	wait := time.Until(deadline.Add(time.Second))
+	active.claimTimer = time.AfterFunc(wait, func() {
+		m.claimTimeout(active)
+	})
+}
+
+// claimTimeout claims the match with a timeout result if the opponent
+// missed the deadline of the current phase.
+func (m *Manager) claimTimeout(active *activeMatch) {
+	if m.ctx.Err() != nil {
+		return
+	}
+
+	active.Lock()
+	winner, ok := active.match.Stalled(nostr.Now())
+	claim := FightMonResultEvent{
+		Winner:   m.transport.PubKey(),
+		Reason:   ResultTimeout,
+		Rounds:   len(active.match.Rounds),
+		Evidence: active.claimEvidence(),
+	}
+	active.Unlock()
+	if !ok || winner != active.side {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, resultTimeout)
+	defer cancel()
+
+	matchId := active.match.MatchId
+	ev, err := GetFightMonResultEvent(matchId, claim)
+	if err == nil {
+		err = m.publish(ctx, matchId, ev)
+	}
+	if err != nil {
+		log.Printf("Unable to claim timeout of match %v: %v", matchId,
+			err)
+	}
+}
+
+// DisputeMatch publishes all events of the match we know of as a bundle,
+// together with the events the relays still store, so third parties can
+// adjudicate its result with VerifyMatch. The bundle is published in the
+// clear even for private matches. It returns the id of the bundle event.
+func (m *Manager) DisputeMatch(ctx context.Context,
+	matchId string) (string, error) {
+
+	var events []*nostr.Event
+	if active, err := m.activeMatch(matchId); err == nil {
+		active.Lock()
+		events = append(events, active.events...)
+		active.Unlock()
+	}
+
+	stored, err := m.transport.Query(
+		ctx, nostr.Filters{MatchFilter(matchId)},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	// Bundles are expanded, so the dispute carries every event only
+	// once.
+	var (
+		bundled []*nostr.Event
+		seen    = make(map[string]struct{})
+	)
+	for _, ev := range expandBundles(matchId, append(events, stored...)) {
+		if _, ok := seen[ev.ID]; ok || GetMatchId(ev) != matchId {
+			continue
+		}
+		seen[ev.ID] = struct{}{}
+		bundled = append(bundled, ev)
+	}
+	if len(bundled) == 0 {
+		return "", ErrUnknownMatch
+	}
+
+	bundle, err := GetFightMonMatchBundleEvent(matchId, bundled)
+	if err != nil {
+		return "", err
+	}
+	if err := m.transport.Publish(ctx, bundle); err != nil {
+		return "", err
+	}
+
+	return bundle.ID, nil
+}
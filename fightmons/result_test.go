@@ -0,0 +1,295 @@
+package fightmons
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+)
+
+// signResult returns the result event of the match signed by the player.
+func signResult(t *testing.T, p *testPlayer, matchId string,
+	result FightMonResultEvent, createdAt nostr.Timestamp) *nostr.Event {
+
+	ev, err := GetFightMonResultEvent(matchId, result)
+	require.NoError(t, err)
+	SetEventVersion(ev, FightMonVersion)
+
+	return p.sign(t, ev, createdAt)
+}
+
+// TestMatchDeadline tests that a player that doesn't reveal before the
+// deadline loses the match once the opponent claims the timeout, that late
+// or backdated events and premature claims don't count and that events
+// unseen by the claimant don't overturn the claim.
+func TestMatchDeadline(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+	matchId, matchNonce, err := NewMatchId(host.pub)
+	require.NoError(t, err)
+
+	var (
+		events []*nostr.Event
+		start  = nostr.Timestamp(1700000000)
+	)
+	add := func(p *testPlayer, ev *nostr.Event,
+		createdAt nostr.Timestamp) *nostr.Event {
+
+		SetEventVersion(ev, FightMonVersion)
+		events = append(events, p.sign(t, ev, createdAt))
+
+		return ev
+	}
+
+	lobby := GetFightMonLookingForMatchEvent(matchId, matchNonce)
+	SetRoundTimeout(lobby, time.Minute)
+	events = append(events, host.sign(t, lobby, start-3))
+
+	request, err := GetFightMonRequestMatchEvent(
+		matchId, Party{challenger.mon},
+	)
+	require.NoError(t, err)
+	events = append(events, challenger.sign(t, request, start-2))
+
+	accept, err := GetFightMonAcceptMatchEvent(
+		matchId, challenger.pub, Party{host.mon},
+	)
+	require.NoError(t, err)
+	add(host, accept, start-1)
+	add(host, GetFightMonStartMatchEvent(matchId), start)
+
+	// Both commit in time, but only the host reveals. The challenger
+	// reveals after the deadline, which is measured from its commit.
+	var seeds [2]string
+	for side, p := range []*testPlayer{host, challenger} {
+		seed, hash, err := NewRoundSeed(ActionAttack, 0)
+		require.NoError(t, err)
+		seeds[side] = seed
+
+		ev, err := GetFightMonRoundEvent(matchId, FightMonRoundEvent{
+			RoundId: 1,
+			RngHash: hash,
+		})
+		require.NoError(t, err)
+		add(p, ev, start+nostr.Timestamp(10+side))
+	}
+	reveal := func(side Side) *nostr.Event {
+		ev, err := GetFightMonRoundCommitEvent(
+			matchId, FightMonRoundCommitEvent{
+				RoundID: 1,
+				Action:  int(ActionAttack),
+				RngSeed: seeds[side],
+			},
+		)
+		require.NoError(t, err)
+
+		return ev
+	}
+	add(host, reveal(SideHost), start+20)
+	late := add(challenger, reveal(SideChallenger), start+72)
+
+	match := NewMatch(matchId)
+	for _, ev := range events {
+		_, err := match.AddEvent(ev)
+		if ev == late {
+			require.ErrorIs(t, err, ErrLate)
+			continue
+		}
+		require.NoError(t, err)
+	}
+	require.Equal(t, time.Minute, match.RoundTimeout)
+	deadline, ok := match.Deadline()
+	require.True(t, ok)
+	require.Equal(t, int64(start+71), deadline.Unix())
+
+	// A reveal dated before the commit it answers doesn't count either.
+	backdated := challenger.sign(t, reveal(SideChallenger), start+5)
+	_, err = match.AddEvent(backdated)
+	require.ErrorIs(t, err, ErrBackdated)
+	require.False(t, match.Acted(SideChallenger))
+
+	// A claim before the deadline is ignored.
+	claim := FightMonResultEvent{
+		Winner: host.pub,
+		Reason: ResultTimeout,
+	}
+	premature := signResult(t, host, matchId, claim, start+71)
+	_, err = match.AddEvent(premature)
+	require.ErrorIs(t, err, ErrInvalidResult)
+
+	// Once the deadline passed, the host wins by claiming the timeout.
+	claim.Evidence = events
+	timeout := signResult(t, host, matchId, claim, start+80)
+	updates, err := match.AddEvent(timeout)
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	require.Equal(t, UpdateFinished, updates[0].Type)
+	require.Equal(t, SideHost, updates[0].Winner)
+	require.True(t, updates[0].TimedOut)
+	require.Equal(t, UpdateResultSigned, updates[1].Type)
+	require.False(t, match.CoSigned())
+
+	// The challenger can't dispute the timeout by signing a win.
+	disputed := signResult(t, challenger, matchId, FightMonResultEvent{
+		Winner: challenger.pub,
+		Reason: ResultFinished,
+		Rounds: 1,
+	}, start+90)
+	_, err = match.AddEvent(disputed)
+	require.ErrorIs(t, err, ErrInvalidResult)
+
+	// The timeout claim alone carries everything to verify the match.
+	verified, err := VerifyMatch(
+		matchId, []*nostr.Event{premature, timeout, disputed}, nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, SideHost, verified.Winner)
+	require.True(t, verified.TimedOut())
+	require.Empty(t, verified.Rounds)
+	require.Equal(t, []*nostr.Event{premature, disputed},
+		verified.Disputed)
+
+	// A reveal dated before the deadline that the host hadn't seen when
+	// it claimed the timeout doesn't overturn the claim.
+	hidden := challenger.sign(t, reveal(SideChallenger), start+70)
+	verified, err = VerifyMatch(
+		matchId, append(events, timeout, hidden), nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, SideHost, verified.Winner)
+	require.True(t, verified.TimedOut())
+	require.Empty(t, verified.Rounds)
+}
+
+// TestResultReceipts tests that the results both players sign are receipts
+// of the verified result and that a winner's result can be verified from
+// the evidence it carries.
+func TestResultReceipts(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+	matchId, events := playTestMatch(t, host, challenger, alwaysAttack)
+	verified, err := VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, SideNone, verified.Winner)
+	require.False(t, verified.CoSigned())
+
+	players := [2]*testPlayer{host, challenger}
+	winner, loser := players[verified.Winner],
+		players[verified.Winner.Opponent()]
+	result := FightMonResultEvent{
+		Winner: winner.pub,
+		Reason: ResultFinished,
+		Rounds: len(verified.Rounds),
+	}
+	createdAt := events[len(events)-1].CreatedAt + 1
+	cosigned := signResult(t, loser, matchId, result, createdAt)
+
+	result.Evidence = events
+	winnerResult := signResult(t, winner, matchId, result, createdAt)
+
+	verified, err = VerifyMatch(
+		matchId, []*nostr.Event{winnerResult, cosigned}, nil,
+	)
+	require.NoError(t, err)
+	require.True(t, verified.CoSigned())
+	require.Equal(t, winnerResult, verified.Receipts()[verified.Winner])
+	require.Empty(t, verified.Disputed)
+
+	// A result that doesn't follow from the events is a dispute that
+	// doesn't affect the match.
+	bogus := signResult(t, loser, matchId, FightMonResultEvent{
+		Winner: loser.pub,
+		Reason: ResultFinished,
+		Rounds: len(verified.Rounds),
+	}, createdAt)
+	verified, err = VerifyMatch(
+		matchId, []*nostr.Event{winnerResult, bogus}, nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, winner.pub, verified.WinnerPubKey())
+	require.False(t, verified.CoSigned())
+	require.Equal(t, []*nostr.Event{bogus}, verified.Disputed)
+}
+
+// TestManagerTimeoutClaim tests that the manager claims a match whose
+// opponent stalls, that the opponent signs the timeout and that a dispute
+// publishes a bundle anyone can verify the result from.
+func TestManagerTimeoutClaim(t *testing.T) {
+	ctx := context.Background()
+	relay := newMemRelay()
+
+	host := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer host.Stop()
+	host.roundTimeout = time.Second
+	challenger := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer challenger.Stop()
+
+	hostMon, challengerMon := newTestPlayer(t).mon, newTestPlayer(t).mon
+	_, matchId, err := host.FindMatch(ctx, true)
+	require.NoError(t, err)
+	hostUpdates, cancel, err := host.SubscribeMatch(matchId)
+	require.NoError(t, err)
+	defer cancel()
+
+	lobbies, _, err := challenger.FindMatch(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, lobbies, 1)
+	require.Equal(t, time.Second, lobbies[0].RoundTimeout)
+
+	err = challenger.ChallengeMon(ctx, matchId, Party{challengerMon})
+	require.NoError(t, err)
+	waitForUpdate(t, hostUpdates, UpdateChallenged)
+	err = host.AcceptChallenge(
+		ctx, matchId, challenger.PubKey(), Party{hostMon},
+	)
+	require.NoError(t, err)
+	waitForUpdate(t, hostUpdates, UpdateStarted)
+
+	// The challenger never commits, so the host wins once the deadline
+	// passed.
+	_, err = host.SubmitAction(ctx, matchId, ActionAttack)
+	require.NoError(t, err)
+	finished := waitForUpdate(t, hostUpdates, UpdateFinished)
+	require.Equal(t, SideHost, finished.Winner)
+	require.True(t, finished.TimedOut)
+
+	// The challenger signs the timeout, so the result is co-signed.
+	verify := func() (*VerifiedMatch, error) {
+		events, err := host.transport.Query(
+			ctx, nostr.Filters{MatchFilter(matchId)},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return VerifyMatch(matchId, events, nil)
+	}
+	require.Eventually(t, func() bool {
+		verified, err := verify()
+		return err == nil && verified.CoSigned()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	verified, err := verify()
+	require.NoError(t, err)
+	require.Equal(t, host.PubKey(), verified.WinnerPubKey())
+	require.True(t, verified.TimedOut())
+
+	// The challenger can't act anymore.
+	_, err = challenger.SubmitAction(ctx, matchId, ActionAttack)
+	require.Error(t, err)
+
+	// A dispute republishes the events of the match as a bundle, which
+	// is enough to verify the result.
+	bundleId, err := challenger.DisputeMatch(ctx, matchId)
+	require.NoError(t, err)
+	bundles, err := host.transport.Query(ctx, nostr.Filters{{
+		IDs: []string{bundleId},
+	}})
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+
+	verified, err = VerifyMatch(matchId, bundles, nil)
+	require.NoError(t, err)
+	require.Equal(t, host.PubKey(), verified.WinnerPubKey())
+	require.True(t, verified.CoSigned())
+}
@@ -0,0 +1,465 @@
+package fightmons
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+const (
+	// MinFightMonVersion is the oldest protocol version we still speak.
+	MinFightMonVersion = 1
+
+	// VersionTag is the tag that carries the protocol version an event is
+	// encoded with. Events published before the tag was introduced are
+	// read as version 1.
+	VersionTag = "version"
+
+	// VersionsTag is the tag of lobby and match request events that lists
+	// all protocol versions the author speaks. The host accepts a match
+	// request with the highest version both players speak, which all
+	// events of the match are encoded with from then on.
+	VersionsTag = "versions"
+)
+
+var (
+	// ErrInvalidSchema is returned for events whose tags or content don't
+	// match the schema of their kind.
+	ErrInvalidSchema = errors.New("invalid event schema")
+
+	// ErrUnsupportedVersion is returned for events encoded with a
+	// protocol version we don't speak.
+	ErrUnsupportedVersion = errors.New("unsupported protocol version")
+)
+
+// SupportedVersions returns all protocol versions we speak, from the oldest
+// to the newest.
+func SupportedVersions() []int {
+	versions := make([]int, 0, FightMonVersion-MinFightMonVersion+1)
+	for v := MinFightMonVersion; v <= FightMonVersion; v++ {
+		versions = append(versions, v)
+	}
+
+	return versions
+}
+
+// EventVersion returns the protocol version the event is encoded with.
+func EventVersion(ev *nostr.Event) (int, error) {
+	tag := ev.Tags.GetFirst([]string{VersionTag})
+	if tag == nil {
+		return 1, nil
+	}
+
+	version, err := strconv.Atoi(tag.Value())
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%w: invalid version %q", ErrInvalidSchema,
+			tag.Value())
+	}
+
+	return version, nil
+}
+
+// EventVersions returns the protocol versions the author of a lobby or match
+// request event speaks. Without a versions tag, the author only speaks the
+// version of the event.
+func EventVersions(ev *nostr.Event) ([]int, error) {
+	tag := ev.Tags.GetFirst([]string{VersionsTag})
+	if tag == nil {
+		version, err := EventVersion(ev)
+		if err != nil {
+			return nil, err
+		}
+
+		return []int{version}, nil
+	}
+
+	versions := make([]int, 0, len(*tag)-1)
+	for _, value := range (*tag)[1:] {
+		version, err := strconv.Atoi(value)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("%w: invalid version %q",
+				ErrInvalidSchema, value)
+		}
+		versions = append(versions, version)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: empty versions tag",
+			ErrInvalidSchema)
+	}
+
+	return versions, nil
+}
+
+// NegotiateVersion returns the highest protocol version in both lists.
+func NegotiateVersion(ours, theirs []int) (int, error) {
+	best := 0
+	for _, a := range ours {
+		for _, b := range theirs {
+			if a == b && a > best {
+				best = a
+			}
+		}
+	}
+	if best == 0 {
+		return 0, fmt.Errorf("%w: no common version in %v and %v",
+			ErrUnsupportedVersion, ours, theirs)
+	}
+
+	return best, nil
+}
+
+// SetEventVersion sets the protocol version the event is encoded with. It
+// must be called before the event is signed.
+func SetEventVersion(ev *nostr.Event, version int) {
+	for _, tag := range ev.Tags {
+		if len(tag) >= 2 && tag[0] == VersionTag {
+			tag[1] = strconv.Itoa(version)
+			return
+		}
+	}
+
+	ev.Tags = append(ev.Tags, nostr.Tag{VersionTag, strconv.Itoa(version)})
+}
+
+// versionTags returns the tags every event is published with.
+func versionTags() nostr.Tags {
+	return nostr.Tags{
+		nostr.Tag{VersionTag, strconv.Itoa(FightMonVersion)},
+	}
+}
+
+// handshakeTags returns the tags of lobby and match request events. They are
+// encoded with the oldest version we speak, so every client we could play
+// with can read them, and list all versions we speak.
+func handshakeTags() nostr.Tags {
+	versions := nostr.Tag{VersionsTag}
+	for _, version := range SupportedVersions() {
+		versions = append(versions, strconv.Itoa(version))
+	}
+
+	return nostr.Tags{
+		nostr.Tag{VersionTag, strconv.Itoa(MinFightMonVersion)},
+		versions,
+	}
+}
+
+// checkEventVersion checks that the event is encoded with the given version.
+func checkEventVersion(ev *nostr.Event, version int) error {
+	eventVersion, err := EventVersion(ev)
+	if err != nil {
+		return err
+	}
+	if eventVersion != version {
+		return fmt.Errorf("event encoded with version %d instead of "+
+			"version %d", eventVersion, version)
+	}
+
+	return nil
+}
+
+// ValidateEvent checks the tags and the content of a fightmon event against
+// the schema of its kind. The event must be encoded with a version we speak,
+// be bound to a match or tournament, and its content must decode into the
+// content of its kind without unknown fields and with all values in range.
+func ValidateEvent(ev *nostr.Event) error {
+	content, err := newEventContent(ev.Kind)
+	if err != nil {
+		return err
+	}
+
+	return decodeEvent(ev, content)
+}
+
+// decodeEvent validates the tags of an event and strictly decodes its
+// content into v, which is validated as well if it has a Validate method.
+func decodeEvent(ev *nostr.Event, v any) error {
+	version, err := EventVersion(ev)
+	if err != nil {
+		return err
+	}
+	if version < MinFightMonVersion || version > FightMonVersion {
+		return fmt.Errorf("%w %d", ErrUnsupportedVersion, version)
+	}
+
+	idTag, indexTag := MatchIdTag, MatchIndexTag
+	if isTournamentKind(ev.Kind) {
+		idTag, indexTag = TournamentIdTag, TournamentIndexTag
+	}
+	id := ev.Tags.GetFirst([]string{idTag})
+	if id == nil {
+		return fmt.Errorf("%w: missing %v tag", ErrInvalidSchema, idTag)
+	}
+	if err := checkHex(idTag, id.Value(), 32); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	index := ev.Tags.GetFirst([]string{indexTag, id.Value()})
+	if index == nil {
+		return fmt.Errorf("%w: missing %v tag", ErrInvalidSchema,
+			indexTag)
+	}
+
+	// Kinds without content must not carry any.
+	if v == nil {
+		if ev.Content != "" {
+			return fmt.Errorf("%w: unexpected content",
+				ErrInvalidSchema)
+		}
+
+		return nil
+	}
+
+	if err := strictUnmarshal([]byte(ev.Content), v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	if validator, ok := v.(interface{ Validate() error }); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+		}
+	}
+
+	return nil
+}
+
+// newEventContent returns a pointer to the content of the given kind to
+// decode into, or nil if events of the kind have no content.
+func newEventContent(kind int) (any, error) {
+	switch kind {
+	case FightMonLookingForMatch, FightMonStartMatch:
+		return nil, nil
+	case FightMonRequestMatch, FightMonAcceptMatch:
+		return &Party{}, nil
+	case FightMonRound:
+		return &FightMonRoundEvent{}, nil
+	case FightMonRoundCommit:
+		return &FightMonRoundCommitEvent{}, nil
+	case FightMonWager:
+		return &FightMonWagerEvent{}, nil
+	case FightMonAnte:
+		return &FightMonAnteEvent{}, nil
+	case FightMonAnteEscrow:
+		return &FightMonAnteEscrowEvent{}, nil
+	case FightMonAnteClaim:
+		return &FightMonAnteClaimEvent{}, nil
+	case FightMonTournament:
+		return &TournamentRules{}, nil
+	case FightMonTournamentRegister:
+		return &FightMon{}, nil
+	case FightMonTournamentEntry:
+		return &FightMonTournamentEntryEvent{}, nil
+	case FightMonTournamentRound:
+		return &FightMonTournamentRoundEvent{}, nil
+	case FightMonTournamentStandings:
+		return &FightMonTournamentStandingsEvent{}, nil
+	case FightMonMatchBundle:
+		return &[]*nostr.Event{}, nil
+	case FightMonPeer:
+		return &FightMonPeerEvent{}, nil
+	case FightMonTurnRelay:
+		return &FightMonTurnRelayEvent{}, nil
+	case FightMonResult:
+		return &FightMonResultEvent{}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %d", ErrInvalidSchema,
+			kind)
+	}
+}
+
+// isTournamentKind returns true if the kind is a tournament event kind.
+func isTournamentKind(kind int) bool {
+	for _, tournamentKind := range TournamentKinds {
+		if kind == tournamentKind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// strictUnmarshal decodes a single json value into v and fails on unknown
+// fields and trailing data.
+func strictUnmarshal(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("trailing data after json value")
+	}
+
+	return nil
+}
+
+// checkHex checks that the value is the hex encoding of size bytes.
+func checkHex(name, value string, size int) error {
+	data, err := hex.DecodeString(value)
+	if err != nil || len(data) != size {
+		return fmt.Errorf("%v is not %d hex encoded bytes", name, size)
+	}
+
+	return nil
+}
+
+// checkRoundRange checks that a round id is within the rounds of a match.
+func checkRoundRange(roundId int) error {
+	if roundId < 1 || roundId > MaxRounds {
+		return fmt.Errorf("round id %d out of range [1, %d]", roundId,
+			MaxRounds)
+	}
+
+	return nil
+}
+
+// Validate checks the round id and the commitment of a round event.
+func (r *FightMonRoundEvent) Validate() error {
+	if err := checkRoundRange(r.RoundId); err != nil {
+		return err
+	}
+
+	return checkHex("rng_hash", r.RngHash, 32)
+}
+
+// Validate checks the round id, the seed and the switch target of a round
+// commit event. Whether the action is legal depends on the state of the
+// battle and is checked when the round is played.
+func (r *FightMonRoundCommitEvent) Validate() error {
+	if err := checkRoundRange(r.RoundID); err != nil {
+		return err
+	}
+	if r.Target < 0 || r.Target >= MaxPartySize {
+		return fmt.Errorf("target %d out of range", r.Target)
+	}
+
+	return checkHex("rng_seed", r.RngSeed, 32)
+}
+
+// Validate checks the amount and invoice of a wager event.
+func (w *FightMonWagerEvent) Validate() error {
+	if w.AmountSat <= 0 {
+		return fmt.Errorf("invalid wager amount %d", w.AmountSat)
+	}
+	if w.Invoice == "" {
+		return fmt.Errorf("wager without invoice")
+	}
+
+	return nil
+}
+
+// Validate checks the escrow, asset id and refund address of an ante event.
+func (a *FightMonAnteEvent) Validate() error {
+	if err := checkHex("escrow", a.Escrow, 32); err != nil {
+		return err
+	}
+	if err := checkHex("asset_id", a.AssetId, 32); err != nil {
+		return err
+	}
+	if a.RefundAddr == "" {
+		return fmt.Errorf("ante without refund address")
+	}
+
+	return nil
+}
+
+// Validate checks the player and deposit address of an ante escrow event.
+func (a *FightMonAnteEscrowEvent) Validate() error {
+	if err := checkHex("player", a.Player, 32); err != nil {
+		return err
+	}
+	if a.DepositAddr == "" {
+		return fmt.Errorf("ante escrow without deposit address")
+	}
+
+	return nil
+}
+
+// Validate checks the claim address of an ante claim event.
+func (a *FightMonAnteClaimEvent) Validate() error {
+	if a.ClaimAddr == "" {
+		return fmt.Errorf("ante claim without claim address")
+	}
+
+	return nil
+}
+
+// Validate checks the player and invoice of a tournament entry event.
+func (e *FightMonTournamentEntryEvent) Validate() error {
+	if err := checkHex("player", e.Player, 32); err != nil {
+		return err
+	}
+	if e.Invoice == "" {
+		return fmt.Errorf("entry without invoice")
+	}
+
+	return nil
+}
+
+// Validate checks the round number of a tournament round event.
+func (r *FightMonTournamentRoundEvent) Validate() error {
+	if r.Round < 1 {
+		return fmt.Errorf("invalid round %d", r.Round)
+	}
+
+	return nil
+}
+
+// Validate checks the round number of a tournament standings event.
+func (s *FightMonTournamentStandingsEvent) Validate() error {
+	if s.Round < 1 {
+		return fmt.Errorf("invalid round %d", s.Round)
+	}
+
+	return nil
+}
+
+// Validate checks that a peer event names a peer and how to reach it.
+func (p *FightMonPeerEvent) Validate() error {
+	if p.PeerId == "" {
+		return fmt.Errorf("peer without peer id")
+	}
+	if len(p.Addrs) == 0 {
+		return fmt.Errorf("peer without addresses")
+	}
+
+	return nil
+}
+
+// Validate checks the address of a TURN relay event.
+func (r *FightMonTurnRelayEvent) Validate() error {
+	if _, err := netip.ParseAddrPort(r.RelayAddr); err != nil {
+		return fmt.Errorf("invalid relay address: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks the winner, reason and number of rounds of a result event.
+func (r *FightMonResultEvent) Validate() error {
+	if r.Winner != "" {
+		if err := checkHex("winner", r.Winner, 32); err != nil {
+			return err
+		}
+	}
+
+	switch r.Reason {
+	case ResultFinished:
+	case ResultTimeout:
+		if r.Winner == "" {
+			return fmt.Errorf("timeout without winner")
+		}
+	default:
+		return fmt.Errorf("unknown reason %q", r.Reason)
+	}
+
+	if r.Rounds < 0 || r.Rounds > MaxRounds {
+		return fmt.Errorf("rounds %d out of range [0, %d]", r.Rounds,
+			MaxRounds)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package fightmons
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNegotiateVersion tests that the highest common version is picked.
+func TestNegotiateVersion(t *testing.T) {
+	version, err := NegotiateVersion([]int{1, 2, 3}, []int{4, 3, 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, version)
+
+	_, err = NegotiateVersion([]int{1}, []int{2, 3})
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	require.Equal(t, []int{1, 2}, SupportedVersions())
+}
+
+// TestValidateEvent tests the schema validation of fightmon events.
+func TestValidateEvent(t *testing.T) {
+	host := newTestPlayer(t)
+	matchId, matchNonce, err := NewMatchId(host.pub)
+	require.NoError(t, err)
+
+	// Every event carries the version it is encoded with, handshakes also
+	// list all versions we speak.
+	lobby := GetFightMonLookingForMatchEvent(matchId, matchNonce)
+	require.NoError(t, ValidateEvent(lobby))
+	versions, err := EventVersions(lobby)
+	require.NoError(t, err)
+	require.Equal(t, SupportedVersions(), versions)
+
+	start := GetFightMonStartMatchEvent(matchId)
+	version, err := EventVersion(start)
+	require.NoError(t, err)
+	require.Equal(t, FightMonVersion, version)
+
+	// Events published before versioning are read as version 1.
+	legacy := &nostr.Event{
+		Kind: FightMonStartMatch,
+		Tags: nostr.Tags{
+			{MatchIdTag, matchId}, {MatchIndexTag, matchId},
+		},
+	}
+	require.NoError(t, ValidateEvent(legacy))
+	version, err = EventVersion(legacy)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	round := func(content string) *nostr.Event {
+		return &nostr.Event{
+			Kind:    FightMonRound,
+			Tags:    matchTags(matchId),
+			Content: content,
+		}
+	}
+	hash := "00" + matchId[2:]
+	valid := `{"round_id":1,"rng_hash":"` + hash + `"}`
+	require.NoError(t, ValidateEvent(round(valid)))
+
+	tests := []struct {
+		name string
+		ev   *nostr.Event
+		err  string
+	}{{
+		name: "unknown field",
+		ev: round(
+			`{"round_id":1,"rng_hash":"` + hash + `","x":1}`,
+		),
+		err: "unknown field",
+	}, {
+		name: "round id too low",
+		ev:   round(`{"round_id":0,"rng_hash":"` + hash + `"}`),
+		err:  "out of range",
+	}, {
+		name: "round id too high",
+		ev:   round(`{"round_id":51,"rng_hash":"` + hash + `"}`),
+		err:  "out of range",
+	}, {
+		name: "invalid hash",
+		ev:   round(`{"round_id":1,"rng_hash":"00"}`),
+		err:  "rng_hash",
+	}, {
+		name: "trailing data",
+		ev:   round(valid + `{}`),
+		err:  "trailing data",
+	}, {
+		name: "missing match id",
+		ev: &nostr.Event{
+			Kind:    FightMonRound,
+			Tags:    versionTags(),
+			Content: valid,
+		},
+		err: "missing match_id",
+	}, {
+		name: "missing index tag",
+		ev: &nostr.Event{
+			Kind:    FightMonRound,
+			Tags:    nostr.Tags{{MatchIdTag, matchId}},
+			Content: valid,
+		},
+		err: "missing t",
+	}, {
+		name: "unexpected content",
+		ev: &nostr.Event{
+			Kind:    FightMonStartMatch,
+			Tags:    matchTags(matchId),
+			Content: "{}",
+		},
+		err: "unexpected content",
+	}, {
+		name: "unknown kind",
+		ev:   &nostr.Event{Kind: 1, Tags: matchTags(matchId)},
+		err:  "unknown kind",
+	}, {
+		name: "unsupported version",
+		ev: &nostr.Event{
+			Kind: FightMonStartMatch,
+			Tags: append(
+				nostr.Tags{
+					{MatchIdTag, matchId},
+					{MatchIndexTag, matchId},
+				},
+				nostr.Tag{VersionTag, "99"},
+			),
+		},
+		err: ErrUnsupportedVersion.Error(),
+	}, {
+		name: "unknown fightmon field",
+		ev: &nostr.Event{
+			Kind:    FightMonRequestMatch,
+			Tags:    matchTags(matchId),
+			Content: `{"id":"` + host.mon.Id + `","hp":999}`,
+		},
+		err: "unknown field",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorContains(t, ValidateEvent(test.ev), test.err)
+		})
+	}
+}
+
+// TestMatchVersion tests that the host accepts with the highest version
+// both players speak and that requests and events encoded with another
+// version are rejected.
+func TestMatchVersion(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+	other := newTestPlayer(t)
+	matchId, matchNonce, err := NewMatchId(host.pub)
+	require.NoError(t, err)
+
+	now := nostr.Timestamp(1700000000)
+	sign := func(p *testPlayer, ev *nostr.Event) *nostr.Event {
+		now++
+		return p.sign(t, ev, now)
+	}
+
+	// The host runs a newer client that also speaks a version we don't.
+	newer := FightMonVersion + 1
+	lobby := GetFightMonLookingForMatchEvent(matchId, matchNonce)
+	lobby.Tags = append(
+		lobby.Tags[:len(lobby.Tags)-1],
+		nostr.Tag{VersionsTag, "1", "2", strconv.Itoa(newer)},
+	)
+	match := NewMatch(matchId)
+	_, err = match.AddEvent(sign(host, lobby))
+	require.NoError(t, err)
+
+	// A client that only speaks a version we don't is ignored.
+	request, err := GetFightMonRequestMatchEvent(matchId, Party{other.mon})
+	require.NoError(t, err)
+	SetEventVersion(request, newer)
+	request.Tags = append(request.Tags[:len(request.Tags)-1],
+		nostr.Tag{VersionsTag, strconv.Itoa(newer)})
+	_, err = match.AddEvent(sign(other, request))
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	request, err = GetFightMonRequestMatchEvent(
+		matchId, Party{challenger.mon},
+	)
+	require.NoError(t, err)
+	_, err = match.AddEvent(sign(challenger, request))
+	require.NoError(t, err)
+
+	version, err := match.NegotiatedVersion(challenger.pub)
+	require.NoError(t, err)
+	require.Equal(t, FightMonVersion, version)
+
+	// The host has to accept with the negotiated version.
+	accept, err := GetFightMonAcceptMatchEvent(
+		matchId, challenger.pub, Party{host.mon},
+	)
+	require.NoError(t, err)
+	SetEventVersion(accept, 1)
+	_, err = match.AddEvent(sign(host, accept))
+	require.ErrorContains(
+		t, err, "instead of version "+strconv.Itoa(FightMonVersion),
+	)
+
+	accept, err = GetFightMonAcceptMatchEvent(
+		matchId, challenger.pub, Party{host.mon},
+	)
+	require.NoError(t, err)
+	match = NewMatch(matchId)
+	for _, ev := range []*nostr.Event{lobby, request, sign(host, accept)} {
+		_, err := match.AddEvent(ev)
+		require.NoError(t, err)
+	}
+	require.Equal(t, FightMonVersion, match.Version)
+}
@@ -0,0 +1,189 @@
+package fightmons
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memStore is an in-memory store of wagers, antes and ratings for tests.
+type memStore struct {
+	mu      sync.Mutex
+	wagers  map[string]Wager
+	antes   map[string]Ante
+	ratings map[RatingKind]map[string]Rating
+	rated   map[string]struct{}
+	history map[RatingKind]map[string][]*RatingHistoryEntry
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		wagers: make(map[string]Wager),
+		antes:  make(map[string]Ante),
+		ratings: map[RatingKind]map[string]Rating{
+			RatingPlayer: make(map[string]Rating),
+			RatingMon:    make(map[string]Rating),
+		},
+		rated: make(map[string]struct{}),
+		history: map[RatingKind]map[string][]*RatingHistoryEntry{
+			RatingPlayer: make(map[string][]*RatingHistoryEntry),
+			RatingMon:    make(map[string][]*RatingHistoryEntry),
+		},
+	}
+}
+
+func (s *memStore) AddWager(_ context.Context, wager *Wager) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.wagers[wager.MatchId] = *wager
+
+	return nil
+}
+
+func (s *memStore) UpdateWager(_ context.Context, wager *Wager) error {
+	return s.AddWager(context.Background(), wager)
+}
+
+func (s *memStore) GetWager(_ context.Context, matchId string) (*Wager,
+	error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wager, ok := s.wagers[matchId]
+	if !ok {
+		return nil, ErrUnknownWager
+	}
+
+	return &wager, nil
+}
+
+func (s *memStore) ListWagers(_ context.Context) ([]*Wager, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wagers := make([]*Wager, 0, len(s.wagers))
+	for _, wager := range s.wagers {
+		wager := wager
+		wagers = append(wagers, &wager)
+	}
+
+	return wagers, nil
+}
+
+func (s *memStore) AddAnte(_ context.Context, ante *Ante) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.antes[ante.MatchId+ante.Player] = *ante
+
+	return nil
+}
+
+func (s *memStore) UpdateAnte(_ context.Context, ante *Ante) error {
+	return s.AddAnte(context.Background(), ante)
+}
+
+func (s *memStore) GetAnte(_ context.Context, matchId,
+	player string) (*Ante, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ante, ok := s.antes[matchId+player]
+	if !ok {
+		return nil, ErrUnknownAnte
+	}
+
+	return &ante, nil
+}
+
+func (s *memStore) ListAntes(_ context.Context) ([]*Ante, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	antes := make([]*Ante, 0, len(s.antes))
+	for _, ante := range s.antes {
+		ante := ante
+		antes = append(antes, &ante)
+	}
+
+	return antes, nil
+}
+
+func (s *memStore) GetRating(_ context.Context, kind RatingKind,
+	subject string) (*Rating, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rating, ok := s.ratings[kind][subject]
+	if !ok {
+		return nil, nil
+	}
+
+	return &rating, nil
+}
+
+func (s *memStore) AddMatchRatings(_ context.Context, matchId string,
+	playedAt time.Time, ratings []*Rating) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rated[matchId]; ok {
+		return ErrMatchRated
+	}
+	s.rated[matchId] = struct{}{}
+
+	for _, rating := range ratings {
+		s.ratings[rating.Kind][rating.Subject] = *rating
+		s.history[rating.Kind][rating.Subject] = append(
+			s.history[rating.Kind][rating.Subject],
+			&RatingHistoryEntry{
+				MatchId:  matchId,
+				Rating:   rating.Rating,
+				PlayedAt: playedAt,
+			},
+		)
+	}
+
+	return nil
+}
+
+func (s *memStore) ListRatings(_ context.Context, kind RatingKind,
+	offset, limit int) ([]*Rating, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ratings := make([]*Rating, 0, len(s.ratings[kind]))
+	for _, rating := range s.ratings[kind] {
+		rating := rating
+		ratings = append(ratings, &rating)
+	}
+	sort.Slice(ratings, func(i, j int) bool {
+		return ratings[i].Rating > ratings[j].Rating
+	})
+
+	if offset > len(ratings) {
+		offset = len(ratings)
+	}
+	ratings = ratings[offset:]
+	if limit < len(ratings) {
+		ratings = ratings[:limit]
+	}
+
+	return ratings, nil
+}
+
+func (s *memStore) GetRatingHistory(_ context.Context,
+	kind RatingKind, subject string) ([]*RatingHistoryEntry, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.history[kind][subject], nil
+}
@@ -0,0 +1,324 @@
+package fightmons
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+const (
+	// DefaultMinimaxDepth is the number of rounds the minimax strategy
+	// looks ahead if no depth is set.
+	DefaultMinimaxDepth = 2
+
+	// chanceBranchLimit is the largest draw of the rng of a round the
+	// minimax strategy branches on. Smaller draws decide whether a special
+	// attack misses and which fightmon moves first on a speed tie, larger
+	// draws only vary the damage.
+	chanceBranchLimit = 4
+
+	// fictitiousPlays is the number of rounds of fictitious play the
+	// minimax strategy of a round is approximated with.
+	fictitiousPlays = 100
+
+	// winValue is the value of a won battle, which is more than any
+	// health difference is worth.
+	winValue = 2.0
+)
+
+// Difficulties are the names of the strategies NewStrategy knows.
+var Difficulties = []string{"random", "greedy", "minimax"}
+
+// Strategy picks the actions of a bot in a battle.
+type Strategy interface {
+	// Action returns the action and switch target the given side takes
+	// in the next round of the battle. It must not modify the battle.
+	Action(battle *Battle, side Side) (Action, int)
+}
+
+// NewStrategy returns the strategy of the given difficulty.
+func NewStrategy(difficulty string) (Strategy, error) {
+	switch difficulty {
+	case "random":
+		return &RandomStrategy{}, nil
+
+	case "greedy":
+		return &GreedyStrategy{}, nil
+
+	case "minimax":
+		return &MinimaxStrategy{Depth: DefaultMinimaxDepth}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown difficulty %q, expected one of "+
+			"%v", difficulty, Difficulties)
+	}
+}
+
+// choice is an action together with its switch target.
+type choice struct {
+	action Action
+	target int
+}
+
+// choices returns every action the side can take in the next round of the
+// battle.
+func choices(b *Battle, side Side) []choice {
+	choices := []choice{
+		{action: ActionAttack},
+		{action: ActionSpecial},
+		{action: ActionDefend},
+	}
+	for target := range b.hp[side] {
+		if b.CheckAction(side, ActionSwitch, target) == nil {
+			choices = append(choices, choice{
+				action: ActionSwitch,
+				target: target,
+			})
+		}
+	}
+
+	return choices
+}
+
+// RandomStrategy picks any action the side can take at random.
+type RandomStrategy struct{}
+
+// Action returns a random action.
+func (s *RandomStrategy) Action(battle *Battle, side Side) (Action, int) {
+	choices := choices(battle, side)
+	pick := choices[rand.Intn(len(choices))]
+
+	return pick.action, pick.target
+}
+
+// GreedyStrategy always attacks with the action that deals the most damage
+// in the next round. It never defends or switches.
+type GreedyStrategy struct{}
+
+// Action returns the attack with the highest expected damage, unless a
+// regular attack knocks out the opponent for sure.
+func (s *GreedyStrategy) Action(battle *Battle, side Side) (Action, int) {
+	stats, hp := battle.Stats(), battle.HP()
+	damage := baseDamage(stats[side], stats[side.Opponent()])
+
+	// A special attack deals more damage on average, but it may miss.
+	if damage*85/100 >= hp[side.Opponent()] {
+		return ActionAttack, 0
+	}
+
+	return ActionSpecial, 0
+}
+
+// MinimaxStrategy looks a number of rounds ahead. As both sides pick their
+// actions at once, every round is a game whose minimax strategy mixes
+// actions at random, so the opponent can't predict them. The strategy picks
+// its action from that mix. The outcome of a pair of actions is the expected
+// outcome over the chances of the round.
+type MinimaxStrategy struct {
+	// Depth is the number of rounds to look ahead.
+	Depth int
+}
+
+// Action returns an action drawn from the minimax strategy of the round.
+func (s *MinimaxStrategy) Action(battle *Battle, side Side) (Action, int) {
+	depth := s.Depth
+	if depth < 1 {
+		depth = DefaultMinimaxDepth
+	}
+
+	ours := choices(battle, side)
+	_, mix := solveGame(s.payoff(battle, side, ours, depth))
+
+	pick, draw := len(ours)-1, rand.Float64()
+	for i, p := range mix {
+		if draw < p {
+			pick = i
+			break
+		}
+		draw -= p
+	}
+
+	return ours[pick].action, ours[pick].target
+}
+
+// value returns the value of the battle for the side if both sides play
+// their minimax strategies for the given number of rounds.
+func (s *MinimaxStrategy) value(b *Battle, side Side, depth int) float64 {
+	if done, winner := b.Finished(); done {
+		switch winner {
+		case side:
+			return winValue
+		case SideNone:
+			return 0
+		default:
+			return -winValue
+		}
+	}
+	if depth == 0 {
+		return healthShare(b, side) - healthShare(b, side.Opponent())
+	}
+
+	value, _ := solveGame(s.payoff(b, side, choices(b, side), depth))
+
+	return value
+}
+
+// payoff returns the expected value of the next round of the battle for the
+// side for each of our choices and each choice of the opponent.
+func (s *MinimaxStrategy) payoff(b *Battle, side Side, ours []choice,
+	depth int) [][]float64 {
+
+	theirs := choices(b, side.Opponent())
+	payoff := make([][]float64, len(ours))
+	for i, our := range ours {
+		payoff[i] = make([]float64, len(theirs))
+		for j, their := range theirs {
+			var (
+				actions [2]Action
+				targets [2]int
+			)
+			actions[side], targets[side] = our.action, our.target
+			actions[side.Opponent()] = their.action
+			targets[side.Opponent()] = their.target
+
+			outcomes := roundOutcomes(b, actions, targets)
+			for _, outcome := range outcomes {
+				value := s.value(outcome.battle, side, depth-1)
+				payoff[i][j] += outcome.p * value
+			}
+		}
+	}
+
+	return payoff
+}
+
+// solveGame approximates the value of the zero-sum game with the given
+// payoff matrix of the row player and the mixed strategy that reaches it for
+// the row player with fictitious play. Both players repeatedly play their
+// best answer to the mix of the actions their opponent played so far.
+func solveGame(payoff [][]float64) (float64, []float64) {
+	var (
+		rows, cols = len(payoff), len(payoff[0])
+		played     = make([]float64, rows)
+		rowTotals  = make([]float64, rows)
+		colTotals  = make([]float64, cols)
+		row        int
+	)
+	for i := 0; i < fictitiousPlays; i++ {
+		played[row]++
+		for j := range colTotals {
+			colTotals[j] += payoff[row][j]
+		}
+
+		col := 0
+		for j := range colTotals {
+			if colTotals[j] < colTotals[col] {
+				col = j
+			}
+		}
+		for j := range rowTotals {
+			rowTotals[j] += payoff[j][col]
+		}
+
+		row = 0
+		for j := range rowTotals {
+			if rowTotals[j] > rowTotals[row] {
+				row = j
+			}
+		}
+	}
+
+	// The best answers to the mixes bound the value from both sides.
+	upper, lower := rowTotals[0], colTotals[0]
+	for _, total := range rowTotals {
+		upper = math.Max(upper, total)
+	}
+	for _, total := range colTotals {
+		lower = math.Min(lower, total)
+	}
+
+	mix := make([]float64, rows)
+	for i := range played {
+		mix[i] = played[i] / fictitiousPlays
+	}
+
+	return (upper + lower) / 2 / fictitiousPlays, mix
+}
+
+// chanceRng is an rng that explores the chances of a round instead of
+// drawing random numbers. A draw of up to chanceBranchLimit numbers branches
+// into drawing zero and drawing any other number, larger draws return their
+// middle number.
+type chanceRng struct {
+	// script are the branches to take at the branching draws, false for
+	// zero. Draws past the script take the zero branch.
+	script []bool
+
+	// path are the branches taken so far.
+	path []bool
+
+	// p is the probability of the branches taken so far.
+	p float64
+}
+
+// Intn returns the number of the branch to take.
+func (r *chanceRng) Intn(n int) int {
+	if n > chanceBranchLimit {
+		return n / 2
+	}
+
+	nonZero := len(r.path) < len(r.script) && r.script[len(r.path)]
+	r.path = append(r.path, nonZero)
+	if !nonZero {
+		r.p /= float64(n)
+		return 0
+	}
+	r.p *= float64(n-1) / float64(n)
+
+	return 1
+}
+
+// outcome is a battle a round may lead to and its probability.
+type outcome struct {
+	battle *Battle
+	p      float64
+}
+
+// roundOutcomes returns every battle the next round of the battle may lead
+// to with the given actions and switch targets.
+func roundOutcomes(b *Battle, actions [2]Action, targets [2]int) []outcome {
+	var (
+		outcomes []outcome
+		script   []bool
+	)
+	for {
+		rng := &chanceRng{script: script, p: 1}
+		next := b.clone()
+		if _, err := next.playRound(actions, targets, rng); err != nil {
+			return nil
+		}
+		outcomes = append(outcomes, outcome{battle: next, p: rng.p})
+
+		// The next path takes the other branch at the last draw that
+		// took the zero branch.
+		i := len(rng.path) - 1
+		for i >= 0 && rng.path[i] {
+			i--
+		}
+		if i < 0 {
+			return outcomes
+		}
+		script = append(rng.path[:i:i], true)
+	}
+}
+
+// healthShare returns the share of its total party health the side has left.
+func healthShare(b *Battle, side Side) float64 {
+	var hp, maxHP int
+	for i, stats := range b.stats[side] {
+		hp += b.hp[side][i]
+		maxHP += stats.HP
+	}
+
+	return float64(hp) / float64(maxHP)
+}
@@ -0,0 +1,157 @@
+package fightmons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBattle returns a battle between two random parties of the given
+// sizes.
+func newTestBattle(t *testing.T, hostSize, challengerSize int) *Battle {
+	host, err := randomParty(hostSize)
+	require.NoError(t, err)
+	challenger, err := randomParty(challengerSize)
+	require.NoError(t, err)
+
+	battle, err := NewBattle(host, challenger)
+	require.NoError(t, err)
+
+	return battle
+}
+
+// playStrategies plays a battle between the strategies and returns the
+// winner.
+func playStrategies(t *testing.T, battle *Battle,
+	strategies [2]Strategy) Side {
+
+	for {
+		if done, winner := battle.Finished(); done {
+			return winner
+		}
+
+		var (
+			actions [2]Action
+			targets [2]int
+		)
+		for _, side := range []Side{SideHost, SideChallenger} {
+			actions[side], targets[side] = strategies[side].Action(
+				battle, side,
+			)
+			require.NoError(t, battle.CheckAction(
+				side, actions[side], targets[side],
+			))
+		}
+
+		var seeds [2]string
+		for side := range seeds {
+			seed, _, err := NewRoundSeed(actions[side], targets[side])
+			require.NoError(t, err)
+			seeds[side] = seed
+		}
+		_, err := battle.PlayRound(actions, targets, seeds)
+		require.NoError(t, err)
+	}
+}
+
+// TestStrategies tests that all strategies only pick actions they can take
+// and don't modify the battle they pick them for, and that the greedy and
+// the minimax strategy beat random play most of the time.
+func TestStrategies(t *testing.T) {
+	for _, difficulty := range Difficulties {
+		strategy, err := NewStrategy(difficulty)
+		require.NoError(t, err)
+
+		battle := newTestBattle(t, 3, 3)
+		before := battle.PartyHP()
+		action, target := strategy.Action(battle, SideChallenger)
+		require.NoError(t, battle.CheckAction(
+			SideChallenger, action, target,
+		))
+		require.Equal(t, before, battle.PartyHP())
+		require.Equal(t, 1, battle.Round())
+
+		playStrategies(t, battle, [2]Strategy{
+			strategy, &RandomStrategy{},
+		})
+	}
+
+	_, err := NewStrategy("impossible")
+	require.Error(t, err)
+
+	// Random mons differ a lot, so both sides play with the same party.
+	const battles = 20
+	for _, difficulty := range Difficulties[1:] {
+		strategy, err := NewStrategy(difficulty)
+		require.NoError(t, err)
+
+		var wins int
+		for i := 0; i < battles; i++ {
+			party, err := randomParty(2)
+			require.NoError(t, err)
+			battle, err := NewBattle(party, party)
+			require.NoError(t, err)
+
+			winner := playStrategies(t, battle, [2]Strategy{
+				strategy, &RandomStrategy{},
+			})
+			if winner == SideHost {
+				wins++
+			}
+		}
+		require.Greater(t, wins, battles/2, "%v won %d of %d battles",
+			difficulty, wins, battles)
+	}
+}
+
+// TestRoundOutcomes tests that the outcomes of a round cover every chance of
+// the round.
+func TestRoundOutcomes(t *testing.T) {
+	party, err := randomParty(1)
+	require.NoError(t, err)
+	battle, err := NewBattle(party, party)
+	require.NoError(t, err)
+
+	// The fightmons are equally fast, so who moves first is left to
+	// chance, as is whether either special attack misses.
+	outcomes := roundOutcomes(
+		battle, [2]Action{ActionSpecial, ActionSpecial}, [2]int{},
+	)
+	require.Len(t, outcomes, 8)
+
+	var p float64
+	for _, outcome := range outcomes {
+		p += outcome.p
+		require.Equal(t, 2, outcome.battle.Round())
+	}
+	require.InDelta(t, 1, p, 1e-9)
+	require.Equal(t, 1, battle.Round())
+}
+
+// TestGreedyFinisher tests that the greedy strategy doesn't risk a special
+// attack that may miss if a regular attack knocks out the opponent.
+func TestGreedyFinisher(t *testing.T) {
+	battle := newTestBattle(t, 1, 1)
+	greedy := &GreedyStrategy{}
+
+	action, _ := greedy.Action(battle, SideHost)
+	require.Equal(t, ActionSpecial, action)
+
+	battle.hp[SideChallenger][0] = 1
+	action, _ = greedy.Action(battle, SideHost)
+	require.Equal(t, ActionAttack, action)
+}
+
+// TestSolveGame tests that the minimax strategy of simple games is found.
+func TestSolveGame(t *testing.T) {
+	// Matching pennies is won by mixing both actions evenly.
+	value, mix := solveGame([][]float64{{1, -1}, {-1, 1}})
+	require.InDelta(t, 0, value, 0.05)
+	require.InDelta(t, 0.5, mix[0], 0.05)
+	require.InDelta(t, 0.5, mix[1], 0.05)
+
+	// A dominated action is never played.
+	value, mix = solveGame([][]float64{{1, 2}, {0, 1}})
+	require.InDelta(t, 1, value, 0.05)
+	require.Equal(t, []float64{1, 0}, mix)
+}
@@ -0,0 +1,910 @@
+package fightmons
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+// TournamentFormat is the bracket format of a tournament.
+type TournamentFormat string
+
+const (
+	// TournamentSingleElimination is a knockout bracket. The loser of
+	// every match is eliminated until a single player is left.
+	TournamentSingleElimination TournamentFormat = "single_elimination"
+
+	// TournamentSwiss is a fixed number of rounds in which players with
+	// similar scores are paired against each other and nobody is
+	// eliminated.
+	TournamentSwiss TournamentFormat = "swiss"
+)
+
+const (
+	// winPoints are the points of a won match and of a bye.
+	winPoints = 1.0
+
+	// drawPoints are the points of a draw and of a match that wasn't
+	// finished before the round was closed.
+	drawPoints = 0.5
+)
+
+// TournamentRules are the rules of a tournament announced by its organizer.
+type TournamentRules struct {
+	// Name is the display name of the tournament.
+	Name string `json:"name"`
+
+	// Format is the bracket format of the tournament.
+	Format TournamentFormat `json:"format"`
+
+	// Rounds is the number of rounds of a swiss tournament. If zero, the
+	// number of rounds needed to find a single undefeated player is
+	// played.
+	Rounds int `json:"rounds,omitempty"`
+
+	// MinLevel is the lowest level of a registered mon.
+	MinLevel int `json:"min_level"`
+
+	// MaxRarity is the highest rarity score of a registered mon, between
+	// 0 and 1. Zero means mons of any rarity can enter.
+	MaxRarity float64 `json:"max_rarity,omitempty"`
+
+	// EntryFeeSat is the entry fee a registered player pays to the
+	// organizer. Players that didn't pay by the end of the registration
+	// don't enter the tournament.
+	EntryFeeSat int64 `json:"entry_fee_sat,omitempty"`
+
+	// MaxPlayers is the maximum number of entrants, zero means no limit.
+	// The players that registered first enter.
+	MaxPlayers int `json:"max_players,omitempty"`
+
+	// RegistrationEnd is the unix time at which the registration closes
+	// and the first round starts.
+	RegistrationEnd int64 `json:"registration_end"`
+
+	// RoundDuration is the time in seconds the players of a round have
+	// to finish their matches.
+	RoundDuration int64 `json:"round_duration"`
+}
+
+// Validate checks that the rules are complete and consistent.
+func (r *TournamentRules) Validate() error {
+	switch {
+	case r.Format != TournamentSingleElimination &&
+		r.Format != TournamentSwiss:
+
+		return fmt.Errorf("unknown tournament format %q", r.Format)
+
+	case r.Rounds < 0:
+		return fmt.Errorf("invalid number of rounds %d", r.Rounds)
+
+	case r.Rounds > 0 && r.Format != TournamentSwiss:
+		return fmt.Errorf("number of rounds only applies to swiss " +
+			"tournaments")
+
+	case r.MinLevel < 0 || r.MinLevel > MaxLevel:
+		return fmt.Errorf("min level %d out of range", r.MinLevel)
+
+	case r.MaxRarity < 0 || r.MaxRarity > 1:
+		return fmt.Errorf("max rarity %v out of range", r.MaxRarity)
+
+	case r.EntryFeeSat < 0:
+		return fmt.Errorf("invalid entry fee %d", r.EntryFeeSat)
+
+	case r.MaxPlayers < 0 || r.MaxPlayers == 1:
+		return fmt.Errorf("invalid max players %d", r.MaxPlayers)
+
+	case r.RegistrationEnd <= 0:
+		return fmt.Errorf("registration end missing")
+
+	case r.RoundDuration <= 0:
+		return fmt.Errorf("invalid round duration %d", r.RoundDuration)
+	}
+
+	return nil
+}
+
+// CheckMon checks that the fightmon may enter a tournament with the rules.
+func (r *TournamentRules) CheckMon(fightMon *FightMon) error {
+	if err := fightMon.Validate(); err != nil {
+		return err
+	}
+
+	if fightMon.Level < r.MinLevel {
+		return fmt.Errorf("level %d below min level %d", fightMon.Level,
+			r.MinLevel)
+	}
+
+	if r.MaxRarity == 0 {
+		return nil
+	}
+
+	mon, err := fightMon.Mon()
+	if err != nil {
+		return err
+	}
+	if rarity := mon.CalculateRarityScore(0); rarity > r.MaxRarity {
+		return fmt.Errorf("rarity %v above max rarity %v", rarity,
+			r.MaxRarity)
+	}
+
+	return nil
+}
+
+// TournamentPairing is a match of a tournament round. The host opens the
+// lobby of the match with the nonce, so the match id is bound to the host.
+type TournamentPairing struct {
+	MatchId    string `json:"match_id"`
+	MatchNonce string `json:"match_nonce"`
+	Host       string `json:"host"`
+	Challenger string `json:"challenger"`
+}
+
+// TournamentResult is the result of a match of a tournament round.
+type TournamentResult struct {
+	MatchId string `json:"match_id"`
+
+	// Winner is the pubkey of the winner or empty if the match was a
+	// draw or wasn't finished before the round was closed.
+	Winner string `json:"winner,omitempty"`
+}
+
+// TournamentStanding is the score of an entrant of a tournament.
+type TournamentStanding struct {
+	Player     string  `json:"player"`
+	Points     float64 `json:"points"`
+	Wins       int     `json:"wins"`
+	Draws      int     `json:"draws"`
+	Losses     int     `json:"losses"`
+	Byes       int     `json:"byes"`
+	Eliminated bool    `json:"eliminated,omitempty"`
+}
+
+// TournamentRegistration is the registration of a mon for a tournament.
+type TournamentRegistration struct {
+	Player       string
+	Mon          FightMon
+	RegisteredAt time.Time
+}
+
+// TournamentRound is a round of a tournament.
+type TournamentRound struct {
+	Round     int
+	Pairings  []TournamentPairing
+	Bye       string
+	StartedAt time.Time
+
+	// Closed is true once the organizer published the results of the
+	// round.
+	Closed    bool
+	Results   []TournamentResult
+	Standings []TournamentStanding
+	ClosedAt  time.Time
+}
+
+// Tournament is the state of a tournament as published by its organizer and
+// its players.
+type Tournament struct {
+	Id        string
+	Organizer string
+	Rules     TournamentRules
+	CreatedAt time.Time
+
+	// Registrations are the valid registrations in the order they were
+	// made.
+	Registrations []*TournamentRegistration
+
+	// Invoices are the entry fee invoices by player.
+	Invoices map[string]string
+
+	// Entrants are the players that entered the tournament in the order
+	// of their seeds. They are fixed when the first round starts.
+	Entrants []string
+
+	Rounds []*TournamentRound
+
+	// Final is true once the final standings were published.
+	Final bool
+
+	// Ignored are the events tagged with the tournament that were
+	// rejected, e.g. invalid registrations or events by third parties.
+	Ignored []*nostr.Event
+}
+
+// Registration returns the registration of a player or nil.
+func (t *Tournament) Registration(player string) *TournamentRegistration {
+	for _, registration := range t.Registrations {
+		if registration.Player == player {
+			return registration
+		}
+	}
+
+	return nil
+}
+
+// CurrentRound returns the latest round or nil if the tournament didn't
+// start yet.
+func (t *Tournament) CurrentRound() *TournamentRound {
+	if len(t.Rounds) == 0 {
+		return nil
+	}
+
+	return t.Rounds[len(t.Rounds)-1]
+}
+
+// Standings returns the standings after the last closed round.
+func (t *Tournament) Standings() []TournamentStanding {
+	for i := len(t.Rounds) - 1; i >= 0; i-- {
+		if t.Rounds[i].Closed {
+			return t.Rounds[i].Standings
+		}
+	}
+
+	return nil
+}
+
+// MatchResult returns the result of a pairing from its verified match,
+// which is nil if the match wasn't finished. Only a match between the
+// paired players with their registered mons counts, any other match is
+// scored as if it wasn't finished.
+func (t *Tournament) MatchResult(pairing TournamentPairing,
+	match *VerifiedMatch) TournamentResult {
+
+	result := TournamentResult{
+		MatchId: pairing.MatchId,
+	}
+	if match == nil || match.MatchId != pairing.MatchId {
+		return result
+	}
+
+	host, challenger := t.Registration(pairing.Host),
+		t.Registration(pairing.Challenger)
+	switch {
+	case host == nil || challenger == nil:
+		return result
+
+	case match.Players != [2]string{pairing.Host, pairing.Challenger}:
+		return result
+
+	// Tournaments are fought by the registered mons alone.
+	case len(match.Parties[SideHost]) != 1,
+		len(match.Parties[SideChallenger]) != 1:
+
+		return result
+
+	case match.Mons[SideHost].Id != host.Mon.Id,
+		match.Mons[SideChallenger].Id != challenger.Mon.Id:
+
+		return result
+	}
+
+	result.Winner = match.WinnerPubKey()
+
+	return result
+}
+
+// ReadTournament reads the state of a tournament from its events. Events
+// that anyone could have published, like invalid registrations, are
+// ignored. An error is returned if the tournament wasn't announced or the
+// organizer published conflicting or out of order rounds.
+func ReadTournament(tournamentId string,
+	events []*nostr.Event) (*Tournament, error) {
+
+	sorted := make([]*nostr.Event, 0, len(events))
+	for _, ev := range events {
+		if GetTournamentId(ev) == tournamentId {
+			sorted = append(sorted, ev)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CreatedAt != sorted[j].CreatedAt {
+			return sorted[i].CreatedAt < sorted[j].CreatedAt
+		}
+
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	t := &Tournament{
+		Id:       tournamentId,
+		Invoices: make(map[string]string),
+	}
+	var (
+		valid     []*nostr.Event
+		seen      = make(map[string]struct{})
+		rounds    = make(map[int]*FightMonTournamentRoundEvent)
+		standings = make(map[int]*FightMonTournamentStandingsEvent)
+		times     = make(map[int][2]time.Time)
+	)
+	for _, ev := range sorted {
+		if _, ok := seen[ev.ID]; ok {
+			continue
+		}
+		seen[ev.ID] = struct{}{}
+
+		if ok, err := ev.CheckSignature(); err != nil || !ok {
+			t.Ignored = append(t.Ignored, ev)
+			continue
+		}
+
+		if ev.Kind != FightMonTournament || t.Organizer != "" {
+			valid = append(valid, ev)
+			continue
+		}
+
+		nonceTag := ev.Tags.GetFirst([]string{TournamentNonceTag})
+		if nonceTag == nil || CheckTournamentId(
+			tournamentId, ev.PubKey, nonceTag.Value(),
+		) != nil {
+
+			t.Ignored = append(t.Ignored, ev)
+			continue
+		}
+
+		var rules TournamentRules
+		if err := decodeEvent(ev, &rules); err != nil {
+			return nil, fmt.Errorf("invalid tournament rules: %w",
+				err)
+		}
+		t.Organizer = ev.PubKey
+		t.Rules = rules
+		t.CreatedAt = ev.CreatedAt.Time()
+	}
+	if t.Organizer == "" {
+		return nil, fmt.Errorf("tournament %v was never announced",
+			tournamentId)
+	}
+
+	for _, ev := range valid {
+		switch {
+		case ev.Kind == FightMonTournamentRegister:
+			if !t.addRegistration(ev) {
+				t.Ignored = append(t.Ignored, ev)
+			}
+
+		case ev.PubKey != t.Organizer:
+			t.Ignored = append(t.Ignored, ev)
+
+		case ev.Kind == FightMonTournamentEntry:
+			var entry FightMonTournamentEntryEvent
+			if err := decodeEvent(ev, &entry); err != nil {
+				t.Ignored = append(t.Ignored, ev)
+				continue
+			}
+			t.Invoices[entry.Player] = entry.Invoice
+
+		case ev.Kind == FightMonTournamentRound:
+			var round FightMonTournamentRoundEvent
+			if err := decodeEvent(ev, &round); err != nil {
+				return nil, fmt.Errorf("invalid round: %w", err)
+			}
+			if _, ok := rounds[round.Round]; ok {
+				return nil, fmt.Errorf("round %d published "+
+					"twice", round.Round)
+			}
+			rounds[round.Round] = &round
+			roundTimes := times[round.Round]
+			roundTimes[0] = ev.CreatedAt.Time()
+			times[round.Round] = roundTimes
+
+		case ev.Kind == FightMonTournamentStandings:
+			var closed FightMonTournamentStandingsEvent
+			if err := decodeEvent(ev, &closed); err != nil {
+				return nil, fmt.Errorf("invalid standings: %w",
+					err)
+			}
+			if _, ok := standings[closed.Round]; ok {
+				return nil, fmt.Errorf("standings of round %d "+
+					"published twice", closed.Round)
+			}
+			standings[closed.Round] = &closed
+			roundTimes := times[closed.Round]
+			roundTimes[1] = ev.CreatedAt.Time()
+			times[closed.Round] = roundTimes
+
+		default:
+			t.Ignored = append(t.Ignored, ev)
+		}
+	}
+
+	// The rounds must follow each other without gaps, each one starting
+	// after the previous one was closed.
+	for i := 1; i <= len(rounds); i++ {
+		round, ok := rounds[i]
+		if !ok {
+			return nil, fmt.Errorf("round %d missing", i)
+		}
+		if t.Final {
+			return nil, fmt.Errorf("round %d started after the "+
+				"final standings", i)
+		}
+		if i > 1 && !t.Rounds[i-2].Closed {
+			return nil, fmt.Errorf("round %d started before round "+
+				"%d was closed", i, i-1)
+		}
+		if i == 1 {
+			t.Entrants = round.Entrants
+		}
+
+		tournamentRound := &TournamentRound{
+			Round:     i,
+			Pairings:  round.Pairings,
+			Bye:       round.Bye,
+			StartedAt: times[i][0],
+		}
+		if closed, ok := standings[i]; ok {
+			tournamentRound.Closed = true
+			tournamentRound.Results = closed.Results
+			tournamentRound.Standings = closed.Standings
+			tournamentRound.ClosedAt = times[i][1]
+			t.Final = closed.Final
+		}
+		t.Rounds = append(t.Rounds, tournamentRound)
+	}
+	for i := range standings {
+		if _, ok := rounds[i]; !ok {
+			return nil, fmt.Errorf("standings of round %d that "+
+				"never started", i)
+		}
+	}
+
+	return t, nil
+}
+
+// addRegistration adds the registration of a player if it is valid. Every
+// player can register once and every mon can only be registered once.
+func (t *Tournament) addRegistration(ev *nostr.Event) bool {
+	if int64(ev.CreatedAt) > t.Rules.RegistrationEnd {
+		return false
+	}
+
+	var fightMon FightMon
+	if err := decodeEvent(ev, &fightMon); err != nil {
+		return false
+	}
+	if err := t.Rules.CheckMon(&fightMon); err != nil {
+		return false
+	}
+
+	for _, registration := range t.Registrations {
+		if registration.Player == ev.PubKey ||
+			registration.Mon.Id == fightMon.Id {
+
+			return false
+		}
+	}
+
+	t.Registrations = append(t.Registrations, &TournamentRegistration{
+		Player:       ev.PubKey,
+		Mon:          fightMon,
+		RegisteredAt: ev.CreatedAt.Time(),
+	})
+
+	return true
+}
+
+// VerifyTournament verifies that the organizer ran the tournament by its
+// rules. The entrants are checked against the registrations, the pairings
+// of every round are derived again from the results of the previous rounds,
+// every result is checked against the match events published before the
+// round was closed and the standings are computed again. Entry fee payments
+// can't be verified, so a player that registered for a tournament with an
+// entry fee may be left out.
+func VerifyTournament(t *Tournament, matchEvents []*nostr.Event) error {
+	if len(t.Rounds) == 0 {
+		return nil
+	}
+
+	if err := t.verifyEntrants(); err != nil {
+		return err
+	}
+
+	b := newBracket(t.Id, t.Rules, t.Entrants)
+	for i, round := range t.Rounds {
+		expected, err := b.next()
+		if err != nil {
+			return err
+		}
+		if expected == nil {
+			return fmt.Errorf("round %d started after the "+
+				"tournament was over", round.Round)
+		}
+		if err := expected.checkPairings(round); err != nil {
+			return err
+		}
+
+		if !round.Closed {
+			break
+		}
+
+		if len(round.Results) != len(round.Pairings) {
+			return fmt.Errorf("round %d has %d results for %d "+
+				"pairings", round.Round, len(round.Results),
+				len(round.Pairings))
+		}
+
+		// Events published after the round was closed don't change
+		// its results.
+		var events []*nostr.Event
+		for _, ev := range matchEvents {
+			if !ev.CreatedAt.Time().After(round.ClosedAt) {
+				events = append(events, ev)
+			}
+		}
+		for j, pairing := range round.Pairings {
+			var match *VerifiedMatch
+			verified, err := VerifyMatch(
+				pairing.MatchId, events, nil,
+			)
+			if err == nil {
+				match = verified
+			}
+
+			result := t.MatchResult(pairing, match)
+			if round.Results[j] != result {
+				return fmt.Errorf("result of match %v in "+
+					"round %d is %q instead of %q",
+					pairing.MatchId, round.Round,
+					round.Results[j].Winner, result.Winner)
+			}
+		}
+		b.close(round)
+
+		standings := b.standings()
+		if !equalStandings(standings, round.Standings) {
+			return fmt.Errorf("standings after round %d don't "+
+				"match the results", round.Round)
+		}
+
+		next, err := b.next()
+		if err != nil {
+			return err
+		}
+		last := i == len(t.Rounds)-1
+		switch {
+		case next == nil && last && !t.Final:
+			return fmt.Errorf("standings after round %d aren't "+
+				"final", round.Round)
+
+		case next != nil && last && t.Final:
+			return fmt.Errorf("standings after round %d are final "+
+				"before the tournament is over", round.Round)
+		}
+	}
+
+	return nil
+}
+
+// verifyEntrants checks that the entrants registered in the given order. If
+// there's no entry fee, all players that registered in time enter up to
+// the maximum number of players.
+func (t *Tournament) verifyEntrants() error {
+	order := make(map[string]int, len(t.Registrations))
+	for i, registration := range t.Registrations {
+		order[registration.Player] = i
+	}
+
+	last := -1
+	for _, entrant := range t.Entrants {
+		i, ok := order[entrant]
+		switch {
+		case !ok:
+			return fmt.Errorf("entrant %v never registered",
+				entrant)
+
+		case i <= last:
+			return fmt.Errorf("entrant %v seeded out of "+
+				"registration order", entrant)
+		}
+		last = i
+	}
+
+	if t.Rules.MaxPlayers > 0 && len(t.Entrants) > t.Rules.MaxPlayers {
+		return fmt.Errorf("%d entrants exceed max players %d",
+			len(t.Entrants), t.Rules.MaxPlayers)
+	}
+
+	if t.Rules.EntryFeeSat > 0 {
+		return nil
+	}
+
+	expected := len(t.Registrations)
+	if t.Rules.MaxPlayers > 0 && expected > t.Rules.MaxPlayers {
+		expected = t.Rules.MaxPlayers
+	}
+	if len(t.Entrants) != expected {
+		return fmt.Errorf("%d of %d registered players entered",
+			len(t.Entrants), expected)
+	}
+
+	return nil
+}
+
+// bracket derives the pairings and standings of a tournament from its
+// entrants and the results of the closed rounds.
+type bracket struct {
+	tournamentId string
+	rules        TournamentRules
+	entrants     []string
+	seeds        map[string]int
+	rounds       []*TournamentRound
+}
+
+func newBracket(tournamentId string, rules TournamentRules,
+	entrants []string) *bracket {
+
+	seeds := make(map[string]int, len(entrants))
+	for i, entrant := range entrants {
+		seeds[entrant] = i
+	}
+
+	return &bracket{
+		tournamentId: tournamentId,
+		rules:        rules,
+		entrants:     entrants,
+		seeds:        seeds,
+	}
+}
+
+// close adds the results of a closed round.
+func (b *bracket) close(round *TournamentRound) {
+	b.rounds = append(b.rounds, round)
+}
+
+// standings computes the standings after the closed rounds, ordered by
+// points and then by seed.
+func (b *bracket) standings() []TournamentStanding {
+	stats := make([]TournamentStanding, len(b.entrants))
+	for i, entrant := range b.entrants {
+		stats[i].Player = entrant
+	}
+	get := func(player string) *TournamentStanding {
+		return &stats[b.seeds[player]]
+	}
+
+	for _, round := range b.rounds {
+		if round.Bye != "" {
+			bye := get(round.Bye)
+			bye.Points += winPoints
+			bye.Byes++
+		}
+
+		for i, pairing := range round.Pairings {
+			host, challenger := get(pairing.Host),
+				get(pairing.Challenger)
+
+			var winner, loser *TournamentStanding
+			switch round.Results[i].Winner {
+			case pairing.Host:
+				winner, loser = host, challenger
+
+			case pairing.Challenger:
+				winner, loser = challenger, host
+
+			default:
+				host.Points += drawPoints
+				host.Draws++
+				challenger.Points += drawPoints
+				challenger.Draws++
+
+				// The higher seed hosts and advances on a
+				// draw.
+				if b.rules.Format ==
+					TournamentSingleElimination {
+
+					challenger.Eliminated = true
+				}
+				continue
+			}
+
+			winner.Points += winPoints
+			winner.Wins++
+			loser.Losses++
+			if b.rules.Format == TournamentSingleElimination {
+				loser.Eliminated = true
+			}
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Points > stats[j].Points
+	})
+
+	return stats
+}
+
+// swissRounds returns the number of rounds of a swiss tournament.
+func (b *bracket) swissRounds() int {
+	if b.rules.Rounds > 0 {
+		return b.rules.Rounds
+	}
+	if len(b.entrants) < 2 {
+		return 1
+	}
+
+	return int(math.Ceil(math.Log2(float64(len(b.entrants)))))
+}
+
+// next returns the pairings of the next round or nil if the tournament is
+// over. The first round is always played, even if there are too few
+// entrants for a match.
+func (b *bracket) next() (*TournamentRound, error) {
+	standings := b.standings()
+	byPlayer := make(map[string]TournamentStanding, len(standings))
+	for _, standing := range standings {
+		byPlayer[standing.Player] = standing
+	}
+
+	var order []string
+	switch b.rules.Format {
+	case TournamentSingleElimination:
+		// The remaining players are paired by seed, the highest
+		// against the lowest.
+		for _, entrant := range b.entrants {
+			if !byPlayer[entrant].Eliminated {
+				order = append(order, entrant)
+			}
+		}
+		if len(b.rounds) > 0 && len(order) <= 1 {
+			return nil, nil
+		}
+
+	case TournamentSwiss:
+		if len(b.rounds) >= b.swissRounds() {
+			return nil, nil
+		}
+		for _, standing := range standings {
+			order = append(order, standing.Player)
+		}
+	}
+
+	round := &TournamentRound{
+		Round: len(b.rounds) + 1,
+	}
+
+	// With an odd number of players, one of them gets a bye. In a single
+	// elimination bracket that's the highest seed, in a swiss
+	// tournament the lowest ranked player that didn't have a bye yet.
+	if len(order)%2 == 1 {
+		bye := 0
+		if b.rules.Format == TournamentSwiss {
+			bye = len(order) - 1
+			for i := len(order) - 1; i >= 0; i-- {
+				if byPlayer[order[i]].Byes == 0 {
+					bye = i
+					break
+				}
+			}
+		}
+		round.Bye = order[bye]
+		order = append(order[:bye:bye], order[bye+1:]...)
+	}
+
+	var pairs [][2]string
+	switch b.rules.Format {
+	case TournamentSingleElimination:
+		for i := 0; i < len(order)/2; i++ {
+			pairs = append(pairs, [2]string{
+				order[i], order[len(order)-1-i],
+			})
+		}
+
+	case TournamentSwiss:
+		pairs = b.swissPairs(order)
+	}
+
+	for table, pair := range pairs {
+		nonce := pairingNonce(b.tournamentId, round.Round, table)
+		matchId, err := deriveMatchId(pair[0], nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		round.Pairings = append(round.Pairings, TournamentPairing{
+			MatchId:    matchId,
+			MatchNonce: nonce,
+			Host:       pair[0],
+			Challenger: pair[1],
+		})
+	}
+
+	return round, nil
+}
+
+// swissPairs pairs every player with the next ranked player they didn't
+// play yet. If there's no such player, the next ranked one is taken.
+func (b *bracket) swissPairs(order []string) [][2]string {
+	played := make(map[[2]string]struct{})
+	for _, round := range b.rounds {
+		for _, pairing := range round.Pairings {
+			played[[2]string{pairing.Host, pairing.Challenger}] =
+				struct{}{}
+			played[[2]string{pairing.Challenger, pairing.Host}] =
+				struct{}{}
+		}
+	}
+
+	paired := make(map[string]bool, len(order))
+	var pairs [][2]string
+	for i, player := range order {
+		if paired[player] {
+			continue
+		}
+
+		opponent := ""
+		for _, candidate := range order[i+1:] {
+			if paired[candidate] {
+				continue
+			}
+			if opponent == "" {
+				opponent = candidate
+			}
+			_, rematch := played[[2]string{player, candidate}]
+			if !rematch {
+				opponent = candidate
+				break
+			}
+		}
+		if opponent == "" {
+			continue
+		}
+
+		paired[player], paired[opponent] = true, true
+		pairs = append(pairs, [2]string{player, opponent})
+	}
+
+	return pairs
+}
+
+// checkPairings checks that a published round has the expected pairings.
+func (r *TournamentRound) checkPairings(published *TournamentRound) error {
+	if published.Bye != r.Bye {
+		return fmt.Errorf("bye of round %d is %q instead of %q",
+			r.Round, published.Bye, r.Bye)
+	}
+	if len(published.Pairings) != len(r.Pairings) {
+		return fmt.Errorf("round %d has %d pairings instead of %d",
+			r.Round, len(published.Pairings), len(r.Pairings))
+	}
+	for i, pairing := range r.Pairings {
+		if published.Pairings[i] != pairing {
+			return fmt.Errorf("pairing %d of round %d doesn't "+
+				"follow the bracket", i, r.Round)
+		}
+	}
+
+	return nil
+}
+
+// equalStandings returns true if both standings are the same.
+func equalStandings(a, b []TournamentStanding) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pairingNonce derives the match nonce of a pairing from the tournament, so
+// the match ids of a tournament can't be chosen by its players.
+func pairingNonce(tournamentId string, round, table int) string {
+	var index [8]byte
+	binary.BigEndian.PutUint32(index[:4], uint32(round))
+	binary.BigEndian.PutUint32(index[4:], uint32(table))
+
+	h := sha256.New()
+	h.Write([]byte(tournamentId))
+	h.Write(index[:])
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,529 @@
+package fightmons
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+)
+
+// closeTestRound closes a round of the bracket with the given winners by
+// table, where an empty winner is a draw.
+func closeTestRound(t *testing.T, b *bracket,
+	winners ...string) *TournamentRound {
+
+	round, err := b.next()
+	require.NoError(t, err)
+	require.NotNil(t, round)
+	require.Len(t, round.Pairings, len(winners))
+
+	round.Closed = true
+	for i, pairing := range round.Pairings {
+		round.Results = append(round.Results, TournamentResult{
+			MatchId: pairing.MatchId,
+			Winner:  winners[i],
+		})
+	}
+	b.close(round)
+
+	return round
+}
+
+// pairs returns the host and challenger of every pairing of a round.
+func pairs(round *TournamentRound) [][2]string {
+	var pairs [][2]string
+	for _, pairing := range round.Pairings {
+		pairs = append(pairs, [2]string{
+			pairing.Host, pairing.Challenger,
+		})
+	}
+
+	return pairs
+}
+
+// TestTournamentBracket tests the pairings and standings of single
+// elimination and swiss brackets.
+func TestTournamentBracket(t *testing.T) {
+	var p []string
+	for i := 0; i < 5; i++ {
+		p = append(p, newTestPlayer(t).pub)
+	}
+
+	// In a single elimination bracket the highest seed gets the bye and
+	// the remaining players are paired highest against lowest seed.
+	rules := TournamentRules{Format: TournamentSingleElimination}
+	b := newBracket("tournament", rules, p)
+	round, err := b.next()
+	require.NoError(t, err)
+	require.Equal(t, p[0], round.Bye)
+	require.Equal(t, [][2]string{{p[1], p[4]}, {p[2], p[3]}}, pairs(round))
+
+	// Match ids are derived from the tournament and bound to the host.
+	pairing := round.Pairings[0]
+	require.NoError(t, CheckMatchId(
+		pairing.MatchId, pairing.Host, pairing.MatchNonce,
+	))
+	require.Equal(t, pairingNonce("tournament", 1, 0), pairing.MatchNonce)
+	require.NotEqual(t, round.Pairings[1].MatchNonce, pairing.MatchNonce)
+
+	// A draw eliminates the lower seed.
+	closeTestRound(t, b, p[4], "")
+	round = closeTestRound(t, b, p[4])
+	require.Equal(t, p[0], round.Bye)
+	require.Equal(t, [][2]string{{p[2], p[4]}}, pairs(round))
+
+	round = closeTestRound(t, b, p[4])
+	require.Empty(t, round.Bye)
+	require.Equal(t, [][2]string{{p[0], p[4]}}, pairs(round))
+
+	round, err = b.next()
+	require.NoError(t, err)
+	require.Nil(t, round)
+
+	standings := b.standings()
+	require.Equal(t, TournamentStanding{
+		Player: p[4],
+		Points: 3,
+		Wins:   3,
+	}, standings[0])
+	require.Equal(t, TournamentStanding{
+		Player:     p[0],
+		Points:     2,
+		Losses:     1,
+		Byes:       2,
+		Eliminated: true,
+	}, standings[1])
+	require.Equal(t, TournamentStanding{
+		Player:     p[3],
+		Points:     0.5,
+		Draws:      1,
+		Eliminated: true,
+	}, standings[3])
+
+	// A swiss tournament pairs players by their points and avoids
+	// rematches.
+	rules = TournamentRules{Format: TournamentSwiss, Rounds: 2}
+	b = newBracket("tournament", rules, p[:4])
+	round = closeTestRound(t, b, "", "")
+	require.Equal(t, [][2]string{{p[0], p[1]}, {p[2], p[3]}}, pairs(round))
+
+	round = closeTestRound(t, b, p[0], p[3])
+	require.Equal(t, [][2]string{{p[0], p[2]}, {p[1], p[3]}}, pairs(round))
+
+	round, err = b.next()
+	require.NoError(t, err)
+	require.Nil(t, round)
+
+	standings = b.standings()
+	require.Equal(t, p[0], standings[0].Player)
+	require.Equal(t, 1.5, standings[0].Points)
+	require.Equal(t, p[3], standings[1].Player)
+
+	// With an odd number of players, the bye goes to the lowest ranked
+	// player without a bye. Without a given number of rounds, as many
+	// rounds are played as needed for a single undefeated player.
+	rules = TournamentRules{Format: TournamentSwiss}
+	b = newBracket("tournament", rules, p[:3])
+	require.Equal(t, 2, b.swissRounds())
+
+	round = closeTestRound(t, b, p[0])
+	require.Equal(t, p[2], round.Bye)
+	require.Equal(t, [][2]string{{p[0], p[1]}}, pairs(round))
+
+	round = closeTestRound(t, b, p[2])
+	require.Equal(t, p[1], round.Bye)
+	require.Equal(t, [][2]string{{p[0], p[2]}}, pairs(round))
+
+	round, err = b.next()
+	require.NoError(t, err)
+	require.Nil(t, round)
+}
+
+// TestTournamentRules tests the validation of tournament rules and the entry
+// rules for mons.
+func TestTournamentRules(t *testing.T) {
+	rules := TournamentRules{
+		Format:          TournamentSwiss,
+		MinLevel:        1,
+		RegistrationEnd: time.Now().Unix(),
+		RoundDuration:   60,
+	}
+	require.NoError(t, rules.Validate())
+
+	invalid := rules
+	invalid.Format = "round_robin"
+	require.Error(t, invalid.Validate())
+
+	invalid = rules
+	invalid.MaxPlayers = 1
+	require.Error(t, invalid.Validate())
+
+	invalid = rules
+	invalid.MaxRarity = 1.5
+	require.Error(t, invalid.Validate())
+
+	invalid = rules
+	invalid.Format = TournamentSingleElimination
+	invalid.Rounds = 3
+	require.Error(t, invalid.Validate())
+
+	player := newTestPlayer(t)
+	require.ErrorContains(t, rules.CheckMon(&player.mon), "min level")
+
+	player.levelUp(t, 1)
+	require.NoError(t, rules.CheckMon(&player.mon))
+
+	mon, err := player.mon.Mon()
+	require.NoError(t, err)
+	rarity := mon.CalculateRarityScore(0)
+
+	rules.MaxRarity = rarity
+	require.NoError(t, rules.CheckMon(&player.mon))
+
+	if rarity > 0 {
+		rules.MaxRarity = rarity / 2
+		require.ErrorContains(
+			t, rules.CheckMon(&player.mon), "max rarity",
+		)
+	}
+}
+
+// TestReadTournament tests that a tournament is read from its events and
+// that a tournament that doesn't follow its bracket doesn't verify.
+func TestReadTournament(t *testing.T) {
+	organizer := newTestPlayer(t)
+	players := []*testPlayer{
+		newTestPlayer(t), newTestPlayer(t), newTestPlayer(t),
+	}
+	for _, player := range players {
+		player.levelUp(t, 1)
+	}
+
+	now := nostr.Now()
+	rules := TournamentRules{
+		Name:            "test",
+		Format:          TournamentSingleElimination,
+		MinLevel:        1,
+		RegistrationEnd: int64(now) + 10,
+		RoundDuration:   60,
+	}
+	tournamentId, nonce, err := NewTournamentId(organizer.pub)
+	require.NoError(t, err)
+
+	announce, err := GetFightMonTournamentEvent(tournamentId, nonce, rules)
+	require.NoError(t, err)
+	events := []*nostr.Event{organizer.sign(t, announce, now)}
+
+	register := func(player *testPlayer, mon FightMon,
+		at nostr.Timestamp) {
+
+		ev, err := GetFightMonTournamentRegisterEvent(
+			tournamentId, organizer.pub, mon,
+		)
+		require.NoError(t, err)
+		events = append(events, player.sign(t, ev, at))
+	}
+	for i, player := range players {
+		register(player, player.mon, now+nostr.Timestamp(i+1))
+	}
+
+	// A second registration, a registration of an already registered
+	// mon, a mon below the min level and a registration after the end of
+	// the registration are ignored.
+	late, low := newTestPlayer(t), newTestPlayer(t)
+	late.levelUp(t, 1)
+	register(players[0], late.mon, now+5)
+	register(low, players[1].mon, now+5)
+	register(low, low.mon, now+5)
+	register(late, late.mon, now+11)
+
+	// Only the organizer announces the tournament and starts rounds.
+	fake, err := GetFightMonTournamentEvent(
+		tournamentId, nonce, TournamentRules{},
+	)
+	require.NoError(t, err)
+	events = append(events, players[0].sign(t, fake, now-1))
+
+	entrants := []string{players[0].pub, players[1].pub, players[2].pub}
+	b := newBracket(tournamentId, rules, entrants)
+	first, err := b.next()
+	require.NoError(t, err)
+
+	roundEvent := func(round *TournamentRound,
+		entrants []string) *nostr.Event {
+
+		ev, err := GetFightMonTournamentRoundEvent(
+			tournamentId, FightMonTournamentRoundEvent{
+				Round:    round.Round,
+				Entrants: entrants,
+				Pairings: round.Pairings,
+				Bye:      round.Bye,
+			},
+		)
+		require.NoError(t, err)
+
+		return ev
+	}
+	events = append(events, players[1].sign(
+		t, roundEvent(first, entrants), now+12,
+	))
+
+	tournament, err := ReadTournament(tournamentId, events)
+	require.NoError(t, err)
+	require.Equal(t, organizer.pub, tournament.Organizer)
+	require.Equal(t, rules, tournament.Rules)
+	require.Len(t, tournament.Registrations, 3)
+	for i, registration := range tournament.Registrations {
+		require.Equal(t, players[i].pub, registration.Player)
+		require.Equal(t, players[i].mon, registration.Mon)
+	}
+	require.Len(t, tournament.Ignored, 6)
+	require.Empty(t, tournament.Rounds)
+	require.NoError(t, VerifyTournament(tournament, nil))
+
+	// The first round follows the bracket and its unplayed match is a
+	// draw, which the higher seed advances from.
+	events = append(events, organizer.sign(
+		t, roundEvent(first, entrants), now+12,
+	))
+	first.Closed = true
+	first.Results = []TournamentResult{{
+		MatchId: first.Pairings[0].MatchId,
+	}}
+	b.close(first)
+
+	second, err := b.next()
+	require.NoError(t, err)
+	require.Equal(t, [][2]string{
+		{players[0].pub, players[1].pub},
+	}, pairs(second))
+
+	standings, err := GetFightMonTournamentStandingsEvent(
+		tournamentId, FightMonTournamentStandingsEvent{
+			Round:     1,
+			Results:   first.Results,
+			Standings: b.standings(),
+		},
+	)
+	require.NoError(t, err)
+	events = append(events, organizer.sign(t, standings, now+80))
+	events = append(events, organizer.sign(
+		t, roundEvent(second, nil), now+80,
+	))
+
+	tournament, err = ReadTournament(tournamentId, events)
+	require.NoError(t, err)
+	require.Equal(t, entrants, tournament.Entrants)
+	require.Len(t, tournament.Rounds, 2)
+	require.True(t, tournament.Rounds[0].Closed)
+	require.False(t, tournament.CurrentRound().Closed)
+	require.Equal(t, b.standings(), tournament.Standings())
+	require.NoError(t, VerifyTournament(tournament, nil))
+
+	// A result that isn't backed by a finished match doesn't verify.
+	tournament.Rounds[0].Results[0].Winner = players[1].pub
+	require.ErrorContains(
+		t, VerifyTournament(tournament, nil), "instead of",
+	)
+	tournament.Rounds[0].Results[0].Winner = ""
+
+	// Neither do pairings that don't follow the bracket.
+	tournament.Rounds[1].Pairings[0].Host = players[2].pub
+	require.ErrorContains(
+		t, VerifyTournament(tournament, nil), "doesn't follow",
+	)
+
+	// Neither do entrants that left out a player.
+	tournament.Entrants = entrants[:2]
+	require.ErrorContains(
+		t, VerifyTournament(tournament, nil), "registered players",
+	)
+
+	// Publishing a round twice is rejected.
+	duplicate := *second
+	duplicate.Bye = players[2].pub
+	events = append(events, organizer.sign(
+		t, roundEvent(&duplicate, nil), now+81,
+	))
+	_, err = ReadTournament(tournamentId, events)
+	require.ErrorContains(t, err, "published twice")
+}
+
+// TestTournaments tests a single elimination tournament with an entry fee
+// from the registration to the final standings.
+func TestTournaments(t *testing.T) {
+	ctx := context.Background()
+	relay := newMemRelay()
+	ln := newMemLightning()
+
+	newTournaments := func() *Tournaments {
+		manager := NewManager(newMemTransport(t, relay), nil, nil, nil)
+		t.Cleanup(manager.Stop)
+
+		tournaments := NewTournaments(manager, &memPayer{ln: ln})
+		t.Cleanup(tournaments.Stop)
+
+		return tournaments
+	}
+
+	organizer := newTournaments()
+	rules := TournamentRules{
+		Name:            "test",
+		Format:          TournamentSingleElimination,
+		EntryFeeSat:     100,
+		RegistrationEnd: time.Now().Add(time.Minute).Unix(),
+		RoundDuration:   600,
+	}
+	tournamentId, err := organizer.Create(ctx, rules)
+	require.NoError(t, err)
+
+	players := make(map[string]*Tournaments)
+	for i := 0; i < 3; i++ {
+		player := newTournaments()
+		players[player.manager.PubKey()] = player
+
+		mon := newTestPlayer(t).mon
+		require.NoError(t, player.Register(ctx, tournamentId, mon))
+
+		err := player.Register(ctx, tournamentId, mon)
+		require.ErrorContains(t, err, "already registered")
+	}
+
+	// All players pay their entry fee to the organizer.
+	require.Eventually(t, func() bool {
+		ln.mu.Lock()
+		defer ln.mu.Unlock()
+
+		settled := 0
+		for _, invoice := range ln.invoices {
+			if invoice.memo == tournamentMemo(tournamentId) &&
+				invoice.state == invoices.ContractSettled {
+
+				settled++
+			}
+		}
+
+		return settled == len(players)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Nothing happens before the registration ends.
+	require.NoError(t, organizer.Poll(ctx, time.Now()))
+	tournament, err := organizer.Get(ctx, tournamentId)
+	require.NoError(t, err)
+	require.Empty(t, tournament.Rounds)
+
+	// Once the registration ended, all paying players enter in the order
+	// they registered.
+	end := time.Unix(rules.RegistrationEnd, 0)
+	require.NoError(t, organizer.Poll(ctx, end.Add(time.Second)))
+
+	tournament, err = organizer.Get(ctx, tournamentId)
+	require.NoError(t, err)
+	require.Len(t, tournament.Entrants, len(players))
+	seeds := tournament.Entrants
+	for i, registration := range tournament.Registrations {
+		require.Equal(t, registration.Player, seeds[i])
+	}
+
+	// playRound plays the only match of the current round and closes the
+	// round.
+	playRound := func() (*TournamentRound, string) {
+		tournament, err := organizer.Get(ctx, tournamentId)
+		require.NoError(t, err)
+
+		round := tournament.CurrentRound()
+		require.Len(t, round.Pairings, 1)
+		pairing := round.Pairings[0]
+
+		type outcome struct {
+			winner Side
+			err    error
+		}
+		outcomes := make(chan outcome, 2)
+		for _, pubKey := range []string{
+			pairing.Host, pairing.Challenger,
+		} {
+			manager := players[pubKey].manager
+
+			// The players join the match once they see the
+			// round.
+			var updates <-chan *MatchUpdate
+			require.Eventually(t, func() bool {
+				var (
+					cancel func()
+					err    error
+				)
+				updates, cancel, err = manager.SubscribeMatch(
+					pairing.MatchId,
+				)
+				if err == nil {
+					t.Cleanup(cancel)
+				}
+
+				return err == nil
+			}, 5*time.Second, 10*time.Millisecond)
+
+			action := ActionAttack
+			if pubKey == pairing.Challenger {
+				action = ActionSpecial
+			}
+			go func() {
+				winner, err := playMatch(
+					ctx, manager, pairing.MatchId, updates,
+					action, nil,
+				)
+				outcomes <- outcome{winner: winner, err: err}
+			}()
+		}
+
+		var winner Side
+		for i := 0; i < 2; i++ {
+			result := <-outcomes
+			require.NoError(t, result.err)
+			winner = result.winner
+		}
+		require.NoError(t, organizer.Poll(ctx, time.Now()))
+
+		switch winner {
+		case SideHost:
+			return round, pairing.Host
+		case SideChallenger:
+			return round, pairing.Challenger
+		default:
+			return round, pairing.Host
+		}
+	}
+
+	// The highest seed has a bye in the first round and plays the
+	// winner of the other two in the final.
+	first, semifinalist := playRound()
+	require.Equal(t, seeds[0], first.Bye)
+	require.Equal(t, [][2]string{{seeds[1], seeds[2]}}, pairs(first))
+
+	final, champion := playRound()
+	require.Empty(t, final.Bye)
+	require.Equal(t, [][2]string{{seeds[0], semifinalist}}, pairs(final))
+
+	// Every player verifies the final standings from the event log.
+	for _, player := range players {
+		tournament, err := player.Verify(ctx, tournamentId)
+		require.NoError(t, err)
+		require.True(t, tournament.Final)
+		require.Equal(t, seeds, tournament.Entrants)
+		require.Len(t, tournament.Rounds, 2)
+
+		standings := tournament.Standings()
+		require.Len(t, standings, 3)
+		require.Equal(t, champion, standings[0].Player)
+		require.False(t, standings[0].Eliminated)
+		require.True(t, standings[1].Eliminated)
+	}
+
+	// Registering after the tournament started fails.
+	late := newTournaments()
+	err = late.Register(ctx, tournamentId, newTestPlayer(t).mon)
+	require.ErrorContains(t, err, "registration")
+}
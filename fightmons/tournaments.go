@@ -0,0 +1,752 @@
+package fightmons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/nbd-wtf/go-nostr"
+)
+
+const (
+	// tournamentPollInterval is the interval at which the rounds of the
+	// tournaments we organize are advanced.
+	tournamentPollInterval = 10 * time.Second
+
+	// tournamentMemoPrefix is the prefix of the memo of entry fee
+	// invoices. The memo binds the invoice to the tournament id.
+	tournamentMemoPrefix = "tapmon tournament "
+
+	// entryMaxFeePercent is the maximum routing fee in percent of the
+	// entry fee we pay for the invoice of the organizer.
+	entryMaxFeePercent = 2
+)
+
+// TournamentFilter returns the filter that matches all events of a
+// tournament.
+func TournamentFilter(tournamentId string) nostr.Filter {
+	return nostr.Filter{
+		Kinds: TournamentKinds,
+		Tags: nostr.TagMap{
+			TournamentIndexTag: []string{tournamentId},
+		},
+	}
+}
+
+// organizedTournament is a tournament we organize.
+type organizedTournament struct {
+	// invoices are the payment hashes of the entry fee invoices by
+	// player.
+	invoices map[string]lntypes.Hash
+}
+
+// joinedTournament is a tournament we registered for.
+type joinedTournament struct {
+	mon FightMon
+
+	// paid is true once we paid the entry fee.
+	paid bool
+
+	// matches are the tournament matches we joined.
+	matches map[string]struct{}
+}
+
+// Tournaments organizes tournaments and plays the matches of the tournaments
+// we registered for. Organizing a tournament means charging the entry fees,
+// starting the rounds with the pairings of the bracket and closing them with
+// the results of the matches. Playing means paying the entry fee, hosting or
+// challenging the paired opponent once a round starts and leaving the
+// actions to the player, just like for any other match.
+type Tournaments struct {
+	manager   *Manager
+	lightning lndclient.LightningClient
+
+	ctx    context.Context
+	cancel func()
+
+	// mu serializes the handling of tournament events, as the actions
+	// taken depend on the ones taken before.
+	mu        sync.Mutex
+	followed  map[string]struct{}
+	organized map[string]*organizedTournament
+	joined    map[string]*joinedTournament
+}
+
+// NewTournaments creates a new tournament handler that plays its matches
+// with the manager. The lightning client is only needed for tournaments
+// with an entry fee.
+func NewTournaments(manager *Manager,
+	lightning lndclient.LightningClient) *Tournaments {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Tournaments{
+		manager:   manager,
+		lightning: lightning,
+		ctx:       ctx,
+		cancel:    cancel,
+		followed:  make(map[string]struct{}),
+		organized: make(map[string]*organizedTournament),
+		joined:    make(map[string]*joinedTournament),
+	}
+	go t.advance()
+
+	return t
+}
+
+// advance periodically advances the tournaments we organize.
+func (t *Tournaments) advance() {
+	ticker := time.NewTicker(tournamentPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.Poll(t.ctx, time.Now()); err != nil {
+				log.Printf("Unable to advance tournaments: %v",
+					err)
+			}
+
+		case <-t.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop stops following all tournaments.
+func (t *Tournaments) Stop() {
+	t.cancel()
+}
+
+// tournamentMemo returns the memo that binds an entry fee invoice to a
+// tournament.
+func tournamentMemo(tournamentId string) string {
+	return tournamentMemoPrefix + tournamentId
+}
+
+// Create announces a new tournament organized by us and returns its id.
+func (t *Tournaments) Create(ctx context.Context,
+	rules TournamentRules) (string, error) {
+
+	if err := rules.Validate(); err != nil {
+		return "", err
+	}
+	if rules.RegistrationEnd <= time.Now().Unix() {
+		return "", fmt.Errorf("registration end is in the past")
+	}
+	if rules.EntryFeeSat > 0 && t.lightning == nil {
+		return "", fmt.Errorf("entry fees require a lightning client")
+	}
+
+	tournamentId, nonce, err := NewTournamentId(t.manager.PubKey())
+	if err != nil {
+		return "", err
+	}
+	ev, err := GetFightMonTournamentEvent(tournamentId, nonce, rules)
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	t.organized[tournamentId] = &organizedTournament{
+		invoices: make(map[string]lntypes.Hash),
+	}
+	t.mu.Unlock()
+
+	if err := t.follow(tournamentId); err != nil {
+		return "", err
+	}
+	if err := t.manager.transport.Publish(ctx, ev); err != nil {
+		return "", err
+	}
+
+	return tournamentId, nil
+}
+
+// Register registers our fightmon for a tournament. Once the tournament
+// starts, the matches of our pairings are opened or challenged
+// automatically.
+func (t *Tournaments) Register(ctx context.Context, tournamentId string,
+	fightMon FightMon) error {
+
+	tournament, err := t.Get(ctx, tournamentId)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(tournament.Rounds) > 0 ||
+		time.Now().Unix() > tournament.Rules.RegistrationEnd:
+
+		return fmt.Errorf("registration of tournament %v closed",
+			tournamentId)
+
+	case tournament.Registration(t.manager.PubKey()) != nil:
+		return fmt.Errorf("already registered for tournament %v",
+			tournamentId)
+
+	case tournament.Rules.EntryFeeSat > 0 && t.lightning == nil:
+		return fmt.Errorf("entry fees require a lightning client")
+	}
+
+	if err := tournament.Rules.CheckMon(&fightMon); err != nil {
+		return err
+	}
+
+	ev, err := GetFightMonTournamentRegisterEvent(
+		tournamentId, tournament.Organizer, fightMon,
+	)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.joined[tournamentId] = &joinedTournament{
+		mon:     fightMon,
+		matches: make(map[string]struct{}),
+	}
+	t.mu.Unlock()
+
+	if err := t.follow(tournamentId); err != nil {
+		return err
+	}
+
+	return t.manager.transport.Publish(ctx, ev)
+}
+
+// Get returns the current state of a tournament.
+func (t *Tournaments) Get(ctx context.Context,
+	tournamentId string) (*Tournament, error) {
+
+	events, err := t.manager.transport.Query(
+		ctx, nostr.Filters{TournamentFilter(tournamentId)},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadTournament(tournamentId, events)
+}
+
+// Verify returns the current state of a tournament after verifying it
+// against the events of its matches.
+func (t *Tournaments) Verify(ctx context.Context,
+	tournamentId string) (*Tournament, error) {
+
+	tournament, err := t.Get(ctx, tournamentId)
+	if err != nil {
+		return nil, err
+	}
+
+	var filters nostr.Filters
+	for _, round := range tournament.Rounds {
+		for _, pairing := range round.Pairings {
+			filters = append(filters, MatchFilter(pairing.MatchId))
+		}
+	}
+
+	var events []*nostr.Event
+	if len(filters) > 0 {
+		events, err = t.manager.transport.Query(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := VerifyTournament(tournament, events); err != nil {
+		return nil, err
+	}
+
+	return tournament, nil
+}
+
+// follow starts following the events of a tournament.
+func (t *Tournaments) follow(tournamentId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.followed[tournamentId]; ok {
+		return nil
+	}
+
+	events, err := t.manager.transport.Subscribe(
+		t.ctx, nostr.Filters{TournamentFilter(tournamentId)},
+	)
+	if err != nil {
+		return err
+	}
+	t.followed[tournamentId] = struct{}{}
+
+	go func() {
+		for ev := range events {
+			t.handleEvent(tournamentId, ev)
+		}
+	}()
+
+	return nil
+}
+
+// handleEvent syncs with a tournament whenever an event arrives that we
+// have to act on.
+func (t *Tournaments) handleEvent(tournamentId string, ev *nostr.Event) {
+	if GetTournamentId(ev) != tournamentId {
+		return
+	}
+
+	switch ev.Kind {
+	case FightMonTournamentRegister, FightMonTournamentEntry,
+		FightMonTournamentRound:
+
+	default:
+		return
+	}
+
+	if err := t.sync(t.ctx, tournamentId); err != nil {
+		log.Printf("Unable to sync tournament %v: %v", tournamentId,
+			err)
+	}
+}
+
+// sync charges the entry fees of a tournament we organize, and pays the
+// entry fee and joins the matches of the current round of a tournament we
+// registered for.
+func (t *Tournaments) sync(ctx context.Context, tournamentId string) error {
+	tournament, err := t.Get(ctx, tournamentId)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if organized, ok := t.organized[tournamentId]; ok {
+		err := t.chargeEntries(ctx, tournament, organized)
+		if err != nil {
+			return err
+		}
+	}
+
+	if joined, ok := t.joined[tournamentId]; ok {
+		if err := t.payEntry(ctx, tournament, joined); err != nil {
+			return err
+		}
+		t.joinRound(ctx, tournament, joined)
+	}
+
+	return nil
+}
+
+// chargeEntries publishes an entry fee invoice for every new registration.
+// The caller must hold the lock.
+func (t *Tournaments) chargeEntries(ctx context.Context,
+	tournament *Tournament, organized *organizedTournament) error {
+
+	fee := btcutil.Amount(tournament.Rules.EntryFeeSat)
+	if fee == 0 || len(tournament.Rounds) > 0 {
+		return nil
+	}
+
+	expiry := tournament.Rules.RegistrationEnd - time.Now().Unix()
+	for _, registration := range tournament.Registrations {
+		if _, ok := organized.invoices[registration.Player]; ok {
+			continue
+		}
+
+		hash, invoice, err := t.lightning.AddInvoice(
+			ctx, &invoicesrpc.AddInvoiceData{
+				Memo:   tournamentMemo(tournament.Id),
+				Value:  lnwire.NewMSatFromSatoshis(fee),
+				Expiry: expiry,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to add invoice: %w", err)
+		}
+		organized.invoices[registration.Player] = hash
+
+		ev, err := GetFightMonTournamentEntryEvent(
+			tournament.Id, FightMonTournamentEntryEvent{
+				Player:  registration.Player,
+				Invoice: invoice,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if err := t.manager.transport.Publish(ctx, ev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// payEntry pays our entry fee invoice after checking that it is bound to
+// the tournament and matches the entry fee. The caller must hold the lock.
+func (t *Tournaments) payEntry(ctx context.Context, tournament *Tournament,
+	joined *joinedTournament) error {
+
+	fee := btcutil.Amount(tournament.Rules.EntryFeeSat)
+	invoice, ok := tournament.Invoices[t.manager.PubKey()]
+	if fee == 0 || joined.paid || !ok {
+		return nil
+	}
+
+	payReq, err := t.lightning.DecodePaymentRequest(ctx, invoice)
+	if err != nil {
+		return fmt.Errorf("invalid entry fee invoice: %w", err)
+	}
+	switch {
+	case payReq.Value.ToSatoshis() != fee:
+		return fmt.Errorf("entry fee invoice amount %v instead of %v",
+			payReq.Value.ToSatoshis(), fee)
+
+	case payReq.Description != tournamentMemo(tournament.Id):
+		return fmt.Errorf("entry fee invoice not bound to tournament")
+	}
+	joined.paid = true
+
+	results := t.lightning.PayInvoice(
+		t.ctx, invoice, fee*entryMaxFeePercent/100, nil,
+	)
+	go func() {
+		select {
+		case result := <-results:
+			if result.Err != nil {
+				log.Printf("Unable to pay entry fee of "+
+					"tournament %v: %v", tournament.Id,
+					result.Err)
+			}
+
+		case <-t.ctx.Done():
+		}
+	}()
+
+	return nil
+}
+
+// joinRound opens the lobbies of the matches we host in the current round
+// and challenges the hosts of the matches we don't. The caller must hold the
+// lock.
+func (t *Tournaments) joinRound(ctx context.Context, tournament *Tournament,
+	joined *joinedTournament) {
+
+	round := tournament.CurrentRound()
+	if round == nil || round.Closed {
+		return
+	}
+
+	ours := t.manager.PubKey()
+	for _, pairing := range round.Pairings {
+		if _, ok := joined.matches[pairing.MatchId]; ok {
+			continue
+		}
+
+		var err error
+		switch ours {
+		case pairing.Host:
+			joined.matches[pairing.MatchId] = struct{}{}
+
+			challenger := tournament.Registration(
+				pairing.Challenger,
+			)
+			if challenger == nil {
+				continue
+			}
+
+			var matchId string
+			matchId, err = t.manager.OpenMatch(
+				ctx, pairing.MatchNonce,
+			)
+			if err == nil && matchId != pairing.MatchId {
+				err = fmt.Errorf("pairing nonce derives match "+
+					"id %v", matchId)
+			}
+			if err == nil {
+				go t.acceptPaired(
+					pairing, challenger.Mon.Id, joined.mon,
+				)
+			}
+
+		case pairing.Challenger:
+			joined.matches[pairing.MatchId] = struct{}{}
+			err = t.manager.ChallengeMon(
+				ctx, pairing.MatchId, Party{joined.mon},
+			)
+
+		default:
+			continue
+		}
+		if err != nil {
+			log.Printf("Unable to join match %v of tournament %v: "+
+				"%v", pairing.MatchId, tournament.Id, err)
+		}
+	}
+}
+
+// acceptPaired accepts the match request of the paired challenger once it
+// arrives. Requests by anyone else or with another party than the registered
+// mon alone are not accepted.
+func (t *Tournaments) acceptPaired(pairing TournamentPairing,
+	challengerMon string, fightMon FightMon) {
+
+	updates, cancel, err := t.manager.SubscribeMatch(pairing.MatchId)
+	if err != nil {
+		log.Printf("Unable to subscribe to match %v: %v",
+			pairing.MatchId, err)
+		return
+	}
+	defer cancel()
+
+	for {
+		var update *MatchUpdate
+		select {
+		case update = <-updates:
+			if update == nil {
+				return
+			}
+
+		case <-t.ctx.Done():
+			return
+		}
+
+		if update.Type != UpdateChallenged ||
+			update.Event.PubKey != pairing.Challenger {
+
+			continue
+		}
+
+		var requested Party
+		err := decodeEvent(update.Event, &requested)
+		if err != nil || len(requested) != 1 ||
+			requested[0].Id != challengerMon {
+
+			continue
+		}
+
+		err = t.manager.AcceptChallenge(
+			t.ctx, pairing.MatchId, pairing.Challenger,
+			Party{fightMon},
+		)
+		if err != nil {
+			log.Printf("Unable to accept challenge of match %v: %v",
+				pairing.MatchId, err)
+		}
+
+		return
+	}
+}
+
+// Poll advances the tournaments we organize. The registration is closed
+// and the first round started once the registration ended. A round is
+// closed once all its matches are finished or its time is up and the next
+// round is started, until the tournament is over.
+func (t *Tournaments) Poll(ctx context.Context, now time.Time) error {
+	t.mu.Lock()
+	tournamentIds := make([]string, 0, len(t.organized))
+	for tournamentId := range t.organized {
+		tournamentIds = append(tournamentIds, tournamentId)
+	}
+	t.mu.Unlock()
+
+	var errs []error
+	for _, tournamentId := range tournamentIds {
+		err := t.advanceTournament(ctx, tournamentId, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tournament %v: %w",
+				tournamentId, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// advanceTournament starts or closes the current round of a tournament we
+// organize if it is due.
+func (t *Tournaments) advanceTournament(ctx context.Context,
+	tournamentId string, now time.Time) error {
+
+	tournament, err := t.Get(ctx, tournamentId)
+	if err != nil {
+		return err
+	}
+	if tournament.Final {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rules := tournament.Rules
+	round := tournament.CurrentRound()
+	if round == nil {
+		if now.Unix() <= rules.RegistrationEnd {
+			return nil
+		}
+
+		entrants, err := t.entrants(ctx, tournament)
+		if err != nil {
+			return err
+		}
+
+		next, err := newBracket(tournamentId, rules, entrants).next()
+		if err != nil {
+			return err
+		}
+
+		return t.startRound(ctx, tournamentId, next, entrants)
+	}
+
+	b := newBracket(tournamentId, rules, tournament.Entrants)
+	for _, closed := range tournament.Rounds {
+		if closed.Closed {
+			b.close(closed)
+		}
+	}
+
+	if !round.Closed {
+		deadline := round.StartedAt.Add(
+			time.Duration(rules.RoundDuration) * time.Second,
+		)
+		results, finished, err := t.roundResults(
+			ctx, tournament, round,
+		)
+		if err != nil {
+			return err
+		}
+		if !finished && now.Before(deadline) {
+			return nil
+		}
+
+		round.Closed = true
+		round.Results = results
+		b.close(round)
+
+		next, err := b.next()
+		if err != nil {
+			return err
+		}
+
+		ev, err := GetFightMonTournamentStandingsEvent(
+			tournamentId, FightMonTournamentStandingsEvent{
+				Round:     round.Round,
+				Results:   results,
+				Standings: b.standings(),
+				Final:     next == nil,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if err := t.manager.transport.Publish(ctx, ev); err != nil {
+			return err
+		}
+		if next == nil {
+			return nil
+		}
+
+		return t.startRound(ctx, tournamentId, next, nil)
+	}
+
+	// The previous round was closed, but the next one wasn't started.
+	next, err := b.next()
+	if err != nil || next == nil {
+		return err
+	}
+
+	return t.startRound(ctx, tournamentId, next, nil)
+}
+
+// entrants returns the players that enter the tournament, which are the
+// players that registered first and paid their entry fee. The caller must
+// hold the lock.
+func (t *Tournaments) entrants(ctx context.Context,
+	tournament *Tournament) ([]string, error) {
+
+	organized := t.organized[tournament.Id]
+	maxPlayers := tournament.Rules.MaxPlayers
+
+	var entrants []string
+	for _, registration := range tournament.Registrations {
+		if maxPlayers > 0 && len(entrants) == maxPlayers {
+			break
+		}
+
+		if tournament.Rules.EntryFeeSat > 0 {
+			hash, ok := organized.invoices[registration.Player]
+			if !ok {
+				continue
+			}
+
+			invoice, err := t.lightning.LookupInvoice(ctx, hash)
+			if err != nil {
+				return nil, fmt.Errorf("unable to look up "+
+					"entry fee invoice: %w", err)
+			}
+			if invoice.State != invoices.ContractSettled {
+				continue
+			}
+		}
+
+		entrants = append(entrants, registration.Player)
+	}
+
+	return entrants, nil
+}
+
+// roundResults returns the results of the matches of a round and whether
+// all of them are finished.
+func (t *Tournaments) roundResults(ctx context.Context,
+	tournament *Tournament, round *TournamentRound) ([]TournamentResult,
+	bool, error) {
+
+	finished := true
+	results := make([]TournamentResult, 0, len(round.Pairings))
+	for _, pairing := range round.Pairings {
+		events, err := t.manager.transport.Query(
+			ctx, nostr.Filters{MatchFilter(pairing.MatchId)},
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		match, err := VerifyMatch(pairing.MatchId, events, nil)
+		if err != nil {
+			match = nil
+			finished = false
+		}
+		results = append(
+			results, tournament.MatchResult(pairing, match),
+		)
+	}
+
+	return results, finished, nil
+}
+
+// startRound publishes the pairings of a round. The entrants are only
+// published with the first round.
+func (t *Tournaments) startRound(ctx context.Context, tournamentId string,
+	round *TournamentRound, entrants []string) error {
+
+	ev, err := GetFightMonTournamentRoundEvent(
+		tournamentId, FightMonTournamentRoundEvent{
+			Round:    round.Round,
+			Entrants: entrants,
+			Pairings: round.Pairings,
+			Bye:      round.Bye,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return t.manager.transport.Publish(ctx, ev)
+}
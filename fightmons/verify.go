@@ -0,0 +1,424 @@
+package fightmons
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+// VerifiedMatch is the outcome of a match that was re-validated and re-run
+// from its events.
+type VerifiedMatch struct {
+	*Match
+
+	// Winner is the side that won the match or SideNone on a draw.
+	Winner Side
+
+	// Ignored are the events tagged with the match that were rejected
+	// because anyone could have published them, e.g. events by third
+	// parties or invalid match requests.
+	Ignored []*nostr.Event
+
+	// Unapplied are the events tagged with the match that were never
+	// applied because the events they build upon are missing, e.g. round
+	// events for a round after the end of the match.
+	Unapplied []*nostr.Event
+
+	// Disputed are the results signed by the players that don't follow
+	// from the events of the match.
+	Disputed []*nostr.Event
+
+	// Violation is the first event by which a player broke the protocol,
+	// e.g. by committing twice or revealing an illegal action. The player
+	// forfeited the match with it. It is nil if both players followed
+	// the protocol.
+	Violation *EventError
+
+	// OwnershipVerified is true if the ownership of the fightmons of both
+	// parties was verified. The events of a match alone only prove the
+	// stats of the fightmons, not that the players own them.
+	OwnershipVerified bool
+}
+
+// OwnershipVerifier verifies that a player owns the mon they fought with.
+type OwnershipVerifier interface {
+	// VerifyOwnership returns an error if the player with the given
+	// pubkey can't prove to own the given fightmon.
+	VerifyOwnership(pubKey string, mon *FightMon) error
+}
+
+// WinnerPubKey returns the pubkey of the winner or an empty string on a
+// draw.
+func (v *VerifiedMatch) WinnerPubKey() string {
+	if v.Winner == SideNone {
+		return ""
+	}
+
+	return v.Players[v.Winner]
+}
+
+// VerifyMatch independently verifies a match from its events. Every event
+// signature is checked, the fightmons and their level proofs are validated,
+// every round commit is checked against its reveal, every action is checked
+// for legality and finally the battle is re-run to determine the winner.
+// Events that are tagged with the match but were published by third parties
+// are ignored. Round events created after their deadline don't count, a
+// match whose player missed a deadline is won by the opponent once it claims
+// the timeout. Events the claimant hadn't seen don't overturn the claim. A
+// player that breaks the protocol after the match started forfeits it to
+// the opponent. The events of private matches are read from their bundles,
+// results are verified together with the evidence attached to them. If an
+// ownership verifier is given, the ownership of every fightmon of both
+// parties is verified as well, otherwise it is reported as unverified.
+func VerifyMatch(matchId string, events []*nostr.Event,
+	ownership OwnershipVerifier) (*VerifiedMatch, error) {
+
+	// Apply the events in the order of the protocol so that as few as
+	// possible have to be kept pending.
+	sorted := make([]*nostr.Event, 0, len(events))
+	for _, ev := range expandBundles(matchId, events) {
+		if GetMatchId(ev) == matchId {
+			sorted = append(sorted, ev)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CreatedAt != sorted[j].CreatedAt {
+			return sorted[i].CreatedAt < sorted[j].CreatedAt
+		}
+
+		return sorted[i].Kind < sorted[j].Kind
+	})
+	sorted = deferUnseen(sorted)
+
+	match := NewMatch(matchId)
+	verified := &VerifiedMatch{
+		Match:  match,
+		Winner: SideNone,
+	}
+	for _, ev := range sorted {
+		_, err := match.AddEvent(ev)
+		if err := verified.adjudicate(err); err != nil {
+			return nil, err
+		}
+	}
+	verified.Ignored = match.Ignored()
+	verified.Unapplied = match.Pending()
+	for _, ev := range verified.Ignored {
+		if _, ok := match.SideOf(ev.PubKey); ok &&
+			ev.Kind == FightMonResult {
+
+			verified.Disputed = append(verified.Disputed, ev)
+		}
+	}
+
+	if !match.Started() {
+		return nil, fmt.Errorf("match %v was never started", matchId)
+	}
+	done, winner := match.Finished()
+	if !done {
+		return nil, fmt.Errorf("match %v is not finished after %d rounds",
+			matchId, len(match.Rounds))
+	}
+	verified.Winner = winner
+
+	if ownership == nil {
+		return verified, nil
+	}
+	for _, side := range []Side{SideHost, SideChallenger} {
+		for i := range match.Parties[side] {
+			mon := &match.Parties[side][i]
+			err := ownership.VerifyOwnership(match.Players[side], mon)
+			if err != nil {
+				return nil, fmt.Errorf("unable to verify "+
+					"ownership of %v mon %v: %w", side,
+					mon.Id, err)
+			}
+		}
+	}
+	verified.OwnershipVerified = true
+
+	return verified, nil
+}
+
+// timeoutClaim is a timeout claim together with the events its claimant had
+// seen when it claimed the timeout.
+type timeoutClaim struct {
+	ev      *nostr.Event
+	roundId int
+	seen    map[string]struct{}
+
+	// phaseKind is the kind of the round events of the claimed phase,
+	// the reveals if the claimant revealed before the claim and the
+	// commits otherwise.
+	phaseKind int
+}
+
+// deferUnseen moves the round events of the claimed phase that a timeout
+// claim doesn't carry as evidence behind the claims, if they were created
+// before the claim. The claimant hadn't seen them when it claimed the
+// timeout, so a player can't overturn a valid claim by publishing an event
+// dated before the deadline after the claim. The claim is judged without
+// them, and they're only applied if the claim turns out to be invalid.
+func deferUnseen(sorted []*nostr.Event) []*nostr.Event {
+	var claims []*timeoutClaim
+	for _, ev := range sorted {
+		if ev.Kind != FightMonResult {
+			continue
+		}
+
+		var result FightMonResultEvent
+		err := decodeEvent(ev, &result)
+		if err != nil || result.Reason != ResultTimeout {
+			continue
+		}
+
+		claim := &timeoutClaim{
+			ev:        ev,
+			roundId:   result.Rounds + 1,
+			seen:      make(map[string]struct{}),
+			phaseKind: FightMonRound,
+		}
+		for _, seen := range result.Evidence {
+			claim.seen[seen.ID] = struct{}{}
+		}
+		claims = append(claims, claim)
+	}
+	if len(claims) == 0 {
+		return sorted
+	}
+
+	// A claimant that revealed had seen the commit of its opponent, so
+	// only the reveals can be unseen.
+	for _, ev := range sorted {
+		roundId, ok := eventRoundId(ev)
+		if !ok || ev.Kind != FightMonRoundCommit {
+			continue
+		}
+		for _, claim := range claims {
+			if roundId == claim.roundId &&
+				ev.PubKey == claim.ev.PubKey &&
+				ev.CreatedAt <= claim.ev.CreatedAt {
+
+				claim.phaseKind = FightMonRoundCommit
+			}
+		}
+	}
+
+	unseen := func(ev *nostr.Event) bool {
+		roundId, ok := eventRoundId(ev)
+		if !ok {
+			return false
+		}
+
+		for _, claim := range claims {
+			_, seen := claim.seen[ev.ID]
+			if !seen && roundId == claim.roundId &&
+				ev.Kind == claim.phaseKind &&
+				ev.PubKey != claim.ev.PubKey &&
+				ev.CreatedAt <= claim.ev.CreatedAt {
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ordered := make([]*nostr.Event, 0, len(sorted))
+	var deferred []*nostr.Event
+	for _, ev := range sorted {
+		if unseen(ev) {
+			deferred = append(deferred, ev)
+			continue
+		}
+		ordered = append(ordered, ev)
+	}
+
+	return append(ordered, deferred...)
+}
+
+// adjudicate decides the match against the player whose event caused the
+// given error if the event violates the protocol. Violations after the match
+// was decided can't change its outcome anymore. Errors of events that can't
+// be attributed to a player are returned.
+func (v *VerifiedMatch) adjudicate(err error) error {
+	var eventErr *EventError
+	for err != nil && !IsIgnorable(err) {
+		if !errors.As(err, &eventErr) || !eventErr.Violation {
+			return err
+		}
+		if done, _ := v.Finished(); done {
+			return nil
+		}
+		v.Violation = eventErr
+
+		side, _ := v.SideOf(eventErr.Event.PubKey)
+		_, err = v.forfeit(side)
+	}
+
+	return nil
+}
+
+// expandBundles adds the events of the match bundles and the evidence of
+// the results of the given match to the events. Anyone could publish a
+// bundle, but as every event in it is signed by its author, only bundled
+// events of the players affect the match. Bundles and results that can't be
+// decoded are skipped.
+func expandBundles(matchId string, events []*nostr.Event) []*nostr.Event {
+	expanded := make([]*nostr.Event, 0, len(events))
+	for _, ev := range events {
+		if GetMatchId(ev) != matchId {
+			expanded = append(expanded, ev)
+			continue
+		}
+
+		var bundled []*nostr.Event
+		switch ev.Kind {
+		case FightMonMatchBundle:
+			if err := decodeEvent(ev, &bundled); err != nil {
+				continue
+			}
+
+		case FightMonResult:
+			expanded = append(expanded, ev)
+
+			var result FightMonResultEvent
+			if err := decodeEvent(ev, &result); err != nil {
+				continue
+			}
+			bundled = result.Evidence
+
+		default:
+			expanded = append(expanded, ev)
+			continue
+		}
+
+		for _, bundledEv := range bundled {
+			if bundledEv.Kind != FightMonMatchBundle {
+				expanded = append(expanded, bundledEv)
+			}
+		}
+	}
+
+	return expanded
+}
+
+// MatchFilter returns the filter that matches all events of a match.
+func MatchFilter(matchId string) nostr.Filter {
+	return nostr.Filter{
+		Kinds: FightMonKinds,
+		Tags: nostr.TagMap{
+			MatchIndexTag: []string{matchId},
+		},
+	}
+}
+
+// FetchMatchEvents fetches all stored events of a match from the given
+// relays. If the context is done before all relays sent their stored events,
+// the events received so far are returned.
+func FetchMatchEvents(ctx context.Context, relays []string,
+	matchId string) ([]*nostr.Event, error) {
+
+	if len(relays) == 0 {
+		return nil, fmt.Errorf("no relays given")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pool := nostr.NewSimplePool(ctx)
+	var events []*nostr.Event
+	for ev := range pool.SubManyEose(
+		ctx, relays, nostr.Filters{MatchFilter(matchId)},
+	) {
+		// The index tag could have been set on events of other
+		// matches, so we only keep events with the match id tag.
+		if ev.Event == nil || GetMatchId(ev.Event) != matchId {
+			continue
+		}
+		events = append(events, ev.Event)
+	}
+
+	return events, nil
+}
+
+// SubscribeMatchEvents streams all stored and future events of a match from
+// the given relays until the context is cancelled.
+func SubscribeMatchEvents(ctx context.Context, relays []string,
+	matchId string) (<-chan *nostr.Event, error) {
+
+	if len(relays) == 0 {
+		return nil, fmt.Errorf("no relays given")
+	}
+
+	pool := nostr.NewSimplePool(ctx)
+	incoming := pool.SubMany(
+		ctx, relays, nostr.Filters{MatchFilter(matchId)},
+	)
+
+	events := make(chan *nostr.Event)
+	go func() {
+		defer close(events)
+
+		for ev := range incoming {
+			// The index tag could have been set on events of other
+			// matches, so we only keep events with the match id
+			// tag.
+			if ev.Event == nil || GetMatchId(ev.Event) != matchId {
+				continue
+			}
+
+			select {
+			case events <- ev.Event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// ReadMatchEvents reads events from a json dump. The dump can either be a
+// json array of events or one json event per line.
+func ReadMatchEvents(r io.Reader) ([]*nostr.Event, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var events []*nostr.Event
+		if err := json.Unmarshal(data, &events); err != nil {
+			return nil, err
+		}
+
+		return events, nil
+	}
+
+	var events []*nostr.Event
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var ev nostr.Event
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, err
+		}
+		events = append(events, &ev)
+	}
+
+	return events, scanner.Err()
+}
@@ -0,0 +1,405 @@
+package fightmons
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+)
+
+// testPlayer is a player with a nostr key and a fightmon.
+type testPlayer struct {
+	sk  string
+	pub string
+	mon FightMon
+}
+
+func newTestPlayer(t *testing.T) *testPlayer {
+	sk := nostr.GeneratePrivateKey()
+	pub, err := nostr.GetPublicKey(sk)
+	require.NoError(t, err)
+
+	var id [32]byte
+	_, err = rand.Read(id[:])
+	require.NoError(t, err)
+
+	return &testPlayer{
+		sk:  sk,
+		pub: pub,
+		mon: FightMon{Id: hex.EncodeToString(id[:])},
+	}
+}
+
+func (p *testPlayer) sign(t *testing.T, ev *nostr.Event,
+	createdAt nostr.Timestamp) *nostr.Event {
+
+	ev.CreatedAt = createdAt
+	require.NoError(t, ev.Sign(p.sk))
+
+	return ev
+}
+
+// levelUp proves the given level for the fightmon of the player.
+func (p *testPlayer) levelUp(t *testing.T, level int) {
+	mon, err := p.mon.Mon()
+	require.NoError(t, err)
+
+	for nonce := 0; ; nonce++ {
+		if mon.VerifyLevelUp(level, nonce) {
+			p.mon.Level = level
+			p.mon.LevelNonce = nonce
+
+			return
+		}
+	}
+}
+
+// playTestMatch plays a full match between host and challenger where both
+// pick their actions with the given function and returns the match id
+// together with all events.
+func playTestMatch(t *testing.T, host, challenger *testPlayer,
+	pick func(Side, int) Action) (string, []*nostr.Event) {
+
+	parties := [2]Party{{host.mon}, {challenger.mon}}
+
+	return playTestPartyMatch(
+		t, host, challenger, parties,
+		func(side Side, roundId int) (Action, int) {
+			return pick(side, roundId), 0
+		},
+	)
+}
+
+// playTestPartyMatch plays a full match between the given parties of host
+// and challenger where both pick their actions and switch targets with the
+// given function and returns the match id together with all events.
+func playTestPartyMatch(t *testing.T, host, challenger *testPlayer,
+	parties [2]Party,
+	pick func(Side, int) (Action, int)) (string, []*nostr.Event) {
+
+	matchId, matchNonce, err := NewMatchId(host.pub)
+	require.NoError(t, err)
+
+	var (
+		events []*nostr.Event
+		now    = nostr.Timestamp(1700000000)
+	)
+	add := func(p *testPlayer, ev *nostr.Event) {
+		now++
+		events = append(events, p.sign(t, ev, now))
+	}
+
+	add(host, GetFightMonLookingForMatchEvent(matchId, matchNonce))
+
+	ev, err := GetFightMonRequestMatchEvent(
+		matchId, parties[SideChallenger],
+	)
+	require.NoError(t, err)
+	add(challenger, ev)
+
+	ev, err = GetFightMonAcceptMatchEvent(
+		matchId, challenger.pub, parties[SideHost],
+	)
+	require.NoError(t, err)
+	add(host, ev)
+
+	add(host, GetFightMonStartMatchEvent(matchId))
+
+	battle, err := NewBattle(parties[SideHost], parties[SideChallenger])
+	require.NoError(t, err)
+
+	players := [2]*testPlayer{host, challenger}
+	for roundId := 1; ; roundId++ {
+		var (
+			actions [2]Action
+			targets [2]int
+			seeds   [2]string
+		)
+		for side, p := range players {
+			actions[side], targets[side] = pick(Side(side), roundId)
+			seed, hash, err := NewRoundSeed(
+				actions[side], targets[side],
+			)
+			require.NoError(t, err)
+			seeds[side] = seed
+
+			ev, err := GetFightMonRoundEvent(
+				matchId, FightMonRoundEvent{
+					RoundId: roundId,
+					RngHash: hash,
+				},
+			)
+			require.NoError(t, err)
+			add(p, ev)
+		}
+		for side, p := range players {
+			ev, err := GetFightMonRoundCommitEvent(
+				matchId, FightMonRoundCommitEvent{
+					RoundID: roundId,
+					Action:  int(actions[side]),
+					RngSeed: seeds[side],
+					Target:  targets[side],
+				},
+			)
+			require.NoError(t, err)
+			add(p, ev)
+		}
+
+		_, err := battle.PlayRound(actions, targets, seeds)
+		require.NoError(t, err)
+		if done, _ := battle.Finished(); done {
+			return matchId, events
+		}
+	}
+}
+
+func alwaysAttack(Side, int) Action {
+	return ActionAttack
+}
+
+// TestVerifyMatch tests that a full match can be verified from its events
+// regardless of their order and that events by third parties are ignored.
+func TestVerifyMatch(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+	spammer := newTestPlayer(t)
+
+	// The challenger fights with a leveled up mon.
+	challenger.levelUp(t, 3)
+
+	matchId, events := playTestMatch(t, host, challenger, alwaysAttack)
+
+	// A third party tries to interfere with the match.
+	ev, err := GetFightMonRequestMatchEvent(matchId, Party{spammer.mon})
+	require.NoError(t, err)
+	events = append(events, spammer.sign(t, ev, nostr.Now()))
+	ev, err = GetFightMonRoundEvent(matchId, FightMonRoundEvent{
+		RoundId: 1,
+	})
+	require.NoError(t, err)
+	events = append(events, spammer.sign(t, ev, nostr.Now()))
+
+	// A player publishes a reveal for a round after the end of the match.
+	var rounds int
+	for _, ev := range events {
+		if ev.Kind == FightMonRound && ev.PubKey == host.pub {
+			rounds++
+		}
+	}
+	seed, _, err := NewRoundSeed(ActionAttack, 0)
+	require.NoError(t, err)
+	ev, err = GetFightMonRoundCommitEvent(
+		matchId, FightMonRoundCommitEvent{
+			RoundID: rounds + 1,
+			RngSeed: seed,
+		},
+	)
+	require.NoError(t, err)
+	events = append(events, host.sign(t, ev, nostr.Now()))
+
+	verified, err := VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+	require.Equal(t, [2]string{host.pub, challenger.pub}, verified.Players)
+	require.Equal(t, 3, verified.Mons[SideChallenger].Level)
+	require.Len(t, verified.Ignored, 1)
+	require.Len(t, verified.Unapplied, 1)
+	require.Contains(t, verified.Requests, spammer.pub)
+	require.False(t, verified.OwnershipVerified)
+	require.NotEqual(t, SideNone, verified.Winner)
+	require.Equal(t, verified.Players[verified.Winner],
+		verified.WinnerPubKey())
+
+	// Shuffle the events, the result must not change.
+	shuffled := make([]*nostr.Event, len(events))
+	copy(shuffled, events)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		require.NoError(t, err)
+		shuffled[i], shuffled[j.Int64()] = shuffled[j.Int64()], shuffled[i]
+	}
+	reverified := NewMatch(matchId)
+	for _, ev := range shuffled {
+		_, err := reverified.AddEvent(ev)
+		if !IsIgnorable(err) {
+			require.NoError(t, err)
+		}
+	}
+	done, winner := reverified.Finished()
+	require.True(t, done)
+	require.Equal(t, verified.Winner, winner)
+	require.Equal(t, verified.Rounds, reverified.Rounds)
+	require.Len(t, reverified.Ignored(), 1)
+	require.Len(t, reverified.Pending(), 1)
+}
+
+// TestVerifyMatchSpoofedLobby tests that a backdated lobby by a third party
+// can't take over the host role of a match.
+func TestVerifyMatchSpoofedLobby(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+	spoofer := newTestPlayer(t)
+
+	matchId, events := playTestMatch(t, host, challenger, alwaysAttack)
+
+	// The spoofer copies the lobby of the host, including the nonce, and
+	// publishes it before the lobby of the host.
+	lobby := events[0]
+	spoofed := &nostr.Event{
+		Kind: lobby.Kind,
+		Tags: lobby.Tags,
+	}
+	events = append(events, spoofer.sign(t, spoofed, lobby.CreatedAt-1))
+
+	// An own lobby with a fresh nonce doesn't help either.
+	_, nonce, err := NewMatchId(spoofer.pub)
+	require.NoError(t, err)
+	own := GetFightMonLookingForMatchEvent(matchId, nonce)
+	events = append(events, spoofer.sign(t, own, lobby.CreatedAt-2))
+
+	verified, err := VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+	require.Equal(t, host.pub, verified.Players[SideHost])
+	require.ElementsMatch(t, []*nostr.Event{spoofed, own},
+		verified.Ignored)
+
+	// Without the lobby of the host, the match never opened.
+	_, err = VerifyMatch(matchId, []*nostr.Event{spoofed, own}, nil)
+	require.ErrorContains(t, err, "never started")
+}
+
+// TestVerifyMatchCheating tests that tampering by a player is detected and
+// that a player that breaks the protocol during the match forfeits it.
+func TestVerifyMatchCheating(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+
+	// tamper replaces the content of the first event of the given kind
+	// and re-signs it as the given player.
+	tamper := func(kind int, p *testPlayer,
+		modify func(map[string]any)) (string, []*nostr.Event) {
+
+		matchId, events := playTestMatch(
+			t, host, challenger, alwaysAttack,
+		)
+		for _, ev := range events {
+			if ev.Kind != kind || ev.PubKey != p.pub {
+				continue
+			}
+
+			content := make(map[string]any)
+			require.NoError(t, json.Unmarshal(
+				[]byte(ev.Content), &content,
+			))
+			modify(content)
+			data, err := json.Marshal(content)
+			require.NoError(t, err)
+			ev.Content = string(data)
+			p.sign(t, ev, ev.CreatedAt)
+
+			break
+		}
+
+		return matchId, events
+	}
+
+	// forfeited asserts that the match was won by the opponent of the
+	// cheater, whose first reveal is the violation.
+	forfeited := func(matchId string, events []*nostr.Event,
+		cheater *testPlayer, reason string) {
+
+		verified, err := VerifyMatch(matchId, events, nil)
+		require.NoError(t, err)
+		require.NotNil(t, verified.Violation)
+		require.Equal(t, cheater.pub, verified.Violation.Event.PubKey)
+		require.Equal(t, FightMonRoundCommit,
+			verified.Violation.Event.Kind)
+		require.ErrorContains(t, verified.Violation, reason)
+		require.True(t, verified.TimedOut())
+		require.Len(t, verified.Rounds, 0)
+
+		side, _ := verified.SideOf(cheater.pub)
+		require.Equal(t, side.Opponent(), verified.Winner)
+	}
+
+	// Revealing a different seed than committed to.
+	matchId, events := tamper(
+		FightMonRoundCommit, challenger, func(c map[string]any) {
+			seed, _, err := NewRoundSeed(ActionAttack, 0)
+			require.NoError(t, err)
+			c["rng_seed"] = seed
+		},
+	)
+	forfeited(matchId, events, challenger, "do not match committed hash")
+
+	// Revealing a different action than committed to.
+	matchId, events = tamper(
+		FightMonRoundCommit, host, func(c map[string]any) {
+			c["action"] = int(ActionDefend)
+		},
+	)
+	forfeited(matchId, events, host, "do not match committed hash")
+
+	// Revealing an illegal action.
+	matchId, events = tamper(
+		FightMonRoundCommit, host, func(c map[string]any) {
+			c["action"] = 42
+		},
+	)
+	forfeited(matchId, events, host, "illegal action")
+
+	// Claiming a level without a valid proof.
+	matchId, events = tamper(
+		FightMonAcceptMatch, host, func(c map[string]any) {
+			c["level"] = 20
+		},
+	)
+	_, err := VerifyMatch(matchId, events, nil)
+	require.ErrorContains(t, err, "invalid level proof")
+
+	// Forging an event of the opponent.
+	matchId, events = playTestMatch(t, host, challenger, alwaysAttack)
+	events[len(events)-1].Content = "{}"
+	_, err = VerifyMatch(matchId, events, nil)
+	require.ErrorContains(t, err, "invalid event id")
+
+	// The error names the forged event even if it was applied as a
+	// pending event of another one.
+	forged := events[len(events)-1]
+	var eventErr *EventError
+	require.ErrorAs(t, err, &eventErr)
+	require.Equal(t, forged, eventErr.Event)
+}
+
+// TestReadMatchEvents tests that match events can be read from a json array
+// and from one json event per line.
+func TestReadMatchEvents(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+	matchId, events := playTestMatch(t, host, challenger, alwaysAttack)
+
+	array, err := json.Marshal(events)
+	require.NoError(t, err)
+
+	var lines bytes.Buffer
+	for _, ev := range events {
+		require.NoError(t, json.NewEncoder(&lines).Encode(ev))
+		lines.WriteString("\n")
+	}
+
+	for _, dump := range [][]byte{array, lines.Bytes()} {
+		read, err := ReadMatchEvents(bytes.NewReader(dump))
+		require.NoError(t, err)
+		require.Len(t, read, len(events))
+		for i, ev := range read {
+			require.Equal(t, events[i].ID, ev.ID)
+			require.Equal(t, events[i].Sig, ev.Sig)
+		}
+
+		_, err = VerifyMatch(matchId, read, nil)
+		require.NoError(t, err)
+	}
+
+	_, err = ReadMatchEvents(bytes.NewReader([]byte("{")))
+	require.Error(t, err)
+}
@@ -0,0 +1,457 @@
+package fightmons
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// DefaultWagerTimeout is the time after which a wager of a match that
+	// didn't finish is canceled and both players are refunded.
+	DefaultWagerTimeout = time.Hour
+
+	// wagerMemoPrefix is the prefix of the memo of wager invoices. The
+	// memo binds the invoice to the match id.
+	wagerMemoPrefix = "tapmon wager "
+
+	// wagerCltvExpiry is the cltv delta of wager invoices. It must leave
+	// enough time to resolve the wager before the htlc times out.
+	wagerCltvExpiry = 144
+
+	// wagerMaxFeePercent is the maximum routing fee in percent of the
+	// wager amount we pay for the opponent's invoice.
+	wagerMaxFeePercent = 2
+)
+
+var (
+	// ErrUnknownWager is returned if there's no wager for a match.
+	ErrUnknownWager = errors.New("unknown wager")
+)
+
+// WagerState is the state of our side of a wager.
+type WagerState int
+
+const (
+	// WagerOffered means our hold invoice was published, but the opponent
+	// didn't lock their stake yet.
+	WagerOffered WagerState = iota
+
+	// WagerLocked means the opponent's payment of our hold invoice is
+	// held until the match is resolved.
+	WagerLocked
+
+	// WagerWon means we won the match and settled the opponent's
+	// payment.
+	WagerWon
+
+	// WagerLost means we lost the match and canceled the opponent's
+	// payment, so the opponent can settle ours.
+	WagerLost
+
+	// WagerCanceled means the opponent's payment was refunded because
+	// the match was a draw or wasn't finished in time.
+	WagerCanceled
+)
+
+// String returns the name of the wager state.
+func (s WagerState) String() string {
+	switch s {
+	case WagerOffered:
+		return "offered"
+	case WagerLocked:
+		return "locked"
+	case WagerWon:
+		return "won"
+	case WagerLost:
+		return "lost"
+	case WagerCanceled:
+		return "canceled"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Resolved returns true if the wager reached a final state.
+func (s WagerState) Resolved() bool {
+	return s >= WagerWon
+}
+
+// Wager is our side of a wager on a match. Both players create a hold
+// invoice for the wager amount and pay the invoice of their opponent. Once
+// both players signed the result of the match, the winner settles the
+// payment of the loser and the loser cancels the payment of the winner. If
+// the result isn't co-signed before the wager expires, both payments are
+// refunded.
+//
+// Wagers are trust-based: each player holds the preimage of their own
+// invoice regardless of the outcome, so nothing but the honesty of the
+// opponent keeps a loser from settling the winner's payment, or a player
+// from settling the opponent's payment without playing at all. Wagers
+// should only be offered to opponents that are trusted with the stake.
+type Wager struct {
+	// MatchId is the match the wager is bound to.
+	MatchId string
+
+	// AmountSat is the stake of each player.
+	AmountSat btcutil.Amount
+
+	// PaymentHash is the hash of our hold invoice.
+	PaymentHash lntypes.Hash
+
+	// Preimage is the preimage of our hold invoice.
+	Preimage lntypes.Preimage
+
+	// Invoice is our hold invoice the opponent pays.
+	Invoice string
+
+	// OpponentInvoice is the hold invoice of the opponent we pay.
+	OpponentInvoice string
+
+	// State is the state of our side of the wager.
+	State WagerState
+
+	// Won is true if the co-signed result of the match names us as the
+	// winner before the opponent's payment was held. The payment is
+	// settled as soon as it is held.
+	Won bool
+
+	// Expiry is the time after which the wager is canceled if the match
+	// isn't finished.
+	Expiry time.Time
+}
+
+// WagerStore persists wagers.
+type WagerStore interface {
+	// AddWager adds a new wager.
+	AddWager(ctx context.Context, wager *Wager) error
+
+	// UpdateWager updates the opponent invoice, state and outcome of a
+	// wager.
+	UpdateWager(ctx context.Context, wager *Wager) error
+
+	// GetWager returns the wager of a match or ErrUnknownWager.
+	GetWager(ctx context.Context, matchId string) (*Wager, error)
+
+	// ListWagers returns all wagers.
+	ListWagers(ctx context.Context) ([]*Wager, error)
+}
+
+// Wagers creates, funds and resolves the wagers of our matches with lnd
+// hold invoices.
+type Wagers struct {
+	store     WagerStore
+	invoices  lndclient.InvoicesClient
+	lightning lndclient.LightningClient
+	timeout   time.Duration
+
+	mu sync.Mutex
+}
+
+// NewWagers creates a new wager handler. Wagers of matches that aren't
+// finished within the timeout are canceled.
+func NewWagers(store WagerStore, invoices lndclient.InvoicesClient,
+	lightning lndclient.LightningClient, timeout time.Duration) *Wagers {
+
+	return &Wagers{
+		store:     store,
+		invoices:  invoices,
+		lightning: lightning,
+		timeout:   timeout,
+	}
+}
+
+// wagerMemo returns the memo that binds a wager invoice to a match.
+func wagerMemo(matchId string) string {
+	return wagerMemoPrefix + matchId
+}
+
+// Offer creates our hold invoice for a wager on the given match.
+func (w *Wagers) Offer(ctx context.Context, matchId string,
+	amount btcutil.Amount) (*Wager, error) {
+
+	if amount <= 0 {
+		return nil, fmt.Errorf("invalid wager amount %v", amount)
+	}
+
+	_, err := w.store.GetWager(ctx, matchId)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("wager for match %v already offered",
+			matchId)
+
+	case !errors.Is(err, ErrUnknownWager):
+		return nil, err
+	}
+
+	var preimage lntypes.Preimage
+	if _, err := rand.Read(preimage[:]); err != nil {
+		return nil, err
+	}
+	hash := preimage.Hash()
+
+	invoice, err := w.invoices.AddHoldInvoice(
+		ctx, &invoicesrpc.AddInvoiceData{
+			Memo:        wagerMemo(matchId),
+			Hash:        &hash,
+			Value:       lnwire.NewMSatFromSatoshis(amount),
+			Expiry:      int64(w.timeout.Seconds()),
+			CltvExpiry:  wagerCltvExpiry,
+			HodlInvoice: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add hold invoice: %w", err)
+	}
+
+	wager := &Wager{
+		MatchId:     matchId,
+		AmountSat:   amount,
+		PaymentHash: hash,
+		Preimage:    preimage,
+		Invoice:     invoice,
+		State:       WagerOffered,
+		Expiry:      time.Now().Add(w.timeout),
+	}
+	if err := w.store.AddWager(ctx, wager); err != nil {
+		return nil, err
+	}
+
+	return wager, nil
+}
+
+// Get returns our wager on the given match.
+func (w *Wagers) Get(ctx context.Context, matchId string) (*Wager, error) {
+	return w.store.GetWager(ctx, matchId)
+}
+
+// List returns all our wagers.
+func (w *Wagers) List(ctx context.Context) ([]*Wager, error) {
+	return w.store.ListWagers(ctx)
+}
+
+// Pay pays the hold invoice of the opponent after checking that it is bound
+// to the match and matches our stake. The payment stays in flight until the
+// opponent settles or cancels it. The returned channel receives the result
+// of the payment.
+func (w *Wagers) Pay(ctx context.Context, matchId string,
+	invoice string) (<-chan lndclient.PaymentResult, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wager, err := w.store.GetWager(ctx, matchId)
+	if err != nil {
+		return nil, err
+	}
+	if wager.OpponentInvoice != "" {
+		return nil, fmt.Errorf("opponent invoice for match %v already "+
+			"paid", matchId)
+	}
+	if wager.State.Resolved() {
+		return nil, fmt.Errorf("wager for match %v already resolved",
+			matchId)
+	}
+
+	payReq, err := w.lightning.DecodePaymentRequest(ctx, invoice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid opponent invoice: %w", err)
+	}
+	switch {
+	case payReq.Value.ToSatoshis() != wager.AmountSat:
+		return nil, fmt.Errorf("opponent invoice amount %v doesn't "+
+			"match wager amount %v", payReq.Value.ToSatoshis(),
+			wager.AmountSat)
+
+	case payReq.Description != wagerMemo(matchId):
+		return nil, fmt.Errorf("opponent invoice not bound to match "+
+			"%v", matchId)
+
+	case payReq.Hash == wager.PaymentHash:
+		return nil, fmt.Errorf("opponent invoice reuses our payment " +
+			"hash")
+	}
+
+	wager.OpponentInvoice = invoice
+	if err := w.store.UpdateWager(ctx, wager); err != nil {
+		return nil, err
+	}
+
+	maxFee := wager.AmountSat * wagerMaxFeePercent / 100
+
+	return w.lightning.PayInvoice(ctx, invoice, maxFee, nil), nil
+}
+
+// WaitForLock waits until the opponent's payment of our hold invoice is held
+// and marks the wager as locked. If the match was already won by then, the
+// payment is settled right away.
+func (w *Wagers) WaitForLock(ctx context.Context, matchId string) error {
+	wager, err := w.store.GetWager(ctx, matchId)
+	if err != nil {
+		return err
+	}
+
+	updates, errChan, err := w.invoices.SubscribeSingleInvoice(
+		ctx, wager.PaymentHash,
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case update := <-updates:
+			switch update.State {
+			case invoices.ContractAccepted:
+				return w.lock(ctx, matchId)
+
+			case invoices.ContractSettled,
+				invoices.ContractCanceled:
+
+				return nil
+			}
+
+		case err := <-errChan:
+			return err
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// lock marks a wager as locked and settles it if we already won the match.
+func (w *Wagers) lock(ctx context.Context, matchId string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wager, err := w.store.GetWager(ctx, matchId)
+	if err != nil {
+		return err
+	}
+	if wager.State != WagerOffered {
+		return nil
+	}
+
+	wager.State = WagerLocked
+	if err := w.store.UpdateWager(ctx, wager); err != nil {
+		return err
+	}
+	if !wager.Won {
+		return nil
+	}
+
+	return w.resolve(ctx, wager, true)
+}
+
+// Resolve settles or cancels the wager of a match whose result both players
+// signed, depending on whether the result names us as the winner.
+func (w *Wagers) Resolve(ctx context.Context, matchId string,
+	won bool) error {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wager, err := w.store.GetWager(ctx, matchId)
+	if err != nil {
+		return err
+	}
+	if wager.State.Resolved() {
+		return nil
+	}
+
+	// We can only settle once the opponent's payment is held, so we
+	// remember that we won until then.
+	if won && wager.State == WagerOffered {
+		wager.Won = true
+		return w.store.UpdateWager(ctx, wager)
+	}
+
+	return w.resolve(ctx, wager, won)
+}
+
+// resolve settles or cancels our hold invoice. The caller must hold the
+// lock.
+func (w *Wagers) resolve(ctx context.Context, wager *Wager, won bool) error {
+	if won {
+		err := w.invoices.SettleInvoice(ctx, wager.Preimage)
+		if err != nil {
+			return fmt.Errorf("unable to settle wager: %w", err)
+		}
+		wager.State = WagerWon
+	} else {
+		err := w.invoices.CancelInvoice(ctx, wager.PaymentHash)
+		if err != nil {
+			return fmt.Errorf("unable to cancel wager: %w", err)
+		}
+		wager.State = WagerLost
+	}
+
+	return w.store.UpdateWager(ctx, wager)
+}
+
+// Cancel cancels the wager of a match that ended in a draw or was abandoned,
+// which refunds the opponent.
+func (w *Wagers) Cancel(ctx context.Context, matchId string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wager, err := w.store.GetWager(ctx, matchId)
+	if err != nil {
+		return err
+	}
+
+	return w.cancel(ctx, wager)
+}
+
+// cancel cancels a wager. The caller must hold the lock.
+func (w *Wagers) cancel(ctx context.Context, wager *Wager) error {
+	if wager.State.Resolved() {
+		return nil
+	}
+
+	err := w.invoices.CancelInvoice(ctx, wager.PaymentHash)
+	if err != nil {
+		return fmt.Errorf("unable to cancel wager: %w", err)
+	}
+	wager.State = WagerCanceled
+
+	return w.store.UpdateWager(ctx, wager)
+}
+
+// CancelExpired cancels all unresolved wagers that expired before the given
+// time.
+func (w *Wagers) CancelExpired(ctx context.Context, now time.Time) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wagers, err := w.store.ListWagers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, wager := range wagers {
+		if wager.State.Resolved() || now.Before(wager.Expiry) {
+			continue
+		}
+
+		log.Printf("Canceling expired wager of match %v",
+			wager.MatchId)
+		if err := w.cancel(ctx, wager); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,430 @@
+package fightmons
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// memInvoice is an invoice of the in-memory lightning network.
+type memInvoice struct {
+	amount btcutil.Amount
+	memo   string
+	hold   bool
+	state  invoices.ContractState
+	subs   []chan lndclient.InvoiceUpdate
+
+	// resolved is closed once the invoice was settled or canceled.
+	resolved chan struct{}
+}
+
+// memLightning is an in-memory lightning network of plain and hold
+// invoices.
+type memLightning struct {
+	mu       sync.Mutex
+	invoices map[lntypes.Hash]*memInvoice
+}
+
+func newMemLightning() *memLightning {
+	return &memLightning{
+		invoices: make(map[lntypes.Hash]*memInvoice),
+	}
+}
+
+// setState updates the state of an invoice and notifies its subscribers.
+// The caller must hold the lock.
+func (l *memLightning) setState(invoice *memInvoice,
+	state invoices.ContractState) {
+
+	invoice.state = state
+	for _, sub := range invoice.subs {
+		sub <- lndclient.InvoiceUpdate{State: state}
+	}
+	if state == invoices.ContractSettled ||
+		state == invoices.ContractCanceled {
+
+		close(invoice.resolved)
+	}
+}
+
+func (l *memLightning) state(hash lntypes.Hash) invoices.ContractState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.invoices[hash].state
+}
+
+// memInvoices is the invoices client of a node of the in-memory network.
+type memInvoices struct {
+	lndclient.InvoicesClient
+
+	ln *memLightning
+}
+
+func (m *memInvoices) AddHoldInvoice(_ context.Context,
+	in *invoicesrpc.AddInvoiceData) (string, error) {
+
+	m.ln.mu.Lock()
+	defer m.ln.mu.Unlock()
+
+	m.ln.invoices[*in.Hash] = &memInvoice{
+		amount:   in.Value.ToSatoshis(),
+		memo:     in.Memo,
+		hold:     true,
+		state:    invoices.ContractOpen,
+		resolved: make(chan struct{}),
+	}
+
+	return fmt.Sprintf("lnmem:%v:%d:%s", in.Hash, in.Value.ToSatoshis(),
+		in.Memo), nil
+}
+
+func (m *memInvoices) SubscribeSingleInvoice(_ context.Context,
+	hash lntypes.Hash) (<-chan lndclient.InvoiceUpdate, <-chan error,
+	error) {
+
+	m.ln.mu.Lock()
+	defer m.ln.mu.Unlock()
+
+	invoice, ok := m.ln.invoices[hash]
+	if !ok {
+		return nil, nil, errors.New("unknown invoice")
+	}
+
+	sub := make(chan lndclient.InvoiceUpdate, 10)
+	sub <- lndclient.InvoiceUpdate{State: invoice.state}
+	invoice.subs = append(invoice.subs, sub)
+
+	return sub, make(chan error), nil
+}
+
+func (m *memInvoices) SettleInvoice(_ context.Context,
+	preimage lntypes.Preimage) error {
+
+	m.ln.mu.Lock()
+	defer m.ln.mu.Unlock()
+
+	invoice, ok := m.ln.invoices[preimage.Hash()]
+	if !ok || invoice.state != invoices.ContractAccepted {
+		return errors.New("invoice not accepted")
+	}
+	m.ln.setState(invoice, invoices.ContractSettled)
+
+	return nil
+}
+
+func (m *memInvoices) CancelInvoice(_ context.Context,
+	hash lntypes.Hash) error {
+
+	m.ln.mu.Lock()
+	defer m.ln.mu.Unlock()
+
+	invoice, ok := m.ln.invoices[hash]
+	if !ok || invoice.state == invoices.ContractSettled {
+		return errors.New("invoice can't be canceled")
+	}
+	if invoice.state != invoices.ContractCanceled {
+		m.ln.setState(invoice, invoices.ContractCanceled)
+	}
+
+	return nil
+}
+
+// memPayer is the lightning client of a node of the in-memory network.
+type memPayer struct {
+	lndclient.LightningClient
+
+	ln *memLightning
+}
+
+func (m *memPayer) AddInvoice(_ context.Context,
+	in *invoicesrpc.AddInvoiceData) (lntypes.Hash, string, error) {
+
+	var preimage lntypes.Preimage
+	if _, err := rand.Read(preimage[:]); err != nil {
+		return lntypes.Hash{}, "", err
+	}
+	hash := preimage.Hash()
+
+	m.ln.mu.Lock()
+	defer m.ln.mu.Unlock()
+
+	m.ln.invoices[hash] = &memInvoice{
+		amount:   in.Value.ToSatoshis(),
+		memo:     in.Memo,
+		state:    invoices.ContractOpen,
+		resolved: make(chan struct{}),
+	}
+
+	return hash, fmt.Sprintf("lnmem:%v:%d:%s", hash,
+		in.Value.ToSatoshis(), in.Memo), nil
+}
+
+func (m *memPayer) LookupInvoice(_ context.Context,
+	hash lntypes.Hash) (*lndclient.Invoice, error) {
+
+	m.ln.mu.Lock()
+	defer m.ln.mu.Unlock()
+
+	invoice, ok := m.ln.invoices[hash]
+	if !ok {
+		return nil, errors.New("unknown invoice")
+	}
+
+	return &lndclient.Invoice{
+		Hash:   hash,
+		Memo:   invoice.memo,
+		Amount: lnwire.NewMSatFromSatoshis(invoice.amount),
+		State:  invoice.state,
+	}, nil
+}
+
+func (m *memPayer) DecodePaymentRequest(_ context.Context,
+	payReq string) (*lndclient.PaymentRequest, error) {
+
+	parts := strings.SplitN(payReq, ":", 4)
+	if len(parts) != 4 || parts[0] != "lnmem" {
+		return nil, errors.New("invalid payment request")
+	}
+	hash, err := lntypes.MakeHashFromStr(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	amount, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lndclient.PaymentRequest{
+		Hash:        hash,
+		Value:       lnwire.NewMSatFromSatoshis(btcutil.Amount(amount)),
+		Description: parts[3],
+	}, nil
+}
+
+func (m *memPayer) PayInvoice(ctx context.Context, payReq string,
+	_ btcutil.Amount, _ *uint64) chan lndclient.PaymentResult {
+
+	results := make(chan lndclient.PaymentResult, 1)
+	decoded, err := m.DecodePaymentRequest(ctx, payReq)
+	if err != nil {
+		results <- lndclient.PaymentResult{Err: err}
+		return results
+	}
+
+	// Plain invoices are settled right away, hold invoices once the
+	// receiver settles them.
+	m.ln.mu.Lock()
+	invoice := m.ln.invoices[decoded.Hash]
+	m.ln.setState(invoice, invoices.ContractAccepted)
+	if !invoice.hold {
+		m.ln.setState(invoice, invoices.ContractSettled)
+	}
+	m.ln.mu.Unlock()
+
+	go func() {
+		<-invoice.resolved
+
+		if m.ln.state(decoded.Hash) == invoices.ContractCanceled {
+			results <- lndclient.PaymentResult{
+				Err: errors.New("payment canceled"),
+			}
+			return
+		}
+		results <- lndclient.PaymentResult{
+			PaidAmt: invoice.amount,
+		}
+	}()
+
+	return results
+}
+
+func newTestWagers(ln *memLightning) *Wagers {
+	return NewWagers(
+		newMemStore(), &memInvoices{ln: ln}, &memPayer{ln: ln},
+		DefaultWagerTimeout,
+	)
+}
+
+// TestManagerWager tests that the wager of a match is paid to the winner and
+// refunded to the loser.
+func TestManagerWager(t *testing.T) {
+	ctx := context.Background()
+	relay := newMemRelay()
+	ln := newMemLightning()
+
+	hostWagers, challengerWagers := newTestWagers(ln), newTestWagers(ln)
+	host := NewManager(newMemTransport(t, relay), hostWagers, nil, nil)
+	defer host.Stop()
+	challenger := NewManager(
+		newMemTransport(t, relay), challengerWagers, nil, nil,
+	)
+	defer challenger.Stop()
+
+	// The match only starts once both stakes are locked, after which no
+	// more wagers can be offered.
+	started := func(player *Manager, matchId string) error {
+		wagers := hostWagers
+		if player == challenger {
+			wagers = challengerWagers
+		}
+		wager, err := wagers.Get(ctx, matchId)
+		if err != nil {
+			return err
+		}
+		if wager.State != WagerLocked {
+			return fmt.Errorf("match started with wager %v",
+				wager.State)
+		}
+
+		_, err = player.OfferWager(ctx, matchId, 1000)
+		if err == nil {
+			return errors.New("wager offered on started match")
+		}
+
+		return nil
+	}
+	matchId, winner := playWageredMatch(
+		t, host, challenger, started, 1000,
+	)
+	require.NotEqual(t, SideNone, winner)
+
+	winnerWagers, loserWagers := hostWagers, challengerWagers
+	if winner == SideChallenger {
+		winnerWagers, loserWagers = challengerWagers, hostWagers
+	}
+
+	// The winner settles the payment of the loser, the loser cancels the
+	// payment of the winner.
+	require.Eventually(t, func() bool {
+		won, err := winnerWagers.Get(ctx, matchId)
+		if err != nil {
+			return false
+		}
+		lost, err := loserWagers.Get(ctx, matchId)
+		if err != nil {
+			return false
+		}
+
+		return won.State == WagerWon && lost.State == WagerLost
+	}, 5*time.Second, 10*time.Millisecond)
+
+	won, err := winnerWagers.Get(ctx, matchId)
+	require.NoError(t, err)
+	lost, err := loserWagers.Get(ctx, matchId)
+	require.NoError(t, err)
+	require.Equal(t, invoices.ContractSettled, ln.state(won.PaymentHash))
+	require.Equal(t, invoices.ContractCanceled, ln.state(lost.PaymentHash))
+
+	// The wagers were resolved by the result both players signed.
+	for _, player := range []*Manager{host, challenger} {
+		active, err := player.activeMatch(matchId)
+		require.NoError(t, err)
+
+		active.Lock()
+		require.True(t, active.match.CoSigned())
+		active.Unlock()
+	}
+}
+
+// TestWagerRestart tests that a won wager whose opponent payment wasn't held
+// yet is settled once the payment is held after a restart.
+func TestWagerRestart(t *testing.T) {
+	ctx := context.Background()
+	ln := newMemLightning()
+	store := newMemStore()
+	wagers := NewWagers(
+		store, &memInvoices{ln: ln}, &memPayer{ln: ln},
+		DefaultWagerTimeout,
+	)
+
+	wager, err := wagers.Offer(ctx, "match", 1000)
+	require.NoError(t, err)
+	require.NoError(t, wagers.Resolve(ctx, "match", true))
+
+	wager, err = wagers.Get(ctx, "match")
+	require.NoError(t, err)
+	require.Equal(t, WagerOffered, wager.State)
+	require.True(t, wager.Won)
+
+	// After a restart, the manager waits for the payment again and
+	// settles it as soon as it is held.
+	restarted := NewWagers(
+		store, &memInvoices{ln: ln}, &memPayer{ln: ln},
+		DefaultWagerTimeout,
+	)
+	manager := NewManager(
+		newMemTransport(t, newMemRelay()), restarted, nil, nil,
+	)
+	defer manager.Stop()
+
+	results := (&memPayer{ln: ln}).PayInvoice(ctx, wager.Invoice, 0, nil)
+	result := <-results
+	require.NoError(t, result.Err)
+	require.Equal(t, invoices.ContractSettled, ln.state(wager.PaymentHash))
+
+	require.Eventually(t, func() bool {
+		wager, err := restarted.Get(ctx, "match")
+		return err == nil && wager.State == WagerWon
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// TestWagerExpiry tests that wagers are only paid if they are bound to the
+// match and that abandoned wagers are refunded.
+func TestWagerExpiry(t *testing.T) {
+	ctx := context.Background()
+	ln := newMemLightning()
+	ours, theirs := newTestWagers(ln), newTestWagers(ln)
+
+	wager, err := ours.Offer(ctx, "match", 1000)
+	require.NoError(t, err)
+	_, err = ours.Offer(ctx, "match", 1000)
+	require.ErrorContains(t, err, "already offered")
+
+	// Invoices for other matches or amounts are rejected.
+	other, err := theirs.Offer(ctx, "other", 1000)
+	require.NoError(t, err)
+	_, err = ours.Pay(ctx, "match", other.Invoice)
+	require.ErrorContains(t, err, "not bound to match")
+
+	cheap, err := theirs.Offer(ctx, "match", 10)
+	require.NoError(t, err)
+	_, err = ours.Pay(ctx, "match", cheap.Invoice)
+	require.ErrorContains(t, err, "doesn't match wager amount")
+
+	// The opponent pays our invoice, but the match is abandoned.
+	results := (&memPayer{ln: ln}).PayInvoice(ctx, wager.Invoice, 0, nil)
+	require.NoError(t, ours.WaitForLock(ctx, "match"))
+	wager, err = ours.Get(ctx, "match")
+	require.NoError(t, err)
+	require.Equal(t, WagerLocked, wager.State)
+
+	require.NoError(t, ours.CancelExpired(ctx, time.Now()))
+	wager, err = ours.Get(ctx, "match")
+	require.NoError(t, err)
+	require.Equal(t, WagerLocked, wager.State)
+
+	require.NoError(t, ours.CancelExpired(
+		ctx, time.Now().Add(DefaultWagerTimeout),
+	))
+	wager, err = ours.Get(ctx, "match")
+	require.NoError(t, err)
+	require.Equal(t, WagerCanceled, wager.State)
+
+	result := <-results
+	require.Error(t, result.Err)
+}
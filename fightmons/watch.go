@@ -0,0 +1,90 @@
+package fightmons
+
+import (
+	"context"
+
+	"github.com/nbd-wtf/go-nostr"
+)
+
+// WatchMatch applies the events of a match as they arrive and streams the
+// updates they cause, so anyone can follow a public match. As every event is
+// validated when it is applied, only verified round results are streamed.
+// The events seen so far can be passed first to catch up with a running
+// match. Events of finished private matches are read from their bundles.
+// The updates are closed once the match is finished, the events end or the
+// context is cancelled.
+func WatchMatch(ctx context.Context, matchId string,
+	events <-chan *nostr.Event) <-chan *MatchUpdate {
+
+	updates := make(chan *MatchUpdate)
+	go func() {
+		defer close(updates)
+
+		match := NewMatch(matchId)
+		for {
+			var ev *nostr.Event
+			select {
+			case ev = <-events:
+				if ev == nil {
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+
+			expanded := expandBundles(matchId, []*nostr.Event{ev})
+			for _, ev := range expanded {
+				// Anyone can tag events with the match, so
+				// invalid events don't stop us from watching.
+				// The updates before them are valid anyway.
+				evUpdates, _ := match.AddEvent(ev)
+				for _, update := range evUpdates {
+					select {
+					case updates <- update:
+					case <-ctx.Done():
+						return
+					}
+
+					if update.Type == UpdateFinished {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return updates
+}
+
+// WatchMatch streams the updates of any public match we can receive the
+// events of until it is finished or the context is cancelled, starting with
+// the updates of the events published so far.
+func (m *Manager) WatchMatch(ctx context.Context,
+	matchId string) (<-chan *MatchUpdate, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	events, err := m.transport.Subscribe(
+		ctx, nostr.Filters{MatchFilter(matchId)},
+	)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	updates := make(chan *MatchUpdate)
+	go func() {
+		defer cancel()
+		defer close(updates)
+
+		for update := range WatchMatch(ctx, matchId, events) {
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
@@ -0,0 +1,124 @@
+package fightmons
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWatchMatch tests that watching a match from its events streams the
+// same verified results as verifying it, even if the events arrive out of
+// order and mixed with events of others.
+func TestWatchMatch(t *testing.T) {
+	host, challenger := newTestPlayer(t), newTestPlayer(t)
+	matchId, events := playTestMatch(
+		t, host, challenger, func(Side, int) Action {
+			return ActionAttack
+		},
+	)
+	verified, err := VerifyMatch(matchId, events, nil)
+	require.NoError(t, err)
+
+	// A third party publishes a commit for the match, which is ignored.
+	spam, err := GetFightMonRoundCommitEvent(
+		matchId, FightMonRoundCommitEvent{RoundID: 1},
+	)
+	require.NoError(t, err)
+	newTestPlayer(t).sign(t, spam, 1700000000)
+
+	feed := make(chan *nostr.Event, len(events)+1)
+	feed <- spam
+	for i := len(events) - 1; i >= 0; i-- {
+		feed <- events[i]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var (
+		results  []*RoundResult
+		accepted *MatchUpdate
+		finished *MatchUpdate
+	)
+	for update := range WatchMatch(ctx, matchId, feed) {
+		switch update.Type {
+		case UpdateAccepted:
+			accepted = update
+
+		case UpdateRoundResult:
+			results = append(results, update.Result)
+
+		case UpdateFinished:
+			finished = update
+		}
+	}
+
+	require.NotNil(t, accepted)
+	require.Equal(t, verified.Players, accepted.Players)
+	require.Equal(t, verified.Parties, accepted.Parties)
+	require.Equal(t, verified.Rounds, results)
+	require.NotNil(t, finished)
+	require.Equal(t, verified.Winner, finished.Winner)
+}
+
+// TestManagerWatchMatch tests that a spectator that starts watching before a
+// match is played receives its round results as they happen.
+func TestManagerWatchMatch(t *testing.T) {
+	relay := newMemRelay()
+
+	host := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer host.Stop()
+	challenger := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer challenger.Stop()
+	spectator := NewManager(newMemTransport(t, relay), nil, nil, nil)
+	defer spectator.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The spectator starts watching once the match started.
+	type watched struct {
+		rounds int
+		winner Side
+	}
+	watchedCh := make(chan watched, 1)
+	onStarted := func(player *Manager, matchId string) error {
+		if player != host {
+			return nil
+		}
+
+		updates, err := spectator.WatchMatch(ctx, matchId)
+		if err != nil {
+			return err
+		}
+		go func() {
+			var w watched
+			for update := range updates {
+				switch update.Type {
+				case UpdateRoundResult:
+					w.rounds++
+
+				case UpdateFinished:
+					w.winner = update.Winner
+				}
+			}
+			watchedCh <- w
+		}()
+
+		return nil
+	}
+
+	_, winner := playManagerMatch(t, host, challenger, onStarted)
+
+	select {
+	case w := <-watchedCh:
+		require.Equal(t, winner, w.winner)
+		require.NotZero(t, w.rounds)
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("spectator didn't see the match finish")
+	}
+}
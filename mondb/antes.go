@@ -0,0 +1,109 @@
+package mondb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/tapmon/tapmond/fightmons"
+	"github.com/tapmon/tapmond/mondb/sqlc"
+)
+
+// AnteStore persists the antes of our matches and the antes we hold as
+// escrow.
+type AnteStore struct {
+	db *SqliteStore
+}
+
+// A compile time check to ensure AnteStore implements the
+// fightmons.AnteStore interface.
+var _ fightmons.AnteStore = (*AnteStore)(nil)
+
+// NewAnteStore creates a new ante store on top of the database.
+func NewAnteStore(db *SqliteStore) *AnteStore {
+	return &AnteStore{
+		db: db,
+	}
+}
+
+// AddAnte adds a new ante.
+func (s *AnteStore) AddAnte(ctx context.Context, ante *fightmons.Ante) error {
+	return s.db.InsertAnte(ctx, sqlc.InsertAnteParams{
+		MatchID:      ante.MatchId,
+		Player:       ante.Player,
+		Escrow:       ante.Escrow,
+		AssetID:      ante.AssetId,
+		RefundAddr:   ante.RefundAddr,
+		DepositAddr:  ante.DepositAddr,
+		ClaimAddr:    ante.ClaimAddr,
+		State:        int64(ante.State),
+		TransferTxid: ante.TransferTxid,
+		Expiry:       ante.Expiry.Unix(),
+	})
+}
+
+// UpdateAnte updates the addresses, state and transfer txid of an ante.
+func (s *AnteStore) UpdateAnte(ctx context.Context,
+	ante *fightmons.Ante) error {
+
+	return s.db.Queries.UpdateAnte(ctx, sqlc.UpdateAnteParams{
+		DepositAddr:  ante.DepositAddr,
+		ClaimAddr:    ante.ClaimAddr,
+		State:        int64(ante.State),
+		TransferTxid: ante.TransferTxid,
+		MatchID:      ante.MatchId,
+		Player:       ante.Player,
+	})
+}
+
+// GetAnte returns the ante of a player for a match.
+func (s *AnteStore) GetAnte(ctx context.Context, matchId,
+	player string) (*fightmons.Ante, error) {
+
+	row, err := s.db.Queries.GetAnte(ctx, sqlc.GetAnteParams{
+		MatchID: matchId,
+		Player:  player,
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fightmons.ErrUnknownAnte
+
+	case err != nil:
+		return nil, err
+	}
+
+	return anteFromRow(row), nil
+}
+
+// ListAntes returns all antes ordered by their expiry.
+func (s *AnteStore) ListAntes(ctx context.Context) ([]*fightmons.Ante,
+	error) {
+
+	rows, err := s.db.Queries.ListAntes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	antes := make([]*fightmons.Ante, 0, len(rows))
+	for _, row := range rows {
+		antes = append(antes, anteFromRow(row))
+	}
+
+	return antes, nil
+}
+
+func anteFromRow(row sqlc.Ante) *fightmons.Ante {
+	return &fightmons.Ante{
+		MatchId:      row.MatchID,
+		Player:       row.Player,
+		Escrow:       row.Escrow,
+		AssetId:      row.AssetID,
+		RefundAddr:   row.RefundAddr,
+		DepositAddr:  row.DepositAddr,
+		ClaimAddr:    row.ClaimAddr,
+		State:        fightmons.AnteState(row.State),
+		TransferTxid: row.TransferTxid,
+		Expiry:       time.Unix(row.Expiry, 0),
+	}
+}
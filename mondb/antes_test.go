@@ -0,0 +1,51 @@
+package mondb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/fightmons"
+)
+
+// TestAnteStore tests that the antes of both players of a match are
+// persisted and updated.
+func TestAnteStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewAnteStore(newTestStore(t))
+
+	_, err := store.GetAnte(ctx, "match", "host")
+	require.ErrorIs(t, err, fightmons.ErrUnknownAnte)
+
+	host := &fightmons.Ante{
+		MatchId:    "match",
+		Player:     "host",
+		Escrow:     "escrow",
+		AssetId:    []byte{1, 2, 3},
+		RefundAddr: "refund",
+		State:      fightmons.AnteOffered,
+		Expiry:     time.Unix(1700000000, 0),
+	}
+	challenger := *host
+	challenger.Player = "challenger"
+	challenger.Expiry = host.Expiry.Add(time.Minute)
+
+	require.NoError(t, store.AddAnte(ctx, host))
+	require.NoError(t, store.AddAnte(ctx, &challenger))
+	require.Error(t, store.AddAnte(ctx, host))
+
+	stored, err := store.GetAnte(ctx, "match", "host")
+	require.NoError(t, err)
+	require.Equal(t, host, stored)
+
+	host.DepositAddr = "deposit"
+	host.ClaimAddr = "claim"
+	host.State = fightmons.AnteForfeited
+	host.TransferTxid = "txid"
+	require.NoError(t, store.UpdateAnte(ctx, host))
+
+	antes, err := store.ListAntes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*fightmons.Ante{host, &challenger}, antes)
+}
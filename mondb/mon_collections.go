@@ -0,0 +1,43 @@
+package mondb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/tapmon/tapmond/mondb/sqlc"
+	"github.com/tapmon/tapmond/mons"
+)
+
+// AddCollection stores a collection we issue together with the key of its
+// asset group.
+func (s *MonStore) AddCollection(ctx context.Context,
+	collection *mons.MonCollection) error {
+
+	return s.db.Queries.InsertMonCollection(
+		ctx, sqlc.InsertMonCollectionParams{
+			Name:     collection.Name,
+			GroupKey: collection.GroupKey,
+		},
+	)
+}
+
+// GetCollection returns the collection we issue with the given name or nil if
+// we didn't mint into it yet.
+func (s *MonStore) GetCollection(ctx context.Context,
+	name string) (*mons.MonCollection, error) {
+
+	row, err := s.db.Queries.GetMonCollection(ctx, name)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return &mons.MonCollection{
+		Name:     row.Name,
+		GroupKey: row.GroupKey,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package mondb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/mons"
+)
+
+// TestMonCollectionStore tests that collections are stored once by their
+// names and group keys and found by their names.
+func TestMonCollectionStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMonStore(newTestStore(t))
+
+	collection, err := store.GetCollection(ctx, "season 1")
+	require.NoError(t, err)
+	require.Nil(t, collection)
+
+	seasonOne := &mons.MonCollection{
+		Name:     "season 1",
+		GroupKey: []byte{1, 2, 3},
+	}
+	require.NoError(t, store.AddCollection(ctx, seasonOne))
+
+	collection, err = store.GetCollection(ctx, "season 1")
+	require.NoError(t, err)
+	require.Equal(t, seasonOne, collection)
+
+	// Names and group keys can't be reused by another collection.
+	require.Error(t, store.AddCollection(ctx, &mons.MonCollection{
+		Name:     "season 1",
+		GroupKey: []byte{4, 5, 6},
+	}))
+	require.Error(t, store.AddCollection(ctx, &mons.MonCollection{
+		Name:     "season 2",
+		GroupKey: []byte{1, 2, 3},
+	}))
+}
@@ -0,0 +1,96 @@
+package mondb
+
+import (
+	"context"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/tapmon/tapmond/mondb/sqlc"
+	"github.com/tapmon/tapmond/mons"
+)
+
+// AddOwner stores an owner of a mon. An owner with the same anchor outpoint
+// is replaced, as its anchor confirmed in a new block.
+func (s *MonStore) AddOwner(ctx context.Context, owner *mons.MonOwner) error {
+	return s.db.Queries.UpsertMonOwner(ctx, sqlc.UpsertMonOwnerParams{
+		AssetID:        owner.AssetId,
+		ScriptKey:      owner.ScriptKey,
+		AnchorOutpoint: owner.AnchorOutpoint.String(),
+		BlockHash:      owner.BlockHash[:],
+		Height:         int64(owner.Height),
+		BlockTime:      owner.BlockTime.Unix(),
+	})
+}
+
+// ListOwners returns the owners of the mon with the given asset id ordered by
+// the height of their anchors.
+func (s *MonStore) ListOwners(ctx context.Context,
+	assetId []byte) ([]*mons.MonOwner, error) {
+
+	rows, err := s.db.Queries.ListMonOwners(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make([]*mons.MonOwner, 0, len(rows))
+	for _, row := range rows {
+		outpoint, err := wire.NewOutPointFromString(row.AnchorOutpoint)
+		if err != nil {
+			return nil, err
+		}
+		blockHash, err := chainhash.NewHash(row.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		owners = append(owners, &mons.MonOwner{
+			AssetId:        row.AssetID,
+			ScriptKey:      row.ScriptKey,
+			AnchorOutpoint: *outpoint,
+			BlockHash:      *blockHash,
+			Height:         uint32(row.Height),
+			BlockTime:      time.Unix(row.BlockTime, 0),
+		})
+	}
+
+	return owners, nil
+}
+
+// AddLevelUp stores a level up of a mon unless the mon already reached the
+// level before.
+func (s *MonStore) AddLevelUp(ctx context.Context,
+	levelUp *mons.MonLevelUp) error {
+
+	return s.db.Queries.InsertMonLevelUp(ctx, sqlc.InsertMonLevelUpParams{
+		AssetID:   levelUp.AssetId,
+		Level:     int64(levelUp.Level),
+		Nonce:     int64(levelUp.Nonce),
+		Height:    int64(levelUp.Height),
+		LeveledAt: levelUp.LeveledAt.Unix(),
+	})
+}
+
+// ListLevelUps returns the level ups of the mon with the given asset id in
+// the order they were stored.
+func (s *MonStore) ListLevelUps(ctx context.Context,
+	assetId []byte) ([]*mons.MonLevelUp, error) {
+
+	rows, err := s.db.Queries.ListMonLevelUps(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	levelUps := make([]*mons.MonLevelUp, 0, len(rows))
+	for _, row := range rows {
+		levelUps = append(levelUps, &mons.MonLevelUp{
+			AssetId:   row.AssetID,
+			Level:     int(row.Level),
+			Nonce:     int(row.Nonce),
+			Height:    uint32(row.Height),
+			LeveledAt: time.Unix(row.LeveledAt, 0),
+		})
+	}
+
+	return levelUps, nil
+}
@@ -0,0 +1,84 @@
+package mondb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/mons"
+)
+
+// TestMonHistoryStore tests that the owners of a mon are stored once per
+// anchor outpoint ordered by height and that a mon levels once per level.
+func TestMonHistoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMonStore(newTestStore(t))
+
+	require.NoError(t, store.AddMon(ctx, &mons.IndexedMon{
+		AssetId: []byte{1},
+		Name:    "mon",
+		Mon: &mons.Mon{
+			Id:     []byte{1, 1},
+			Scores: []uint8{1, 2, 3},
+		},
+		Metadata: &mons.MonMetadata{
+			MonVersion:    mons.MonVersion,
+			BreedCooldown: mons.BreedCooldown(0),
+		},
+		BlockHash: chainhash.Hash{100},
+		TxHash:    chainhash.Hash{1},
+		Height:    100,
+	}))
+
+	owners, err := store.ListOwners(ctx, []byte{1})
+	require.NoError(t, err)
+	require.Empty(t, owners)
+
+	newOwner := func(scriptKey byte, height uint32) *mons.MonOwner {
+		return &mons.MonOwner{
+			AssetId:   []byte{1},
+			ScriptKey: []byte{scriptKey},
+			AnchorOutpoint: wire.OutPoint{
+				Hash:  chainhash.Hash{scriptKey},
+				Index: 1,
+			},
+			BlockHash: chainhash.Hash{byte(height)},
+			Height:    height,
+			BlockTime: time.Unix(int64(height)*600, 0),
+		}
+	}
+	transfer, mint := newOwner(2, 120), newOwner(1, 100)
+	require.NoError(t, store.AddOwner(ctx, transfer))
+	require.NoError(t, store.AddOwner(ctx, mint))
+
+	owners, err = store.ListOwners(ctx, []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []*mons.MonOwner{mint, transfer}, owners)
+
+	// A transfer that confirmed in a new block replaces the old anchor.
+	reanchored := newOwner(2, 121)
+	require.NoError(t, store.AddOwner(ctx, reanchored))
+	owners, err = store.ListOwners(ctx, []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []*mons.MonOwner{mint, reanchored}, owners)
+
+	levelUp := &mons.MonLevelUp{
+		AssetId:   []byte{1},
+		Level:     2,
+		Nonce:     42,
+		Height:    400,
+		LeveledAt: time.Unix(1000, 0),
+	}
+	require.NoError(t, store.AddLevelUp(ctx, levelUp))
+
+	again := *levelUp
+	again.Nonce = 43
+	require.NoError(t, store.AddLevelUp(ctx, &again))
+
+	levelUps, err := store.ListLevelUps(ctx, []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []*mons.MonLevelUp{levelUp}, levelUps)
+}
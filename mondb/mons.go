@@ -0,0 +1,325 @@
+package mondb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/tapmon/tapmond/mondb/sqlc"
+	"github.com/tapmon/tapmond/mons"
+)
+
+// MonStore persists the mons found by the indexer, their parents and their
+// history.
+type MonStore struct {
+	db *SqliteStore
+}
+
+// A compile time check to ensure MonStore implements the mons.MonStore
+// interface.
+var _ mons.MonStore = (*MonStore)(nil)
+
+// NewMonStore creates a new mon store on top of the database.
+func NewMonStore(db *SqliteStore) *MonStore {
+	return &MonStore{
+		db: db,
+	}
+}
+
+// AddMon atomically stores a mon together with the references to its
+// parents.
+func (s *MonStore) AddMon(ctx context.Context, mon *mons.IndexedMon) error {
+	metadata, err := json.Marshal(mon.Metadata)
+	if err != nil {
+		return err
+	}
+	parents, err := mon.Metadata.ParentIds()
+	if err != nil {
+		return err
+	}
+
+	return s.db.ExecTx(ctx, func(q *sqlc.Queries) error {
+		err := q.InsertMon(ctx, sqlc.InsertMonParams{
+			AssetID:   mon.AssetId,
+			Name:      mon.Name,
+			MonID:     mon.Mon.Id,
+			Scores:    mon.Mon.Scores,
+			Metadata:  metadata,
+			BlockHash: mon.BlockHash[:],
+			TxHash:    mon.TxHash[:],
+			Height:    int64(mon.Height),
+			GroupKey:  mon.GroupKey,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, parent := range parents {
+			params := sqlc.InsertMonParentParams{
+				ChildID:  mon.AssetId,
+				ParentID: parent,
+			}
+			if err := q.InsertMonParent(ctx, params); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetMon returns the mon with the given asset id or nil if it isn't indexed.
+func (s *MonStore) GetMon(ctx context.Context,
+	assetId []byte) (*mons.IndexedMon, error) {
+
+	row, err := s.db.Queries.GetMon(ctx, assetId)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return monFromRow(row)
+}
+
+// GetMonById returns the mon with the given mon id or nil if it isn't
+// indexed.
+func (s *MonStore) GetMonById(ctx context.Context,
+	monId []byte) (*mons.IndexedMon, error) {
+
+	row, err := s.db.Queries.GetMonByMonId(ctx, monId)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return monFromRow(row)
+}
+
+// LastBred returns the highest mint height up to the given height of the
+// children of the mon with the given asset id that aren't reorged, or zero if
+// it didn't breed yet.
+func (s *MonStore) LastBred(ctx context.Context, assetId []byte,
+	height uint32) (uint32, error) {
+
+	last, err := s.db.Queries.GetLastBred(ctx, sqlc.GetLastBredParams{
+		ParentID: assetId,
+		Height:   int64(height),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(last), nil
+}
+
+// ListMons returns the mons minted at or above the given height ordered by
+// their height.
+func (s *MonStore) ListMons(ctx context.Context,
+	minHeight uint32) ([]*mons.IndexedMon, error) {
+
+	rows, err := s.db.Queries.ListMons(ctx, int64(minHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	return monsFromRows(rows)
+}
+
+// ListMonPage returns the page of mons selected by the query ordered by
+// their height. The mons of a collection are looked up by their group key.
+func (s *MonStore) ListMonPage(ctx context.Context,
+	query *mons.MonQuery) ([]*mons.IndexedMon, error) {
+
+	// A negative limit lifts the limit in sqlite.
+	limit := int64(query.Limit)
+	if limit == 0 {
+		limit = -1
+	}
+
+	var (
+		rows   []sqlc.Mon
+		err    error
+		bounds = statusBounds(query)
+	)
+	if len(query.GroupKey) == 0 {
+		rows, err = s.db.Queries.ListMonsPage(
+			ctx, sqlc.ListMonsPageParams{
+				Pending:            bounds.Pending,
+				TipHeight:          bounds.TipHeight,
+				Tentative:          bounds.Tentative,
+				TentativeHeight:    bounds.TentativeHeight,
+				MaxTentativeHeight: bounds.MaxTentativeHeight,
+				Final:              bounds.Final,
+				FinalHeight:        bounds.FinalHeight,
+				PageLimit:          limit,
+				PageOffset:         int64(query.Offset),
+			},
+		)
+	} else {
+		rows, err = s.db.Queries.ListCollectionMonsPage(
+			ctx, sqlc.ListCollectionMonsPageParams{
+				GroupKey:           query.GroupKey,
+				Pending:            bounds.Pending,
+				TipHeight:          bounds.TipHeight,
+				Tentative:          bounds.Tentative,
+				TentativeHeight:    bounds.TentativeHeight,
+				MaxTentativeHeight: bounds.MaxTentativeHeight,
+				Final:              bounds.Final,
+				FinalHeight:        bounds.FinalHeight,
+				PageLimit:          limit,
+				PageOffset:         int64(query.Offset),
+			},
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return monsFromRows(rows)
+}
+
+// CountMons returns the number of mons selected by the query, ignoring its
+// paging.
+func (s *MonStore) CountMons(ctx context.Context,
+	query *mons.MonQuery) (int, error) {
+
+	bounds := statusBounds(query)
+	if len(query.GroupKey) == 0 {
+		count, err := s.db.Queries.CountMons(ctx, bounds)
+
+		return int(count), err
+	}
+
+	count, err := s.db.Queries.CountCollectionMons(
+		ctx, sqlc.CountCollectionMonsParams{
+			GroupKey:           query.GroupKey,
+			Pending:            bounds.Pending,
+			TipHeight:          bounds.TipHeight,
+			Tentative:          bounds.Tentative,
+			TentativeHeight:    bounds.TentativeHeight,
+			MaxTentativeHeight: bounds.MaxTentativeHeight,
+			Final:              bounds.Final,
+			FinalHeight:        bounds.FinalHeight,
+		},
+	)
+
+	return int(count), err
+}
+
+// statusBounds returns the statuses selected by the query with the height
+// bounds of each status. Mons minted above the tip are pending, from the
+// tentative height up to the tip tentative and below it final. Reorged mons
+// are pending at any height.
+func statusBounds(query *mons.MonQuery) sqlc.CountMonsParams {
+	return sqlc.CountMonsParams{
+		Pending:            query.Pending,
+		TipHeight:          int64(query.TipHeight),
+		Tentative:          query.Tentative,
+		TentativeHeight:    int64(query.TentativeHeight),
+		MaxTentativeHeight: int64(query.TipHeight),
+		Final:              query.Final,
+		FinalHeight:        int64(query.TentativeHeight),
+	}
+}
+
+// ListMonsByName returns the mons whose lowercase names are the given name
+// key ordered by their height, reorged mons included.
+func (s *MonStore) ListMonsByName(ctx context.Context,
+	nameKey string) ([]*mons.IndexedMon, error) {
+
+	rows, err := s.db.Queries.ListMonsByName(ctx, nameKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return monsFromRows(rows)
+}
+
+// GetChildren returns the asset ids of the children of the mon with the
+// given asset id.
+func (s *MonStore) GetChildren(ctx context.Context,
+	assetId []byte) ([][]byte, error) {
+
+	return s.db.Queries.GetMonChildren(ctx, assetId)
+}
+
+// MarkReorged atomically marks the mons with the given asset ids as reorged.
+func (s *MonStore) MarkReorged(ctx context.Context, assetIds [][]byte) error {
+	return s.db.ExecTx(ctx, func(q *sqlc.Queries) error {
+		for _, assetId := range assetIds {
+			if err := q.SetMonReorged(ctx, assetId); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateMon updates a reorged mon with the mon derived from its new anchor
+// and clears its reorged flag.
+func (s *MonStore) UpdateMon(ctx context.Context,
+	mon *mons.IndexedMon) error {
+
+	return s.db.Queries.UpdateMon(ctx, sqlc.UpdateMonParams{
+		MonID:     mon.Mon.Id,
+		Scores:    mon.Mon.Scores,
+		BlockHash: mon.BlockHash[:],
+		TxHash:    mon.TxHash[:],
+		Height:    int64(mon.Height),
+		AssetID:   mon.AssetId,
+	})
+}
+
+// monsFromRows converts database rows into indexed mons.
+func monsFromRows(rows []sqlc.Mon) ([]*mons.IndexedMon, error) {
+	indexed := make([]*mons.IndexedMon, 0, len(rows))
+	for _, row := range rows {
+		mon, err := monFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		indexed = append(indexed, mon)
+	}
+
+	return indexed, nil
+}
+
+// monFromRow converts a database row into an indexed mon.
+func monFromRow(row sqlc.Mon) (*mons.IndexedMon, error) {
+	var metadata mons.MonMetadata
+	if err := json.Unmarshal(row.Metadata, &metadata); err != nil {
+		return nil, err
+	}
+	blockHash, err := chainhash.NewHash(row.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	txHash, err := chainhash.NewHash(row.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mons.IndexedMon{
+		AssetId: row.AssetID,
+		Name:    row.Name,
+		Mon: &mons.Mon{
+			Id:     row.MonID,
+			Scores: row.Scores,
+		},
+		Metadata:  &metadata,
+		BlockHash: *blockHash,
+		TxHash:    *txHash,
+		Height:    uint32(row.Height),
+		GroupKey:  row.GroupKey,
+		Reorged:   row.Reorged,
+	}, nil
+}
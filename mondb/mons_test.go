@@ -0,0 +1,179 @@
+package mondb
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/mons"
+)
+
+// TestMonStore tests that indexed mons are stored with their parents, that
+// they are found by their asset and mon ids, that the last breeding height of
+// a parent is found and that reorged mons are marked and updated.
+func TestMonStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMonStore(newTestStore(t))
+
+	mon, err := store.GetMon(ctx, []byte{1})
+	require.NoError(t, err)
+	require.Nil(t, mon)
+
+	newMon := func(assetId byte, height uint32,
+		parents ...string) *mons.IndexedMon {
+
+		generation := uint32(len(parents) / 2)
+
+		return &mons.IndexedMon{
+			AssetId: []byte{assetId},
+			Name:    "mon",
+			Mon: &mons.Mon{
+				Id:     []byte{assetId, 1},
+				Scores: []uint8{assetId, 2, 3},
+			},
+			Metadata: &mons.MonMetadata{
+				MonVersion:    mons.MonVersion,
+				Parents:       parents,
+				Generation:    generation,
+				BreedCooldown: mons.BreedCooldown(generation),
+			},
+			BlockHash: chainhash.Hash{byte(height)},
+			TxHash:    chainhash.Hash{assetId},
+			Height:    height,
+		}
+	}
+	parentA := hex.EncodeToString([]byte{1})
+	parentB := hex.EncodeToString([]byte{2})
+
+	monA, monB := newMon(1, 100), newMon(2, 110)
+	monB.Name = "Mon"
+	require.NoError(t, store.AddMon(ctx, monA))
+	require.NoError(t, store.AddMon(ctx, monB))
+	require.Error(t, store.AddMon(ctx, monA))
+
+	child := newMon(3, 300, parentA, parentB)
+	child.GroupKey = []byte{7, 7}
+	require.NoError(t, store.AddMon(ctx, child))
+	require.NoError(t, store.AddMon(ctx, newMon(4, 500, parentA, parentB)))
+
+	stored, err := store.GetMon(ctx, []byte{3})
+	require.NoError(t, err)
+	require.Equal(t, child, stored)
+
+	stored, err = store.GetMonById(ctx, child.Mon.Id)
+	require.NoError(t, err)
+	require.Equal(t, child, stored)
+
+	stored, err = store.GetMonById(ctx, []byte{3})
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	last, err := store.LastBred(ctx, []byte{1}, 499)
+	require.NoError(t, err)
+	require.EqualValues(t, 300, last)
+
+	last, err = store.LastBred(ctx, []byte{2}, 500)
+	require.NoError(t, err)
+	require.EqualValues(t, 500, last)
+
+	last, err = store.LastBred(ctx, []byte{3}, 1000)
+	require.NoError(t, err)
+	require.Zero(t, last)
+
+	children, err := store.GetChildren(ctx, []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{3}, {4}}, children)
+
+	listed, err := store.ListMons(ctx, 110)
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	require.Equal(t, monB, listed[0])
+
+	// Pages of mons are selected by their status at the tip and their
+	// group key.
+	page := func(query *mons.MonQuery) []byte {
+		t.Helper()
+
+		listed, err := store.ListMonPage(ctx, query)
+		require.NoError(t, err)
+		count, err := store.CountMons(ctx, &mons.MonQuery{
+			GroupKey:        query.GroupKey,
+			Pending:         query.Pending,
+			Tentative:       query.Tentative,
+			Final:           query.Final,
+			TipHeight:       query.TipHeight,
+			TentativeHeight: query.TentativeHeight,
+		})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, count, len(listed))
+
+		assetIds := make([]byte, 0, len(listed))
+		for _, mon := range listed {
+			assetIds = append(assetIds, mon.AssetId[0])
+		}
+
+		return assetIds
+	}
+	atTip := func(offset, limit int) *mons.MonQuery {
+		return &mons.MonQuery{
+			Pending:         true,
+			Tentative:       true,
+			Final:           true,
+			TipHeight:       400,
+			TentativeHeight: 300,
+			Offset:          offset,
+			Limit:           limit,
+		}
+	}
+	require.Equal(t, []byte{1, 2, 3, 4}, page(atTip(0, 0)))
+	require.Equal(t, []byte{2, 3}, page(atTip(1, 2)))
+	require.Empty(t, page(atTip(4, 0)))
+
+	final := atTip(0, 0)
+	final.Pending, final.Tentative = false, false
+	require.Equal(t, []byte{1, 2}, page(final))
+
+	tentative := atTip(0, 0)
+	tentative.Pending, tentative.Final = false, false
+	require.Equal(t, []byte{3}, page(tentative))
+
+	pending := atTip(0, 0)
+	pending.Tentative, pending.Final = false, false
+	require.Equal(t, []byte{4}, page(pending))
+
+	collection := atTip(0, 0)
+	collection.GroupKey = []byte{7, 7}
+	require.Equal(t, []byte{3}, page(collection))
+	collection.GroupKey = []byte{7}
+	require.Empty(t, page(collection))
+
+	// Names are looked up by their lowercase keys.
+	named, err := store.ListMonsByName(ctx, "mon")
+	require.NoError(t, err)
+	require.Len(t, named, 4)
+	require.Equal(t, monB, named[1])
+
+	named, err = store.ListMonsByName(ctx, "other")
+	require.NoError(t, err)
+	require.Empty(t, named)
+
+	// Reorged children don't count as bred.
+	require.NoError(t, store.MarkReorged(ctx, [][]byte{{4}}))
+	stored, err = store.GetMon(ctx, []byte{4})
+	require.NoError(t, err)
+	require.True(t, stored.Reorged)
+
+	last, err = store.LastBred(ctx, []byte{2}, 500)
+	require.NoError(t, err)
+	require.EqualValues(t, 300, last)
+
+	// Updating a reorged mon clears its flag.
+	updated := newMon(4, 501, parentA, parentB)
+	updated.Mon.Scores = []uint8{9, 9, 9}
+	require.NoError(t, store.UpdateMon(ctx, updated))
+	stored, err = store.GetMon(ctx, []byte{4})
+	require.NoError(t, err)
+	require.Equal(t, updated, stored)
+}
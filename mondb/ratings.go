@@ -0,0 +1,153 @@
+package mondb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/tapmon/tapmond/fightmons"
+	"github.com/tapmon/tapmond/mondb/sqlc"
+)
+
+// RatingStore persists the ratings of players and mons and their history.
+type RatingStore struct {
+	db *SqliteStore
+}
+
+// A compile time check to ensure RatingStore implements the
+// fightmons.RatingStore interface.
+var _ fightmons.RatingStore = (*RatingStore)(nil)
+
+// NewRatingStore creates a new rating store on top of the database.
+func NewRatingStore(db *SqliteStore) *RatingStore {
+	return &RatingStore{
+		db: db,
+	}
+}
+
+// GetRating returns the rating of a subject or nil if it wasn't rated yet.
+func (s *RatingStore) GetRating(ctx context.Context,
+	kind fightmons.RatingKind, subject string) (*fightmons.Rating, error) {
+
+	row, err := s.db.Queries.GetRating(ctx, sqlc.GetRatingParams{
+		Kind:    int64(kind),
+		Subject: subject,
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return ratingFromRow(row), nil
+}
+
+// AddMatchRatings atomically stores the new ratings of the subjects of a
+// match and adds them to the rating history.
+func (s *RatingStore) AddMatchRatings(ctx context.Context, matchId string,
+	playedAt time.Time, ratings []*fightmons.Rating) error {
+
+	return s.db.ExecTx(ctx, func(q *sqlc.Queries) error {
+		rated, err := q.IsMatchRated(ctx, matchId)
+		if err != nil {
+			return err
+		}
+		if rated != 0 {
+			return fightmons.ErrMatchRated
+		}
+
+		for _, rating := range ratings {
+			err := q.UpsertRating(ctx, sqlc.UpsertRatingParams{
+				Kind:    int64(rating.Kind),
+				Subject: rating.Subject,
+				Rating:  rating.Rating,
+				Games:   int64(rating.Games),
+				Wins:    int64(rating.Wins),
+				Losses:  int64(rating.Losses),
+			})
+			if err != nil {
+				return err
+			}
+
+			err = q.InsertRatingHistory(
+				ctx, sqlc.InsertRatingHistoryParams{
+					Kind:     int64(rating.Kind),
+					Subject:  rating.Subject,
+					MatchID:  matchId,
+					Rating:   rating.Rating,
+					PlayedAt: playedAt.Unix(),
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListRatings returns the ratings of the given kind from the highest to the
+// lowest, skipping the first offset ratings.
+func (s *RatingStore) ListRatings(ctx context.Context,
+	kind fightmons.RatingKind, offset, limit int) ([]*fightmons.Rating,
+	error) {
+
+	rows, err := s.db.Queries.ListRatings(ctx, sqlc.ListRatingsParams{
+		Kind:   int64(kind),
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ratings := make([]*fightmons.Rating, 0, len(rows))
+	for _, row := range rows {
+		ratings = append(ratings, ratingFromRow(row))
+	}
+
+	return ratings, nil
+}
+
+// GetRatingHistory returns the ratings of a subject after each of its rated
+// matches in the order they were rated.
+func (s *RatingStore) GetRatingHistory(ctx context.Context,
+	kind fightmons.RatingKind,
+	subject string) ([]*fightmons.RatingHistoryEntry, error) {
+
+	rows, err := s.db.Queries.GetRatingHistory(
+		ctx, sqlc.GetRatingHistoryParams{
+			Kind:    int64(kind),
+			Subject: subject,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*fightmons.RatingHistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, &fightmons.RatingHistoryEntry{
+			MatchId:  row.MatchID,
+			Rating:   row.Rating,
+			PlayedAt: time.Unix(row.PlayedAt, 0),
+		})
+	}
+
+	return history, nil
+}
+
+func ratingFromRow(row sqlc.Rating) *fightmons.Rating {
+	return &fightmons.Rating{
+		Kind:    fightmons.RatingKind(row.Kind),
+		Subject: row.Subject,
+		Rating:  row.Rating,
+		Games:   int(row.Games),
+		Wins:    int(row.Wins),
+		Losses:  int(row.Losses),
+	}
+}
@@ -0,0 +1,93 @@
+package mondb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/fightmons"
+)
+
+// TestRatingStore tests that the ratings of a match are stored atomically
+// together with their history and listed from the highest to the lowest.
+func TestRatingStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewRatingStore(newTestStore(t))
+
+	rating, err := store.GetRating(ctx, fightmons.RatingPlayer, "alice")
+	require.NoError(t, err)
+	require.Nil(t, rating)
+
+	playedAt := time.Unix(1700000000, 0)
+	alice := &fightmons.Rating{
+		Kind:    fightmons.RatingPlayer,
+		Subject: "alice",
+		Rating:  1516,
+		Games:   1,
+		Wins:    1,
+	}
+	bob := &fightmons.Rating{
+		Kind:    fightmons.RatingPlayer,
+		Subject: "bob",
+		Rating:  1484,
+		Games:   1,
+		Losses:  1,
+	}
+	mon := &fightmons.Rating{
+		Kind:    fightmons.RatingMon,
+		Subject: "mon",
+		Rating:  1600,
+		Games:   1,
+		Wins:    1,
+	}
+	err = store.AddMatchRatings(
+		ctx, "match1", playedAt, []*fightmons.Rating{bob, alice, mon},
+	)
+	require.NoError(t, err)
+
+	// A match can only be rated once.
+	err = store.AddMatchRatings(
+		ctx, "match1", playedAt, []*fightmons.Rating{alice},
+	)
+	require.ErrorIs(t, err, fightmons.ErrMatchRated)
+
+	// Bob wins the rematch and overtakes Alice.
+	bob.Rating, bob.Games, bob.Wins = 1530, 2, 1
+	alice.Rating, alice.Games, alice.Losses = 1470, 2, 1
+	err = store.AddMatchRatings(
+		ctx, "match2", playedAt.Add(time.Hour),
+		[]*fightmons.Rating{alice, bob},
+	)
+	require.NoError(t, err)
+
+	stored, err := store.GetRating(ctx, fightmons.RatingPlayer, "alice")
+	require.NoError(t, err)
+	require.Equal(t, alice, stored)
+
+	ratings, err := store.ListRatings(ctx, fightmons.RatingPlayer, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*fightmons.Rating{bob, alice}, ratings)
+
+	ratings, err = store.ListRatings(ctx, fightmons.RatingPlayer, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*fightmons.Rating{alice}, ratings)
+
+	ratings, err = store.ListRatings(ctx, fightmons.RatingMon, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*fightmons.Rating{mon}, ratings)
+
+	history, err := store.GetRatingHistory(
+		ctx, fightmons.RatingPlayer, "alice",
+	)
+	require.NoError(t, err)
+	require.Equal(t, []*fightmons.RatingHistoryEntry{{
+		MatchId:  "match1",
+		Rating:   1516,
+		PlayedAt: playedAt,
+	}, {
+		MatchId:  "match2",
+		Rating:   1470,
+		PlayedAt: playedAt.Add(time.Hour),
+	}}, history)
+}
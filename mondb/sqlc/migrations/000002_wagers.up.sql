@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS wagers (
+    match_id TEXT PRIMARY KEY,
+    amount_sat BIGINT NOT NULL,
+    payment_hash BLOB NOT NULL,
+    preimage BLOB NOT NULL,
+    invoice TEXT NOT NULL,
+    opponent_invoice TEXT NOT NULL,
+    state INTEGER NOT NULL,
+    expiry BIGINT NOT NULL
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS antes (
+    match_id TEXT NOT NULL,
+    player TEXT NOT NULL,
+    escrow TEXT NOT NULL,
+    asset_id BLOB NOT NULL,
+    refund_addr TEXT NOT NULL,
+    deposit_addr TEXT NOT NULL,
+    claim_addr TEXT NOT NULL,
+    state INTEGER NOT NULL,
+    transfer_txid TEXT NOT NULL,
+    expiry BIGINT NOT NULL,
+    PRIMARY KEY (match_id, player)
+);
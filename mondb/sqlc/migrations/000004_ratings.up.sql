@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS ratings (
+    kind INTEGER NOT NULL,
+    subject TEXT NOT NULL,
+    rating REAL NOT NULL,
+    games INTEGER NOT NULL,
+    wins INTEGER NOT NULL,
+    losses INTEGER NOT NULL,
+    PRIMARY KEY (kind, subject)
+);
+
+CREATE INDEX IF NOT EXISTS ratings_rating_idx ON ratings (kind, rating);
+
+CREATE TABLE IF NOT EXISTS rating_history (
+    id INTEGER PRIMARY KEY,
+    kind INTEGER NOT NULL,
+    subject TEXT NOT NULL,
+    match_id TEXT NOT NULL,
+    rating REAL NOT NULL,
+    played_at BIGINT NOT NULL,
+    UNIQUE (kind, subject, match_id)
+);
+
+CREATE INDEX IF NOT EXISTS rating_history_match_idx ON rating_history (match_id);
@@ -0,0 +1,2 @@
+DROP TABLE mon_parents;
+DROP TABLE mons;
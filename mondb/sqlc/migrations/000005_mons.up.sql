@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS mons (
+    asset_id BLOB PRIMARY KEY,
+    name TEXT NOT NULL,
+    mon_id BLOB NOT NULL,
+    scores BLOB NOT NULL,
+    metadata BLOB NOT NULL,
+    block_hash BLOB NOT NULL,
+    tx_hash BLOB NOT NULL,
+    height INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS mon_parents (
+    child_id BLOB NOT NULL REFERENCES mons (asset_id),
+    parent_id BLOB NOT NULL REFERENCES mons (asset_id),
+    PRIMARY KEY (child_id, parent_id)
+);
+
+CREATE INDEX IF NOT EXISTS mon_parents_parent_idx ON mon_parents (parent_id);
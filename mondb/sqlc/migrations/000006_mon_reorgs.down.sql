@@ -0,0 +1,2 @@
+DROP INDEX mons_height_idx;
+ALTER TABLE mons DROP COLUMN reorged;
@@ -0,0 +1,3 @@
+ALTER TABLE mons ADD COLUMN reorged BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS mons_height_idx ON mons (height);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mon_level_ups;
+DROP TABLE IF EXISTS mon_owners;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS mon_owners (
+    id INTEGER PRIMARY KEY,
+    asset_id BLOB NOT NULL REFERENCES mons (asset_id),
+    script_key BLOB NOT NULL,
+    anchor_outpoint TEXT NOT NULL,
+    block_hash BLOB NOT NULL,
+    height INTEGER NOT NULL,
+    block_time BIGINT NOT NULL,
+    UNIQUE (asset_id, anchor_outpoint)
+);
+
+CREATE TABLE IF NOT EXISTS mon_level_ups (
+    id INTEGER PRIMARY KEY,
+    asset_id BLOB NOT NULL REFERENCES mons (asset_id),
+    level INTEGER NOT NULL,
+    nonce INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    leveled_at BIGINT NOT NULL,
+    UNIQUE (asset_id, level)
+);
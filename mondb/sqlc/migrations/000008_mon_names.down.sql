@@ -0,0 +1 @@
+DROP INDEX IF EXISTS mons_name_idx;
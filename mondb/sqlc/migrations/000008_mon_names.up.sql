@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS mons_name_idx ON mons (lower(name));
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS mons_group_key_idx;
+ALTER TABLE mons DROP COLUMN group_key;
+
+DROP TABLE IF EXISTS mon_collections;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS mon_collections (
+    name TEXT PRIMARY KEY,
+    group_key BLOB NOT NULL UNIQUE
+);
+
+ALTER TABLE mons ADD COLUMN group_key BLOB;
+
+CREATE INDEX IF NOT EXISTS mons_group_key_idx ON mons (group_key);
@@ -0,0 +1 @@
+ALTER TABLE wagers DROP COLUMN won;
@@ -0,0 +1 @@
+ALTER TABLE wagers ADD COLUMN won BOOLEAN NOT NULL DEFAULT FALSE;
@@ -6,7 +6,92 @@ package sqlc
 
 import ()
 
+type Ante struct {
+	MatchID      string
+	Player       string
+	Escrow       string
+	AssetID      []byte
+	RefundAddr   string
+	DepositAddr  string
+	ClaimAddr    string
+	State        int64
+	TransferTxid string
+	Expiry       int64
+}
+
 type KnownMon struct {
 	ID    int64
 	Block []byte
 }
+
+type Mon struct {
+	AssetID   []byte
+	Name      string
+	MonID     []byte
+	Scores    []byte
+	Metadata  []byte
+	BlockHash []byte
+	TxHash    []byte
+	Height    int64
+	Reorged   bool
+	GroupKey  []byte
+}
+
+type MonCollection struct {
+	Name     string
+	GroupKey []byte
+}
+
+type MonLevelUp struct {
+	ID        int64
+	AssetID   []byte
+	Level     int64
+	Nonce     int64
+	Height    int64
+	LeveledAt int64
+}
+
+type MonOwner struct {
+	ID             int64
+	AssetID        []byte
+	ScriptKey      []byte
+	AnchorOutpoint string
+	BlockHash      []byte
+	Height         int64
+	BlockTime      int64
+}
+
+type MonParent struct {
+	ChildID  []byte
+	ParentID []byte
+}
+
+type Rating struct {
+	Kind    int64
+	Subject string
+	Rating  float64
+	Games   int64
+	Wins    int64
+	Losses  int64
+}
+
+type RatingHistory struct {
+	ID       int64
+	Kind     int64
+	Subject  string
+	MatchID  string
+	Rating   float64
+	PlayedAt int64
+}
+
+type Wager struct {
+	MatchID         string
+	AmountSat       int64
+	PaymentHash     []byte
+	Preimage        []byte
+	Invoice         string
+	OpponentInvoice string
+	State           int64
+	Expiry          int64
+	Won             bool
+}
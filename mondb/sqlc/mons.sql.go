@@ -9,6 +9,80 @@ import (
 	"context"
 )
 
+const countCollectionMons = `-- name: CountCollectionMons :one
+SELECT COUNT(*) FROM mons
+WHERE group_key = ? AND (
+    (? AND (reorged OR height > ?))
+        OR (? AND NOT reorged
+            AND height BETWEEN ?
+            AND ?)
+        OR (? AND NOT reorged
+            AND height < ?)
+)
+`
+
+type CountCollectionMonsParams struct {
+	GroupKey           []byte
+	Pending            bool
+	TipHeight          int64
+	Tentative          bool
+	TentativeHeight    int64
+	MaxTentativeHeight int64
+	Final              bool
+	FinalHeight        int64
+}
+
+func (q *Queries) CountCollectionMons(ctx context.Context, arg CountCollectionMonsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCollectionMons,
+		arg.GroupKey,
+		arg.Pending,
+		arg.TipHeight,
+		arg.Tentative,
+		arg.TentativeHeight,
+		arg.MaxTentativeHeight,
+		arg.Final,
+		arg.FinalHeight,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countMons = `-- name: CountMons :one
+SELECT COUNT(*) FROM mons
+WHERE (? AND (reorged OR height > ?))
+    OR (? AND NOT reorged
+        AND height BETWEEN ?
+        AND ?)
+    OR (? AND NOT reorged
+        AND height < ?)
+`
+
+type CountMonsParams struct {
+	Pending            bool
+	TipHeight          int64
+	Tentative          bool
+	TentativeHeight    int64
+	MaxTentativeHeight int64
+	Final              bool
+	FinalHeight        int64
+}
+
+func (q *Queries) CountMons(ctx context.Context, arg CountMonsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMons,
+		arg.Pending,
+		arg.TipHeight,
+		arg.Tentative,
+		arg.TentativeHeight,
+		arg.MaxTentativeHeight,
+		arg.Final,
+		arg.FinalHeight,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getKnownMons = `-- name: GetKnownMons :many
 SELECT id, block FROM known_mons
 `
@@ -35,3 +109,393 @@ func (q *Queries) GetKnownMons(ctx context.Context) ([]KnownMon, error) {
 	}
 	return items, nil
 }
+
+const getLastBred = `-- name: GetLastBred :one
+SELECT CAST(COALESCE(MAX(mons.height), 0) AS INTEGER)
+FROM mon_parents JOIN mons ON mons.asset_id = mon_parents.child_id
+WHERE mon_parents.parent_id = ? AND mons.height <= ? AND NOT mons.reorged
+`
+
+type GetLastBredParams struct {
+	ParentID []byte
+	Height   int64
+}
+
+func (q *Queries) GetLastBred(ctx context.Context, arg GetLastBredParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLastBred, arg.ParentID, arg.Height)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const getMon = `-- name: GetMon :one
+SELECT asset_id, name, mon_id, scores, metadata, block_hash, tx_hash, height, reorged, group_key FROM mons WHERE asset_id = ?
+`
+
+func (q *Queries) GetMon(ctx context.Context, assetID []byte) (Mon, error) {
+	row := q.db.QueryRowContext(ctx, getMon, assetID)
+	var i Mon
+	err := row.Scan(
+		&i.AssetID,
+		&i.Name,
+		&i.MonID,
+		&i.Scores,
+		&i.Metadata,
+		&i.BlockHash,
+		&i.TxHash,
+		&i.Height,
+		&i.Reorged,
+		&i.GroupKey,
+	)
+	return i, err
+}
+
+const getMonByMonId = `-- name: GetMonByMonId :one
+SELECT asset_id, name, mon_id, scores, metadata, block_hash, tx_hash, height, reorged, group_key FROM mons WHERE mon_id = ?
+`
+
+func (q *Queries) GetMonByMonId(ctx context.Context, monID []byte) (Mon, error) {
+	row := q.db.QueryRowContext(ctx, getMonByMonId, monID)
+	var i Mon
+	err := row.Scan(
+		&i.AssetID,
+		&i.Name,
+		&i.MonID,
+		&i.Scores,
+		&i.Metadata,
+		&i.BlockHash,
+		&i.TxHash,
+		&i.Height,
+		&i.Reorged,
+		&i.GroupKey,
+	)
+	return i, err
+}
+
+const getMonChildren = `-- name: GetMonChildren :many
+SELECT child_id FROM mon_parents WHERE parent_id = ? ORDER BY child_id
+`
+
+func (q *Queries) GetMonChildren(ctx context.Context, parentID []byte) ([][]byte, error) {
+	rows, err := q.db.QueryContext(ctx, getMonChildren, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items [][]byte
+	for rows.Next() {
+		var child_id []byte
+		if err := rows.Scan(&child_id); err != nil {
+			return nil, err
+		}
+		items = append(items, child_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertMon = `-- name: InsertMon :exec
+INSERT INTO mons (
+    asset_id, name, mon_id, scores, metadata, block_hash, tx_hash, height,
+    group_key
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type InsertMonParams struct {
+	AssetID   []byte
+	Name      string
+	MonID     []byte
+	Scores    []byte
+	Metadata  []byte
+	BlockHash []byte
+	TxHash    []byte
+	Height    int64
+	GroupKey  []byte
+}
+
+func (q *Queries) InsertMon(ctx context.Context, arg InsertMonParams) error {
+	_, err := q.db.ExecContext(ctx, insertMon,
+		arg.AssetID,
+		arg.Name,
+		arg.MonID,
+		arg.Scores,
+		arg.Metadata,
+		arg.BlockHash,
+		arg.TxHash,
+		arg.Height,
+		arg.GroupKey,
+	)
+	return err
+}
+
+const insertMonParent = `-- name: InsertMonParent :exec
+INSERT INTO mon_parents (child_id, parent_id) VALUES (?, ?)
+`
+
+type InsertMonParentParams struct {
+	ChildID  []byte
+	ParentID []byte
+}
+
+func (q *Queries) InsertMonParent(ctx context.Context, arg InsertMonParentParams) error {
+	_, err := q.db.ExecContext(ctx, insertMonParent, arg.ChildID, arg.ParentID)
+	return err
+}
+
+const listCollectionMonsPage = `-- name: ListCollectionMonsPage :many
+SELECT asset_id, name, mon_id, scores, metadata, block_hash, tx_hash, height, reorged, group_key FROM mons
+WHERE group_key = ? AND (
+    (? AND (reorged OR height > ?))
+        OR (? AND NOT reorged
+            AND height BETWEEN ?
+            AND ?)
+        OR (? AND NOT reorged
+            AND height < ?)
+)
+ORDER BY height, asset_id
+LIMIT ? OFFSET ?
+`
+
+type ListCollectionMonsPageParams struct {
+	GroupKey           []byte
+	Pending            bool
+	TipHeight          int64
+	Tentative          bool
+	TentativeHeight    int64
+	MaxTentativeHeight int64
+	Final              bool
+	FinalHeight        int64
+	PageLimit          int64
+	PageOffset         int64
+}
+
+func (q *Queries) ListCollectionMonsPage(ctx context.Context, arg ListCollectionMonsPageParams) ([]Mon, error) {
+	rows, err := q.db.QueryContext(ctx, listCollectionMonsPage,
+		arg.GroupKey,
+		arg.Pending,
+		arg.TipHeight,
+		arg.Tentative,
+		arg.TentativeHeight,
+		arg.MaxTentativeHeight,
+		arg.Final,
+		arg.FinalHeight,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Mon
+	for rows.Next() {
+		var i Mon
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.Name,
+			&i.MonID,
+			&i.Scores,
+			&i.Metadata,
+			&i.BlockHash,
+			&i.TxHash,
+			&i.Height,
+			&i.Reorged,
+			&i.GroupKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMons = `-- name: ListMons :many
+SELECT asset_id, name, mon_id, scores, metadata, block_hash, tx_hash, height, reorged, group_key FROM mons WHERE height >= ? ORDER BY height, asset_id
+`
+
+func (q *Queries) ListMons(ctx context.Context, height int64) ([]Mon, error) {
+	rows, err := q.db.QueryContext(ctx, listMons, height)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Mon
+	for rows.Next() {
+		var i Mon
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.Name,
+			&i.MonID,
+			&i.Scores,
+			&i.Metadata,
+			&i.BlockHash,
+			&i.TxHash,
+			&i.Height,
+			&i.Reorged,
+			&i.GroupKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonsByName = `-- name: ListMonsByName :many
+SELECT asset_id, name, mon_id, scores, metadata, block_hash, tx_hash, height, reorged, group_key FROM mons WHERE lower(name) = ? ORDER BY height, asset_id
+`
+
+func (q *Queries) ListMonsByName(ctx context.Context, lower string) ([]Mon, error) {
+	rows, err := q.db.QueryContext(ctx, listMonsByName, lower)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Mon
+	for rows.Next() {
+		var i Mon
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.Name,
+			&i.MonID,
+			&i.Scores,
+			&i.Metadata,
+			&i.BlockHash,
+			&i.TxHash,
+			&i.Height,
+			&i.Reorged,
+			&i.GroupKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonsPage = `-- name: ListMonsPage :many
+SELECT asset_id, name, mon_id, scores, metadata, block_hash, tx_hash, height, reorged, group_key FROM mons
+WHERE (? AND (reorged OR height > ?))
+    OR (? AND NOT reorged
+        AND height BETWEEN ?
+        AND ?)
+    OR (? AND NOT reorged
+        AND height < ?)
+ORDER BY height, asset_id
+LIMIT ? OFFSET ?
+`
+
+type ListMonsPageParams struct {
+	Pending            bool
+	TipHeight          int64
+	Tentative          bool
+	TentativeHeight    int64
+	MaxTentativeHeight int64
+	Final              bool
+	FinalHeight        int64
+	PageLimit          int64
+	PageOffset         int64
+}
+
+func (q *Queries) ListMonsPage(ctx context.Context, arg ListMonsPageParams) ([]Mon, error) {
+	rows, err := q.db.QueryContext(ctx, listMonsPage,
+		arg.Pending,
+		arg.TipHeight,
+		arg.Tentative,
+		arg.TentativeHeight,
+		arg.MaxTentativeHeight,
+		arg.Final,
+		arg.FinalHeight,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Mon
+	for rows.Next() {
+		var i Mon
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.Name,
+			&i.MonID,
+			&i.Scores,
+			&i.Metadata,
+			&i.BlockHash,
+			&i.TxHash,
+			&i.Height,
+			&i.Reorged,
+			&i.GroupKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setMonReorged = `-- name: SetMonReorged :exec
+UPDATE mons SET reorged = TRUE WHERE asset_id = ?
+`
+
+func (q *Queries) SetMonReorged(ctx context.Context, assetID []byte) error {
+	_, err := q.db.ExecContext(ctx, setMonReorged, assetID)
+	return err
+}
+
+const updateMon = `-- name: UpdateMon :exec
+UPDATE mons
+SET mon_id = ?, scores = ?, block_hash = ?, tx_hash = ?, height = ?,
+    reorged = FALSE
+WHERE asset_id = ?
+`
+
+type UpdateMonParams struct {
+	MonID     []byte
+	Scores    []byte
+	BlockHash []byte
+	TxHash    []byte
+	Height    int64
+	AssetID   []byte
+}
+
+func (q *Queries) UpdateMon(ctx context.Context, arg UpdateMonParams) error {
+	_, err := q.db.ExecContext(ctx, updateMon,
+		arg.MonID,
+		arg.Scores,
+		arg.BlockHash,
+		arg.TxHash,
+		arg.Height,
+		arg.AssetID,
+	)
+	return err
+}
@@ -9,7 +9,41 @@ import (
 )
 
 type Querier interface {
+	CountCollectionMons(ctx context.Context, arg CountCollectionMonsParams) (int64, error)
+	CountMons(ctx context.Context, arg CountMonsParams) (int64, error)
+	GetAnte(ctx context.Context, arg GetAnteParams) (Ante, error)
 	GetKnownMons(ctx context.Context) ([]KnownMon, error)
+	GetLastBred(ctx context.Context, arg GetLastBredParams) (int64, error)
+	GetMon(ctx context.Context, assetID []byte) (Mon, error)
+	GetMonByMonId(ctx context.Context, monID []byte) (Mon, error)
+	GetMonChildren(ctx context.Context, parentID []byte) ([][]byte, error)
+	GetMonCollection(ctx context.Context, name string) (MonCollection, error)
+	GetRating(ctx context.Context, arg GetRatingParams) (Rating, error)
+	GetRatingHistory(ctx context.Context, arg GetRatingHistoryParams) ([]RatingHistory, error)
+	GetWager(ctx context.Context, matchID string) (Wager, error)
+	InsertAnte(ctx context.Context, arg InsertAnteParams) error
+	InsertMon(ctx context.Context, arg InsertMonParams) error
+	InsertMonCollection(ctx context.Context, arg InsertMonCollectionParams) error
+	InsertMonLevelUp(ctx context.Context, arg InsertMonLevelUpParams) error
+	InsertMonParent(ctx context.Context, arg InsertMonParentParams) error
+	InsertRatingHistory(ctx context.Context, arg InsertRatingHistoryParams) error
+	InsertWager(ctx context.Context, arg InsertWagerParams) error
+	IsMatchRated(ctx context.Context, matchID string) (int64, error)
+	ListAntes(ctx context.Context) ([]Ante, error)
+	ListCollectionMonsPage(ctx context.Context, arg ListCollectionMonsPageParams) ([]Mon, error)
+	ListMons(ctx context.Context, height int64) ([]Mon, error)
+	ListMonsByName(ctx context.Context, lower string) ([]Mon, error)
+	ListMonsPage(ctx context.Context, arg ListMonsPageParams) ([]Mon, error)
+	ListMonLevelUps(ctx context.Context, assetID []byte) ([]MonLevelUp, error)
+	ListMonOwners(ctx context.Context, assetID []byte) ([]MonOwner, error)
+	ListRatings(ctx context.Context, arg ListRatingsParams) ([]Rating, error)
+	ListWagers(ctx context.Context) ([]Wager, error)
+	SetMonReorged(ctx context.Context, assetID []byte) error
+	UpdateAnte(ctx context.Context, arg UpdateAnteParams) error
+	UpdateMon(ctx context.Context, arg UpdateMonParams) error
+	UpdateWager(ctx context.Context, arg UpdateWagerParams) error
+	UpsertMonOwner(ctx context.Context, arg UpsertMonOwnerParams) error
+	UpsertRating(ctx context.Context, arg UpsertRatingParams) error
 }
 
 var _ Querier = (*Queries)(nil)
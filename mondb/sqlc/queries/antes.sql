@@ -0,0 +1,16 @@
+-- name: InsertAnte :exec
+INSERT INTO antes (
+    match_id, player, escrow, asset_id, refund_addr, deposit_addr,
+    claim_addr, state, transfer_txid, expiry
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateAnte :exec
+UPDATE antes
+SET deposit_addr = ?, claim_addr = ?, state = ?, transfer_txid = ?
+WHERE match_id = ? AND player = ?;
+
+-- name: GetAnte :one
+SELECT * FROM antes WHERE match_id = ? AND player = ?;
+
+-- name: ListAntes :many
+SELECT * FROM antes ORDER BY expiry;
@@ -0,0 +1,5 @@
+-- name: InsertMonCollection :exec
+INSERT INTO mon_collections (name, group_key) VALUES (?, ?);
+
+-- name: GetMonCollection :one
+SELECT * FROM mon_collections WHERE name = ?;
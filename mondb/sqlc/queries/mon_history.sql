@@ -0,0 +1,19 @@
+-- name: UpsertMonOwner :exec
+INSERT INTO mon_owners (
+    asset_id, script_key, anchor_outpoint, block_hash, height, block_time
+) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (asset_id, anchor_outpoint) DO UPDATE SET
+    script_key = excluded.script_key, block_hash = excluded.block_hash,
+    height = excluded.height, block_time = excluded.block_time;
+
+-- name: ListMonOwners :many
+SELECT * FROM mon_owners WHERE asset_id = ? ORDER BY height, id;
+
+-- name: InsertMonLevelUp :exec
+INSERT INTO mon_level_ups (
+    asset_id, level, nonce, height, leveled_at
+) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (asset_id, level) DO NOTHING;
+
+-- name: ListMonLevelUps :many
+SELECT * FROM mon_level_ups WHERE asset_id = ? ORDER BY id;
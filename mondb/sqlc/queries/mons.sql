@@ -1,2 +1,84 @@
 -- name: GetKnownMons :many
-SELECT * FROM known_mons;
\ No newline at end of file
+SELECT * FROM known_mons;
+
+-- name: InsertMon :exec
+INSERT INTO mons (
+    asset_id, name, mon_id, scores, metadata, block_hash, tx_hash, height,
+    group_key
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: InsertMonParent :exec
+INSERT INTO mon_parents (child_id, parent_id) VALUES (?, ?);
+
+-- name: GetMon :one
+SELECT * FROM mons WHERE asset_id = ?;
+
+-- name: GetMonByMonId :one
+SELECT * FROM mons WHERE mon_id = ?;
+
+-- name: GetLastBred :one
+SELECT CAST(COALESCE(MAX(mons.height), 0) AS INTEGER)
+FROM mon_parents JOIN mons ON mons.asset_id = mon_parents.child_id
+WHERE mon_parents.parent_id = ? AND mons.height <= ? AND NOT mons.reorged;
+
+-- name: UpdateMon :exec
+UPDATE mons
+SET mon_id = ?, scores = ?, block_hash = ?, tx_hash = ?, height = ?,
+    reorged = FALSE
+WHERE asset_id = ?;
+
+-- name: SetMonReorged :exec
+UPDATE mons SET reorged = TRUE WHERE asset_id = ?;
+
+-- name: ListMons :many
+SELECT * FROM mons WHERE height >= ? ORDER BY height, asset_id;
+
+-- name: ListMonsPage :many
+SELECT * FROM mons
+WHERE (sqlc.arg(pending) AND (reorged OR height > sqlc.arg(tip_height)))
+    OR (sqlc.arg(tentative) AND NOT reorged
+        AND height BETWEEN sqlc.arg(tentative_height)
+        AND sqlc.arg(max_tentative_height))
+    OR (sqlc.arg(final) AND NOT reorged
+        AND height < sqlc.arg(final_height))
+ORDER BY height, asset_id
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountMons :one
+SELECT COUNT(*) FROM mons
+WHERE (sqlc.arg(pending) AND (reorged OR height > sqlc.arg(tip_height)))
+    OR (sqlc.arg(tentative) AND NOT reorged
+        AND height BETWEEN sqlc.arg(tentative_height)
+        AND sqlc.arg(max_tentative_height))
+    OR (sqlc.arg(final) AND NOT reorged
+        AND height < sqlc.arg(final_height));
+
+-- name: ListCollectionMonsPage :many
+SELECT * FROM mons
+WHERE group_key = sqlc.arg(group_key) AND (
+    (sqlc.arg(pending) AND (reorged OR height > sqlc.arg(tip_height)))
+        OR (sqlc.arg(tentative) AND NOT reorged
+            AND height BETWEEN sqlc.arg(tentative_height)
+            AND sqlc.arg(max_tentative_height))
+        OR (sqlc.arg(final) AND NOT reorged
+            AND height < sqlc.arg(final_height))
+)
+ORDER BY height, asset_id
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountCollectionMons :one
+SELECT COUNT(*) FROM mons
+WHERE group_key = sqlc.arg(group_key) AND (
+    (sqlc.arg(pending) AND (reorged OR height > sqlc.arg(tip_height)))
+        OR (sqlc.arg(tentative) AND NOT reorged
+            AND height BETWEEN sqlc.arg(tentative_height)
+            AND sqlc.arg(max_tentative_height))
+        OR (sqlc.arg(final) AND NOT reorged
+            AND height < sqlc.arg(final_height))
+);
+
+-- name: ListMonsByName :many
+SELECT * FROM mons WHERE lower(name) = ? ORDER BY height, asset_id;
+
+-- name: GetMonChildren :many
+SELECT child_id FROM mon_parents WHERE parent_id = ? ORDER BY child_id;
@@ -0,0 +1,27 @@
+-- name: GetRating :one
+SELECT * FROM ratings WHERE kind = ? AND subject = ?;
+
+-- name: UpsertRating :exec
+INSERT INTO ratings (
+    kind, subject, rating, games, wins, losses
+) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (kind, subject) DO UPDATE SET
+    rating = excluded.rating, games = excluded.games, wins = excluded.wins,
+    losses = excluded.losses;
+
+-- name: ListRatings :many
+SELECT * FROM ratings
+WHERE kind = ?
+ORDER BY rating DESC, subject
+LIMIT ? OFFSET ?;
+
+-- name: InsertRatingHistory :exec
+INSERT INTO rating_history (
+    kind, subject, match_id, rating, played_at
+) VALUES (?, ?, ?, ?, ?);
+
+-- name: GetRatingHistory :many
+SELECT * FROM rating_history WHERE kind = ? AND subject = ? ORDER BY id;
+
+-- name: IsMatchRated :one
+SELECT EXISTS (SELECT 1 FROM rating_history WHERE match_id = ?);
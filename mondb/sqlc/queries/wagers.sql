@@ -0,0 +1,14 @@
+-- name: InsertWager :exec
+INSERT INTO wagers (
+    match_id, amount_sat, payment_hash, preimage, invoice, opponent_invoice,
+    state, expiry
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateWager :exec
+UPDATE wagers SET opponent_invoice = ?, state = ?, won = ? WHERE match_id = ?;
+
+-- name: GetWager :one
+SELECT * FROM wagers WHERE match_id = ?;
+
+-- name: ListWagers :many
+SELECT * FROM wagers ORDER BY expiry;
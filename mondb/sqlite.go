@@ -0,0 +1,112 @@
+package mondb
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/golang-migrate/migrate/v4"
+	sqlite_migrate "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/tapmon/tapmond/mondb/sqlc"
+	_ "modernc.org/sqlite" // Register the sqlite driver.
+)
+
+// sqliteOptionPrefix is the string prefix sqlite uses to set pragma
+// options in the dsn.
+const sqliteOptionPrefix = "_pragma"
+
+//go:embed sqlc/migrations/*.up.sql
+var sqlSchemas embed.FS
+
+// SqliteStore is the sqlite database of tapmond.
+type SqliteStore struct {
+	*sql.DB
+	*sqlc.Queries
+}
+
+// NewSqliteStore opens the sqlite database at the given path and applies
+// all migrations.
+func NewSqliteStore(path string) (*SqliteStore, error) {
+	pragmaOptions := []struct {
+		name  string
+		value string
+	}{
+		{name: "foreign_keys", value: "on"},
+		{name: "journal_mode", value: "WAL"},
+		{name: "busy_timeout", value: "5000"},
+	}
+	sqliteOptions := make(url.Values)
+	for _, option := range pragmaOptions {
+		sqliteOptions.Add(
+			sqliteOptionPrefix,
+			fmt.Sprintf("%v=%v", option.name, option.value),
+		)
+	}
+
+	dsn := fmt.Sprintf("%v?%v", path, sqliteOptions.Encode())
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateSqlite(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to migrate database: %w", err)
+	}
+
+	return &SqliteStore{
+		DB:      db,
+		Queries: sqlc.New(db),
+	}, nil
+}
+
+// ExecTx runs the given function in a single transaction, which is
+// committed if the function succeeds and rolled back otherwise.
+func (s *SqliteStore) ExecTx(ctx context.Context,
+	txBody func(*sqlc.Queries) error) error {
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := txBody(s.Queries.WithTx(tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// migrateSqlite applies all embedded migrations that weren't applied yet.
+func migrateSqlite(db *sql.DB) error {
+	driver, err := sqlite_migrate.WithInstance(
+		db, &sqlite_migrate.Config{},
+	)
+	if err != nil {
+		return err
+	}
+
+	source, err := iofs.New(sqlSchemas, "sqlc/migrations")
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migrate.NewWithInstance(
+		"migrations", source, "sqlite", driver,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = migrator.Up()
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	return nil
+}
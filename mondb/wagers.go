@@ -0,0 +1,117 @@
+package mondb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/tapmon/tapmond/fightmons"
+	"github.com/tapmon/tapmond/mondb/sqlc"
+)
+
+// WagerStore persists the wagers of our matches.
+type WagerStore struct {
+	db *SqliteStore
+}
+
+// A compile time check to ensure WagerStore implements the
+// fightmons.WagerStore interface.
+var _ fightmons.WagerStore = (*WagerStore)(nil)
+
+// NewWagerStore creates a new wager store on top of the database.
+func NewWagerStore(db *SqliteStore) *WagerStore {
+	return &WagerStore{
+		db: db,
+	}
+}
+
+// AddWager adds a new wager.
+func (s *WagerStore) AddWager(ctx context.Context,
+	wager *fightmons.Wager) error {
+
+	return s.db.InsertWager(ctx, sqlc.InsertWagerParams{
+		MatchID:         wager.MatchId,
+		AmountSat:       int64(wager.AmountSat),
+		PaymentHash:     wager.PaymentHash[:],
+		Preimage:        wager.Preimage[:],
+		Invoice:         wager.Invoice,
+		OpponentInvoice: wager.OpponentInvoice,
+		State:           int64(wager.State),
+		Expiry:          wager.Expiry.Unix(),
+	})
+}
+
+// UpdateWager updates the opponent invoice, state and outcome of a wager.
+func (s *WagerStore) UpdateWager(ctx context.Context,
+	wager *fightmons.Wager) error {
+
+	return s.db.Queries.UpdateWager(ctx, sqlc.UpdateWagerParams{
+		OpponentInvoice: wager.OpponentInvoice,
+		State:           int64(wager.State),
+		Won:             wager.Won,
+		MatchID:         wager.MatchId,
+	})
+}
+
+// GetWager returns the wager of a match.
+func (s *WagerStore) GetWager(ctx context.Context,
+	matchId string) (*fightmons.Wager, error) {
+
+	row, err := s.db.Queries.GetWager(ctx, matchId)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fightmons.ErrUnknownWager
+
+	case err != nil:
+		return nil, err
+	}
+
+	return wagerFromRow(row)
+}
+
+// ListWagers returns all wagers ordered by their expiry.
+func (s *WagerStore) ListWagers(ctx context.Context) ([]*fightmons.Wager,
+	error) {
+
+	rows, err := s.db.Queries.ListWagers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wagers := make([]*fightmons.Wager, 0, len(rows))
+	for _, row := range rows {
+		wager, err := wagerFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		wagers = append(wagers, wager)
+	}
+
+	return wagers, nil
+}
+
+func wagerFromRow(row sqlc.Wager) (*fightmons.Wager, error) {
+	hash, err := lntypes.MakeHash(row.PaymentHash)
+	if err != nil {
+		return nil, err
+	}
+	preimage, err := lntypes.MakePreimage(row.Preimage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fightmons.Wager{
+		MatchId:         row.MatchID,
+		AmountSat:       btcutil.Amount(row.AmountSat),
+		PaymentHash:     hash,
+		Preimage:        preimage,
+		Invoice:         row.Invoice,
+		OpponentInvoice: row.OpponentInvoice,
+		State:           fightmons.WagerState(row.State),
+		Won:             row.Won,
+		Expiry:          time.Unix(row.Expiry, 0),
+	}, nil
+}
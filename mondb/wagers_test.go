@@ -0,0 +1,63 @@
+package mondb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/fightmons"
+)
+
+func newTestStore(t *testing.T) *SqliteStore {
+	db, err := NewSqliteStore(filepath.Join(t.TempDir(), "tapmon.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
+
+// TestWagerStore tests that wagers are persisted and updated.
+func TestWagerStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewWagerStore(newTestStore(t))
+
+	_, err := store.GetWager(ctx, "match")
+	require.ErrorIs(t, err, fightmons.ErrUnknownWager)
+
+	preimage := lntypes.Preimage{1, 2, 3}
+	wager := &fightmons.Wager{
+		MatchId:     "match",
+		AmountSat:   1000,
+		PaymentHash: preimage.Hash(),
+		Preimage:    preimage,
+		Invoice:     "invoice",
+		State:       fightmons.WagerOffered,
+		Expiry:      time.Unix(1700000000, 0),
+	}
+	require.NoError(t, store.AddWager(ctx, wager))
+	require.Error(t, store.AddWager(ctx, wager))
+
+	stored, err := store.GetWager(ctx, "match")
+	require.NoError(t, err)
+	require.Equal(t, wager, stored)
+
+	wager.OpponentInvoice = "opponent"
+	wager.Won = true
+	require.NoError(t, store.UpdateWager(ctx, wager))
+
+	stored, err = store.GetWager(ctx, "match")
+	require.NoError(t, err)
+	require.Equal(t, wager, stored)
+
+	wager.State = fightmons.WagerWon
+	require.NoError(t, store.UpdateWager(ctx, wager))
+
+	wagers, err := store.ListWagers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*fightmons.Wager{wager}, wagers)
+}
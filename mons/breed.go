@@ -0,0 +1,81 @@
+package mons
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+const (
+	// BaseBreedCooldown is the number of blocks a mon of the first
+	// generation has to wait after it was minted or bred before it can
+	// breed again.
+	BaseBreedCooldown = 144
+
+	// maxCooldownDoublings is the generation from which on the breeding
+	// cooldown doesn't double anymore.
+	maxCooldownDoublings = 6
+
+	// mutationThreshold is the byte of the mutation hash below which an
+	// attribute of a child mutates instead of being inherited, so one in
+	// 16 attributes mutates on average.
+	mutationThreshold = 16
+)
+
+// BreedCooldown returns the number of blocks a mon of the given generation
+// has to wait after it was minted or bred before it can breed again. Every
+// generation waits twice as long as its parents, up to a limit.
+func BreedCooldown(generation uint32) uint32 {
+	if generation > maxCooldownDoublings {
+		generation = maxCooldownDoublings
+	}
+
+	return BaseBreedCooldown << generation
+}
+
+// BreedMonster derives the child of two mons from the block and tx hash its
+// asset was anchored in. The id of the child is derived like that of any
+// other mon, but its scores are inherited from its parents: every attribute
+// is taken from one of the parents, picked by the id, unless it mutates into
+// a score of the id itself.
+func BreedMonster(blockHash, txHash *chainhash.Hash,
+	parentA, parentB *Mon) (*Mon, error) {
+
+	if len(parentA.Scores) != len(parentB.Scores) {
+		return nil, fmt.Errorf("parents have %d and %d attributes",
+			len(parentA.Scores), len(parentB.Scores))
+	}
+
+	combinedHash, err := combineAndHash(blockHash, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mon{
+		Id:     combinedHash,
+		Scores: inheritScores(combinedHash, parentA, parentB),
+	}, nil
+}
+
+// inheritScores derives the scores of the child with the given id from the
+// scores of its parents.
+func inheritScores(id []byte, parentA, parentB *Mon) []uint8 {
+	mutations := sha256.Sum256(id)
+
+	scores := make([]uint8, len(parentA.Scores))
+	for i := range scores {
+		switch {
+		case mutations[i%len(mutations)] < mutationThreshold:
+			scores[i] = id[i%len(id)]
+
+		case id[i%len(id)]&1 == 0:
+			scores[i] = parentA.Scores[i]
+
+		default:
+			scores[i] = parentB.Scores[i]
+		}
+	}
+
+	return scores
+}
@@ -0,0 +1,588 @@
+package mons
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// memMonStore is a mon store in memory.
+type memMonStore struct {
+	mons        map[string]*IndexedMon
+	owners      map[string][]*MonOwner
+	levelUps    map[string][]*MonLevelUp
+	collections map[string]*MonCollection
+}
+
+func newMemMonStore() *memMonStore {
+	return &memMonStore{
+		mons:        make(map[string]*IndexedMon),
+		owners:      make(map[string][]*MonOwner),
+		levelUps:    make(map[string][]*MonLevelUp),
+		collections: make(map[string]*MonCollection),
+	}
+}
+
+func (s *memMonStore) AddMon(_ context.Context, mon *IndexedMon) error {
+	s.mons[string(mon.AssetId)] = mon
+	return nil
+}
+
+func (s *memMonStore) GetMon(_ context.Context,
+	assetId []byte) (*IndexedMon, error) {
+
+	return s.mons[string(assetId)], nil
+}
+
+func (s *memMonStore) GetMonById(_ context.Context,
+	monId []byte) (*IndexedMon, error) {
+
+	for _, mon := range s.mons {
+		if bytes.Equal(mon.Mon.Id, monId) {
+			return mon, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *memMonStore) LastBred(_ context.Context, assetId []byte,
+	height uint32) (uint32, error) {
+
+	var last uint32
+	for _, mon := range s.mons {
+		if mon.Reorged {
+			continue
+		}
+		for _, parent := range mon.Metadata.Parents {
+			if parent == hex.EncodeToString(assetId) &&
+				mon.Height <= height && mon.Height > last {
+
+				last = mon.Height
+			}
+		}
+	}
+
+	return last, nil
+}
+
+func (s *memMonStore) ListMons(_ context.Context,
+	minHeight uint32) ([]*IndexedMon, error) {
+
+	var mons []*IndexedMon
+	for _, mon := range s.mons {
+		if mon.Height >= minHeight {
+			mons = append(mons, mon)
+		}
+	}
+	sort.Slice(mons, func(i, j int) bool {
+		if mons[i].Height != mons[j].Height {
+			return mons[i].Height < mons[j].Height
+		}
+
+		return bytes.Compare(mons[i].AssetId, mons[j].AssetId) < 0
+	})
+
+	return mons, nil
+}
+
+func (s *memMonStore) ListMonPage(ctx context.Context,
+	query *MonQuery) ([]*IndexedMon, error) {
+
+	all, err := s.ListMons(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var mons []*IndexedMon
+	for _, mon := range all {
+		if query.matches(mon) {
+			mons = append(mons, mon)
+		}
+	}
+
+	return pageMons(mons, query.Offset, query.Limit), nil
+}
+
+func (s *memMonStore) CountMons(ctx context.Context,
+	query *MonQuery) (int, error) {
+
+	all, err := s.ListMons(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, mon := range all {
+		if query.matches(mon) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *memMonStore) ListMonsByName(ctx context.Context,
+	nameKey string) ([]*IndexedMon, error) {
+
+	all, err := s.ListMons(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var mons []*IndexedMon
+	for _, mon := range all {
+		if strings.ToLower(mon.Name) == nameKey {
+			mons = append(mons, mon)
+		}
+	}
+
+	return mons, nil
+}
+
+func (s *memMonStore) GetChildren(_ context.Context,
+	assetId []byte) ([][]byte, error) {
+
+	var children [][]byte
+	for _, mon := range s.mons {
+		for _, parent := range mon.Metadata.Parents {
+			if parent == hex.EncodeToString(assetId) {
+				children = append(children, mon.AssetId)
+			}
+		}
+	}
+
+	return children, nil
+}
+
+func (s *memMonStore) MarkReorged(_ context.Context,
+	assetIds [][]byte) error {
+
+	for _, assetId := range assetIds {
+		s.mons[string(assetId)].Reorged = true
+	}
+
+	return nil
+}
+
+func (s *memMonStore) UpdateMon(_ context.Context, mon *IndexedMon) error {
+	s.mons[string(mon.AssetId)] = mon
+	return nil
+}
+
+func (s *memMonStore) AddOwner(_ context.Context, owner *MonOwner) error {
+	owners := s.owners[string(owner.AssetId)]
+	for i, existing := range owners {
+		if existing.AnchorOutpoint == owner.AnchorOutpoint {
+			owners[i] = owner
+			return nil
+		}
+	}
+	owners = append(owners, owner)
+	sort.SliceStable(owners, func(i, j int) bool {
+		return owners[i].Height < owners[j].Height
+	})
+	s.owners[string(owner.AssetId)] = owners
+
+	return nil
+}
+
+func (s *memMonStore) ListOwners(_ context.Context,
+	assetId []byte) ([]*MonOwner, error) {
+
+	return s.owners[string(assetId)], nil
+}
+
+func (s *memMonStore) AddLevelUp(_ context.Context,
+	levelUp *MonLevelUp) error {
+
+	for _, existing := range s.levelUps[string(levelUp.AssetId)] {
+		if existing.Level == levelUp.Level {
+			return nil
+		}
+	}
+	s.levelUps[string(levelUp.AssetId)] = append(
+		s.levelUps[string(levelUp.AssetId)], levelUp,
+	)
+
+	return nil
+}
+
+func (s *memMonStore) ListLevelUps(_ context.Context,
+	assetId []byte) ([]*MonLevelUp, error) {
+
+	return s.levelUps[string(assetId)], nil
+}
+
+func (s *memMonStore) AddCollection(_ context.Context,
+	collection *MonCollection) error {
+
+	if _, ok := s.collections[collection.Name]; ok {
+		return fmt.Errorf("collection %q exists", collection.Name)
+	}
+	s.collections[collection.Name] = collection
+
+	return nil
+}
+
+func (s *memMonStore) GetCollection(_ context.Context,
+	name string) (*MonCollection, error) {
+
+	return s.collections[name], nil
+}
+
+// memChain is a chain in memory whose block at every height has the height
+// as its hash unless it was reorged.
+type memChain struct {
+	lndclient.ChainKitClient
+
+	reorged map[int64]chainhash.Hash
+	blocks  map[chainhash.Hash]*wire.MsgBlock
+	tip     int32
+}
+
+func newMemChain() *memChain {
+	return &memChain{
+		reorged: make(map[int64]chainhash.Hash),
+	}
+}
+
+func (c *memChain) GetBlockHash(_ context.Context,
+	height int64) (chainhash.Hash, error) {
+
+	if hash, ok := c.reorged[height]; ok {
+		return hash, nil
+	}
+
+	return chainhash.Hash{byte(height)}, nil
+}
+
+// GetBlockHeader returns a header whose timestamp is ten minutes per block
+// after the first byte of the hash.
+func (c *memChain) GetBlockHeader(_ context.Context,
+	hash chainhash.Hash) (*wire.BlockHeader, error) {
+
+	return &wire.BlockHeader{
+		Timestamp: time.Unix(int64(hash[0])*600, 0),
+	}, nil
+}
+
+func (c *memChain) GetBestBlock(ctx context.Context) (chainhash.Hash, int32,
+	error) {
+
+	hash, err := c.GetBlockHash(ctx, int64(c.tip))
+	return hash, c.tip, err
+}
+
+// testPolicy is the finality policy of the tests, whose mons stay tentative
+// for long enough to be reorged.
+var testPolicy = FinalityPolicy{
+	MintConfirmations:  1,
+	FinalConfirmations: 288,
+}
+
+// testCreator is the creator of the mons of the tests, the public key of the
+// generator point.
+const testCreator = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959" +
+	"f2815b16f81798"
+
+// testMon returns a mon to index with the given asset id minted at the
+// given height with the given parents.
+func testMon(assetId byte, height uint32, generation uint32,
+	parents ...byte) *IndexedMon {
+
+	name := fmt.Sprintf("mon %d", assetId)
+	meta := &MonMetadata{
+		Namespace:     MonNamespace,
+		MonVersion:    MonVersion,
+		Name:          name,
+		Creator:       testCreator,
+		Generation:    generation,
+		BreedCooldown: BreedCooldown(generation),
+	}
+	for _, parent := range parents {
+		meta.Parents = append(
+			meta.Parents, hex.EncodeToString([]byte{parent}),
+		)
+	}
+
+	return &IndexedMon{
+		AssetId:   []byte{assetId},
+		Name:      name,
+		Metadata:  meta,
+		BlockHash: chainhash.Hash{byte(height)},
+		TxHash:    chainhash.Hash{assetId},
+		Height:    height,
+	}
+}
+
+// TestBreedMonster tests that a child is derived deterministically from its
+// anchor and inherits most of its scores from its parents.
+func TestBreedMonster(t *testing.T) {
+	parentA, err := GenerateMonster(&testBlockHash, &chainhash.Hash{1})
+	require.NoError(t, err)
+	parentB, err := GenerateMonster(&testBlockHash, &chainhash.Hash{2})
+	require.NoError(t, err)
+
+	child, err := BreedMonster(
+		&testBlockHash, &chainhash.Hash{3}, parentA, parentB,
+	)
+	require.NoError(t, err)
+
+	again, err := BreedMonster(
+		&testBlockHash, &chainhash.Hash{3}, parentA, parentB,
+	)
+	require.NoError(t, err)
+	require.Equal(t, child, again)
+
+	sibling, err := BreedMonster(
+		&testBlockHash, &chainhash.Hash{4}, parentA, parentB,
+	)
+	require.NoError(t, err)
+	require.NotEqual(t, child.Id, sibling.Id)
+
+	var inherited int
+	for i, score := range child.Scores {
+		if score == parentA.Scores[i] || score == parentB.Scores[i] {
+			inherited++
+		}
+	}
+	require.Greater(t, inherited, len(child.Scores)/2)
+
+	_, err = BreedMonster(
+		&testBlockHash, &testTxHash, parentA,
+		&Mon{Scores: parentB.Scores[:1]},
+	)
+	require.Error(t, err)
+}
+
+// TestBreedCooldown tests that the breeding cooldown doubles with every
+// generation up to its limit.
+func TestBreedCooldown(t *testing.T) {
+	require.EqualValues(t, BaseBreedCooldown, BreedCooldown(0))
+	require.EqualValues(t, 2*BaseBreedCooldown, BreedCooldown(1))
+	require.Equal(
+		t, BreedCooldown(maxCooldownDoublings),
+		BreedCooldown(maxCooldownDoublings+1),
+	)
+}
+
+// TestDeriveMon tests that bred mons are only derived if they follow the
+// breeding rules.
+func TestDeriveMon(t *testing.T) {
+	ctx := context.Background()
+	store := newMemMonStore()
+
+	index := func(mon *IndexedMon) error {
+		var err error
+		mon.Mon, err = deriveMon(ctx, store, mon)
+		if err != nil {
+			return err
+		}
+
+		return store.AddMon(ctx, mon)
+	}
+
+	// Mint two mons of the first generation and a third one later.
+	require.NoError(t, index(testMon(1, 100, 0)))
+	require.NoError(t, index(testMon(2, 110, 0)))
+	require.NoError(t, index(testMon(3, 200, 0)))
+
+	// The parents need to be off their cooldown since they were minted.
+	err := index(testMon(10, 110+BaseBreedCooldown-1, 1, 1, 2))
+	require.ErrorContains(t, err, "cooldown")
+
+	child := testMon(10, 110+BaseBreedCooldown, 1, 1, 2)
+	require.NoError(t, index(child))
+
+	parentA, _ := store.GetMon(ctx, []byte{1})
+	parentB, _ := store.GetMon(ctx, []byte{2})
+	bred, err := BreedMonster(
+		&child.BlockHash, &child.TxHash, parentA.Mon, parentB.Mon,
+	)
+	require.NoError(t, err)
+	require.Equal(t, bred, child.Mon)
+
+	// Breeding again resets the cooldown of both parents.
+	err = index(testMon(11, 110+2*BaseBreedCooldown-1, 1, 1, 3))
+	require.ErrorContains(t, err, "cooldown")
+	require.NoError(t, index(testMon(11, 110+2*BaseBreedCooldown, 1, 1, 3)))
+
+	// A mon bred in the same block as another child of a parent is on
+	// cooldown.
+	err = index(testMon(12, 110+2*BaseBreedCooldown, 1, 2, 3))
+	require.ErrorContains(t, err, "cooldown")
+
+	// The generation follows from the highest generation of the parents.
+	height := uint32(110 + 2*BaseBreedCooldown + BreedCooldown(1))
+	err = index(testMon(13, height, 1, 10, 11))
+	require.ErrorContains(t, err, "generation")
+	require.NoError(t, index(testMon(13, height, 2, 10, 11)))
+
+	// The cooldown in the metadata must match the generation.
+	mon := testMon(14, 10000, 1, 1, 2)
+	mon.Metadata.BreedCooldown = BaseBreedCooldown
+	require.ErrorContains(t, index(mon), "cooldown")
+
+	// Parents must be known, distinct and minted before their child.
+	err = index(testMon(15, 10000, 1, 1, 99))
+	require.ErrorIs(t, err, ErrUnknownParent)
+	require.ErrorContains(t, index(testMon(15, 10000, 1, 1, 1)), "same")
+	require.ErrorContains(t, index(testMon(15, 10000, 1, 1)), "parents")
+	require.ErrorContains(t, index(testMon(15, 10000, 1)), "no parents")
+	require.ErrorContains(t, index(testMon(15, 50, 1, 1, 2)), "before")
+}
+
+// memTapd is a tapd that lists a fixed set of genesis assets.
+type memTapd struct {
+	taprpc.TaprootAssetsClient
+
+	assets    []*taprpc.Asset
+	metas     map[string]*taprpc.AssetMeta
+	transfers []*taprpc.AssetTransfer
+	proofs    map[string]*taprpc.Asset
+	addrs     map[string]*taprpc.Addr
+	receives  chan *taprpc.ReceiveEvent
+	files     map[string][]byte
+
+	// decodeErr is returned by DecodeProof if it's set.
+	decodeErr error
+}
+
+func (m *memTapd) ListAssets(_ context.Context, _ *taprpc.ListAssetRequest,
+	_ ...grpc.CallOption) (*taprpc.ListAssetResponse, error) {
+
+	return &taprpc.ListAssetResponse{Assets: m.assets}, nil
+}
+
+func (m *memTapd) FetchAssetMeta(_ context.Context,
+	req *taprpc.FetchAssetMetaRequest,
+	_ ...grpc.CallOption) (*taprpc.AssetMeta, error) {
+
+	return m.metas[string(req.GetAssetId())], nil
+}
+
+func (m *memTapd) ListTransfers(_ context.Context,
+	_ *taprpc.ListTransfersRequest,
+	_ ...grpc.CallOption) (*taprpc.ListTransfersResponse, error) {
+
+	return &taprpc.ListTransfersResponse{Transfers: m.transfers}, nil
+}
+
+func (m *memTapd) DecodeProof(_ context.Context,
+	req *taprpc.DecodeProofRequest,
+	_ ...grpc.CallOption) (*taprpc.DecodeProofResponse, error) {
+
+	if m.decodeErr != nil {
+		return nil, m.decodeErr
+	}
+
+	asset, ok := m.proofs[string(req.RawProof)]
+	if !ok {
+		return nil, fmt.Errorf("unknown proof")
+	}
+
+	decoded := &taprpc.DecodedProof{Asset: asset}
+	if req.WithMetaReveal && asset.AssetGenesis != nil {
+		decoded.MetaReveal = m.metas[string(asset.AssetGenesis.AssetId)]
+	}
+
+	return &taprpc.DecodeProofResponse{DecodedProof: decoded}, nil
+}
+
+// addMon adds the genesis asset of the mon to the tapd.
+func (m *memTapd) addMon(t *testing.T, mon *IndexedMon) {
+	m.assets = append(m.assets, genesisAsset(mon))
+	m.addMeta(t, mon)
+}
+
+// addMeta adds the metadata of the mon to the tapd.
+func (m *memTapd) addMeta(t *testing.T, mon *IndexedMon) {
+	data, err := mon.Metadata.Encode()
+	require.NoError(t, err)
+
+	m.metas[string(mon.AssetId)] = &taprpc.AssetMeta{
+		Type: taprpc.AssetMetaType_META_TYPE_JSON,
+		Data: data,
+	}
+}
+
+// genesisAsset returns the genesis asset of the mon.
+func genesisAsset(mon *IndexedMon) *taprpc.Asset {
+	return &taprpc.Asset{
+		AssetGenesis: &taprpc.GenesisInfo{
+			AssetId: mon.AssetId,
+			Name:    mon.Name,
+		},
+		ScriptKey: []byte{mon.AssetId[0]},
+		ChainAnchor: &taprpc.AnchorInfo{
+			AnchorBlockHash: mon.BlockHash.String(),
+			AnchorOutpoint:  mon.TxHash.String() + ":0",
+			BlockHeight:     mon.Height,
+		},
+		PrevWitnesses: []*taprpc.PrevWitness{{
+			PrevId: &taprpc.PrevInputAsset{
+				AssetId: make([]byte, 32),
+			},
+		}},
+	}
+}
+
+// TestIndexMons tests that the indexer indexes parents before their children
+// and skips assets that aren't mons or don't follow the breeding rules.
+func TestIndexMons(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{
+		metas: make(map[string]*taprpc.AssetMeta),
+	}
+	store := newMemMonStore()
+	manager := NewManager(
+		tapd, nil, nil, nil, newMemChain(), store, testPolicy, nil,
+	)
+
+	// The child is listed before its parents.
+	tapd.addMon(t, testMon(10, 110+BaseBreedCooldown, 1, 1, 2))
+	tapd.addMon(t, testMon(1, 100, 0))
+	tapd.addMon(t, testMon(2, 110, 0))
+	tapd.addMon(t, testMon(11, 120, 1, 1, 2))
+
+	tapd.assets = append(tapd.assets, &taprpc.Asset{
+		AssetGenesis: &taprpc.GenesisInfo{AssetId: []byte{20}},
+		ChainAnchor:  &taprpc.AnchorInfo{BlockHeight: 100},
+		PrevWitnesses: []*taprpc.PrevWitness{{
+			PrevId: &taprpc.PrevInputAsset{},
+		}},
+	})
+	tapd.metas[string([]byte{20})] = &taprpc.AssetMeta{
+		Type: taprpc.AssetMetaType_META_TYPE_JSON,
+		Data: []byte(`{"name": "no mon"}`),
+	}
+
+	// A mon whose metadata names another asset is rejected.
+	spoofed := testMon(3, 100, 0)
+	tapd.addMon(t, spoofed)
+	tapd.assets[len(tapd.assets)-1].AssetGenesis.Name = "mon 1"
+
+	require.NoError(t, manager.IndexMons(ctx))
+	require.Len(t, store.mons, 3)
+	require.Contains(t, store.mons, string([]byte{10}))
+	require.NotContains(t, store.mons, string([]byte{11}))
+	require.NotContains(t, store.mons, string([]byte{20}))
+	require.NotContains(t, store.mons, string([]byte{3}))
+
+	child := store.mons[string([]byte{10})]
+	require.EqualValues(t, 1, child.Metadata.Generation)
+	require.Equal(t, "mon 10", child.Name)
+}
@@ -0,0 +1,140 @@
+package mons
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/mintrpc"
+)
+
+var (
+	// ErrInvalidCollectionName is returned for collection names that
+	// don't follow the name policy.
+	ErrInvalidCollectionName = errors.New("invalid collection name")
+
+	// ErrUnknownCollection is returned when looking up a collection that
+	// we didn't mint into yet.
+	ErrUnknownCollection = errors.New("unknown collection")
+)
+
+// MonCollection is a collection or season of mons that we issue. The mons of
+// a collection are minted into the same asset group, so that its supply is
+// provably controlled by the key of the group.
+type MonCollection struct {
+	// Name is the name of the collection.
+	Name string
+
+	// GroupKey is the tweaked key of the asset group of the collection.
+	// It's unset until the first mon of the collection is minted, which
+	// creates the group.
+	GroupKey []byte
+}
+
+// ValidateCollectionName checks that a collection name follows the same
+// policy as the names of mons.
+func ValidateCollectionName(name string) error {
+	if err := ValidateMonName(name); err != nil {
+		return fmt.Errorf("%w: %q must consist of %d to %d letters "+
+			"and digits separated by single spaces or hyphens",
+			ErrInvalidCollectionName, name, MinMonNameLength,
+			MaxMonNameLength)
+	}
+
+	return nil
+}
+
+// GetCollection returns the collection we issue with the given name. It
+// returns ErrUnknownCollection if we didn't mint into it yet.
+func (m *Manager) GetCollection(ctx context.Context,
+	name string) (*MonCollection, error) {
+
+	collection, err := m.store.GetCollection(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownCollection, name)
+	}
+
+	return collection, nil
+}
+
+// mintCollection returns the collection to mint a mon into. A new collection
+// without a group key is returned if we didn't mint into it yet.
+func (m *Manager) mintCollection(ctx context.Context,
+	name string) (*MonCollection, error) {
+
+	if err := ValidateCollectionName(name); err != nil {
+		return nil, err
+	}
+
+	collection, err := m.store.GetCollection(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil {
+		return &MonCollection{Name: name}, nil
+	}
+
+	return collection, nil
+}
+
+// groupMintAsset sets up the asset to be minted into the group of the
+// collection. A new group is created if the collection has no group key yet.
+func groupMintAsset(asset *mintrpc.MintAsset, collection *MonCollection) {
+	if collection == nil {
+		return
+	}
+
+	if len(collection.GroupKey) == 0 {
+		asset.NewGroupedAsset = true
+		return
+	}
+	asset.GroupedAsset = true
+	asset.GroupKey = collection.GroupKey
+}
+
+// addCollection stores a new collection with the key of the group that was
+// created by minting the asset with the given name in the given batch.
+func (m *Manager) addCollection(ctx context.Context,
+	collection *MonCollection, assetName string,
+	batchHash *chainhash.Hash) error {
+
+	resp, err := m.tapClient.ListAssets(ctx, &taprpc.ListAssetRequest{})
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range resp.Assets {
+		if asset.AssetGenesis == nil || asset.ChainAnchor == nil ||
+			asset.AssetGroup == nil ||
+			asset.AssetGenesis.Name != assetName {
+
+			continue
+		}
+
+		outpoint, err := wire.NewOutPointFromString(
+			asset.ChainAnchor.AnchorOutpoint,
+		)
+		if err != nil || outpoint.Hash != *batchHash {
+			continue
+		}
+
+		collection.GroupKey = asset.AssetGroup.TweakedGroupKey
+		return m.store.AddCollection(ctx, collection)
+	}
+
+	return fmt.Errorf("no grouped asset %q minted in batch %v", assetName,
+		batchHash)
+}
+
+// inCollection returns true if the mon was minted into the group with the
+// given key, or any group if no key is given.
+func (i *IndexedMon) inCollection(groupKey []byte) bool {
+	return len(groupKey) == 0 || bytes.Equal(i.GroupKey, groupKey)
+}
@@ -0,0 +1,193 @@
+package mons
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/mintrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func (m *memTapd) GetInfo(_ context.Context, _ *taprpc.GetInfoRequest,
+	_ ...grpc.CallOption) (*taprpc.GetInfoResponse, error) {
+
+	return &taprpc.GetInfoResponse{LndIdentityPubkey: testCreator}, nil
+}
+
+// memMint mints the assets of each batch into the assets of a tapd, creating
+// new asset groups with sequential keys.
+type memMint struct {
+	mintrpc.MintClient
+
+	tapd    *memTapd
+	pending []*mintrpc.MintAsset
+	batches int
+	groups  int
+}
+
+func (m *memMint) MintAsset(_ context.Context, req *mintrpc.MintAssetRequest,
+	_ ...grpc.CallOption) (*mintrpc.MintAssetResponse, error) {
+
+	m.pending = append(m.pending, req.Asset)
+	return &mintrpc.MintAssetResponse{}, nil
+}
+
+func (m *memMint) FinalizeBatch(_ context.Context,
+	_ *mintrpc.FinalizeBatchRequest,
+	_ ...grpc.CallOption) (*mintrpc.FinalizeBatchResponse, error) {
+
+	m.batches++
+	batchHash := chainhash.Hash{0xba, byte(m.batches)}
+	for i, pending := range m.pending {
+		asset := &taprpc.Asset{
+			AssetGenesis: &taprpc.GenesisInfo{
+				AssetId: []byte{0xba, byte(m.batches), byte(i)},
+				Name:    pending.Name,
+			},
+			ChainAnchor: &taprpc.AnchorInfo{
+				AnchorOutpoint: fmt.Sprintf("%v:%d", batchHash,
+					i),
+			},
+		}
+		switch {
+		case pending.NewGroupedAsset:
+			m.groups++
+			asset.AssetGroup = &taprpc.AssetGroup{
+				TweakedGroupKey: []byte{0x02, byte(m.groups)},
+			}
+
+		case pending.GroupedAsset:
+			asset.AssetGroup = &taprpc.AssetGroup{
+				TweakedGroupKey: pending.GroupKey,
+			}
+		}
+		m.tapd.assets = append(m.tapd.assets, asset)
+	}
+	m.pending = nil
+
+	return &mintrpc.FinalizeBatchResponse{
+		Batch: &mintrpc.MintingBatch{
+			BatchTxid: batchHash.String(),
+		},
+	}, nil
+}
+
+// TestMintMonCollection tests that the first mon of a collection creates its
+// asset group and that later mons are minted into the same group.
+func TestMintMonCollection(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{
+		metas: make(map[string]*taprpc.AssetMeta),
+	}
+	mint := &memMint{tapd: tapd}
+	notifier := &memNotifier{height: 150}
+	store := newMemMonStore()
+	manager := NewManager(
+		tapd, mint, nil, notifier, newMemChain(), store, testPolicy,
+		nil,
+	)
+
+	_, err := manager.MintMon(ctx, "ember", "s")
+	require.ErrorIs(t, err, ErrInvalidCollectionName)
+	_, err = manager.GetCollection(ctx, "season 1")
+	require.ErrorIs(t, err, ErrUnknownCollection)
+
+	// Mons without a collection are minted ungrouped.
+	_, err = manager.MintMon(ctx, "ember", "")
+	require.NoError(t, err)
+	require.Nil(t, tapd.assets[0].AssetGroup)
+
+	_, err = manager.MintMon(ctx, "blaze", "season 1")
+	require.NoError(t, err)
+	collection, err := manager.GetCollection(ctx, "season 1")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x02, 1}, collection.GroupKey)
+
+	_, err = manager.MintMon(ctx, "drake", "season 1")
+	require.NoError(t, err)
+	grouped := tapd.assets[2].AssetGroup
+	require.Equal(t, collection.GroupKey, grouped.TweakedGroupKey)
+
+	_, err = manager.MintMon(ctx, "wyvern", "season 2")
+	require.NoError(t, err)
+	collection, err = manager.GetCollection(ctx, "season 2")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x02, 2}, collection.GroupKey)
+
+	// Concurrent first mints into a collection create a single group,
+	// even while the first one is confirming.
+	notifier.confDelay = 50 * time.Millisecond
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, name := range []string{"golem", "sprite"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := manager.MintMon(ctx, name, "season 3")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, mint.groups)
+	collection, err = manager.GetCollection(ctx, "season 3")
+	require.NoError(t, err)
+	for _, asset := range tapd.assets[4:] {
+		require.Equal(
+			t, collection.GroupKey, asset.AssetGroup.TweakedGroupKey,
+		)
+	}
+}
+
+// TestListMonsByCollection tests that indexed mons keep the key of the asset
+// group they were minted into and are listed by it.
+func TestListMonsByCollection(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{
+		metas: make(map[string]*taprpc.AssetMeta),
+	}
+	manager := NewManager(
+		tapd, nil, nil, nil, newMemChain(), newMemMonStore(),
+		testPolicy, nil,
+	)
+
+	groupKey := []byte{0x02, 1}
+	for _, mon := range []*IndexedMon{
+		testMon(1, 100, 0), testMon(2, 110, 0), testMon(3, 120, 0),
+	} {
+		tapd.addMon(t, mon)
+		if mon.AssetId[0] != 2 {
+			tapd.assets[len(tapd.assets)-1].AssetGroup =
+				&taprpc.AssetGroup{TweakedGroupKey: groupKey}
+		}
+	}
+	require.NoError(t, manager.IndexMons(ctx))
+
+	list := func(key []byte) []byte {
+		t.Helper()
+
+		infos, err := manager.ListMons(ctx, MonFilter{GroupKey: key})
+		require.NoError(t, err)
+
+		assetIds := make([]byte, 0, len(infos))
+		for _, info := range infos {
+			assetIds = append(assetIds, info.AssetId[0])
+		}
+
+		return assetIds
+	}
+
+	require.Equal(t, []byte{1, 2, 3}, list(nil))
+	require.Equal(t, []byte{1, 3}, list(groupKey))
+	require.Empty(t, list([]byte{0x02, 2}))
+}
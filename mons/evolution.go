@@ -0,0 +1,139 @@
+package mons
+
+import (
+	"fmt"
+	"math"
+)
+
+// Types are the types a mon can gain by evolving.
+var Types = []string{
+	"fire", "water", "earth", "air", "light", "shadow", "metal", "nature",
+}
+
+// Form is an evolution stage of a mon. A mon reaches a form once it proves a
+// level of at least the minimum level of the form.
+type Form struct {
+	// Stage is the number of the form, zero for the form a mon is minted
+	// with.
+	Stage int
+
+	// Name is the name of the form.
+	Name string
+
+	// MinLevel is the level from which on a mon takes the form.
+	MinLevel int
+
+	// Boost is added to every score of a mon in the form, up to the
+	// maximum score.
+	Boost uint8
+
+	// AddsType is set if the mon gains a type when reaching the form.
+	AddsType bool
+}
+
+// evolutionRules are the forms a mon evolves through by the version of its
+// metadata, ordered by their minimum levels. They are versioned together
+// with the breeding rules, so that the forms of existing mons never change.
+var evolutionRules = map[uint32][]Form{
+	1: {
+		{Stage: 0, Name: "hatchling", MinLevel: 0},
+		{Stage: 1, Name: "juvenile", MinLevel: 2, Boost: 10},
+		{
+			Stage: 2, Name: "adult", MinLevel: 4, Boost: 25,
+			AddsType: true,
+		},
+		{
+			Stage: 3, Name: "elder", MinLevel: 6, Boost: 40,
+			AddsType: true,
+		},
+	},
+}
+
+// EvolutionForms returns the forms of mons of the given version ordered by
+// their minimum levels.
+func EvolutionForms(version uint32) ([]Form, error) {
+	forms, ok := evolutionRules[version]
+	if !ok {
+		return nil, fmt.Errorf("no evolution rules for mon version %d",
+			version)
+	}
+
+	return forms, nil
+}
+
+// FormAt returns the form a mon of the given version takes at the level.
+func FormAt(version uint32, level int) (*Form, error) {
+	forms, err := EvolutionForms(version)
+	if err != nil {
+		return nil, err
+	}
+
+	form := forms[0]
+	for _, next := range forms[1:] {
+		if level < next.MinLevel {
+			break
+		}
+		form = next
+	}
+
+	return &form, nil
+}
+
+// Evolved returns a copy of the mon at the given level in the form it takes
+// at that level, if the nonce proves the level. The scores of the copy are
+// boosted and it gains the types of all forms it passed. The mon itself must
+// not be evolved yet.
+func (m *Mon) Evolved(version uint32, level, nonce int) (*Mon, error) {
+	if level < 0 || level > MaxLevel {
+		return nil, fmt.Errorf("level %d out of range", level)
+	}
+	if level > 0 && !m.VerifyLevelUp(level, nonce) {
+		return nil, fmt.Errorf("invalid level proof for level %d",
+			level)
+	}
+
+	forms, err := EvolutionForms(version)
+	if err != nil {
+		return nil, err
+	}
+	form, err := FormAt(version, level)
+	if err != nil {
+		return nil, err
+	}
+
+	evolved := &Mon{
+		Id:     m.Id,
+		Scores: make([]uint8, len(m.Scores)),
+		Types:  append([]string(nil), m.Types...),
+		Level:  level,
+		Nonce:  nonce,
+		Form:   form,
+	}
+	for i, score := range m.Scores {
+		boosted := int(score) + int(form.Boost)
+		if boosted > math.MaxUint8 {
+			boosted = math.MaxUint8
+		}
+		evolved.Scores[i] = uint8(boosted)
+	}
+	for _, passed := range forms[:form.Stage+1] {
+		if passed.AddsType {
+			evolved.Types = append(evolved.Types, m.gainedType(
+				len(evolved.Types),
+			))
+		}
+	}
+
+	return evolved, nil
+}
+
+// gainedType returns the type the mon gains as its type with the given
+// index. The types are picked by the id of the mon and never repeat.
+func (m *Mon) gainedType(index int) string {
+	var first int
+	if len(m.Id) > 0 {
+		first = int(m.Id[len(m.Id)-1])
+	}
+
+	return Types[(first+index)%len(Types)]
+}
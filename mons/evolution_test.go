@@ -0,0 +1,121 @@
+package mons
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFormAt tests that a mon takes the form with the highest minimum level
+// it reached.
+func TestFormAt(t *testing.T) {
+	forms, err := EvolutionForms(MonVersion)
+	require.NoError(t, err)
+
+	for i, form := range forms {
+		require.Equal(t, i, form.Stage)
+
+		reached, err := FormAt(MonVersion, form.MinLevel)
+		require.NoError(t, err)
+		require.Equal(t, form, *reached)
+
+		if i > 0 {
+			previous, err := FormAt(MonVersion, form.MinLevel-1)
+			require.NoError(t, err)
+			require.Equal(t, forms[i-1], *previous)
+		}
+	}
+
+	form, err := FormAt(MonVersion, MaxLevel)
+	require.NoError(t, err)
+	require.Equal(t, forms[len(forms)-1], *form)
+
+	_, err = FormAt(MonVersion+100, 0)
+	require.Error(t, err)
+}
+
+// TestEvolved tests that a mon only evolves with a valid level proof and
+// that its form boosts its scores and adds its types.
+func TestEvolved(t *testing.T) {
+	ctx := context.Background()
+
+	mon, err := GenerateMonster(&testBlockHash, &testTxHash)
+	require.NoError(t, err)
+	mon.Scores[0] = math.MaxUint8
+
+	base, err := mon.Evolved(MonVersion, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, base.Form.Stage)
+	require.Equal(t, mon.Scores, base.Scores)
+	require.Empty(t, base.Types)
+
+	forms, err := EvolutionForms(MonVersion)
+	require.NoError(t, err)
+	juvenile, adult := forms[1], forms[2]
+
+	nonce := mon.GetLevelNonce(ctx, juvenile.MinLevel, 0)
+	evolved, err := mon.Evolved(MonVersion, juvenile.MinLevel, nonce)
+	require.NoError(t, err)
+	require.Equal(t, juvenile, *evolved.Form)
+	require.Equal(t, juvenile.MinLevel, evolved.Level)
+	require.Equal(t, nonce, evolved.Nonce)
+	require.EqualValues(t, math.MaxUint8, evolved.Scores[0])
+	for i := 1; i < len(mon.Scores); i++ {
+		require.LessOrEqual(
+			t, int(mon.Scores[i]), int(evolved.Scores[i]),
+		)
+	}
+	require.Empty(t, evolved.Types)
+
+	// The mon we evolved from isn't modified.
+	require.Nil(t, mon.Form)
+
+	_, err = mon.Evolved(MonVersion, juvenile.MinLevel, nonce+1)
+	if !mon.VerifyLevelUp(juvenile.MinLevel, nonce+1) {
+		require.ErrorContains(t, err, "invalid level proof")
+	}
+
+	nonce = mon.GetLevelNonce(ctx, adult.MinLevel, 0)
+	evolved, err = mon.Evolved(MonVersion, adult.MinLevel, nonce)
+	require.NoError(t, err)
+	require.Equal(t, adult, *evolved.Form)
+	require.Equal(t, []string{mon.gainedType(0)}, evolved.Types)
+
+	_, err = mon.Evolved(MonVersion, MaxLevel+1, 0)
+	require.Error(t, err)
+}
+
+// TestLevelMon tests that leveling a final indexed mon returns it in the form
+// it evolved into.
+func TestLevelMon(t *testing.T) {
+	ctx := context.Background()
+	store := newMemMonStore()
+	chain := newMemChain()
+	manager := NewManager(nil, nil, nil, nil, chain, store, testPolicy, nil)
+
+	indexed := testMon(1, 100, 0)
+	var err error
+	indexed.Mon, err = deriveMon(ctx, store, indexed)
+	require.NoError(t, err)
+	require.NoError(t, store.AddMon(ctx, indexed))
+
+	_, _, err = manager.LevelMon(ctx, []byte{1}, 2, 0)
+	require.ErrorContains(t, err, "unknown mon")
+
+	_, _, err = manager.LevelMon(ctx, indexed.Mon.Id, 0, 0)
+	require.ErrorContains(t, err, "out of range")
+
+	// Tentative mons can't be leveled.
+	chain.tip = 100 + int32(testPolicy.FinalConfirmations) - 2
+	_, _, err = manager.LevelMon(ctx, indexed.Mon.Id, 2, 0)
+	require.ErrorContains(t, err, "tentative")
+
+	chain.tip++
+
+	mon, nonce, err := manager.LevelMon(ctx, indexed.Mon.Id, 2, 0)
+	require.NoError(t, err)
+	require.True(t, indexed.Mon.VerifyLevelUp(2, nonce))
+	require.Equal(t, "juvenile", mon.Form.Name)
+}
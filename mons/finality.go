@@ -0,0 +1,99 @@
+package mons
+
+import (
+	"fmt"
+)
+
+// MonStatus is the status of an indexed mon relative to the tip of the
+// chain.
+type MonStatus uint8
+
+const (
+	// MonPending is the status of a mon that isn't confirmed in the main
+	// chain, because its mint is unconfirmed or its anchor block was
+	// reorged out.
+	MonPending MonStatus = iota
+
+	// MonTentative is the status of a confirmed mon that doesn't have
+	// enough confirmations to be final yet. Its anchor block may still be
+	// reorged out, which changes its attributes.
+	MonTentative
+
+	// MonFinal is the status of a mon with enough confirmations that its
+	// attributes are assumed to never change.
+	MonFinal
+)
+
+// String returns the name of the status.
+func (s MonStatus) String() string {
+	switch s {
+	case MonPending:
+		return "pending"
+
+	case MonTentative:
+		return "tentative"
+
+	case MonFinal:
+		return "final"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// FinalityPolicy is the number of confirmations mons need on a network.
+type FinalityPolicy struct {
+	// MintConfirmations is the number of confirmations a mint waits for
+	// before the minted mon is derived. Transfers of mons wait for as
+	// many confirmations.
+	MintConfirmations uint32
+
+	// FinalConfirmations is the number of confirmations from which on a
+	// mon is final. The anchors of final mons aren't checked for reorgs
+	// anymore.
+	FinalConfirmations uint32
+}
+
+// finalityPolicies are the finality policies by network.
+var finalityPolicies = map[string]FinalityPolicy{
+	"regtest": {MintConfirmations: 1, FinalConfirmations: 1},
+	"simnet":  {MintConfirmations: 1, FinalConfirmations: 1},
+	"signet":  {MintConfirmations: 2, FinalConfirmations: 6},
+	"testnet": {MintConfirmations: 2, FinalConfirmations: 6},
+	"mainnet": {MintConfirmations: 2, FinalConfirmations: 6},
+}
+
+// FinalityPolicyForNetwork returns the finality policy of the network with
+// the given name.
+func FinalityPolicyForNetwork(network string) (FinalityPolicy, error) {
+	policy, ok := finalityPolicies[network]
+	if !ok {
+		return FinalityPolicy{}, fmt.Errorf("no finality policy for "+
+			"network %q", network)
+	}
+
+	return policy, nil
+}
+
+// Status returns the status of the mon at the given tip height.
+func (p FinalityPolicy) Status(mon *IndexedMon, tipHeight uint32) MonStatus {
+	if mon.Reorged || mon.Height == 0 || mon.Height > tipHeight {
+		return MonPending
+	}
+
+	if tipHeight-mon.Height+1 < p.FinalConfirmations {
+		return MonTentative
+	}
+
+	return MonFinal
+}
+
+// tentativeHeight returns the lowest height at which mons are still
+// tentative at the given tip height.
+func (p FinalityPolicy) tentativeHeight(tipHeight uint32) uint32 {
+	if tipHeight+2 <= p.FinalConfirmations {
+		return 0
+	}
+
+	return tipHeight + 2 - p.FinalConfirmations
+}
@@ -0,0 +1,127 @@
+package mons
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFinalityPolicy tests that the status of a mon follows its
+// confirmations and that only tentative mons are checked for reorgs.
+func TestFinalityPolicy(t *testing.T) {
+	policy, err := FinalityPolicyForNetwork("mainnet")
+	require.NoError(t, err)
+	require.EqualValues(t, 6, policy.FinalConfirmations)
+
+	_, err = FinalityPolicyForNetwork("unknown")
+	require.Error(t, err)
+
+	mon := testMon(1, 100, 0)
+	require.Equal(t, MonPending, policy.Status(mon, 99))
+	require.Equal(t, MonTentative, policy.Status(mon, 100))
+	require.Equal(t, MonTentative, policy.Status(mon, 104))
+	require.Equal(t, MonFinal, policy.Status(mon, 105))
+
+	mon.Reorged = true
+	require.Equal(t, MonPending, policy.Status(mon, 105))
+
+	// Only the anchors of tentative mons are checked for reorgs.
+	require.EqualValues(t, 100, policy.tentativeHeight(104))
+	require.EqualValues(t, 101, policy.tentativeHeight(105))
+	require.Zero(t, policy.tentativeHeight(3))
+
+	regtest, err := FinalityPolicyForNetwork("regtest")
+	require.NoError(t, err)
+	require.Equal(t, MonFinal, regtest.Status(testMon(1, 100, 0), 100))
+	require.EqualValues(t, 101, regtest.tentativeHeight(100))
+}
+
+// TestListMons tests that listed mons are filtered by their status and
+// ownership and paginated.
+func TestListMons(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{
+		metas: make(map[string]*taprpc.AssetMeta),
+	}
+	chain := newMemChain()
+	store := newMemMonStore()
+	policy := FinalityPolicy{MintConfirmations: 1, FinalConfirmations: 6}
+	manager := NewManager(tapd, nil, nil, nil, chain, store, policy, nil)
+
+	tapd.addMon(t, testMon(1, 100, 0))
+	tapd.addMon(t, testMon(2, 110, 0))
+	tapd.addMon(t, testMon(3, 120, 0))
+	require.NoError(t, manager.IndexMons(ctx))
+
+	// Only the mons in tapd are owned.
+	notOwned := testMon(4, 130, 0)
+	var err error
+	notOwned.Mon, err = deriveMon(ctx, store, notOwned)
+	require.NoError(t, err)
+	require.NoError(t, store.AddMon(ctx, notOwned))
+	chain.tip = 114
+
+	list := func(filter MonFilter) []byte {
+		t.Helper()
+
+		infos, err := manager.ListMons(ctx, filter)
+		require.NoError(t, err)
+
+		assetIds := make([]byte, 0, len(infos))
+		for _, info := range infos {
+			assetIds = append(assetIds, info.AssetId[0])
+		}
+
+		return assetIds
+	}
+
+	require.Equal(t, []byte{1, 2, 3, 4}, list(MonFilter{}))
+	require.Equal(t, []byte{1}, list(MonFilter{
+		Statuses: []MonStatus{MonFinal},
+	}))
+	require.Equal(t, []byte{2, 3, 4}, list(MonFilter{
+		Statuses: []MonStatus{MonTentative, MonPending},
+	}))
+	require.Equal(t, []byte{2, 3}, list(MonFilter{
+		Statuses: []MonStatus{MonTentative, MonPending},
+		Owned:    true,
+	}))
+	require.Equal(t, []byte{2, 3}, list(MonFilter{Offset: 1, Limit: 2}))
+	require.Empty(t, list(MonFilter{Offset: 4}))
+
+	info, err := manager.GetMon(ctx, store.mons[string([]byte{2})].Mon.Id)
+	require.NoError(t, err)
+	require.Equal(t, MonTentative, info.Status)
+
+	_, err = manager.GetMon(ctx, []byte{2})
+	require.ErrorContains(t, err, "unknown mon")
+	// Unconfirmed mints are listed as pending after the indexed mons
+	// until they're confirmed.
+	tapd.addMon(t, testMon(5, 0, 0))
+	require.NoError(t, manager.IndexMons(ctx))
+	require.NotContains(t, store.mons, string([]byte{5}))
+	require.Equal(t, []byte{1, 2, 3, 4, 5}, list(MonFilter{}))
+	require.Equal(t, []byte{3, 5}, list(MonFilter{
+		Statuses: []MonStatus{MonPending},
+		Owned:    true,
+	}))
+
+	infos, err := manager.ListMons(ctx, MonFilter{Offset: 4})
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, MonPending, infos[0].Status)
+	require.Nil(t, infos[0].Mon)
+
+	tapd.assets[len(tapd.assets)-1] = genesisAsset(testMon(5, 114, 0))
+	require.NoError(t, manager.IndexMons(ctx))
+	require.Contains(t, store.mons, string([]byte{5}))
+	require.Equal(t, []byte{2, 5}, list(MonFilter{
+		Statuses: []MonStatus{MonTentative},
+	}))
+	require.Equal(t, []byte{3}, list(MonFilter{
+		Statuses: []MonStatus{MonPending},
+		Owned:    true,
+	}))
+}
@@ -0,0 +1,310 @@
+package mons
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+)
+
+// MonEventType is the type of an event in the history of a mon.
+type MonEventType uint8
+
+const (
+	// MonEventMint is the mint of a mon to its first owner.
+	MonEventMint MonEventType = iota
+
+	// MonEventTransfer is the transfer of a mon to a new owner.
+	MonEventTransfer
+
+	// MonEventLevelUp is a level up of a mon by us.
+	MonEventLevelUp
+
+	// MonEventFight is a rated match the mon fought in.
+	MonEventFight
+)
+
+// String returns the name of the event type.
+func (t MonEventType) String() string {
+	switch t {
+	case MonEventMint:
+		return "mint"
+
+	case MonEventTransfer:
+		return "transfer"
+
+	case MonEventLevelUp:
+		return "level_up"
+
+	case MonEventFight:
+		return "fight"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// MonOwner is an owner of a mon in its ownership history, identified by the
+// script key the mon was sent to.
+type MonOwner struct {
+	// AssetId is the asset id of the mon.
+	AssetId []byte
+
+	// ScriptKey is the script key of the owner.
+	ScriptKey []byte
+
+	// AnchorOutpoint is the outpoint that anchors the mon while it is
+	// owned by the owner.
+	AnchorOutpoint wire.OutPoint
+
+	// BlockHash is the hash of the block that confirmed the anchor.
+	BlockHash chainhash.Hash
+
+	// Height is the height of the block that confirmed the anchor.
+	Height uint32
+
+	// BlockTime is the timestamp of the block that confirmed the anchor.
+	BlockTime time.Time
+}
+
+// MonLevelUp is a level up of a mon.
+type MonLevelUp struct {
+	// AssetId is the asset id of the mon.
+	AssetId []byte
+
+	// Level is the level the mon reached.
+	Level int
+
+	// Nonce is the nonce proving the level.
+	Nonce int
+
+	// Height is the height of the tip of the chain when the mon leveled.
+	Height uint32
+
+	// LeveledAt is the time the mon leveled.
+	LeveledAt time.Time
+}
+
+// MonEvent is an event in the history of a mon.
+type MonEvent struct {
+	// Type is the type of the event.
+	Type MonEventType
+
+	// Timestamp is the time of the event. The time of mints and transfers
+	// is the timestamp of the block that confirmed them.
+	Timestamp time.Time
+
+	// Height is the height of the block that confirmed a mint or
+	// transfer, or the height of the tip of the chain at a level up. It
+	// is zero for fights.
+	Height uint32
+
+	// Owner is the new owner of a mint or transfer.
+	Owner *MonOwner
+
+	// LevelUp is the level up of a level up event.
+	LevelUp *MonLevelUp
+
+	// MatchId is the id of the match of a fight.
+	MatchId string
+}
+
+// SortHistory sorts events of the history of a mon chronologically.
+func SortHistory(events []*MonEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+}
+
+// TrackOwners records the owners of the indexed mons tapd knows about: the
+// mons we hold or held and the mons we sent to others, whose new owners are
+// taken from the proofs of our transfers. Owners anchored in blocks that
+// aren't part of the main chain are skipped until they confirm again.
+func (m *Manager) TrackOwners(ctx context.Context) error {
+	resp, err := m.tapClient.ListAssets(ctx, &taprpc.ListAssetRequest{
+		IncludeSpent: true,
+	})
+	if err != nil {
+		return err
+	}
+	assets := resp.Assets
+
+	transfers, err := m.tapClient.ListTransfers(
+		ctx, &taprpc.ListTransfersRequest{},
+	)
+	if err != nil {
+		return err
+	}
+	for _, transfer := range transfers.Transfers {
+		for _, output := range transfer.Outputs {
+			// Our own outputs are listed as our assets.
+			if output.ScriptKeyIsLocal || output.Amount == 0 ||
+				len(output.NewProofBlob) == 0 {
+
+				continue
+			}
+
+			decoded, err := m.tapClient.DecodeProof(
+				ctx, &taprpc.DecodeProofRequest{
+					RawProof: output.NewProofBlob,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			if proof := decoded.DecodedProof; proof != nil {
+				assets = append(assets, proof.Asset)
+			}
+		}
+	}
+
+	for _, asset := range assets {
+		if err := m.trackOwner(ctx, asset); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// trackOwner records the owner of an asset if it is an indexed mon that is
+// confirmed in the main chain.
+func (m *Manager) trackOwner(ctx context.Context, asset *taprpc.Asset) error {
+	if asset == nil || asset.AssetGenesis == nil ||
+		asset.ChainAnchor == nil || asset.ChainAnchor.BlockHeight == 0 {
+
+		return nil
+	}
+
+	assetId, anchor := asset.AssetGenesis.AssetId, asset.ChainAnchor
+	indexed, err := m.store.GetMon(ctx, assetId)
+	if err != nil {
+		return err
+	}
+	if indexed == nil || indexed.Reorged {
+		return nil
+	}
+
+	blockHash, err := chainhash.NewHashFromStr(anchor.AnchorBlockHash)
+	if err != nil {
+		return fmt.Errorf("invalid anchor block of asset %x: %w",
+			assetId, err)
+	}
+	outpoint, err := wire.NewOutPointFromString(anchor.AnchorOutpoint)
+	if err != nil {
+		return fmt.Errorf("invalid anchor outpoint of asset %x: %w",
+			assetId, err)
+	}
+
+	owners, err := m.store.ListOwners(ctx, assetId)
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		if owner.AnchorOutpoint == *outpoint &&
+			owner.BlockHash == *blockHash {
+
+			return nil
+		}
+	}
+
+	onChain, err := m.onMainChain(ctx, anchor.BlockHeight, *blockHash)
+	if err != nil || !onChain {
+		return err
+	}
+	header, err := m.chainKit.GetBlockHeader(ctx, *blockHash)
+	if err != nil {
+		return err
+	}
+
+	owner := &MonOwner{
+		AssetId:        assetId,
+		ScriptKey:      asset.ScriptKey,
+		AnchorOutpoint: *outpoint,
+		BlockHash:      *blockHash,
+		Height:         anchor.BlockHeight,
+		BlockTime:      header.Timestamp,
+	}
+	if err := m.store.AddOwner(ctx, owner); err != nil {
+		return err
+	}
+	log.Printf("Mon %x is owned by script key %x at %v", assetId,
+		owner.ScriptKey, owner.AnchorOutpoint)
+
+	return nil
+}
+
+// MonOwner returns the latest known owner of the mon with the given mon id
+// or nil if no owner is known.
+func (m *Manager) MonOwner(ctx context.Context, monId []byte) (*MonOwner,
+	error) {
+
+	indexed, err := m.store.GetMonById(ctx, monId)
+	if err != nil {
+		return nil, err
+	}
+	if indexed == nil {
+		return nil, fmt.Errorf("unknown mon %x", monId)
+	}
+
+	owners, err := m.store.ListOwners(ctx, indexed.AssetId)
+	if err != nil || len(owners) == 0 {
+		return nil, err
+	}
+
+	return owners[len(owners)-1], nil
+}
+
+// MonHistory returns the mint, the transfers and the level ups of the mon
+// with the given mon id in chronological order.
+func (m *Manager) MonHistory(ctx context.Context, monId []byte) ([]*MonEvent,
+	error) {
+
+	indexed, err := m.store.GetMonById(ctx, monId)
+	if err != nil {
+		return nil, err
+	}
+	if indexed == nil {
+		return nil, fmt.Errorf("unknown mon %x", monId)
+	}
+
+	owners, err := m.store.ListOwners(ctx, indexed.AssetId)
+	if err != nil {
+		return nil, err
+	}
+	levelUps, err := m.store.ListLevelUps(ctx, indexed.AssetId)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*MonEvent, 0, len(owners)+len(levelUps))
+	for _, owner := range owners {
+		eventType := MonEventTransfer
+		if owner.AnchorOutpoint.Hash == indexed.TxHash {
+			eventType = MonEventMint
+		}
+
+		events = append(events, &MonEvent{
+			Type:      eventType,
+			Timestamp: owner.BlockTime,
+			Height:    owner.Height,
+			Owner:     owner,
+		})
+	}
+	for _, levelUp := range levelUps {
+		events = append(events, &MonEvent{
+			Type:      MonEventLevelUp,
+			Timestamp: levelUp.LeveledAt,
+			Height:    levelUp.Height,
+			LevelUp:   levelUp,
+		})
+	}
+	SortHistory(events)
+
+	return events, nil
+}
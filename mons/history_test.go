@@ -0,0 +1,109 @@
+package mons
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMonHistory tests that the owners of a mon are tracked through the
+// transfers of tapd and that its history lists the mint, its transfers and
+// its level ups in chronological order.
+func TestMonHistory(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{
+		metas:  make(map[string]*taprpc.AssetMeta),
+		proofs: make(map[string]*taprpc.Asset),
+	}
+	chain := newMemChain()
+	store := newMemMonStore()
+	manager := NewManager(
+		tapd, nil, nil, nil, chain, store, testPolicy, nil,
+	)
+
+	minted := testMon(1, 100, 0)
+	tapd.addMon(t, minted)
+	require.NoError(t, manager.IndexMons(ctx))
+	require.NoError(t, manager.TrackOwners(ctx))
+
+	indexed := store.mons[string([]byte{1})]
+	owner, err := manager.MonOwner(ctx, indexed.Mon.Id)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, owner.ScriptKey)
+	require.Equal(t, minted.TxHash, owner.AnchorOutpoint.Hash)
+
+	// We send the mon away. The proof of the output of the recipient
+	// tells its new owner once it confirms.
+	sent := func(scriptKey byte, height uint32) *taprpc.Asset {
+		outpoint := wire.OutPoint{Hash: chainhash.Hash{scriptKey}}
+		return &taprpc.Asset{
+			AssetGenesis: &taprpc.GenesisInfo{AssetId: []byte{1}},
+			ScriptKey:    []byte{scriptKey},
+			ChainAnchor: &taprpc.AnchorInfo{
+				AnchorBlockHash: chainhash.Hash{
+					byte(height),
+				}.String(),
+				AnchorOutpoint: outpoint.String(),
+				BlockHeight:    height,
+			},
+		}
+	}
+	tapd.proofs["recipient"] = sent(0xaa, 120)
+	tapd.proofs["unconfirmed"] = sent(0xbb, 0)
+	tapd.transfers = []*taprpc.AssetTransfer{{
+		Outputs: []*taprpc.TransferOutput{{
+			ScriptKey:    []byte{0xaa},
+			Amount:       1,
+			NewProofBlob: []byte("recipient"),
+		}, {
+			ScriptKey:        []byte{0xcc},
+			ScriptKeyIsLocal: true,
+			NewProofBlob:     []byte("tombstone"),
+		}},
+	}, {
+		Outputs: []*taprpc.TransferOutput{{
+			ScriptKey:    []byte{0xbb},
+			Amount:       1,
+			NewProofBlob: []byte("unconfirmed"),
+		}},
+	}}
+	require.NoError(t, manager.TrackOwners(ctx))
+	require.NoError(t, manager.TrackOwners(ctx))
+	require.Len(t, store.owners[string([]byte{1})], 2)
+
+	owner, err = manager.MonOwner(ctx, indexed.Mon.Id)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xaa}, owner.ScriptKey)
+	require.EqualValues(t, 120, owner.Height)
+
+	// Level ups are recorded once per level.
+	chain.tip = 100 + int32(testPolicy.FinalConfirmations)
+	_, nonce, err := manager.LevelMon(ctx, indexed.Mon.Id, 2, 0)
+	require.NoError(t, err)
+	_, _, err = manager.LevelMon(ctx, indexed.Mon.Id, 2, 0)
+	require.NoError(t, err)
+
+	history, err := manager.MonHistory(ctx, indexed.Mon.Id)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	require.Equal(t, MonEventMint, history[0].Type)
+	require.EqualValues(t, 100, history[0].Height)
+	require.Equal(t, []byte{1}, history[0].Owner.ScriptKey)
+
+	require.Equal(t, MonEventTransfer, history[1].Type)
+	require.Equal(t, owner, history[1].Owner)
+	require.True(t, history[0].Timestamp.Before(history[1].Timestamp))
+
+	require.Equal(t, MonEventLevelUp, history[2].Type)
+	require.Equal(t, 2, history[2].LevelUp.Level)
+	require.Equal(t, nonce, history[2].LevelUp.Nonce)
+	require.Equal(t, uint32(chain.tip), history[2].Height)
+
+	_, err = manager.MonHistory(ctx, []byte{1})
+	require.ErrorContains(t, err, "unknown mon")
+}
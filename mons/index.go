@@ -0,0 +1,522 @@
+package mons
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+)
+
+var (
+	// ErrNotMon is returned if the metadata of an asset doesn't describe
+	// a mon.
+	ErrNotMon = errors.New("asset is not a mon")
+
+	// ErrUnknownParent is returned if a parent of a bred mon isn't
+	// indexed (yet).
+	ErrUnknownParent = errors.New("unknown parent")
+)
+
+// IndexedMon is a mon the indexer found on chain together with its asset and
+// the anchor of the transaction it was minted in.
+type IndexedMon struct {
+	// AssetId is the id of the asset of the mon.
+	AssetId []byte
+
+	// Name is the name of the asset.
+	Name string
+
+	// Mon is the mon derived from the mint anchor and, for bred mons,
+	// from the parents.
+	Mon *Mon
+
+	// Metadata is the metadata the asset was minted with.
+	Metadata *MonMetadata
+
+	// BlockHash is the hash of the block the mint was confirmed in.
+	BlockHash chainhash.Hash
+
+	// TxHash is the hash of the mint transaction.
+	TxHash chainhash.Hash
+
+	// Height is the height of the block the mint was confirmed in.
+	Height uint32
+
+	// GroupKey is the tweaked key of the asset group the mon was minted
+	// into, unset for mons that aren't part of a collection.
+	GroupKey []byte
+
+	// Reorged is set if the block the mint was confirmed in was reorged
+	// out and the mon wasn't derived from its new anchor yet.
+	Reorged bool
+}
+
+// MonStore persists the mons found by the indexer.
+type MonStore interface {
+	// AddMon stores a verified mon.
+	AddMon(ctx context.Context, mon *IndexedMon) error
+
+	// GetMon returns the mon with the given asset id or nil if it isn't
+	// indexed.
+	GetMon(ctx context.Context, assetId []byte) (*IndexedMon, error)
+
+	// GetMonById returns the mon with the given mon id or nil if it isn't
+	// indexed.
+	GetMonById(ctx context.Context, monId []byte) (*IndexedMon, error)
+
+	// LastBred returns the highest mint height up to the given height of
+	// the children of the mon with the given asset id that aren't
+	// reorged, or zero if it didn't breed yet.
+	LastBred(ctx context.Context, assetId []byte, height uint32) (uint32,
+		error)
+
+	// ListMons returns the mons minted at or above the given height
+	// ordered by their height.
+	ListMons(ctx context.Context, minHeight uint32) ([]*IndexedMon, error)
+
+	// ListMonPage returns the page of mons selected by the query ordered
+	// by their height.
+	ListMonPage(ctx context.Context, query *MonQuery) ([]*IndexedMon,
+		error)
+
+	// CountMons returns the number of mons selected by the query,
+	// ignoring its paging.
+	CountMons(ctx context.Context, query *MonQuery) (int, error)
+
+	// ListMonsByName returns the mons whose lowercase names are the given
+	// name key ordered by their height, reorged mons included.
+	ListMonsByName(ctx context.Context, nameKey string) ([]*IndexedMon,
+		error)
+
+	// GetChildren returns the asset ids of the children of the mon with
+	// the given asset id.
+	GetChildren(ctx context.Context, assetId []byte) ([][]byte, error)
+
+	// MarkReorged atomically marks the mons with the given asset ids as
+	// reorged.
+	MarkReorged(ctx context.Context, assetIds [][]byte) error
+
+	// UpdateMon updates a reorged mon with the mon derived from its new
+	// anchor and clears its reorged flag.
+	UpdateMon(ctx context.Context, mon *IndexedMon) error
+
+	// AddOwner stores an owner of a mon. An owner with the same anchor
+	// outpoint is replaced, as its anchor confirmed in a new block.
+	AddOwner(ctx context.Context, owner *MonOwner) error
+
+	// ListOwners returns the owners of the mon with the given asset id
+	// ordered by the height of their anchors.
+	ListOwners(ctx context.Context, assetId []byte) ([]*MonOwner, error)
+
+	// AddLevelUp stores a level up of a mon unless the mon already
+	// reached the level before.
+	AddLevelUp(ctx context.Context, levelUp *MonLevelUp) error
+
+	// ListLevelUps returns the level ups of the mon with the given asset
+	// id in the order they were stored.
+	ListLevelUps(ctx context.Context, assetId []byte) ([]*MonLevelUp,
+		error)
+
+	// AddCollection stores a collection we issue together with the key
+	// of its asset group.
+	AddCollection(ctx context.Context, collection *MonCollection) error
+
+	// GetCollection returns the collection we issue with the given name
+	// or nil if we didn't mint into it yet.
+	GetCollection(ctx context.Context, name string) (*MonCollection,
+		error)
+}
+
+// lineage checks the version, generation and breeding cooldown of the
+// metadata against each other and returns the asset ids of the two parents
+// of a bred mon, none for a minted mon.
+func (m *MonMetadata) lineage() ([][]byte, error) {
+	if m.MonVersion != MonVersion {
+		return nil, fmt.Errorf("unsupported mon version %d",
+			m.MonVersion)
+	}
+	if m.BreedCooldown != BreedCooldown(m.Generation) {
+		return nil, fmt.Errorf("breeding cooldown %d doesn't match "+
+			"generation %d", m.BreedCooldown, m.Generation)
+	}
+
+	parentIds, err := m.ParentIds()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(parentIds) == 0 && m.Generation == 0:
+		return nil, nil
+
+	case len(parentIds) == 0:
+		return nil, fmt.Errorf("mon of generation %d has no parents",
+			m.Generation)
+
+	case len(parentIds) != 2:
+		return nil, fmt.Errorf("mon has %d parents", len(parentIds))
+
+	case bytes.Equal(parentIds[0], parentIds[1]):
+		return nil, fmt.Errorf("mon has the same parent twice")
+	}
+
+	return parentIds, nil
+}
+
+// breedingReadyHeight returns the height from which on the mon may breed
+// again when breeding a child that is minted at the given height.
+func breedingReadyHeight(ctx context.Context, store MonStore,
+	parent *IndexedMon, height uint32) (uint32, error) {
+
+	last, err := store.LastBred(ctx, parent.AssetId, height)
+	if err != nil {
+		return 0, err
+	}
+	if last < parent.Height {
+		last = parent.Height
+	}
+
+	return last + BreedCooldown(parent.Metadata.Generation), nil
+}
+
+// deriveMon derives the mon of an indexed asset from its mint anchor. For a
+// bred mon it verifies that its parents are indexed and were off their
+// breeding cooldowns when it was minted, and that its generation and
+// cooldown follow from theirs.
+func deriveMon(ctx context.Context, store MonStore,
+	indexed *IndexedMon) (*Mon, error) {
+
+	meta := indexed.Metadata
+	parentIds, err := meta.lineage()
+	if err != nil {
+		return nil, err
+	}
+	if len(parentIds) == 0 {
+		return GenerateMonster(&indexed.BlockHash, &indexed.TxHash)
+	}
+
+	var (
+		parents    [2]*IndexedMon
+		generation uint32
+	)
+	for i, id := range parentIds {
+		parent, err := store.GetMon(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || parent.Reorged {
+			return nil, fmt.Errorf("%w %x", ErrUnknownParent, id)
+		}
+		if parent.Height >= indexed.Height {
+			return nil, fmt.Errorf("parent %x minted at height "+
+				"%d, not before its child", id, parent.Height)
+		}
+
+		ready, err := breedingReadyHeight(
+			ctx, store, parent, indexed.Height,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if indexed.Height < ready {
+			return nil, fmt.Errorf("parent %x bred at height %d "+
+				"while on cooldown until height %d", id,
+				indexed.Height, ready)
+		}
+
+		if parent.Metadata.Generation >= generation {
+			generation = parent.Metadata.Generation + 1
+		}
+		parents[i] = parent
+	}
+	if meta.Generation != generation {
+		return nil, fmt.Errorf("generation %d doesn't match "+
+			"generation %d of parents", meta.Generation, generation)
+	}
+
+	return BreedMonster(
+		&indexed.BlockHash, &indexed.TxHash, parents[0].Mon,
+		parents[1].Mon,
+	)
+}
+
+// isGenesisAsset returns true if the asset wasn't transferred since it was
+// minted, so that its chain anchor is the mint transaction.
+func isGenesisAsset(asset *taprpc.Asset) bool {
+	if len(asset.PrevWitnesses) != 1 {
+		return false
+	}
+
+	prevId := asset.PrevWitnesses[0].PrevId
+	if prevId == nil {
+		return false
+	}
+
+	// The witness of a genesis asset spends the zero asset id.
+	return len(bytes.Trim(prevId.AssetId, "\x00")) == 0
+}
+
+// MonInfo is an indexed mon together with its status at the tip of the chain
+// it was looked up at.
+type MonInfo struct {
+	*IndexedMon
+
+	// Status is the status of the mon.
+	Status MonStatus
+}
+
+// MonFilter selects the mons that are listed.
+type MonFilter struct {
+	// Statuses are the statuses of the listed mons. Mons of any status
+	// are listed if none are given.
+	Statuses []MonStatus
+
+	// Owned restricts the listed mons to the ones we own.
+	Owned bool
+
+	// GroupKey restricts the listed mons to the ones minted into the
+	// asset group of a collection with the given key.
+	GroupKey []byte
+
+	// Offset is the number of matching mons that are skipped.
+	Offset int
+
+	// Limit is the maximum number of mons listed, unlimited if zero.
+	Limit int
+}
+
+// MonQuery selects a page of indexed mons by the status they have at a tip
+// of the chain and the asset group they were minted into.
+type MonQuery struct {
+	// GroupKey restricts the mons to the ones minted into the asset group
+	// with the given key, unless it's empty.
+	GroupKey []byte
+
+	// Pending, Tentative and Final select the mons with the respective
+	// status.
+	Pending, Tentative, Final bool
+
+	// TipHeight is the height of the tip the statuses are relative to.
+	// Mons minted above it are pending.
+	TipHeight uint32
+
+	// TentativeHeight is the lowest height at which mons are still
+	// tentative at the tip. Mons minted below it are final.
+	TentativeHeight uint32
+
+	// Offset is the number of selected mons that are skipped.
+	Offset int
+
+	// Limit is the maximum number of mons returned, unlimited if zero.
+	Limit int
+}
+
+// matches returns true if the query selects the mon, ignoring its paging.
+func (q *MonQuery) matches(mon *IndexedMon) bool {
+	if !mon.inCollection(q.GroupKey) {
+		return false
+	}
+
+	switch {
+	case mon.Reorged || mon.Height > q.TipHeight:
+		return q.Pending
+
+	case mon.Height >= q.TentativeHeight:
+		return q.Tentative
+
+	default:
+		return q.Final
+	}
+}
+
+// matches returns true if the filter matches a mon with the given status.
+func (f *MonFilter) matches(status MonStatus) bool {
+	if len(f.Statuses) == 0 {
+		return true
+	}
+
+	for _, wanted := range f.Statuses {
+		if status == wanted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetMon returns the indexed mon with the given mon id and its status.
+func (m *Manager) GetMon(ctx context.Context, monId []byte) (*MonInfo,
+	error) {
+
+	indexed, err := m.store.GetMonById(ctx, monId)
+	if err != nil {
+		return nil, err
+	}
+	if indexed == nil {
+		return nil, fmt.Errorf("unknown mon %x", monId)
+	}
+
+	tipHeight, err := m.tipHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MonInfo{
+		IndexedMon: indexed,
+		Status:     m.policy.Status(indexed, tipHeight),
+	}, nil
+}
+
+// ListMons returns the indexed mons matching the filter ordered by their
+// height, followed by the pending mints of tapd. Pending mints aren't derived
+// yet, so their mon is nil. Only the requested page of indexed mons is loaded
+// from the store, unless the mons are restricted to the ones we own.
+func (m *Manager) ListMons(ctx context.Context,
+	filter MonFilter) ([]*MonInfo, error) {
+
+	tipHeight, err := m.tipHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := &MonQuery{
+		GroupKey:        filter.GroupKey,
+		Pending:         filter.matches(MonPending),
+		Tentative:       filter.matches(MonTentative),
+		Final:           filter.matches(MonFinal),
+		TipHeight:       tipHeight,
+		TentativeHeight: m.policy.tentativeHeight(tipHeight),
+		Offset:          filter.Offset,
+		Limit:           filter.Limit,
+	}
+
+	var pending []*IndexedMon
+	if query.Pending {
+		for _, mon := range m.pendingMons() {
+			if mon.inCollection(filter.GroupKey) {
+				pending = append(pending, mon)
+			}
+		}
+	}
+
+	var indexed []*IndexedMon
+	if filter.Owned {
+		owned, err := m.ownedMons(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		// Unconfirmed mints are ours, but tapd doesn't list them as
+		// owned.
+		indexed = pageMons(
+			append(owned, pending...), filter.Offset, filter.Limit,
+		)
+	} else {
+		indexed, err = m.listMonPage(ctx, query, pending)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	infos := make([]*MonInfo, 0, len(indexed))
+	for _, mon := range indexed {
+		infos = append(infos, &MonInfo{
+			IndexedMon: mon,
+			Status:     m.policy.Status(mon, tipHeight),
+		})
+	}
+
+	return infos, nil
+}
+
+// listMonPage returns the page of indexed mons selected by the query,
+// followed by the given pending mints.
+func (m *Manager) listMonPage(ctx context.Context, query *MonQuery,
+	pending []*IndexedMon) ([]*IndexedMon, error) {
+
+	indexed, err := m.store.ListMonPage(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 ||
+		(query.Limit > 0 && len(indexed) >= query.Limit) {
+
+		return indexed, nil
+	}
+
+	// The pending mints follow the indexed mons, so the page only starts
+	// within them if it's past all indexed mons.
+	var pendingOffset int
+	if len(indexed) == 0 && query.Offset > 0 {
+		count, err := m.store.CountMons(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		pendingOffset = max(query.Offset-count, 0)
+	}
+	var pendingLimit int
+	if query.Limit > 0 {
+		pendingLimit = query.Limit - len(indexed)
+	}
+
+	return append(
+		indexed, pageMons(pending, pendingOffset, pendingLimit)...,
+	), nil
+}
+
+// ownedMons returns the indexed mons we own that are selected by the query
+// ordered by their height, ignoring the paging of the query.
+func (m *Manager) ownedMons(ctx context.Context,
+	query *MonQuery) ([]*IndexedMon, error) {
+
+	owned, err := m.ownedAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexed []*IndexedMon
+	for assetId := range owned {
+		mon, err := m.store.GetMon(ctx, []byte(assetId))
+		if err != nil {
+			return nil, err
+		}
+		if mon != nil && query.matches(mon) {
+			indexed = append(indexed, mon)
+		}
+	}
+	sort.Slice(indexed, func(i, j int) bool {
+		if indexed[i].Height != indexed[j].Height {
+			return indexed[i].Height < indexed[j].Height
+		}
+
+		return bytes.Compare(indexed[i].AssetId, indexed[j].AssetId) < 0
+	})
+
+	return indexed, nil
+}
+
+// pageMons returns the mons left after skipping the given number of mons,
+// at most limit of them unless it's zero.
+func pageMons(mons []*IndexedMon, offset, limit int) []*IndexedMon {
+	if offset >= len(mons) {
+		return nil
+	}
+	mons = mons[offset:]
+	if limit > 0 && limit < len(mons) {
+		mons = mons[:limit]
+	}
+
+	return mons
+}
+
+// tipHeight returns the height of the tip of the chain.
+func (m *Manager) tipHeight(ctx context.Context) (uint32, error) {
+	_, height, err := m.chainKit.GetBestBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(height), nil
+}
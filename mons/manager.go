@@ -1,101 +1,666 @@
 package mons
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"log"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
 	"github.com/lightninglabs/taproot-assets/taprpc/mintrpc"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 )
 
-type MonMetadata struct {
-	MonVersion uint32 `json:"mon_version"`
-}
-
 type Manager struct {
 	tapClient     taprpc.TaprootAssetsClient
 	mintClient    mintrpc.MintClient
+	walletClient  assetwalletrpc.AssetWalletClient
 	chainNotifier lndclient.ChainNotifierClient
 	chainKit      lndclient.ChainKitClient
+	store         MonStore
+	policy        FinalityPolicy
+	universe      *UniverseSyncer
+
+	mu          sync.Mutex
+	subscribers map[chan *MonChange]struct{}
+
+	// pending are the mons among the unconfirmed mints of tapd by asset
+	// id. They aren't derived yet and are listed until they're indexed.
+	pending map[string]*IndexedMon
+
+	// collectionLocks serialize the first mints into collections by
+	// collection name, so that only one of them creates the group.
+	collectionLocks map[string]*sync.Mutex
 }
 
-func NewManager(client taprpc.TaprootAssetsClient) *Manager {
+func NewManager(client taprpc.TaprootAssetsClient,
+	mintClient mintrpc.MintClient,
+	walletClient assetwalletrpc.AssetWalletClient,
+	chainNotifier lndclient.ChainNotifierClient,
+	chainKit lndclient.ChainKitClient, store MonStore,
+	policy FinalityPolicy, universe *UniverseSyncer) *Manager {
+
 	return &Manager{
-		tapClient: client,
+		tapClient:       client,
+		mintClient:      mintClient,
+		walletClient:    walletClient,
+		chainNotifier:   chainNotifier,
+		chainKit:        chainKit,
+		store:           store,
+		policy:          policy,
+		universe:        universe,
+		subscribers:     make(map[chan *MonChange]struct{}),
+		pending:         make(map[string]*IndexedMon),
+		collectionLocks: make(map[string]*sync.Mutex),
+	}
+}
+
+// IndexMons indexes the mons among the assets of tapd and the issuances in
+// its universe that weren't indexed yet and derives reorged mons from their
+// new anchors. Mons are indexed in the order they were minted, so that bred
+// mons can be verified against their parents. Mons anchored in blocks that
+// aren't part of the main chain and assets that fail verification are
+// skipped, those whose parents aren't known yet are retried on the next run.
+// Unconfirmed mints of tapd are listed as pending until they're indexed.
+func (m *Manager) IndexMons(ctx context.Context) error {
+	if err := m.indexPending(ctx); err != nil {
+		return err
+	}
+
+	candidates, err := m.genesisAssets(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		found   []*IndexedMon
+		visited = make(map[string]struct{})
+		reorged = make(map[string]*IndexedMon)
+	)
+	for _, candidate := range candidates {
+		assetId := candidate.asset.AssetGenesis.AssetId
+		if _, ok := visited[string(assetId)]; ok {
+			continue
+		}
+		visited[string(assetId)] = struct{}{}
+
+		previous, err := m.store.GetMon(ctx, assetId)
+		if err != nil {
+			return err
+		}
+		if previous != nil && !previous.Reorged {
+			continue
+		}
+
+		indexed, err := m.monAsset(ctx, candidate)
+		switch {
+		case errors.Is(err, ErrNotMon):
+			continue
+
+		case errors.Is(err, ErrInvalidMonMetadata):
+			log.Printf("Rejecting asset %x: %v", assetId, err)
+			continue
+
+		case err != nil:
+			return err
+		}
+
+		onChain, err := m.onMainChain(
+			ctx, indexed.Height, indexed.BlockHash,
+		)
+		if err != nil {
+			return err
+		}
+		if !onChain {
+			log.Printf("Skipping mon %x anchored in block %v, which "+
+				"isn't part of the main chain", assetId,
+				indexed.BlockHash)
+			continue
+		}
+
+		if previous != nil {
+			reorged[string(assetId)] = previous
+		}
+		found = append(found, indexed)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Height != found[j].Height {
+			return found[i].Height < found[j].Height
+		}
+
+		return bytes.Compare(found[i].AssetId, found[j].AssetId) < 0
+	})
+
+	for _, indexed := range found {
+		indexed.Mon, err = deriveMon(ctx, m.store, indexed)
+		if err != nil {
+			log.Printf("Skipping invalid mon %x: %v",
+				indexed.AssetId, err)
+			continue
+		}
+
+		previous, ok := reorged[string(indexed.AssetId)]
+		if !ok {
+			if err := m.store.AddMon(ctx, indexed); err != nil {
+				return err
+			}
+			log.Printf("Indexed mon %x: %v", indexed.AssetId,
+				indexed.Mon)
+			m.logNameClaim(ctx, indexed)
+
+			continue
+		}
+
+		if err := m.store.UpdateMon(ctx, indexed); err != nil {
+			return err
+		}
+		log.Printf("Derived reorged mon %x from block %v: %v",
+			indexed.AssetId, indexed.BlockHash, indexed.Mon)
+		m.logNameClaim(ctx, indexed)
+
+		m.notify(&MonChange{
+			AssetId:  indexed.AssetId,
+			Previous: previous.Mon,
+			Mon:      indexed.Mon,
+		})
+	}
+
+	return nil
+}
+
+// monCandidate is a genesis asset that may be a mon.
+type monCandidate struct {
+	asset *taprpc.Asset
+
+	// meta is the metadata revealed by the issuance proof of the asset,
+	// it's fetched from tapd if nil.
+	meta *taprpc.AssetMeta
+}
+
+// genesisAssets returns the genesis assets of tapd followed by the mons in
+// its universe that weren't indexed yet. The universe mons are skipped if the
+// universe can't be queried, so that the mons of tapd are still indexed.
+func (m *Manager) genesisAssets(ctx context.Context) ([]*monCandidate,
+	error) {
+
+	resp, err := m.tapClient.ListAssets(ctx, &taprpc.ListAssetRequest{
+		IncludeSpent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*monCandidate
+	for _, asset := range resp.Assets {
+		// Unconfirmed mints are pending, they're indexed once they're
+		// confirmed.
+		if !isGenesisAsset(asset) || asset.AssetGenesis == nil ||
+			asset.ChainAnchor == nil ||
+			asset.ChainAnchor.BlockHeight == 0 {
+
+			continue
+		}
+		candidates = append(candidates, &monCandidate{asset: asset})
+	}
+
+	universeMons, err := m.universeMons(ctx)
+	if err != nil {
+		log.Printf("Unable to list the mons in the universe: %v", err)
 	}
+
+	return append(candidates, universeMons...), nil
 }
 
-// IndexMons will index all monsters
-func (m *Manager) IndexMons() error {
+// indexPending replaces the pending mons by the mons among the unconfirmed
+// mints of tapd.
+func (m *Manager) indexPending(ctx context.Context) error {
+	resp, err := m.tapClient.ListAssets(ctx, &taprpc.ListAssetRequest{
+		IncludeUnconfirmedMints: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	pending := make(map[string]*IndexedMon)
+	for _, asset := range resp.Assets {
+		if !isGenesisAsset(asset) || asset.AssetGenesis == nil ||
+			asset.ChainAnchor == nil ||
+			asset.ChainAnchor.BlockHeight != 0 {
+
+			continue
+		}
+
+		indexed, err := m.monAsset(ctx, &monCandidate{asset: asset})
+		switch {
+		case errors.Is(err, ErrNotMon),
+			errors.Is(err, ErrInvalidMonMetadata):
+
+			continue
+
+		case err != nil:
+			return err
+		}
+		pending[string(indexed.AssetId)] = indexed
+	}
+
+	m.mu.Lock()
+	m.pending = pending
+	m.mu.Unlock()
 
 	return nil
 }
 
-// MintMon will mint a new monster
-func (m *Manager) MintMon(ctx context.Context, name string) (*Mon, error) {
+// pendingMons returns the pending mons ordered by their asset id.
+func (m *Manager) pendingMons() []*IndexedMon {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending := make([]*IndexedMon, 0, len(m.pending))
+	for _, indexed := range m.pending {
+		pending = append(pending, indexed)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return bytes.Compare(
+			pending[i].AssetId, pending[j].AssetId,
+		) < 0
+	})
+
+	return pending
+}
+
+// monAsset fetches the metadata of a genesis asset unless it's known already
+// and returns it as a mon to index. It returns ErrNotMon if the asset isn't a
+// mon.
+func (m *Manager) monAsset(ctx context.Context,
+	candidate *monCandidate) (*IndexedMon, error) {
+
+	genesis := candidate.asset.AssetGenesis
+	anchor := candidate.asset.ChainAnchor
+	meta := candidate.meta
+	if meta == nil {
+		var err error
+		meta, err = m.tapClient.FetchAssetMeta(
+			ctx, &taprpc.FetchAssetMetaRequest{
+				Asset: &taprpc.FetchAssetMetaRequest_AssetId{
+					AssetId: genesis.AssetId,
+				},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if meta.Type != taprpc.AssetMetaType_META_TYPE_JSON {
+		return nil, ErrNotMon
+	}
+	monMetadata, err := ParseMonMetadata(meta.Data)
+	if err != nil {
+		return nil, err
+	}
+	if monMetadata.Name != genesis.Name {
+		return nil, fmt.Errorf("%w: name %q isn't the asset name %q",
+			ErrInvalidMonMetadata, monMetadata.Name, genesis.Name)
+	}
+
+	// Unconfirmed mints aren't anchored in a block yet.
+	var blockHash chainhash.Hash
+	if anchor.BlockHeight != 0 {
+		hash, err := chainhash.NewHashFromStr(anchor.AnchorBlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid anchor block of asset "+
+				"%x: %w", genesis.AssetId, err)
+		}
+		blockHash = *hash
+	}
+	outpoint, err := wire.NewOutPointFromString(anchor.AnchorOutpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid anchor outpoint of asset %x: "+
+			"%w", genesis.AssetId, err)
+	}
+
+	indexed := &IndexedMon{
+		AssetId:   genesis.AssetId,
+		Name:      genesis.Name,
+		Metadata:  monMetadata,
+		BlockHash: blockHash,
+		TxHash:    outpoint.Hash,
+		Height:    anchor.BlockHeight,
+	}
+	if group := candidate.asset.AssetGroup; group != nil {
+		indexed.GroupKey = group.TweakedGroupKey
+	}
+
+	return indexed, nil
+}
+
+// MintMon will mint a new monster. The mon is derived from the block its
+// mint confirmed in, if that block is reorged out the indexer derives it
+// again from its new anchor. If a collection is given, the mon is minted into
+// its asset group, which is created by minting the first mon of the
+// collection. Other mints into the collection wait until its group is
+// stored.
+func (m *Manager) MintMon(ctx context.Context, name,
+	collectionName string) (*Mon, error) {
+
+	var (
+		collection *MonCollection
+		unlock     = func() {}
+	)
+	defer func() {
+		unlock()
+	}()
+	if collectionName != "" {
+		unlock = m.lockCollection(collectionName)
+
+		var err error
+		collection, err = m.mintCollection(ctx, collectionName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the first mint creates the group.
+		if len(collection.GroupKey) != 0 {
+			unlock()
+			unlock = func() {}
+		}
+	}
+
+	monMetadata := &MonMetadata{
+		Namespace:     MonNamespace,
+		MonVersion:    MonVersion,
+		Name:          name,
+		BreedCooldown: BreedCooldown(0),
+	}
+	conf, batchHash, err := m.mint(ctx, name, monMetadata, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection != nil && len(collection.GroupKey) == 0 {
+		err := m.addCollection(ctx, collection, name, batchHash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to store collection %q: "+
+				"%w", collection.Name, err)
+		}
+	}
+
+	// Get the resulting Mon
+	mintedMon, err := GenerateMonster(conf.BlockHash, batchHash)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Minted Mon: %v\n", mintedMon)
+
+	return mintedMon, nil
+}
+
+// lockCollection locks the collection with the given name and returns the
+// function that unlocks it.
+func (m *Manager) lockCollection(name string) func() {
+	m.mu.Lock()
+	lock, ok := m.collectionLocks[name]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.collectionLocks[name] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+
+	return lock.Unlock
+}
+
+// BreedMon mints a child of two final indexed mons we own. The parents must
+// be off their breeding cooldowns by the next block. The attributes of the
+// child are inherited from the parents once the mint is confirmed.
+func (m *Manager) BreedMon(ctx context.Context, name string, parentA,
+	parentB []byte) (*Mon, error) {
+
+	if bytes.Equal(parentA, parentB) {
+		return nil, fmt.Errorf("a mon can't breed with itself")
+	}
+
+	owned, err := m.ownedAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tipHeight, err := m.tipHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nextHeight := tipHeight + 1
+
+	var (
+		parents    [2]*IndexedMon
+		generation uint32
+	)
+	for i, id := range [][]byte{parentA, parentB} {
+		parent, err := m.store.GetMon(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || parent.Reorged {
+			return nil, fmt.Errorf("%w %x", ErrUnknownParent, id)
+		}
+		if _, ok := owned[string(id)]; !ok {
+			return nil, fmt.Errorf("parent %x isn't owned", id)
+		}
+		status := m.policy.Status(parent, tipHeight)
+		if status != MonFinal {
+			return nil, fmt.Errorf("parent %x is %v, only final "+
+				"mons can breed", id, status)
+		}
+
+		ready, err := breedingReadyHeight(
+			ctx, m.store, parent, nextHeight,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if nextHeight < ready {
+			return nil, fmt.Errorf("parent %x is on breeding "+
+				"cooldown until height %d", id, ready)
+		}
+
+		if parent.Metadata.Generation >= generation {
+			generation = parent.Metadata.Generation + 1
+		}
+		parents[i] = parent
+	}
+
 	monMetadata := &MonMetadata{
-		MonVersion: 1,
+		Namespace:  MonNamespace,
+		MonVersion: MonVersion,
+		Name:       name,
+		Parents: []string{
+			hex.EncodeToString(parentA),
+			hex.EncodeToString(parentB),
+		},
+		Generation:    generation,
+		BreedCooldown: BreedCooldown(generation),
+	}
+	conf, batchHash, err := m.mint(ctx, name, monMetadata, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := BreedMonster(
+		conf.BlockHash, batchHash, parents[0].Mon, parents[1].Mon,
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Bred Mon: %v\n", child)
+
+	return child, nil
+}
+
+// LevelMon searches the nonce proving the requested level of the indexed mon
+// with the given mon id, starting at the given nonce. It returns the mon
+// evolved to the level under the rules of its version and the nonce, and
+// records the level up in the history of the mon. Only final mons can level.
+func (m *Manager) LevelMon(ctx context.Context, monId []byte, level,
+	startNonce int) (*Mon, int, error) {
+
+	if level < 1 || level > MaxLevel {
+		return nil, 0, fmt.Errorf("level %d out of range", level)
+	}
+
+	indexed, err := m.store.GetMonById(ctx, monId)
+	if err != nil {
+		return nil, 0, err
+	}
+	if indexed == nil {
+		return nil, 0, fmt.Errorf("unknown mon %x", monId)
+	}
+
+	tipHeight, err := m.tipHeight(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	status := m.policy.Status(indexed, tipHeight)
+	if status != MonFinal {
+		return nil, 0, fmt.Errorf("mon %x is %v, only final mons can "+
+			"level", monId, status)
+	}
+
+	nonce := indexed.Mon.GetLevelNonce(ctx, level, startNonce)
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	evolved, err := indexed.Mon.Evolved(
+		indexed.Metadata.MonVersion, level, nonce,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = m.store.AddLevelUp(ctx, &MonLevelUp{
+		AssetId:   indexed.AssetId,
+		Level:     level,
+		Nonce:     nonce,
+		Height:    tipHeight,
+		LeveledAt: time.Now(),
+	})
+	if err != nil {
+		return nil, 0, err
 	}
-	// Create the json string of the metadata
-	monMetadataBytes, err := json.Marshal(monMetadata)
+
+	return evolved, nonce, nil
+}
+
+// ownedAssets returns the ids of the assets we own.
+func (m *Manager) ownedAssets(ctx context.Context) (map[string]struct{},
+	error) {
+
+	resp, err := m.tapClient.ListAssets(ctx, &taprpc.ListAssetRequest{})
 	if err != nil {
 		return nil, err
 	}
+
+	owned := make(map[string]struct{}, len(resp.Assets))
+	for _, asset := range resp.Assets {
+		if asset.AssetGenesis != nil {
+			owned[string(asset.AssetGenesis.AssetId)] = struct{}{}
+		}
+	}
+
+	return owned, nil
+}
+
+// mint mints an asset with the given name and metadata and waits for the
+// mint to confirm. The name must follow the name policy and not be claimed
+// by an indexed mon yet. The lnd node of tapd is recorded as the creator of the
+// mon. The asset is minted into the group of the collection unless it's nil.
+// It returns the confirmation and the hash of the mint transaction.
+func (m *Manager) mint(ctx context.Context, name string,
+	monMetadata *MonMetadata, collection *MonCollection) (
+	*chainntnfs.TxConfirmation, *chainhash.Hash, error) {
+
+	if err := m.checkNameAvailable(ctx, name); err != nil {
+		return nil, nil, err
+	}
+
+	info, err := m.tapClient.GetInfo(ctx, &taprpc.GetInfoRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	monMetadata.Creator = info.LndIdentityPubkey
+
+	// Mons that wouldn't be indexed aren't minted in the first place.
+	if err := monMetadata.validate(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidMonMetadata,
+			err)
+	}
+	monMetadataBytes, err := monMetadata.Encode()
+	if err != nil {
+		return nil, nil, err
+	}
+	asset := &mintrpc.MintAsset{
+		Name:         name,
+		AssetVersion: taprpc.AssetVersion_ASSET_VERSION_V1,
+		AssetMeta: &taprpc.AssetMeta{
+			Type: taprpc.AssetMetaType_META_TYPE_JSON,
+			Data: monMetadataBytes,
+		},
+	}
+	groupMintAsset(asset, collection)
 	_, err = m.mintClient.MintAsset(
 		ctx, &mintrpc.MintAssetRequest{
-			Asset: &mintrpc.MintAsset{
-				Name:         name,
-				AssetVersion: taprpc.AssetVersion_ASSET_VERSION_V1,
-				AssetMeta: &taprpc.AssetMeta{
-					Type: taprpc.AssetMetaType_META_TYPE_JSON,
-					Data: monMetadataBytes,
-				},
-			},
+			Asset: asset,
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	finalizeRes, err := m.mintClient.FinalizeBatch(
 		ctx, &mintrpc.FinalizeBatchRequest{},
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	log.Printf("Batch txid: %v\n", finalizeRes.Batch.BatchTxid)
 
+	// The mon is listed as pending until its mint is confirmed.
+	if err := m.indexPending(ctx); err != nil {
+		log.Printf("Unable to list pending mints: %v", err)
+	}
+
 	batchHash, err := chainhash.NewHashFromStr(finalizeRes.Batch.BatchTxid)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	// We'll wait for the transaction to have 2 confirmations before
-	// returning.
+	// We'll wait for the transaction to have the confirmations of our
+	// finality policy before returning.
 	confChan, errChan, err := m.chainNotifier.RegisterConfirmationsNtfn(
-		ctx, batchHash, nil, 2, int32(finalizeRes.Batch.HeightHint),
+		ctx, batchHash, nil, int32(m.policy.MintConfirmations),
+		int32(finalizeRes.Batch.HeightHint),
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	var conf *chainntnfs.TxConfirmation
 	select {
-	case <-errChan:
-		return nil, err
+	case err := <-errChan:
+		return nil, nil, err
 	case conf = <-confChan:
 		log.Printf("Batch txid %v has been confirmed in block %v\n",
 			finalizeRes.Batch.BatchTxid, conf.Block)
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
 	}
 
-	// Get the resulting Mon
-	mintedMon, err := GenerateMonster(conf.BlockHash, batchHash)
-	if err != nil {
-		return nil, err
-	}
-	log.Printf("Minted Mon: %v\n", mintedMon)
-
-	return mintedMon, nil
+	return conf, batchHash, nil
 }
@@ -0,0 +1,237 @@
+package mons
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+const (
+	// MonNamespace identifies the metadata of an asset as that of a mon.
+	MonNamespace = "tapmon"
+
+	// MonVersion is the version of the metadata of mons.
+	MonVersion = 1
+
+	// maxAssetNameLength is the maximum length of asset names in tapd.
+	// Mons minted elsewhere may use any name tapd accepts, the stricter
+	// name policy is only enforced when minting.
+	maxAssetNameLength = 64
+)
+
+// ErrInvalidMonMetadata is returned for metadata in the mon namespace that
+// doesn't follow the schema of mon metadata.
+var ErrInvalidMonMetadata = errors.New("invalid mon metadata")
+
+// MonMetadata is the JSON metadata a mon is minted with. Metadata is only
+// read as that of a mon if its namespace is MonNamespace, and it's rejected
+// unless it's in the canonical encoding of Encode: the fields in the order
+// below, without whitespace or unknown fields and with lowercase hex
+// strings. The metadata is committed to by the asset id, so the attributes
+// of a mon can't be changed after it was minted.
+type MonMetadata struct {
+	// Namespace is always MonNamespace.
+	Namespace string `json:"namespace"`
+
+	// MonVersion selects the breeding and evolution rules of the mon.
+	MonVersion uint32 `json:"mon_version"`
+
+	// Name is the name of the mon, which must be the name of its asset.
+	Name string `json:"name"`
+
+	// Creator is the hex encoded compressed public key of the creator of
+	// the mon.
+	Creator string `json:"creator"`
+
+	// Parents are the hex encoded asset ids of the parents of a bred
+	// mon.
+	Parents []string `json:"parents,omitempty"`
+
+	// Generation is zero for minted mons and one more than the highest
+	// generation of the parents for bred mons.
+	Generation uint32 `json:"generation"`
+
+	// BreedCooldown is the number of blocks the mon has to wait after it
+	// was minted or bred before it can breed again.
+	BreedCooldown uint32 `json:"breed_cooldown"`
+
+	// Signature is the optional hex encoded BIP-340 signature of the
+	// creator over the digest of the metadata, which proves that the mon
+	// was minted by the creator.
+	Signature string `json:"signature,omitempty"`
+}
+
+// ParseMonMetadata strictly parses the JSON metadata of an asset. It returns
+// ErrNotMon if the metadata isn't in the mon namespace and
+// ErrInvalidMonMetadata with the reason if it is, but doesn't follow the
+// schema.
+func ParseMonMetadata(data []byte) (*MonMetadata, error) {
+	var namespace struct {
+		Namespace string `json:"namespace"`
+	}
+	err := json.Unmarshal(data, &namespace)
+	if err != nil || namespace.Namespace != MonNamespace {
+		return nil, ErrNotMon
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var meta MonMetadata
+	if err := decoder.Decode(&meta); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMonMetadata, err)
+	}
+	if err := meta.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMonMetadata, err)
+	}
+
+	canonical, err := meta.Encode()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(canonical, data) {
+		return nil, fmt.Errorf("%w: not canonically encoded",
+			ErrInvalidMonMetadata)
+	}
+
+	return &meta, nil
+}
+
+// Encode returns the canonical encoding of the metadata.
+func (m *MonMetadata) Encode() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+// Digest returns the digest of the canonical encoding of the metadata
+// without its signature, which is signed by the creator.
+func (m *MonMetadata) Digest() ([32]byte, error) {
+	unsigned := *m
+	unsigned.Signature = ""
+
+	data, err := unsigned.Encode()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return sha256.Sum256(data), nil
+}
+
+// Sign sets the creator of the metadata to the public key of the given
+// private key and signs the metadata with it.
+func (m *MonMetadata) Sign(privKey *btcec.PrivateKey) error {
+	m.Creator = hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+
+	digest, err := m.Digest()
+	if err != nil {
+		return err
+	}
+	sig, err := schnorr.Sign(privKey, digest[:])
+	if err != nil {
+		return err
+	}
+	m.Signature = hex.EncodeToString(sig.Serialize())
+
+	return nil
+}
+
+// CreatorKey returns the public key of the creator of the mon.
+func (m *MonMetadata) CreatorKey() (*btcec.PublicKey, error) {
+	key, err := decodeCanonicalHex(m.Creator)
+	if err != nil {
+		return nil, fmt.Errorf("invalid creator: %w", err)
+	}
+	if len(key) != btcec.PubKeyBytesLenCompressed {
+		return nil, fmt.Errorf("creator key isn't compressed")
+	}
+
+	pubKey, err := btcec.ParsePubKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid creator: %w", err)
+	}
+
+	return pubKey, nil
+}
+
+// ParentIds returns the asset ids of the parents in the metadata.
+func (m *MonMetadata) ParentIds() ([][]byte, error) {
+	parents := make([][]byte, 0, len(m.Parents))
+	for _, parent := range m.Parents {
+		id, err := decodeCanonicalHex(parent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent %q: %w", parent,
+				err)
+		}
+		parents = append(parents, id)
+	}
+
+	return parents, nil
+}
+
+// validate checks the fields of the metadata against the schema. The
+// lineage of the mon is checked against its parents when it's derived.
+func (m *MonMetadata) validate() error {
+	if m.Namespace != MonNamespace {
+		return fmt.Errorf("namespace %q isn't %q", m.Namespace,
+			MonNamespace)
+	}
+	if m.MonVersion != MonVersion {
+		return fmt.Errorf("unsupported mon version %d", m.MonVersion)
+	}
+	if m.Name == "" {
+		return fmt.Errorf("no name")
+	}
+	if len(m.Name) > maxAssetNameLength {
+		return fmt.Errorf("name longer than %d bytes",
+			maxAssetNameLength)
+	}
+
+	creator, err := m.CreatorKey()
+	if err != nil {
+		return err
+	}
+	if _, err := m.ParentIds(); err != nil {
+		return err
+	}
+
+	if m.Signature == "" {
+		return nil
+	}
+
+	rawSig, err := decodeCanonicalHex(m.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	sig, err := schnorr.ParseSignature(rawSig)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	digest, err := m.Digest()
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(digest[:], creator) {
+		return fmt.Errorf("signature isn't valid for creator %s",
+			m.Creator)
+	}
+
+	return nil
+}
+
+// decodeCanonicalHex decodes a lowercase hex string.
+func decodeCanonicalHex(s string) ([]byte, error) {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(data) != s {
+		return nil, fmt.Errorf("%q isn't lowercase hex", s)
+	}
+
+	return data, nil
+}
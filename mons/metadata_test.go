@@ -0,0 +1,97 @@
+package mons
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseMonMetadata tests that only canonically encoded metadata in the
+// mon namespace that follows the schema is parsed and that the reasons for
+// rejecting metadata are returned.
+func TestParseMonMetadata(t *testing.T) {
+	meta := testMon(10, 500, 1, 1, 2).Metadata
+	data, err := meta.Encode()
+	require.NoError(t, err)
+	require.Equal(
+		t, `{"namespace":"tapmon","mon_version":1,"name":"mon 10",`+
+			`"creator":"`+testCreator+`","parents":["01","02"],`+
+			`"generation":1,"breed_cooldown":288}`, string(data),
+	)
+
+	parsed, err := ParseMonMetadata(data)
+	require.NoError(t, err)
+	require.Equal(t, meta, parsed)
+
+	// Metadata outside the namespace isn't a mon.
+	for _, data := range []string{
+		`not json`, `{"mon_version":1}`, `{"namespace":"other"}`,
+	} {
+		_, err := ParseMonMetadata([]byte(data))
+		require.ErrorIs(t, err, ErrNotMon)
+	}
+
+	invalid := func(reason string, mutate func(*MonMetadata)) {
+		t.Helper()
+
+		spoofed := *meta
+		mutate(&spoofed)
+		data, err := spoofed.Encode()
+		require.NoError(t, err)
+
+		_, err = ParseMonMetadata(data)
+		require.ErrorIs(t, err, ErrInvalidMonMetadata)
+		require.ErrorContains(t, err, reason)
+	}
+	invalid("version", func(m *MonMetadata) { m.MonVersion = 2 })
+	invalid("no name", func(m *MonMetadata) { m.Name = "" })
+	invalid("longer", func(m *MonMetadata) {
+		m.Name = strings.Repeat("a", maxAssetNameLength+1)
+	})
+	invalid("creator", func(m *MonMetadata) { m.Creator = "" })
+	invalid("creator", func(m *MonMetadata) {
+		m.Creator = strings.ToUpper(m.Creator)
+	})
+	invalid("compressed", func(m *MonMetadata) { m.Creator = "0102" })
+	invalid("parent", func(m *MonMetadata) {
+		m.Parents = []string{"01", "0A"}
+	})
+	invalid("signature", func(m *MonMetadata) { m.Signature = "00" })
+
+	// The encoding must be canonical.
+	for _, data := range []string{
+		strings.Replace(string(data), `,"name"`, `, "name"`, 1),
+		strings.Replace(
+			string(data), `"generation":1,"breed_cooldown":288`,
+			`"breed_cooldown":288,"generation":1`, 1,
+		),
+		strings.Replace(
+			string(data), `}`, `,"unknown":true}`, 1,
+		),
+	} {
+		_, err := ParseMonMetadata([]byte(data))
+		require.ErrorIs(t, err, ErrInvalidMonMetadata)
+	}
+
+	// A signature proves that the creator minted the mon.
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	signed := *meta
+	require.NoError(t, signed.Sign(key))
+	data, err = signed.Encode()
+	require.NoError(t, err)
+	parsed, err = ParseMonMetadata(data)
+	require.NoError(t, err)
+	require.Equal(t, &signed, parsed)
+
+	invalid("isn't valid", func(m *MonMetadata) {
+		m.Creator = signed.Creator
+		m.Signature = signed.Signature
+		m.Name = "other"
+	})
+	invalid("isn't valid", func(m *MonMetadata) {
+		m.Signature = signed.Signature
+	})
+}
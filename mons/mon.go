@@ -11,12 +11,21 @@ import (
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 )
 
+// MaxLevel is the highest level a mon can prove. A level is proven by a hash
+// with as many leading zero hex characters as the level, so the level can't
+// exceed the length of a hex encoded sha256 hash.
+const MaxLevel = 64
+
 type Mon struct {
 	Id     []byte
 	Scores []uint8
 	Types  []string
 	Level  int
 	Nonce  int
+
+	// Form is the evolution form the mon takes at its level, nil if it
+	// wasn't derived.
+	Form *Form
 }
 
 // String returns a string representation of the monster.
@@ -41,6 +50,17 @@ func GenerateMonster(blockHash, txHash *chainhash.Hash) (*Mon, error) {
 	return monster, nil
 }
 
+// MonsterFromId recreates a monster from its id. As the scores of a monster
+// are derived from its id alone, this allows anyone to verify the attributes
+// of a monster without access to the chain. It doesn't prove that a monster
+// with the id was ever minted.
+func MonsterFromId(id []byte) *Mon {
+	return &Mon{
+		Id:     id,
+		Scores: determineScores(id),
+	}
+}
+
 func (m *Mon) GetLevelNonce(ctx context.Context, targetLevel,
 	startNonce int) int {
 
@@ -82,20 +102,20 @@ func (m *Mon) GetLevelNonce(ctx context.Context, targetLevel,
 							fmt.Printf("Monster leveled up! New level: %d, Nonce: %d, Hash: %s\n", m.Level, nonce, hashStr)
 						}
 						mu.Unlock()
-						break
+						return
 					}
 
 					nonce += numWorkers
 					mu.Lock()
 					if found {
 						mu.Unlock()
-						break
+						return
 					}
 					mu.Unlock()
 				}
 
 			}
-		}(i, i)
+		}(i, startNonce+i)
 	}
 
 	wg.Wait()
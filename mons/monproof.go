@@ -0,0 +1,818 @@
+package mons
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+)
+
+const (
+	// MonProofVersion is the version of the mon proofs we export.
+	MonProofVersion = 1
+
+	// meanTimestampBlocks is the number of blocks whose timestamps are
+	// averaged for the mean timestamp of a block.
+	meanTimestampBlocks = 11
+)
+
+// LevelProof is a nonce proving a level of a mon.
+type LevelProof struct {
+	// Level is the proven level.
+	Level int `json:"level"`
+
+	// Nonce is the nonce proving the level.
+	Nonce int `json:"nonce"`
+}
+
+// MonStats are the stats of a mon as claimed by a mon proof.
+type MonStats struct {
+	// Id is the hex encoded id of the mon.
+	Id string `json:"id"`
+
+	// Scores are the scores of the mon in its form.
+	Scores []int `json:"scores"`
+
+	// Types are the types the mon gained by evolving.
+	Types []string `json:"types,omitempty"`
+
+	// Level is the highest proven level of the mon.
+	Level int `json:"level"`
+
+	// Form is the name of the form the mon takes at its level.
+	Form string `json:"form"`
+}
+
+// newMonStats returns the stats of a derived mon.
+func newMonStats(mon *Mon) *MonStats {
+	stats := &MonStats{
+		Id:     hex.EncodeToString(mon.Id),
+		Scores: make([]int, len(mon.Scores)),
+		Types:  mon.Types,
+		Level:  mon.Level,
+	}
+	for i, score := range mon.Scores {
+		stats.Scores[i] = int(score)
+	}
+	if mon.Form != nil {
+		stats.Form = mon.Form.Name
+	}
+
+	return stats
+}
+
+// MonProof is a self-contained bundle proving that a mon is genuine and who
+// owns it. Anyone who knows the headers of the blocks the mon and its
+// ancestors were anchored in can verify it offline, without trusting the
+// daemon that exported it.
+type MonProof struct {
+	// Version is the version of the bundle.
+	Version uint32 `json:"version"`
+
+	// AssetId is the hex encoded asset id of the mon.
+	AssetId string `json:"asset_id"`
+
+	// ProofFile is the hex encoded tapd proof file of the asset from its
+	// mint up to its current owner. The proof files of parents only
+	// contain the mint.
+	ProofFile string `json:"proof_file"`
+
+	// AnchorHeader is the hex encoded header of the block the mint of the
+	// mon was anchored in.
+	AnchorHeader string `json:"anchor_header"`
+
+	// AnchorHeight is the height of the block the mint was anchored in.
+	AnchorHeight uint32 `json:"anchor_height"`
+
+	// Stats are the stats of the mon at its highest proven level.
+	Stats *MonStats `json:"stats"`
+
+	// LevelProofs are the proofs of the levels the mon reached.
+	LevelProofs []LevelProof `json:"level_proofs,omitempty"`
+
+	// Parents are the proofs of the parents of a bred mon in the order of
+	// its metadata. They prove the parents as they were minted, without
+	// their levels and owners.
+	Parents []*MonProof `json:"parents,omitempty"`
+
+	// OwnershipProof is the hex encoded tapd ownership proof of the
+	// current owner: the last proof of the proof file together with a
+	// witness signed by the script key of the owner. Proofs of parents
+	// have none.
+	OwnershipProof string `json:"ownership_proof,omitempty"`
+}
+
+// VerifiedMon is a mon whose proof was verified.
+type VerifiedMon struct {
+	*IndexedMon
+
+	// Evolved is the mon at its highest proven level.
+	Evolved *Mon
+
+	// Owner is the owner that signed the proof.
+	Owner *MonOwner
+}
+
+// BlockHeaders are block headers by their height, which mon proofs are
+// verified against. They must be taken from a source the verifier trusts,
+// such as its own node.
+type BlockHeaders map[uint32]wire.BlockHeader
+
+// ReadBlockHeaders reads block headers from lines of a height and a hex
+// encoded header separated by whitespace. Empty lines are skipped.
+func ReadBlockHeaders(r io.Reader) (BlockHeaders, error) {
+	headers := make(BlockHeaders)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a height "+
+				"and a header", line)
+		}
+
+		height, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid height: %w",
+				line, err)
+		}
+		header, err := decodeHeader(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		headers[uint32(height)] = *header
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return headers, nil
+}
+
+// verifyHeader returns an error if the header isn't the known header at the
+// given height.
+func (h BlockHeaders) verifyHeader(header wire.BlockHeader,
+	height uint32) error {
+
+	known, ok := h[height]
+	if !ok {
+		return fmt.Errorf("no block header known at height %d", height)
+	}
+	if known.BlockHash() != header.BlockHash() {
+		return fmt.Errorf("block %v isn't the block at height %d",
+			header.BlockHash(), height)
+	}
+
+	return nil
+}
+
+// headerChainLookup looks up the chain in block headers and the anchors of a
+// proof file, so that the timelocks of its proofs can be verified offline.
+type headerChainLookup struct {
+	headers BlockHeaders
+	file    *proof.File
+}
+
+// TxBlockHeight returns the height of the block an anchor of the proof file
+// was confirmed in.
+func (l *headerChainLookup) TxBlockHeight(_ context.Context,
+	txid chainhash.Hash) (uint32, error) {
+
+	for i := 0; i < l.file.NumProofs(); i++ {
+		p, err := l.file.ProofAt(uint32(i))
+		if err != nil {
+			return 0, err
+		}
+		if p.AnchorTx.TxHash() == txid {
+			return p.BlockHeight, nil
+		}
+	}
+
+	return 0, fmt.Errorf("transaction %v isn't an anchor of the proof "+
+		"file", txid)
+}
+
+// MeanBlockTimestamp returns the mean timestamp of the known headers of the
+// block at the given height and the blocks before it.
+func (l *headerChainLookup) MeanBlockTimestamp(_ context.Context,
+	blockHeight uint32) (time.Time, error) {
+
+	if _, ok := l.headers[blockHeight]; !ok {
+		return time.Time{}, fmt.Errorf("no block header known at "+
+			"height %d", blockHeight)
+	}
+
+	var sum, count int64
+	for i := uint32(0); i < meanTimestampBlocks && i <= blockHeight; i++ {
+		header, ok := l.headers[blockHeight-i]
+		if !ok {
+			continue
+		}
+		sum += header.Timestamp.Unix()
+		count++
+	}
+
+	return time.Unix(sum/count, 0), nil
+}
+
+// CurrentHeight returns the height of the highest known header.
+func (l *headerChainLookup) CurrentHeight(context.Context) (uint32, error) {
+	var height uint32
+	for h := range l.headers {
+		if h > height {
+			height = h
+		}
+	}
+
+	return height, nil
+}
+
+// rejectGroupKey rejects grouped assets, as mons are minted without group
+// keys.
+func rejectGroupKey(groupKey *btcec.PublicKey) error {
+	return fmt.Errorf("%w: asset has group key %x", ErrNotMon,
+		groupKey.SerializeCompressed())
+}
+
+// decodeHeader decodes a hex encoded block header.
+func decodeHeader(encoded string) (*wire.BlockHeader, error) {
+	raw, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block header: %w", err)
+	}
+
+	var header wire.BlockHeader
+	if err := header.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("invalid block header: %w", err)
+	}
+
+	return &header, nil
+}
+
+// encodeHeader hex encodes a block header.
+func encodeHeader(header *wire.BlockHeader) (string, error) {
+	var buf bytes.Buffer
+	if err := header.Serialize(&buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeProofFile decodes a tapd proof file.
+func decodeProofFile(raw []byte) (*proof.File, error) {
+	var file proof.File
+	if err := file.Decode(bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("invalid proof file: %w", err)
+	}
+	if file.NumProofs() == 0 {
+		return nil, fmt.Errorf("empty proof file")
+	}
+
+	return &file, nil
+}
+
+// evolve checks the level proofs of a mon and returns it evolved to the
+// highest proven level.
+func evolve(mon *Mon, version uint32, levelProofs []LevelProof) (*Mon,
+	error) {
+
+	evolved, err := mon.Evolved(version, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, levelProof := range levelProofs {
+		leveled, err := mon.Evolved(
+			version, levelProof.Level, levelProof.Nonce,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if leveled.Level > evolved.Level {
+			evolved = leveled
+		}
+	}
+
+	return evolved, nil
+}
+
+// VerifyMonProof verifies a mon proof offline against the given block
+// headers. The proof files of the mon and its ancestors are verified by the
+// rules of tapd, the mon is derived from its mint anchor and the proofs of
+// its parents, its level proofs are checked and its stats have to follow
+// from them. Finally the ownership proof has to be signed by the script key
+// that holds the mon at the end of its proof file. The breeding cooldowns of
+// the parents can't be verified offline, as they depend on all of their
+// children.
+func VerifyMonProof(ctx context.Context, monProof *MonProof,
+	headers BlockHeaders) (*VerifiedMon, error) {
+
+	if monProof.OwnershipProof == "" {
+		return nil, fmt.Errorf("mon proof has no ownership proof")
+	}
+
+	verified, file, err := verifyMon(ctx, monProof, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(monProof.OwnershipProof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ownership proof: %w", err)
+	}
+	var ownership proof.Proof
+	if err := ownership.Decode(bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("invalid ownership proof: %w", err)
+	}
+	if len(ownership.ChallengeWitness) == 0 {
+		return nil, fmt.Errorf("ownership proof isn't signed")
+	}
+
+	// The ownership proof has to prove the asset as it is held at the
+	// end of the proof file.
+	last, err := file.LastProof()
+	if err != nil {
+		return nil, err
+	}
+	if ownership.Asset.ID() != last.Asset.ID() ||
+		!ownership.Asset.ScriptKey.PubKey.IsEqual(
+			last.Asset.ScriptKey.PubKey,
+		) ||
+		ownership.AnchorTx.TxHash() != last.AnchorTx.TxHash() ||
+		ownership.InclusionProof.OutputIndex !=
+			last.InclusionProof.OutputIndex {
+
+		return nil, fmt.Errorf("ownership proof doesn't prove the " +
+			"current owner")
+	}
+
+	snapshot, err := ownership.Verify(
+		ctx, nil, headers.verifyHeader, proof.DefaultMerkleVerifier,
+		rejectGroupKey, &headerChainLookup{
+			headers: headers,
+			file:    file,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ownership proof: %w", err)
+	}
+
+	scriptKey := snapshot.Asset.ScriptKey.PubKey
+	verified.Owner = &MonOwner{
+		AssetId:        verified.AssetId,
+		ScriptKey:      scriptKey.SerializeCompressed(),
+		AnchorOutpoint: snapshot.OutPoint,
+		BlockHash:      snapshot.AnchorBlockHash,
+		Height:         snapshot.AnchorBlockHeight,
+		BlockTime:      ownership.BlockHeader.Timestamp,
+	}
+
+	return verified, nil
+}
+
+// VerifyMonProof verifies a mon proof against the headers of the main chain
+// as our node knows it, so that the proofs of other players can be verified
+// without trusting the block headers they came with.
+func (m *Manager) VerifyMonProof(ctx context.Context,
+	monProof *MonProof) (*VerifiedMon, error) {
+
+	heights := make(map[uint32]struct{})
+	if err := proofHeights(monProof, heights); err != nil {
+		return nil, err
+	}
+
+	headers := make(BlockHeaders, len(heights))
+	for height := range heights {
+		hash, err := m.chainKit.GetBlockHash(ctx, int64(height))
+		if err != nil {
+			return nil, fmt.Errorf("unable to get block hash at "+
+				"height %d: %w", height, err)
+		}
+		header, err := m.chainKit.GetBlockHeader(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get block header at "+
+				"height %d: %w", height, err)
+		}
+		headers[height] = *header
+	}
+
+	return VerifyMonProof(ctx, monProof, headers)
+}
+
+// proofHeights adds the heights of the blocks the proofs of a mon proof and
+// the proofs of its parents are anchored in to the given heights, together
+// with the heights of the blocks before them whose timestamps are averaged
+// to verify timelocks.
+func proofHeights(monProof *MonProof, heights map[uint32]struct{}) error {
+	add := func(height uint32) {
+		for i := uint32(0); i < meanTimestampBlocks && i <= height; i++ {
+			heights[height-i] = struct{}{}
+		}
+	}
+
+	raw, err := hex.DecodeString(monProof.ProofFile)
+	if err != nil {
+		return fmt.Errorf("invalid proof file: %w", err)
+	}
+	file, err := decodeProofFile(raw)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < file.NumProofs(); i++ {
+		p, err := file.ProofAt(uint32(i))
+		if err != nil {
+			return err
+		}
+		add(p.BlockHeight)
+	}
+
+	if monProof.OwnershipProof != "" {
+		raw, err := hex.DecodeString(monProof.OwnershipProof)
+		if err != nil {
+			return fmt.Errorf("invalid ownership proof: %w", err)
+		}
+		var ownership proof.Proof
+		err = ownership.Decode(bytes.NewReader(raw))
+		if err != nil {
+			return fmt.Errorf("invalid ownership proof: %w", err)
+		}
+		add(ownership.BlockHeight)
+	}
+
+	for _, parent := range monProof.Parents {
+		if err := proofHeights(parent, heights); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyMon verifies the proof file of a mon proof and derives the mon from
+// its mint anchor and the proofs of its parents. It returns the verified mon
+// without its owner and the verified proof file.
+func verifyMon(ctx context.Context, monProof *MonProof,
+	headers BlockHeaders) (*VerifiedMon, *proof.File, error) {
+
+	if monProof.Version != MonProofVersion {
+		return nil, nil, fmt.Errorf("unsupported mon proof version %d",
+			monProof.Version)
+	}
+
+	raw, err := hex.DecodeString(monProof.ProofFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid proof file: %w", err)
+	}
+	file, err := decodeProofFile(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = file.Verify(
+		ctx, headers.verifyHeader, proof.DefaultMerkleVerifier,
+		rejectGroupKey, &headerChainLookup{
+			headers: headers,
+			file:    file,
+		},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid proof file: %w", err)
+	}
+
+	mint, err := file.ProofAt(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	assetId := mint.Asset.ID()
+	if hex.EncodeToString(assetId[:]) != monProof.AssetId {
+		return nil, nil, fmt.Errorf("proof file proves asset %x "+
+			"instead of %v", assetId[:], monProof.AssetId)
+	}
+	if mint.MetaReveal == nil || mint.MetaReveal.Type != proof.MetaJson {
+		return nil, nil, fmt.Errorf("%w: asset %x has no JSON "+
+			"metadata", ErrNotMon, assetId[:])
+	}
+	meta, err := ParseMonMetadata(mint.MetaReveal.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if meta.Name != mint.Asset.Genesis.Tag {
+		return nil, nil, fmt.Errorf("%w: name %q isn't the asset name "+
+			"%q", ErrInvalidMonMetadata, meta.Name,
+			mint.Asset.Genesis.Tag)
+	}
+
+	header, err := decodeHeader(monProof.AnchorHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.BlockHash() != mint.BlockHeader.BlockHash() ||
+		monProof.AnchorHeight != mint.BlockHeight {
+
+		return nil, nil, fmt.Errorf("anchor header doesn't match " +
+			"the mint of the proof file")
+	}
+
+	indexed := &IndexedMon{
+		AssetId:   assetId[:],
+		Name:      mint.Asset.Genesis.Tag,
+		Metadata:  meta,
+		BlockHash: header.BlockHash(),
+		TxHash:    mint.AnchorTx.TxHash(),
+		Height:    mint.BlockHeight,
+	}
+	indexed.Mon, err = deriveProvenMon(
+		ctx, indexed, monProof.Parents, headers,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	evolved, err := evolve(
+		indexed.Mon, meta.MonVersion, monProof.LevelProofs,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !reflect.DeepEqual(newMonStats(evolved), monProof.Stats) {
+		return nil, nil, fmt.Errorf("stats of mon %x don't follow "+
+			"from its proof", evolved.Id)
+	}
+
+	return &VerifiedMon{
+		IndexedMon: indexed,
+		Evolved:    evolved,
+	}, file, nil
+}
+
+// deriveProvenMon derives a mon from its mint anchor. For a bred mon the
+// proofs of its parents are verified, which must have been minted before
+// it, and its generation has to follow from theirs.
+func deriveProvenMon(ctx context.Context, indexed *IndexedMon,
+	parents []*MonProof, headers BlockHeaders) (*Mon, error) {
+
+	meta := indexed.Metadata
+	parentIds, err := meta.lineage()
+	if err != nil {
+		return nil, err
+	}
+	if len(parentIds) != len(parents) {
+		return nil, fmt.Errorf("mon has %d parents, but proofs of %d",
+			len(parentIds), len(parents))
+	}
+	if len(parentIds) == 0 {
+		return GenerateMonster(&indexed.BlockHash, &indexed.TxHash)
+	}
+
+	var (
+		parentMons [2]*Mon
+		generation uint32
+	)
+	for i, id := range parentIds {
+		parent, _, err := verifyMon(ctx, parents[i], headers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof of parent %x: %w",
+				id, err)
+		}
+		if !bytes.Equal(parent.AssetId, id) {
+			return nil, fmt.Errorf("proof of parent %x proves "+
+				"asset %x", id, parent.AssetId)
+		}
+		if parent.Height >= indexed.Height {
+			return nil, fmt.Errorf("parent %x minted at height "+
+				"%d, not before its child", id, parent.Height)
+		}
+
+		if parent.Metadata.Generation >= generation {
+			generation = parent.Metadata.Generation + 1
+		}
+		parentMons[i] = parent.Mon
+	}
+	if meta.Generation != generation {
+		return nil, fmt.Errorf("generation %d doesn't match "+
+			"generation %d of parents", meta.Generation, generation)
+	}
+
+	return BreedMonster(
+		&indexed.BlockHash, &indexed.TxHash, parentMons[0],
+		parentMons[1],
+	)
+}
+
+// ExportMonProof exports a proof of the indexed mon with the given mon id,
+// which we must own. The proof bundles the proof file of its asset, the
+// proofs of its ancestors as far as tapd knows them, the level ups we
+// recorded and an ownership proof signed with the script key holding the
+// mon.
+func (m *Manager) ExportMonProof(ctx context.Context, monId []byte) (*MonProof,
+	error) {
+
+	indexed, err := m.store.GetMonById(ctx, monId)
+	if err != nil {
+		return nil, err
+	}
+	if indexed == nil {
+		return nil, fmt.Errorf("unknown mon %x", monId)
+	}
+	if indexed.Reorged {
+		return nil, fmt.Errorf("mon %x is reorged", monId)
+	}
+
+	resp, err := m.tapClient.ListAssets(ctx, &taprpc.ListAssetRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var owned *taprpc.Asset
+	for _, asset := range resp.Assets {
+		if asset.AssetGenesis == nil || asset.ChainAnchor == nil {
+			continue
+		}
+		if bytes.Equal(asset.AssetGenesis.AssetId, indexed.AssetId) {
+			owned = asset
+			break
+		}
+	}
+	if owned == nil {
+		return nil, fmt.Errorf("mon %x isn't owned", monId)
+	}
+
+	levelUps, err := m.store.ListLevelUps(ctx, indexed.AssetId)
+	if err != nil {
+		return nil, err
+	}
+	levelProofs := make([]LevelProof, 0, len(levelUps))
+	for _, levelUp := range levelUps {
+		levelProofs = append(levelProofs, LevelProof{
+			Level: levelUp.Level,
+			Nonce: levelUp.Nonce,
+		})
+	}
+
+	monProof, err := m.monProof(ctx, indexed, owned, levelProofs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	outpoint, err := rpcOutPoint(owned.ChainAnchor.AnchorOutpoint)
+	if err != nil {
+		return nil, err
+	}
+	ownership, err := m.walletClient.ProveAssetOwnership(
+		ctx, &assetwalletrpc.ProveAssetOwnershipRequest{
+			AssetId:   indexed.AssetId,
+			ScriptKey: owned.ScriptKey,
+			Outpoint:  outpoint,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	monProof.OwnershipProof = hex.EncodeToString(
+		ownership.ProofWithWitness,
+	)
+
+	return monProof, nil
+}
+
+// monProof exports the proof file of the given asset of an indexed mon and
+// bundles it with the proofs of its parents. If mintOnly is set, the proof
+// file is cut down to the mint of the asset.
+func (m *Manager) monProof(ctx context.Context, indexed *IndexedMon,
+	asset *taprpc.Asset, levelProofs []LevelProof,
+	mintOnly bool) (*MonProof, error) {
+
+	outpoint, err := rpcOutPoint(asset.ChainAnchor.AnchorOutpoint)
+	if err != nil {
+		return nil, err
+	}
+	exported, err := m.tapClient.ExportProof(
+		ctx, &taprpc.ExportProofRequest{
+			AssetId:   indexed.AssetId,
+			ScriptKey: asset.ScriptKey,
+			Outpoint:  outpoint,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	rawFile := exported.RawProofFile
+
+	file, err := decodeProofFile(rawFile)
+	if err != nil {
+		return nil, err
+	}
+	mint, err := file.ProofAt(0)
+	if err != nil {
+		return nil, err
+	}
+	if mintOnly {
+		file, err = proof.NewFile(proof.V0, *mint)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := file.Encode(&buf); err != nil {
+			return nil, err
+		}
+		rawFile = buf.Bytes()
+	}
+	header, err := encodeHeader(&mint.BlockHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	evolved, err := evolve(
+		indexed.Mon, indexed.Metadata.MonVersion, levelProofs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	monProof := &MonProof{
+		Version:      MonProofVersion,
+		AssetId:      hex.EncodeToString(indexed.AssetId),
+		ProofFile:    hex.EncodeToString(rawFile),
+		AnchorHeader: header,
+		AnchorHeight: mint.BlockHeight,
+		Stats:        newMonStats(evolved),
+		LevelProofs:  levelProofs,
+	}
+
+	parentIds, err := indexed.Metadata.ParentIds()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range parentIds {
+		parent, err := m.store.GetMon(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("%w %x", ErrUnknownParent, id)
+		}
+
+		parentAsset, err := m.knownAsset(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		parentProof, err := m.monProof(
+			ctx, parent, parentAsset, nil, true,
+		)
+		if err != nil {
+			return nil, err
+		}
+		monProof.Parents = append(monProof.Parents, parentProof)
+	}
+
+	return monProof, nil
+}
+
+// knownAsset returns an asset with the given asset id that tapd knows, held
+// by us or spent.
+func (m *Manager) knownAsset(ctx context.Context,
+	assetId []byte) (*taprpc.Asset, error) {
+
+	resp, err := m.tapClient.ListAssets(ctx, &taprpc.ListAssetRequest{
+		IncludeSpent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, asset := range resp.Assets {
+		if asset.AssetGenesis != nil && asset.ChainAnchor != nil &&
+			bytes.Equal(asset.AssetGenesis.AssetId, assetId) {
+
+			return asset, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no proof of asset %x known", assetId)
+}
+
+// rpcOutPoint converts an outpoint string to its rpc representation.
+func rpcOutPoint(outpoint string) (*taprpc.OutPoint, error) {
+	op, err := wire.NewOutPointFromString(outpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid outpoint %q: %w", outpoint, err)
+	}
+
+	return &taprpc.OutPoint{
+		Txid:        op.Hash[:],
+		OutputIndex: op.Index,
+	}, nil
+}
@@ -0,0 +1,446 @@
+package mons
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func (m *memTapd) ExportProof(_ context.Context,
+	req *taprpc.ExportProofRequest,
+	_ ...grpc.CallOption) (*taprpc.ProofFile, error) {
+
+	rawFile, ok := m.files[string(req.AssetId)]
+	if !ok {
+		return nil, fmt.Errorf("no proof of asset %x", req.AssetId)
+	}
+
+	return &taprpc.ProofFile{RawProofFile: rawFile}, nil
+}
+
+// memWallet is an asset wallet that proves the ownership of a fixed set of
+// assets.
+type memWallet struct {
+	assetwalletrpc.AssetWalletClient
+
+	ownership map[string][]byte
+}
+
+func (w *memWallet) ProveAssetOwnership(_ context.Context,
+	req *assetwalletrpc.ProveAssetOwnershipRequest,
+	_ ...grpc.CallOption) (*assetwalletrpc.ProveAssetOwnershipResponse,
+	error) {
+
+	return &assetwalletrpc.ProveAssetOwnershipResponse{
+		ProofWithWitness: w.ownership[string(req.AssetId)],
+	}, nil
+}
+
+// headerChain is a chain that knows the given headers and a header of its own
+// at every other height.
+type headerChain struct {
+	lndclient.ChainKitClient
+
+	headers BlockHeaders
+}
+
+// header returns the header of the chain at the given height.
+func (c *headerChain) header(height uint32) wire.BlockHeader {
+	if header, ok := c.headers[height]; ok {
+		return header
+	}
+
+	return wire.BlockHeader{
+		Nonce:     height,
+		Timestamp: time.Unix(int64(height)*600, 0),
+	}
+}
+
+func (c *headerChain) GetBlockHash(_ context.Context,
+	height int64) (chainhash.Hash, error) {
+
+	header := c.header(uint32(height))
+	return header.BlockHash(), nil
+}
+
+func (c *headerChain) GetBlockHeader(_ context.Context,
+	hash chainhash.Hash) (*wire.BlockHeader, error) {
+
+	for height := uint32(0); height < 1000; height++ {
+		header := c.header(height)
+		if header.BlockHash() == hash {
+			return &header, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown block %v", hash)
+}
+
+// testMint is a genuine mint of a mon, anchored in a block of its own.
+type testMint struct {
+	proof *proof.Proof
+	key   *btcec.PrivateKey
+}
+
+// newTestMint mints a mon with the given metadata in a block at the given
+// height.
+func newTestMint(t *testing.T, name string, meta *MonMetadata,
+	height uint32) *testMint {
+
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	data, err := meta.Encode()
+	require.NoError(t, err)
+	metaReveal := &proof.MetaReveal{Type: proof.MetaJson, Data: data}
+
+	genesis := asset.Genesis{
+		FirstPrevOut: wire.OutPoint{Hash: chainhash.Hash{byte(height)}},
+		Tag:          name,
+		MetaHash:     metaReveal.MetaHash(),
+		Type:         asset.Collectible,
+	}
+	tapCommitment, assets, err := commitment.Mint(
+		nil, genesis, nil, &commitment.AssetDetails{
+			Type:      asset.Collectible,
+			ScriptKey: keychain.KeyDescriptor{PubKey: key.PubKey()},
+		},
+	)
+	require.NoError(t, err)
+	minted := assets[0]
+	_, commitmentProof, err := tapCommitment.Proof(
+		minted.TapCommitmentKey(), minted.AssetCommitmentKey(),
+	)
+	require.NoError(t, err)
+
+	internalKey, err := schnorr.ParsePubKey(
+		schnorr.SerializePubKey(key.PubKey()),
+	)
+	require.NoError(t, err)
+	tapscriptRoot := tapCommitment.TapscriptRoot(nil)
+	pkScript, err := txscript.PayToTaprootScript(
+		txscript.ComputeTaprootOutputKey(internalKey, tapscriptRoot[:]),
+	)
+	require.NoError(t, err)
+
+	anchorTx := &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: genesis.FirstPrevOut,
+		}},
+		TxOut: []*wire.TxOut{{PkScript: pkScript, Value: 1000}},
+	}
+	merkleProof, err := proof.NewTxMerkleProof(
+		[]*wire.MsgTx{anchorTx}, 0,
+	)
+	require.NoError(t, err)
+
+	txHash := anchorTx.TxHash()
+	header := wire.NewBlockHeader(0, &chainhash.Hash{}, &txHash, 0, 0)
+	header.Timestamp = time.Unix(int64(height)*600, 0)
+
+	return &testMint{
+		proof: &proof.Proof{
+			PrevOut:       genesis.FirstPrevOut,
+			BlockHeader:   *header,
+			BlockHeight:   height,
+			AnchorTx:      *anchorTx,
+			TxMerkleProof: *merkleProof,
+			Asset:         *minted,
+			InclusionProof: proof.TaprootProof{
+				InternalKey: internalKey,
+				CommitmentProof: &proof.CommitmentProof{
+					Proof: *commitmentProof,
+				},
+			},
+			MetaReveal:    metaReveal,
+			GenesisReveal: &genesis,
+		},
+		key: key,
+	}
+}
+
+// file returns the encoded proof file of the mint.
+func (m *testMint) file(t *testing.T) []byte {
+	file, err := proof.NewFile(proof.V0, *m.proof)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, file.Encode(&buf))
+
+	return buf.Bytes()
+}
+
+// ownershipProof returns the encoded mint proof with a witness signed by the
+// script key of the minted asset.
+func (m *testMint) ownershipProof(t *testing.T) []byte {
+	owned := m.proof.Asset.Copy()
+	prevId, proofAsset := proof.CreateOwnershipProofAsset(owned)
+	virtualTx, _, err := tapscript.VirtualTx(
+		proofAsset, commitment.InputSet{prevId: owned},
+	)
+	require.NoError(t, err)
+	sigHash, err := tapscript.InputKeySpendSigHash(
+		virtualTx, owned, proofAsset, 0, txscript.SigHashDefault,
+	)
+	require.NoError(t, err)
+	sig, err := schnorr.Sign(
+		txscript.TweakTaprootPrivKey(*m.key, nil), sigHash,
+	)
+	require.NoError(t, err)
+
+	ownership := *m.proof
+	ownership.ChallengeWitness = wire.TxWitness{sig.Serialize()}
+
+	var buf bytes.Buffer
+	require.NoError(t, ownership.Encode(&buf))
+
+	return buf.Bytes()
+}
+
+// indexed returns the mint as an indexed mon.
+func (m *testMint) indexed(t *testing.T) *IndexedMon {
+	assetId := m.proof.Asset.ID()
+	meta, err := ParseMonMetadata(m.proof.MetaReveal.Data)
+	require.NoError(t, err)
+
+	return &IndexedMon{
+		AssetId:   assetId[:],
+		Name:      m.proof.Asset.Genesis.Tag,
+		Metadata:  meta,
+		BlockHash: m.proof.BlockHeader.BlockHash(),
+		TxHash:    m.proof.AnchorTx.TxHash(),
+		Height:    m.proof.BlockHeight,
+	}
+}
+
+// rpcAsset returns the minted asset as tapd lists it.
+func (m *testMint) rpcAsset() *taprpc.Asset {
+	assetId := m.proof.Asset.ID()
+	outpoint := wire.OutPoint{Hash: m.proof.AnchorTx.TxHash()}
+
+	return &taprpc.Asset{
+		AssetGenesis: &taprpc.GenesisInfo{
+			AssetId: assetId[:],
+			Name:    m.proof.Asset.Genesis.Tag,
+		},
+		ScriptKey: m.proof.Asset.ScriptKey.PubKey.SerializeCompressed(),
+		ChainAnchor: &taprpc.AnchorInfo{
+			AnchorOutpoint: outpoint.String(),
+			BlockHeight:    m.proof.BlockHeight,
+		},
+	}
+}
+
+// TestMonProof tests that an exported proof of a bred mon verifies offline
+// against the headers of the blocks it and its parents were minted in, and
+// that tampering with any part of it is detected.
+func TestMonProof(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{files: make(map[string][]byte)}
+	wallet := &memWallet{ownership: make(map[string][]byte)}
+	store := newMemMonStore()
+	chain := &headerChain{}
+	manager := NewManager(
+		tapd, nil, wallet, nil, chain, store, testPolicy, nil,
+	)
+
+	minted := func(name string) *MonMetadata {
+		return &MonMetadata{
+			Namespace:     MonNamespace,
+			MonVersion:    MonVersion,
+			Name:          name,
+			Creator:       testCreator,
+			BreedCooldown: BreedCooldown(0),
+		}
+	}
+	parentA := newTestMint(t, "parent a", minted("parent a"), 100)
+	parentB := newTestMint(t, "parent b", minted("parent b"), 101)
+	parentIdA := parentA.indexed(t).AssetId
+	parentIdB := parentB.indexed(t).AssetId
+	child := newTestMint(t, "child", &MonMetadata{
+		Namespace:  MonNamespace,
+		MonVersion: MonVersion,
+		Name:       "child",
+		Creator:    testCreator,
+		Parents: []string{
+			hex.EncodeToString(parentIdA),
+			hex.EncodeToString(parentIdB),
+		},
+		Generation:    1,
+		BreedCooldown: BreedCooldown(1),
+	}, 101+BaseBreedCooldown)
+
+	headers := make(BlockHeaders)
+	for _, mint := range []*testMint{parentA, parentB, child} {
+		indexed := mint.indexed(t)
+		var err error
+		indexed.Mon, err = deriveMon(ctx, store, indexed)
+		require.NoError(t, err)
+		require.NoError(t, store.AddMon(ctx, indexed))
+
+		tapd.assets = append(tapd.assets, mint.rpcAsset())
+		tapd.files[string(indexed.AssetId)] = mint.file(t)
+		headers[mint.proof.BlockHeight] = mint.proof.BlockHeader
+	}
+	indexed := store.mons[string(child.indexed(t).AssetId)]
+	wallet.ownership[string(indexed.AssetId)] = child.ownershipProof(t)
+
+	leveled := *indexed.Mon
+	nonce := leveled.GetLevelNonce(ctx, 2, 0)
+	require.NoError(t, store.AddLevelUp(ctx, &MonLevelUp{
+		AssetId: indexed.AssetId,
+		Level:   2,
+		Nonce:   nonce,
+	}))
+
+	_, err := manager.ExportMonProof(ctx, []byte{1})
+	require.ErrorContains(t, err, "unknown mon")
+
+	exported, err := manager.ExportMonProof(ctx, indexed.Mon.Id)
+	require.NoError(t, err)
+	require.Len(t, exported.Parents, 2)
+	require.Empty(t, exported.Parents[0].OwnershipProof)
+
+	// The proof survives being passed around as JSON.
+	data, err := json.Marshal(exported)
+	require.NoError(t, err)
+	var monProof MonProof
+	require.NoError(t, json.Unmarshal(data, &monProof))
+
+	verified, err := VerifyMonProof(ctx, &monProof, headers)
+	require.NoError(t, err)
+	require.Equal(t, indexed.AssetId, verified.AssetId)
+	require.Equal(t, "child", verified.Name)
+	require.Equal(t, indexed.Mon, verified.Mon)
+	require.Equal(t, 2, verified.Evolved.Level)
+	require.Equal(t, "juvenile", verified.Evolved.Form.Name)
+	require.Equal(t, tapd.assets[2].ScriptKey, verified.Owner.ScriptKey)
+	require.Equal(t, child.proof.AnchorTx.TxHash(),
+		verified.Owner.AnchorOutpoint.Hash)
+	require.Equal(t, child.proof.BlockHeight, verified.Owner.Height)
+
+	// The proof verifies against the headers of the main chain of our
+	// node just as well.
+	chain.headers = headers
+	fromChain, err := manager.VerifyMonProof(ctx, &monProof)
+	require.NoError(t, err)
+	require.Equal(t, verified, fromChain)
+
+	// Every part of the proof is verified.
+	tamper := func(mutate func(p *MonProof)) error {
+		var tampered MonProof
+		require.NoError(t, json.Unmarshal(data, &tampered))
+		mutate(&tampered)
+
+		_, err := VerifyMonProof(ctx, &tampered, headers)
+		return err
+	}
+	err = tamper(func(p *MonProof) {
+		p.Stats.Scores[0]++
+	})
+	require.ErrorContains(t, err, "don't follow from its proof")
+
+	err = tamper(func(p *MonProof) {
+		p.LevelProofs[0].Nonce++
+	})
+	require.ErrorContains(t, err, "invalid level proof")
+
+	err = tamper(func(p *MonProof) {
+		p.Parents[0], p.Parents[1] = p.Parents[1], p.Parents[0]
+	})
+	require.ErrorContains(t, err, "proof of parent")
+
+	err = tamper(func(p *MonProof) {
+		p.Parents = p.Parents[:1]
+	})
+	require.ErrorContains(t, err, "mon has 2 parents, but proofs of 1")
+
+	err = tamper(func(p *MonProof) {
+		p.AnchorHeader = p.Parents[0].AnchorHeader
+	})
+	require.ErrorContains(t, err, "doesn't match the mint")
+
+	err = tamper(func(p *MonProof) {
+		p.OwnershipProof = ""
+	})
+	require.ErrorContains(t, err, "has no ownership proof")
+
+	err = tamper(func(p *MonProof) {
+		p.OwnershipProof = hex.EncodeToString(parentA.ownershipProof(t))
+	})
+	require.ErrorContains(t, err, "doesn't prove the current owner")
+
+	// An ownership proof signed by another key doesn't verify.
+	forged := *child
+	forged.key, err = btcec.NewPrivateKey()
+	require.NoError(t, err)
+	err = tamper(func(p *MonProof) {
+		p.OwnershipProof = hex.EncodeToString(forged.ownershipProof(t))
+	})
+	require.ErrorContains(t, err, "invalid ownership proof")
+
+	// The proof only verifies against the headers of the main chain.
+	reorged := make(BlockHeaders)
+	for height, header := range headers {
+		reorged[height] = header
+	}
+	delete(reorged, parentB.proof.BlockHeight)
+	_, err = VerifyMonProof(ctx, &monProof, reorged)
+	require.ErrorContains(t, err, "no block header known at height 101")
+
+	header := reorged[parentA.proof.BlockHeight]
+	header.Nonce++
+	reorged[parentA.proof.BlockHeight] = header
+	reorged[parentB.proof.BlockHeight] = headers[parentB.proof.BlockHeight]
+	_, err = VerifyMonProof(ctx, &monProof, reorged)
+	require.ErrorContains(t, err, "isn't the block at height 100")
+
+	delete(reorged, parentA.proof.BlockHeight)
+	chain.headers = reorged
+	_, err = manager.VerifyMonProof(ctx, &monProof)
+	require.ErrorContains(t, err, "isn't the block at height 100")
+}
+
+// TestReadBlockHeaders tests that block headers are read by their height.
+func TestReadBlockHeaders(t *testing.T) {
+	header := wire.NewBlockHeader(
+		1, &chainhash.Hash{1}, &chainhash.Hash{2}, 3, 4,
+	)
+	header.Timestamp = time.Unix(1000, 0)
+	encoded, err := encodeHeader(header)
+	require.NoError(t, err)
+
+	headers, err := ReadBlockHeaders(strings.NewReader(
+		"100 " + encoded + "\n\n  101\t" + encoded + "\n",
+	))
+	require.NoError(t, err)
+	require.Equal(t, BlockHeaders{100: *header, 101: *header}, headers)
+
+	_, err = ReadBlockHeaders(strings.NewReader(encoded))
+	require.ErrorContains(t, err, "line 1: expected a height")
+
+	_, err = ReadBlockHeaders(strings.NewReader("100 00"))
+	require.ErrorContains(t, err, "line 1: invalid block header")
+}
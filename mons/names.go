@@ -0,0 +1,254 @@
+package mons
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+const (
+	// MinMonNameLength is the minimum length of the name of a mon.
+	MinMonNameLength = 3
+
+	// MaxMonNameLength is the maximum length of the name of a mon.
+	MaxMonNameLength = 32
+)
+
+var (
+	// ErrInvalidMonName is returned for names that don't follow the name
+	// policy.
+	ErrInvalidMonName = errors.New("invalid mon name")
+
+	// ErrMonNameTaken is returned when minting a mon with a name that is
+	// already claimed by another mon.
+	ErrMonNameTaken = errors.New("mon name taken")
+
+	// monNamePattern are words of ASCII letters and digits separated by
+	// single spaces or hyphens.
+	monNamePattern = regexp.MustCompile(`^[A-Za-z0-9]+([ -][A-Za-z0-9]+)*$`)
+)
+
+// ValidateMonName checks that a name follows the name policy: it consists of
+// MinMonNameLength to MaxMonNameLength ASCII letters and digits, which may
+// be separated by single spaces or hyphens.
+func ValidateMonName(name string) error {
+	if len(name) < MinMonNameLength || len(name) > MaxMonNameLength {
+		return fmt.Errorf("%w: %q must have %d to %d characters",
+			ErrInvalidMonName, name, MinMonNameLength,
+			MaxMonNameLength)
+	}
+	if !monNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q must consist of letters and digits "+
+			"separated by single spaces or hyphens",
+			ErrInvalidMonName, name)
+	}
+
+	return nil
+}
+
+// MonNameKey returns the key that identifies a name in the registry. Names
+// are compared case insensitively, so the key is the lowercase name.
+func MonNameKey(name string) (string, error) {
+	if err := ValidateMonName(name); err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(name), nil
+}
+
+// ContestedNameError is returned when looking up a mon by a name that is
+// claimed by several mons while the mon minted first isn't final yet, so
+// that a reorg may still pass the name on to another mon.
+type ContestedNameError struct {
+	// Name is the contested name.
+	Name string
+
+	// Candidates are the mons claiming the name in the order they were
+	// minted.
+	Candidates []*MonInfo
+}
+
+// Error returns the contested name together with all candidates.
+func (e *ContestedNameError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, candidate := range e.Candidates {
+		candidates = append(candidates, fmt.Sprintf("mon %x (asset "+
+			"%x, %q, %v, minted at height %d)", candidate.Mon.Id,
+			candidate.AssetId, candidate.Name, candidate.Status,
+			candidate.Height))
+	}
+
+	return fmt.Sprintf("mon name %q is contested by %d mons, the first "+
+		"one holds it once final: %s", e.Name, len(e.Candidates),
+		strings.Join(candidates, ", "))
+}
+
+// GetMonByName returns the indexed mon holding the given name together with
+// its status. A name is held by the mon that was minted first, by the height
+// of its anchor and the position of its mint transaction in the block. If
+// that mon isn't final yet while other mons claim the name as well, a
+// ContestedNameError with all candidates is returned.
+func (m *Manager) GetMonByName(ctx context.Context, name string) (*MonInfo,
+	error) {
+
+	candidates, err := m.nameCandidates(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("unknown mon name %q", name)
+	}
+
+	tipHeight, err := m.tipHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*MonInfo, 0, len(candidates))
+	for _, candidate := range candidates {
+		infos = append(infos, &MonInfo{
+			IndexedMon: candidate,
+			Status:     m.policy.Status(candidate, tipHeight),
+		})
+	}
+	if len(infos) > 1 && infos[0].Status != MonFinal {
+		return nil, &ContestedNameError{
+			Name:       name,
+			Candidates: infos,
+		}
+	}
+
+	return infos[0], nil
+}
+
+// nameCandidates returns the mons that aren't reorged and claim the given
+// name in the order they were minted.
+func (m *Manager) nameCandidates(ctx context.Context,
+	name string) ([]*IndexedMon, error) {
+
+	key, err := MonNameKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := m.store.ListMonsByName(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*IndexedMon, 0, len(claims))
+	for _, claim := range claims {
+		if !claim.Reorged {
+			candidates = append(candidates, claim)
+		}
+	}
+	if err := m.sortMintOrder(ctx, candidates); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// sortMintOrder sorts mons in the order they were minted: by the height of
+// their anchors and the position of their mint transactions in the block.
+// Mons minted in the same transaction are sorted by their asset ids.
+func (m *Manager) sortMintOrder(ctx context.Context,
+	mons []*IndexedMon) error {
+
+	// The blocks of the mons minted at the same height as another one
+	// are fetched to find the positions of their mint transactions.
+	heights := make(map[uint32]int)
+	for _, mon := range mons {
+		heights[mon.Height]++
+	}
+	positions := make(map[chainhash.Hash]int)
+	for _, mon := range mons {
+		if heights[mon.Height] < 2 {
+			continue
+		}
+		if _, ok := positions[mon.TxHash]; ok {
+			continue
+		}
+
+		block, err := m.chainKit.GetBlock(ctx, mon.BlockHash)
+		if err != nil {
+			return fmt.Errorf("unable to fetch block %v: %w",
+				mon.BlockHash, err)
+		}
+		for i, tx := range block.Transactions {
+			positions[tx.TxHash()] = i
+		}
+		if _, ok := positions[mon.TxHash]; !ok {
+			return fmt.Errorf("mint transaction %v of mon %x not "+
+				"found in block %v", mon.TxHash, mon.AssetId,
+				mon.BlockHash)
+		}
+	}
+
+	sort.SliceStable(mons, func(i, j int) bool {
+		if mons[i].Height != mons[j].Height {
+			return mons[i].Height < mons[j].Height
+		}
+		if mons[i].TxHash != mons[j].TxHash {
+			return positions[mons[i].TxHash] <
+				positions[mons[j].TxHash]
+		}
+
+		return bytes.Compare(mons[i].AssetId, mons[j].AssetId) < 0
+	})
+
+	return nil
+}
+
+// checkNameAvailable returns an error if the name doesn't follow the name
+// policy or is already claimed by an indexed mon.
+func (m *Manager) checkNameAvailable(ctx context.Context, name string) error {
+	candidates, err := m.nameCandidates(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	claims := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		claims = append(claims, fmt.Sprintf("%x", candidate.Mon.Id))
+	}
+
+	return fmt.Errorf("%w: %q is claimed by mon %s", ErrMonNameTaken,
+		name, strings.Join(claims, ", "))
+}
+
+// logNameClaim logs how the name of a newly indexed mon is resolved. Mons
+// whose names don't follow the name policy or were minted after another mon
+// with the same name can't be looked up by their names.
+func (m *Manager) logNameClaim(ctx context.Context, indexed *IndexedMon) {
+	candidates, err := m.nameCandidates(ctx, indexed.Name)
+	switch {
+	case errors.Is(err, ErrInvalidMonName):
+		log.Printf("Mon %x doesn't hold its name: %v",
+			indexed.AssetId, err)
+		return
+
+	case err != nil:
+		log.Printf("Unable to resolve the name of mon %x: %v",
+			indexed.AssetId, err)
+		return
+	}
+
+	if len(candidates) > 1 &&
+		!bytes.Equal(candidates[0].AssetId, indexed.AssetId) {
+
+		log.Printf("Mon %x claims name %q, which is held by mon %x "+
+			"that was minted first", indexed.AssetId, indexed.Name,
+			candidates[0].AssetId)
+	}
+}
@@ -0,0 +1,134 @@
+package mons
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func (c *memChain) GetBlock(_ context.Context,
+	hash chainhash.Hash) (*wire.MsgBlock, error) {
+
+	block, ok := c.blocks[hash]
+	if !ok {
+		return nil, fmt.Errorf("unknown block %v", hash)
+	}
+
+	return block, nil
+}
+
+// TestValidateMonName tests that only names following the name policy are
+// accepted and that names are compared case insensitively.
+func TestValidateMonName(t *testing.T) {
+	for _, name := range []string{
+		"ember", "Ember 2", "fire-drake", "a1b", "mon 10",
+		"abcdefghijklmnopqrstuvwxyz123456",
+	} {
+		require.NoError(t, ValidateMonName(name), name)
+	}
+
+	for _, name := range []string{
+		"", "ab", "abcdefghijklmnopqrstuvwxyz1234567", " ember",
+		"ember ", "fire  drake", "fire--drake", "fire_drake", "émber",
+		"ember!", "ember\n",
+	} {
+		require.ErrorIs(t, ValidateMonName(name), ErrInvalidMonName,
+			name)
+	}
+
+	key, err := MonNameKey("Fire-Drake")
+	require.NoError(t, err)
+	require.Equal(t, "fire-drake", key)
+}
+
+// TestGetMonByName tests that a name is held by the mon minted first, by
+// height and position in the block, and that looking up a name fails with
+// all candidates while the holder isn't final.
+func TestGetMonByName(t *testing.T) {
+	ctx := context.Background()
+	chain := newMemChain()
+	chain.blocks = make(map[chainhash.Hash]*wire.MsgBlock)
+	chain.tip = 150
+	store := newMemMonStore()
+	manager := NewManager(
+		nil, nil, nil, nil, chain, store, testPolicy, nil,
+	)
+
+	index := func(mon *IndexedMon, name string) *IndexedMon {
+		t.Helper()
+
+		mon.Name = name
+		mon.Metadata.Name = name
+		var err error
+		mon.Mon, err = deriveMon(ctx, store, mon)
+		require.NoError(t, err)
+		require.NoError(t, store.AddMon(ctx, mon))
+
+		return mon
+	}
+
+	first := index(testMon(1, 100, 0), "Ember")
+	second := index(testMon(2, 120, 0), "ember")
+	other := index(testMon(3, 110, 0), "blaze")
+
+	_, err := manager.GetMonByName(ctx, "unknown")
+	require.ErrorContains(t, err, "unknown mon name")
+	_, err = manager.GetMonByName(ctx, "e")
+	require.ErrorIs(t, err, ErrInvalidMonName)
+
+	info, err := manager.GetMonByName(ctx, "BLAZE")
+	require.NoError(t, err)
+	require.Equal(t, other, info.IndexedMon)
+
+	// While the first mon isn't final, the name is contested.
+	_, err = manager.GetMonByName(ctx, "EMBER")
+	var contested *ContestedNameError
+	require.ErrorAs(t, err, &contested)
+	require.Len(t, contested.Candidates, 2)
+	require.Equal(t, first, contested.Candidates[0].IndexedMon)
+	require.Equal(t, second, contested.Candidates[1].IndexedMon)
+	require.ErrorContains(t, err, fmt.Sprintf("%x", second.Mon.Id))
+
+	chain.tip = 500
+	info, err = manager.GetMonByName(ctx, "EMBER")
+	require.NoError(t, err)
+	require.Equal(t, first, info.IndexedMon)
+	require.Equal(t, MonFinal, info.Status)
+
+	// Once the first mon is reorged out, the name passes on.
+	require.NoError(t, store.MarkReorged(ctx, [][]byte{{1}}))
+	info, err = manager.GetMonByName(ctx, "ember")
+	require.NoError(t, err)
+	require.Equal(t, second, info.IndexedMon)
+
+	// Mons minted in the same block are ordered by the position of their
+	// mint transactions.
+	txA := wire.NewMsgTx(2)
+	txA.LockTime = 1
+	txB := wire.NewMsgTx(2)
+	txB.LockTime = 2
+	monA, monB := testMon(4, 130, 0), testMon(5, 130, 0)
+	monA.TxHash, monB.TxHash = txA.TxHash(), txB.TxHash()
+	chain.blocks[monA.BlockHash] = &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{wire.NewMsgTx(2), txB, txA},
+	}
+	index(monA, "Drake")
+	index(monB, "drake")
+
+	info, err = manager.GetMonByName(ctx, "drake")
+	require.NoError(t, err)
+	require.Equal(t, monB, info.IndexedMon)
+
+	// Names that are claimed can't be minted again.
+	err = manager.checkNameAvailable(ctx, "DRAKE")
+	require.ErrorIs(t, err, ErrMonNameTaken)
+	require.ErrorContains(t, err, fmt.Sprintf("%x", monA.Mon.Id))
+	require.ErrorIs(
+		t, manager.checkNameAvailable(ctx, "d"), ErrInvalidMonName,
+	)
+	require.NoError(t, manager.checkNameAvailable(ctx, "wyvern"))
+}
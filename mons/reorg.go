@@ -0,0 +1,216 @@
+package mons
+
+import (
+	"context"
+	"log"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+const (
+	// changeSubscriberBuffer is the number of mon changes buffered per
+	// subscriber.
+	changeSubscriberBuffer = 100
+)
+
+// MonChange notifies about an indexed mon whose anchor block was reorged out
+// of the main chain.
+type MonChange struct {
+	// AssetId is the asset id of the mon.
+	AssetId []byte
+
+	// Previous is the mon as it was derived before the change.
+	Previous *Mon
+
+	// Mon is the mon derived from its new anchor. It is nil if the mon
+	// was just reorged out and isn't derived again yet.
+	Mon *Mon
+}
+
+// Reorged returns true if the mon was reorged out and isn't derived again
+// yet.
+func (c *MonChange) Reorged() bool {
+	return c.Mon == nil
+}
+
+// SubscribeMonChanges returns a channel with all future changes of indexed
+// mons. The subscription ends when the returned cancel function is called.
+func (m *Manager) SubscribeMonChanges() (<-chan *MonChange, func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	changes := make(chan *MonChange, changeSubscriberBuffer)
+	m.subscribers[changes] = struct{}{}
+
+	cancel := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if _, ok := m.subscribers[changes]; ok {
+			delete(m.subscribers, changes)
+			close(changes)
+		}
+	}
+
+	return changes, cancel
+}
+
+// notify sends the change to all subscribers.
+func (m *Manager) notify(change *MonChange) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for subscriber := range m.subscribers {
+		select {
+		case subscriber <- change:
+		default:
+			log.Printf("Dropping change of mon %x for slow subscriber",
+				change.AssetId)
+		}
+	}
+}
+
+// RunIndexer indexes mons whenever a block is mined until the context is
+// cancelled or the block subscription fails. Before indexing, it marks the
+// mons whose anchor blocks were reorged out, so that they are derived again
+// from their new anchors.
+func (m *Manager) RunIndexer(ctx context.Context) error {
+	blocks, errChan, err := m.chainNotifier.RegisterBlockEpochNtfn(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case height := <-blocks:
+			if err := m.handleBlock(ctx, height); err != nil {
+				log.Printf("Unable to index mons at height %d: %v",
+					height, err)
+			}
+
+		case err := <-errChan:
+			return err
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// handleBlock detects reorgs, syncs the universe, indexes mons and tracks
+// their owners at a new tip of the chain.
+func (m *Manager) handleBlock(ctx context.Context, height int32) error {
+	if err := m.detectReorgs(ctx, height); err != nil {
+		return err
+	}
+
+	// Mons already known to tapd are indexed even if the universe can't
+	// be synced.
+	if err := m.SyncUniverses(ctx); err != nil {
+		log.Printf("Unable to sync universes: %v", err)
+	}
+
+	if err := m.IndexMons(ctx); err != nil {
+		return err
+	}
+
+	return m.TrackOwners(ctx)
+}
+
+// detectReorgs marks the tentative mons anchored in blocks that were reorged
+// out of the chain with the given tip height as reorged, together with all of
+// their descendants, whose scores are inherited from them. Final mons are
+// assumed to stay in the main chain.
+func (m *Manager) detectReorgs(ctx context.Context, tipHeight int32) error {
+	minHeight := m.policy.tentativeHeight(uint32(tipHeight))
+	recent, err := m.store.ListMons(ctx, minHeight)
+	if err != nil {
+		return err
+	}
+
+	var reorged [][]byte
+	for _, mon := range recent {
+		if mon.Reorged {
+			continue
+		}
+
+		onChain := mon.Height <= uint32(tipHeight)
+		if onChain {
+			onChain, err = m.onMainChain(
+				ctx, mon.Height, mon.BlockHash,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		if !onChain {
+			reorged = append(reorged, mon.AssetId)
+		}
+	}
+	if len(reorged) == 0 {
+		return nil
+	}
+
+	// The scores of bred mons are inherited from their parents, so all
+	// descendants have to be derived again too.
+	var (
+		affected []*IndexedMon
+		visited  = make(map[string]struct{})
+	)
+	for len(reorged) > 0 {
+		assetId := reorged[0]
+		reorged = reorged[1:]
+		if _, ok := visited[string(assetId)]; ok {
+			continue
+		}
+		visited[string(assetId)] = struct{}{}
+
+		mon, err := m.store.GetMon(ctx, assetId)
+		if err != nil {
+			return err
+		}
+		if mon == nil || mon.Reorged {
+			continue
+		}
+		affected = append(affected, mon)
+
+		children, err := m.store.GetChildren(ctx, assetId)
+		if err != nil {
+			return err
+		}
+		reorged = append(reorged, children...)
+	}
+
+	assetIds := make([][]byte, 0, len(affected))
+	for _, mon := range affected {
+		assetIds = append(assetIds, mon.AssetId)
+	}
+	if err := m.store.MarkReorged(ctx, assetIds); err != nil {
+		return err
+	}
+
+	for _, mon := range affected {
+		log.Printf("Mon %x anchored in block %v at height %d was "+
+			"reorged", mon.AssetId, mon.BlockHash, mon.Height)
+
+		m.notify(&MonChange{
+			AssetId:  mon.AssetId,
+			Previous: mon.Mon,
+		})
+	}
+
+	return nil
+}
+
+// onMainChain returns true if the block with the given hash is the block of
+// the main chain at the given height.
+func (m *Manager) onMainChain(ctx context.Context, height uint32,
+	blockHash chainhash.Hash) (bool, error) {
+
+	hash, err := m.chainKit.GetBlockHash(ctx, int64(height))
+	if err != nil {
+		return false, err
+	}
+
+	return hash == blockHash, nil
+}
@@ -0,0 +1,119 @@
+package mons
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/stretchr/testify/require"
+)
+
+// reanchor moves the genesis asset of the mon with the given asset id to a new
+// anchor block.
+func (m *memTapd) reanchor(assetId byte, blockHash chainhash.Hash,
+	height uint32) {
+
+	for _, asset := range m.assets {
+		if asset.AssetGenesis.AssetId[0] == assetId {
+			asset.ChainAnchor.AnchorBlockHash = blockHash.String()
+			asset.ChainAnchor.BlockHeight = height
+		}
+	}
+}
+
+// waitForChange returns the next mon change or fails the test.
+func waitForChange(t *testing.T, changes <-chan *MonChange) *MonChange {
+	t.Helper()
+
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(time.Second):
+		t.Fatalf("no mon change received")
+		return nil
+	}
+}
+
+// TestReorg tests that mons anchored in reorged blocks are marked as reorged
+// together with their descendants and derived again from their new anchors.
+func TestReorg(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{
+		metas: make(map[string]*taprpc.AssetMeta),
+	}
+	chain := newMemChain()
+	store := newMemMonStore()
+	manager := NewManager(
+		tapd, nil, nil, nil, chain, store, testPolicy, nil,
+	)
+
+	tapd.addMon(t, testMon(1, 100, 0))
+	tapd.addMon(t, testMon(2, 110, 0))
+	tapd.addMon(t, testMon(3, 200, 0))
+	tapd.addMon(t, testMon(10, 110+BaseBreedCooldown, 1, 1, 2))
+	require.NoError(t, manager.IndexMons(ctx))
+	require.Len(t, store.mons, 4)
+
+	changes, cancel := manager.SubscribeMonChanges()
+	defer cancel()
+
+	// Nothing changes as long as all anchors are in the main chain.
+	require.NoError(t, manager.detectReorgs(ctx, 300))
+	require.Empty(t, changes)
+
+	// Reorging the anchor of a parent reorgs its child as well.
+	oldParent := store.mons[string([]byte{1})].Mon
+	oldChild := store.mons[string([]byte{10})].Mon
+	chain.reorged[100] = chainhash.Hash{0xff}
+	require.NoError(t, manager.detectReorgs(ctx, 300))
+
+	reorged := make(map[byte]*MonChange)
+	for i := 0; i < 2; i++ {
+		change := waitForChange(t, changes)
+		require.True(t, change.Reorged())
+		reorged[change.AssetId[0]] = change
+	}
+	require.Equal(t, oldParent, reorged[1].Previous)
+	require.Equal(t, oldChild, reorged[10].Previous)
+	require.True(t, store.mons[string([]byte{1})].Reorged)
+	require.True(t, store.mons[string([]byte{10})].Reorged)
+	require.False(t, store.mons[string([]byte{2})].Reorged)
+	require.False(t, store.mons[string([]byte{3})].Reorged)
+
+	// As long as tapd reports the reorged anchor, the mons stay reorged.
+	require.NoError(t, manager.IndexMons(ctx))
+	require.Empty(t, changes)
+	require.True(t, store.mons[string([]byte{10})].Reorged)
+
+	// Once the parent confirms again, both are derived from the new
+	// anchor.
+	tapd.reanchor(1, chainhash.Hash{101}, 101)
+	require.NoError(t, manager.IndexMons(ctx))
+
+	parentChange := waitForChange(t, changes)
+	require.Equal(t, []byte{1}, parentChange.AssetId)
+	require.False(t, parentChange.Reorged())
+	require.NotEqual(t, oldParent.Id, parentChange.Mon.Id)
+
+	childChange := waitForChange(t, changes)
+	require.Equal(t, []byte{10}, childChange.AssetId)
+	require.Equal(t, oldChild.Id, childChange.Mon.Id)
+
+	child := store.mons[string([]byte{10})]
+	parentB := store.mons[string([]byte{2})]
+	expected, err := BreedMonster(
+		&child.BlockHash, &child.TxHash, parentChange.Mon, parentB.Mon,
+	)
+	require.NoError(t, err)
+	require.Equal(t, expected, childChange.Mon)
+	require.False(t, child.Reorged)
+	require.EqualValues(t, 101, store.mons[string([]byte{1})].Height)
+
+	// A mon anchored above the tip of a shorter chain is reorged too.
+	require.NoError(t, manager.detectReorgs(ctx, 250))
+	change := waitForChange(t, changes)
+	require.Equal(t, []byte{10}, change.AssetId)
+	require.True(t, change.Reorged())
+}
@@ -0,0 +1,312 @@
+package mons
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// ReceiveStatus is the status of a mon we receive.
+type ReceiveStatus uint8
+
+const (
+	// ReceiveDetected is the status of a receive whose transaction was
+	// detected but isn't confirmed yet.
+	ReceiveDetected ReceiveStatus = iota
+
+	// ReceiveConfirmed is the status of a receive whose transaction
+	// confirmed while its proof wasn't received yet.
+	ReceiveConfirmed
+
+	// ReceiveProofReceived is the status of a receive whose proof was
+	// received but isn't imported yet.
+	ReceiveProofReceived
+
+	// ReceiveCompleted is the status of a receive whose proof was
+	// imported, so that we own the mon.
+	ReceiveCompleted
+)
+
+// String returns the name of the status.
+func (s ReceiveStatus) String() string {
+	switch s {
+	case ReceiveDetected:
+		return "detected"
+
+	case ReceiveConfirmed:
+		return "confirmed"
+
+	case ReceiveProofReceived:
+		return "proof_received"
+
+	case ReceiveCompleted:
+		return "completed"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// receiveStatus maps the status of a tapd address event to the status of a
+// receive. It returns false for unknown statuses.
+func receiveStatus(status taprpc.AddrEventStatus) (ReceiveStatus, bool) {
+	switch status {
+	case taprpc.AddrEventStatus_ADDR_EVENT_STATUS_TRANSACTION_DETECTED:
+		return ReceiveDetected, true
+
+	case taprpc.AddrEventStatus_ADDR_EVENT_STATUS_TRANSACTION_CONFIRMED:
+		return ReceiveConfirmed, true
+
+	case taprpc.AddrEventStatus_ADDR_EVENT_STATUS_PROOF_RECEIVED:
+		return ReceiveProofReceived, true
+
+	case taprpc.AddrEventStatus_ADDR_EVENT_STATUS_COMPLETED:
+		return ReceiveCompleted, true
+
+	default:
+		return 0, false
+	}
+}
+
+// MonReceive is an update of a mon we receive on one of our addresses.
+type MonReceive struct {
+	// AssetId is the asset id of the mon.
+	AssetId []byte
+
+	// Address is the encoded address the mon is sent to.
+	Address string
+
+	// Outpoint is the outpoint that anchors the received mon.
+	Outpoint string
+
+	// Status is the status of the receive.
+	Status ReceiveStatus
+
+	// ConfirmationHeight is the height the transaction confirmed at, zero
+	// if it isn't confirmed yet.
+	ConfirmationHeight uint32
+
+	// Error is the error tapd ran into while receiving the mon, if any.
+	Error string
+}
+
+// knownMon returns the indexed mon with the given asset id or an error
+// wrapping ErrNotMon if it isn't indexed or reorged.
+func (m *Manager) knownMon(ctx context.Context,
+	assetId []byte) (*IndexedMon, error) {
+
+	indexed, err := m.store.GetMon(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+	if indexed == nil {
+		return nil, fmt.Errorf("%w: asset %x isn't an indexed mon",
+			ErrNotMon, assetId)
+	}
+	if indexed.Reorged {
+		return nil, fmt.Errorf("mon %x is reorged", assetId)
+	}
+
+	return indexed, nil
+}
+
+// NewMonAddress creates a tap address to receive the indexed mon with the
+// given asset id.
+func (m *Manager) NewMonAddress(ctx context.Context,
+	assetId []byte) (string, error) {
+
+	if _, err := m.knownMon(ctx, assetId); err != nil {
+		return "", err
+	}
+
+	addr, err := m.tapClient.NewAddr(ctx, &taprpc.NewAddrRequest{
+		AssetId: assetId,
+		Amt:     1,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return addr.Encoded, nil
+}
+
+// SendMon sends the mon requested by a tap address to it and waits for the
+// transfer to have the confirmations of our finality policy. The address
+// must request a single indexed mon we own. It returns the new owner, which
+// is recorded in the ownership history of the mon.
+func (m *Manager) SendMon(ctx context.Context, addr string) (*MonOwner,
+	error) {
+
+	decoded, err := m.tapClient.DecodeAddr(ctx, &taprpc.DecodeAddrRequest{
+		Addr: addr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decoded.Amount != 1 {
+		return nil, fmt.Errorf("address requests %d units, mons are "+
+			"sent one at a time", decoded.Amount)
+	}
+
+	assetId := decoded.AssetId
+	if _, err := m.knownMon(ctx, assetId); err != nil {
+		return nil, err
+	}
+	owned, err := m.ownedAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := owned[string(assetId)]; !ok {
+		return nil, fmt.Errorf("mon %x isn't owned", assetId)
+	}
+
+	resp, err := m.tapClient.SendAsset(ctx, &taprpc.SendAssetRequest{
+		TapAddrs: []string{addr},
+	})
+	if err != nil {
+		return nil, err
+	}
+	transfer := resp.Transfer
+
+	var output *taprpc.TransferOutput
+	for _, out := range transfer.Outputs {
+		if bytes.Equal(out.ScriptKey, decoded.ScriptKey) {
+			output = out
+		}
+	}
+	if output == nil || output.Anchor == nil {
+		return nil, fmt.Errorf("transfer of mon %x has no output to "+
+			"the address", assetId)
+	}
+	outpoint, err := wire.NewOutPointFromString(output.Anchor.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	txHash, err := chainhash.NewHash(transfer.AnchorTxHash)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Sending mon %x in transaction %v", assetId, txHash)
+
+	confChan, errChan, err := m.chainNotifier.RegisterConfirmationsNtfn(
+		ctx, txHash, nil, int32(m.policy.MintConfirmations),
+		int32(transfer.AnchorTxHeightHint),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var conf *chainntnfs.TxConfirmation
+	select {
+	case err := <-errChan:
+		return nil, err
+
+	case conf = <-confChan:
+		log.Printf("Transfer of mon %x has been confirmed in block %v",
+			assetId, conf.BlockHash)
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	header, err := m.chainKit.GetBlockHeader(ctx, *conf.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &MonOwner{
+		AssetId:        assetId,
+		ScriptKey:      output.ScriptKey,
+		AnchorOutpoint: *outpoint,
+		BlockHash:      *conf.BlockHash,
+		Height:         conf.BlockHeight,
+		BlockTime:      header.Timestamp,
+	}
+	if err := m.store.AddOwner(ctx, owner); err != nil {
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+// SubscribeMonReceives returns a channel with the updates of the mons we
+// receive on our addresses until the context is cancelled. Receives of
+// assets that aren't indexed mons are skipped. Once a receive completes, the
+// owners of the mons are tracked, so that we are recorded as the new owner.
+func (m *Manager) SubscribeMonReceives(ctx context.Context) (
+	<-chan *MonReceive, <-chan error, error) {
+
+	stream, err := m.tapClient.SubscribeReceiveEvents(
+		ctx, &taprpc.SubscribeReceiveEventsRequest{},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receives := make(chan *MonReceive)
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			receive, err := m.monReceive(ctx, event)
+			if err != nil {
+				errChan <- err
+				return
+			}
+			if receive == nil {
+				continue
+			}
+
+			if receive.Status == ReceiveCompleted {
+				if err := m.TrackOwners(ctx); err != nil {
+					errChan <- err
+					return
+				}
+			}
+
+			select {
+			case receives <- receive:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return receives, errChan, nil
+}
+
+// monReceive converts a receive event of tapd into a mon receive. It returns
+// nil if the event isn't about an indexed mon.
+func (m *Manager) monReceive(ctx context.Context,
+	event *taprpc.ReceiveEvent) (*MonReceive, error) {
+
+	status, ok := receiveStatus(event.Status)
+	if !ok || event.Address == nil {
+		return nil, nil
+	}
+
+	indexed, err := m.store.GetMon(ctx, event.Address.AssetId)
+	if err != nil || indexed == nil {
+		return nil, err
+	}
+
+	return &MonReceive{
+		AssetId:            indexed.AssetId,
+		Address:            event.Address.Encoded,
+		Outpoint:           event.Outpoint,
+		Status:             status,
+		ConfirmationHeight: event.ConfirmationHeight,
+		Error:              event.Error,
+	}, nil
+}
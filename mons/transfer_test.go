@@ -0,0 +1,249 @@
+package mons
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func (m *memTapd) NewAddr(_ context.Context, req *taprpc.NewAddrRequest,
+	_ ...grpc.CallOption) (*taprpc.Addr, error) {
+
+	addr := &taprpc.Addr{
+		Encoded:   fmt.Sprintf("addr-%x-%d", req.AssetId, req.Amt),
+		AssetId:   req.AssetId,
+		Amount:    req.Amt,
+		ScriptKey: append([]byte{0xaa}, req.AssetId...),
+	}
+	m.addrs[addr.Encoded] = addr
+
+	return addr, nil
+}
+
+func (m *memTapd) DecodeAddr(_ context.Context,
+	req *taprpc.DecodeAddrRequest, _ ...grpc.CallOption) (*taprpc.Addr,
+	error) {
+
+	addr, ok := m.addrs[req.Addr]
+	if !ok {
+		return nil, fmt.Errorf("invalid address")
+	}
+
+	return addr, nil
+}
+
+// SendAsset sends the asset in a transaction whose hash is the first byte
+// of the asset id and keeps the change.
+func (m *memTapd) SendAsset(_ context.Context, req *taprpc.SendAssetRequest,
+	_ ...grpc.CallOption) (*taprpc.SendAssetResponse, error) {
+
+	addr := m.addrs[req.TapAddrs[0]]
+	txHash := chainhash.Hash{addr.AssetId[0]}
+
+	return &taprpc.SendAssetResponse{
+		Transfer: &taprpc.AssetTransfer{
+			AnchorTxHash: txHash[:],
+			Outputs: []*taprpc.TransferOutput{{
+				Anchor: &taprpc.TransferOutputAnchor{
+					Outpoint: wire.OutPoint{
+						Hash: txHash,
+					}.String(),
+				},
+				ScriptKey:        []byte{0xcc},
+				ScriptKeyIsLocal: true,
+			}, {
+				Anchor: &taprpc.TransferOutputAnchor{
+					Outpoint: wire.OutPoint{
+						Hash:  txHash,
+						Index: 1,
+					}.String(),
+				},
+				ScriptKey: addr.ScriptKey,
+				Amount:    addr.Amount,
+			}},
+		},
+	}, nil
+}
+
+// memReceiveStream streams the receive events of a channel until it is
+// closed.
+type memReceiveStream struct {
+	grpc.ClientStream
+
+	events chan *taprpc.ReceiveEvent
+}
+
+func (s *memReceiveStream) Recv() (*taprpc.ReceiveEvent, error) {
+	event, ok := <-s.events
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return event, nil
+}
+
+func (m *memTapd) SubscribeReceiveEvents(_ context.Context,
+	_ *taprpc.SubscribeReceiveEventsRequest, _ ...grpc.CallOption) (
+	taprpc.TaprootAssets_SubscribeReceiveEventsClient, error) {
+
+	return &memReceiveStream{events: m.receives}, nil
+}
+
+// memNotifier confirms every transaction at a fixed height.
+type memNotifier struct {
+	lndclient.ChainNotifierClient
+
+	height uint32
+
+	// confDelay is the time after which transactions confirm.
+	confDelay time.Duration
+}
+
+func (n *memNotifier) RegisterConfirmationsNtfn(_ context.Context,
+	_ *chainhash.Hash, _ []byte, _, _ int32,
+	_ ...lndclient.NotifierOption) (chan *chainntnfs.TxConfirmation,
+	chan error, error) {
+
+	confChan := make(chan *chainntnfs.TxConfirmation, 1)
+	conf := &chainntnfs.TxConfirmation{
+		BlockHash:   &chainhash.Hash{byte(n.height)},
+		BlockHeight: n.height,
+	}
+	if n.confDelay == 0 {
+		confChan <- conf
+		return confChan, make(chan error), nil
+	}
+
+	time.AfterFunc(n.confDelay, func() {
+		confChan <- conf
+	})
+
+	return confChan, make(chan error), nil
+}
+
+// TestSendMon tests that only indexed mons we own are sent to addresses
+// requesting a single mon and that the recipient becomes their owner.
+func TestSendMon(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{
+		metas: make(map[string]*taprpc.AssetMeta),
+		addrs: make(map[string]*taprpc.Addr),
+	}
+	store := newMemMonStore()
+	manager := NewManager(
+		tapd, nil, nil, &memNotifier{height: 150}, newMemChain(),
+		store, testPolicy, nil,
+	)
+
+	tapd.addMon(t, testMon(1, 100, 0))
+	require.NoError(t, manager.IndexMons(ctx))
+
+	_, err := manager.NewMonAddress(ctx, []byte{2})
+	require.ErrorIs(t, err, ErrNotMon)
+
+	addr, err := manager.NewMonAddress(ctx, []byte{1})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, tapd.addrs[addr].Amount)
+
+	// Addresses must request a single indexed mon we own.
+	_, err = manager.SendMon(ctx, "unknown")
+	require.ErrorContains(t, err, "invalid address")
+
+	tapd.addrs["two"] = &taprpc.Addr{AssetId: []byte{1}, Amount: 2}
+	_, err = manager.SendMon(ctx, "two")
+	require.ErrorContains(t, err, "one at a time")
+
+	tapd.addrs["unknown mon"] = &taprpc.Addr{AssetId: []byte{2}, Amount: 1}
+	_, err = manager.SendMon(ctx, "unknown mon")
+	require.ErrorIs(t, err, ErrNotMon)
+
+	notOwned := testMon(2, 110, 0)
+	notOwned.Mon, err = deriveMon(ctx, store, notOwned)
+	require.NoError(t, err)
+	require.NoError(t, store.AddMon(ctx, notOwned))
+	_, err = manager.SendMon(ctx, "unknown mon")
+	require.ErrorContains(t, err, "isn't owned")
+
+	owner, err := manager.SendMon(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, tapd.addrs[addr].ScriptKey, owner.ScriptKey)
+	require.Equal(t, wire.OutPoint{
+		Hash:  chainhash.Hash{1},
+		Index: 1,
+	}, owner.AnchorOutpoint)
+	require.EqualValues(t, 150, owner.Height)
+	require.Equal(t, []*MonOwner{owner}, store.owners[string([]byte{1})])
+}
+
+// TestSubscribeMonReceives tests that only the receives of indexed mons are
+// streamed until the tapd stream ends.
+func TestSubscribeMonReceives(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tapd := &memTapd{
+		metas:    make(map[string]*taprpc.AssetMeta),
+		receives: make(chan *taprpc.ReceiveEvent, 10),
+	}
+	manager := NewManager(
+		tapd, nil, nil, nil, newMemChain(), newMemMonStore(),
+		testPolicy, nil,
+	)
+
+	tapd.addMon(t, testMon(1, 100, 0))
+	require.NoError(t, manager.IndexMons(ctx))
+
+	receives, errChan, err := manager.SubscribeMonReceives(ctx)
+	require.NoError(t, err)
+
+	event := func(assetId byte,
+		status taprpc.AddrEventStatus) *taprpc.ReceiveEvent {
+
+		return &taprpc.ReceiveEvent{
+			Address: &taprpc.Addr{
+				Encoded: "addr",
+				AssetId: []byte{assetId},
+			},
+			Outpoint: "outpoint",
+			Status:   status,
+		}
+	}
+	var (
+		unknown   = taprpc.AddrEventStatus_ADDR_EVENT_STATUS_UNKNOWN
+		detected  = taprpc.AddrEventStatus_ADDR_EVENT_STATUS_TRANSACTION_DETECTED
+		completed = event(
+			1, taprpc.AddrEventStatus_ADDR_EVENT_STATUS_COMPLETED,
+		)
+	)
+	completed.ConfirmationHeight = 120
+
+	tapd.receives <- event(2, detected)
+	tapd.receives <- event(1, unknown)
+	tapd.receives <- event(1, detected)
+	tapd.receives <- completed
+	close(tapd.receives)
+
+	receive := <-receives
+	require.Equal(t, &MonReceive{
+		AssetId:  []byte{1},
+		Address:  "addr",
+		Outpoint: "outpoint",
+		Status:   ReceiveDetected,
+	}, receive)
+
+	receive = <-receives
+	require.Equal(t, ReceiveCompleted, receive.Status)
+	require.EqualValues(t, 120, receive.ConfirmationHeight)
+
+	require.ErrorIs(t, <-errChan, io.EOF)
+}
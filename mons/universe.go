@@ -0,0 +1,517 @@
+package mons
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/universerpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// universePageSize is the number of universe roots queried at once,
+	// which is the maximum page size of tapd.
+	universePageSize = 512
+)
+
+// UniverseSyncer syncs the universe of our tapd with a set of universe
+// servers, so that mons minted on other nodes are discovered by the indexer.
+// The issuance proofs of all assets are synced, as their metadata is needed
+// to tell whether they are mons, but transfer proofs only of indexed mons.
+// Issuances are only inserted if the federation sync config of tapd allows
+// it, the syncer overrides the global issuance config only if asked to.
+type UniverseSyncer struct {
+	client        universerpc.UniverseClient
+	servers       []string
+	syncIssuances bool
+
+	mu sync.Mutex
+
+	// configured is true once the servers were added to the federation
+	// of tapd.
+	configured bool
+
+	// monConfigs are the asset ids of the mons whose transfer proofs are
+	// allowed to be synced.
+	monConfigs map[string]struct{}
+
+	// notMons are the asset ids of the synced assets that aren't mons,
+	// so that their issuance proofs aren't decoded again.
+	notMons map[string]struct{}
+
+	// scanned is true once all issuance universes of tapd were looked up
+	// for mons. Afterwards, only the universes changed by syncs are.
+	scanned bool
+
+	// changed are the issuance universes changed by syncs that weren't
+	// looked up for mons yet, by universe key.
+	changed map[string]*universerpc.ID
+
+	// candidates are the mons found in the universe that weren't indexed
+	// yet, by asset id.
+	candidates map[string]*monCandidate
+}
+
+// NewUniverseSyncer creates a syncer for the universe of the given tapd that
+// syncs with the universe servers at the given hosts. If syncIssuances is
+// set, the global federation sync config of tapd is changed to insert the
+// issuance proofs of all assets, which tapd then also syncs in the
+// background. Otherwise, the configured sync policy of tapd is kept and
+// only the issuances it allows are discovered.
+func NewUniverseSyncer(client universerpc.UniverseClient, servers []string,
+	syncIssuances bool) *UniverseSyncer {
+
+	return &UniverseSyncer{
+		client:        client,
+		servers:       servers,
+		syncIssuances: syncIssuances,
+		monConfigs:    make(map[string]struct{}),
+		notMons:       make(map[string]struct{}),
+		changed:       make(map[string]*universerpc.ID),
+		candidates:    make(map[string]*monCandidate),
+	}
+}
+
+// configure adds the servers that aren't part of the federation of tapd yet
+// and, if enabled, allows the issuance proofs of all assets to be inserted.
+func (s *UniverseSyncer) configure(ctx context.Context) error {
+	if s.configured {
+		return nil
+	}
+
+	resp, err := s.client.ListFederationServers(
+		ctx, &universerpc.ListFederationServersRequest{},
+	)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]struct{}, len(resp.Servers))
+	for _, server := range resp.Servers {
+		known[server.Host] = struct{}{}
+	}
+
+	var added []*universerpc.UniverseFederationServer
+	for _, host := range s.servers {
+		if _, ok := known[host]; ok {
+			continue
+		}
+		known[host] = struct{}{}
+		added = append(added, &universerpc.UniverseFederationServer{
+			Host: host,
+		})
+	}
+	if len(added) > 0 {
+		_, err := s.client.AddFederationServer(
+			ctx, &universerpc.AddFederationServerRequest{
+				Servers: added,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to add universe servers: %w",
+				err)
+		}
+	}
+
+	if s.syncIssuances {
+		if err := s.configureIssuances(ctx); err != nil {
+			return err
+		}
+	}
+
+	s.configured = true
+
+	return nil
+}
+
+// configureIssuances allows the issuance proofs of all assets to be inserted
+// into the universe of tapd. Whether they're exported is left as configured.
+func (s *UniverseSyncer) configureIssuances(ctx context.Context) error {
+	resp, err := s.client.QueryFederationSyncConfig(
+		ctx, &universerpc.QueryFederationSyncConfigRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	config := &universerpc.GlobalFederationSyncConfig{
+		ProofType:       universerpc.ProofType_PROOF_TYPE_ISSUANCE,
+		AllowSyncInsert: true,
+	}
+	for _, global := range resp.GlobalSyncConfigs {
+		if global.ProofType == config.ProofType {
+			config.AllowSyncExport = global.AllowSyncExport
+		}
+	}
+
+	_, err = s.client.SetFederationSyncConfig(
+		ctx, &universerpc.SetFederationSyncConfigRequest{
+			GlobalSyncConfigs: []*universerpc.GlobalFederationSyncConfig{
+				config,
+			},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to configure issuance sync: %w", err)
+	}
+
+	return nil
+}
+
+// configureMons allows the transfer proofs of the given mons to be synced.
+func (s *UniverseSyncer) configureMons(ctx context.Context,
+	mons []*IndexedMon) error {
+
+	var configs []*universerpc.AssetFederationSyncConfig
+	for _, mon := range mons {
+		if _, ok := s.monConfigs[string(mon.AssetId)]; ok {
+			continue
+		}
+		config := &universerpc.AssetFederationSyncConfig{
+			Id:              transferUniverse(mon.AssetId),
+			AllowSyncInsert: true,
+			AllowSyncExport: true,
+		}
+		configs = append(configs, config)
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+
+	_, err := s.client.SetFederationSyncConfig(
+		ctx, &universerpc.SetFederationSyncConfigRequest{
+			AssetSyncConfigs: configs,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to configure mon sync: %w", err)
+	}
+
+	for _, config := range configs {
+		s.monConfigs[string(config.Id.GetAssetId())] = struct{}{}
+	}
+
+	return nil
+}
+
+// transferUniverse returns the id of the transfer universe of an asset.
+func transferUniverse(assetId []byte) *universerpc.ID {
+	return &universerpc.ID{
+		Id: &universerpc.ID_AssetId{
+			AssetId: assetId,
+		},
+		ProofType: universerpc.ProofType_PROOF_TYPE_TRANSFER,
+	}
+}
+
+// SyncUniverses syncs the issuance proofs of all assets and the transfer
+// proofs of all indexed mons from each universe server. The issuance
+// universes changed by the syncs are looked up for mons by the next run of
+// the indexer. Servers that can't be synced with are skipped, so that a
+// single unreachable server doesn't hold back the discovery of mons. Nothing
+// is synced if no universe syncer is configured.
+func (m *Manager) SyncUniverses(ctx context.Context) error {
+	s := m.universe
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.configure(ctx); err != nil {
+		return err
+	}
+
+	indexed, err := m.store.ListMons(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if err := s.configureMons(ctx, indexed); err != nil {
+		return err
+	}
+
+	targets := make([]*universerpc.SyncTarget, 0, len(indexed))
+	for _, mon := range indexed {
+		targets = append(targets, &universerpc.SyncTarget{
+			Id: transferUniverse(mon.AssetId),
+		})
+	}
+
+	var (
+		issuanceOnly = universerpc.UniverseSyncMode_SYNC_ISSUANCE_ONLY
+		full         = universerpc.UniverseSyncMode_SYNC_FULL
+	)
+	for _, host := range s.servers {
+		resp, err := s.client.SyncUniverse(ctx, &universerpc.SyncRequest{
+			UniverseHost: host,
+			SyncMode:     issuanceOnly,
+		})
+		if err != nil {
+			log.Printf("Unable to sync issuances with universe "+
+				"%v: %v", host, err)
+			continue
+		}
+		for _, synced := range resp.SyncedUniverses {
+			s.addChanged(synced.GetNewAssetRoot().GetId())
+		}
+
+		if len(targets) == 0 {
+			continue
+		}
+		_, err = s.client.SyncUniverse(ctx, &universerpc.SyncRequest{
+			UniverseHost: host,
+			SyncMode:     full,
+			SyncTargets:  targets,
+		})
+		if err != nil {
+			log.Printf("Unable to sync mon transfers with "+
+				"universe %v: %v", host, err)
+		}
+	}
+
+	return nil
+}
+
+// addChanged records an issuance universe to be looked up for mons.
+func (s *UniverseSyncer) addChanged(id *universerpc.ID) {
+	if id.GetProofType() != universerpc.ProofType_PROOF_TYPE_ISSUANCE {
+		return
+	}
+
+	s.changed[universeKey(id)] = id
+}
+
+// universeKey returns the key of a universe in the maps of the syncer.
+func universeKey(id *universerpc.ID) string {
+	if groupKey := id.GetGroupKey(); len(groupKey) != 0 {
+		return "group-" + hex.EncodeToString(groupKey)
+	}
+
+	return "asset-" + hex.EncodeToString(id.GetAssetId())
+}
+
+// scanRoots records all issuance universes of tapd to be looked up for mons.
+func (s *UniverseSyncer) scanRoots(ctx context.Context) error {
+	for offset := int32(0); ; offset += universePageSize {
+		resp, err := s.client.AssetRoots(
+			ctx, &universerpc.AssetRootRequest{
+				Offset: offset,
+				Limit:  universePageSize,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, root := range resp.UniverseRoots {
+			s.addChanged(root.GetId())
+		}
+
+		if len(resp.UniverseRoots) < universePageSize {
+			return nil
+		}
+	}
+}
+
+// universeMons returns the genesis assets of the mons in the universe of
+// tapd that weren't indexed yet or were reorged, together with their
+// metadata. All issuance universes are looked up on the first run, later
+// runs only look up the ones changed by syncs. Universes that can't be
+// looked up are retried on the next run.
+func (m *Manager) universeMons(ctx context.Context) ([]*monCandidate,
+	error) {
+
+	s := m.universe
+	if s == nil {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.scanned {
+		if err := s.scanRoots(ctx); err != nil {
+			return nil, err
+		}
+		s.scanned = true
+	}
+
+	for key, id := range s.changed {
+		found, err := m.universeIssuances(ctx, id)
+		if err != nil {
+			log.Printf("Unable to look up universe %v: %v", key, err)
+			continue
+		}
+		delete(s.changed, key)
+
+		for _, candidate := range found {
+			assetId := candidate.asset.AssetGenesis.AssetId
+			s.candidates[string(assetId)] = candidate
+		}
+	}
+
+	candidates := make([]*monCandidate, 0, len(s.candidates))
+	for assetId, candidate := range s.candidates {
+		previous, err := m.store.GetMon(ctx, []byte(assetId))
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil && !previous.Reorged {
+			delete(s.candidates, assetId)
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// universeIssuances returns the mons among the issuances of an issuance
+// universe that weren't indexed yet or were reorged. The universe of an asset
+// holds its issuance, the universe of an asset group the issuances of all
+// assets of the group, like the mons of a collection.
+func (m *Manager) universeIssuances(ctx context.Context,
+	id *universerpc.ID) ([]*monCandidate, error) {
+
+	s := m.universe
+	assetId := id.GetAssetId()
+	if len(assetId) != 0 {
+		needed, err := m.universeMonNeeded(ctx, assetId)
+		if err != nil || !needed {
+			return nil, err
+		}
+	}
+
+	leaves, err := s.client.AssetLeaves(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(assetId) != 0 && len(leaves.Leaves) != 1 {
+		// Mons are minted one at a time, so the issuance universe of a
+		// mon that isn't part of a collection holds a single leaf.
+		s.notMons[string(assetId)] = struct{}{}
+		return nil, nil
+	}
+
+	var candidates []*monCandidate
+	for _, leaf := range leaves.Leaves {
+		candidate, err := m.universeMon(ctx, leaf)
+		if err != nil {
+			return nil, err
+		}
+		if candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates, nil
+}
+
+// universeMonNeeded returns true if the asset with the given id may be a mon
+// that wasn't indexed yet or was reorged.
+func (m *Manager) universeMonNeeded(ctx context.Context,
+	assetId []byte) (bool, error) {
+
+	if _, ok := m.universe.notMons[string(assetId)]; ok {
+		return false, nil
+	}
+
+	previous, err := m.store.GetMon(ctx, assetId)
+	if err != nil {
+		return false, err
+	}
+
+	return previous == nil || previous.Reorged, nil
+}
+
+// universeMon decodes the issuance proof of an issuance universe leaf. It
+// returns nil if the asset isn't a mon or doesn't need to be indexed. Assets
+// whose issuance proof is malformed are skipped, while an error is returned
+// if tapd can't be reached, so that the proof is decoded again on the next
+// run.
+func (m *Manager) universeMon(ctx context.Context,
+	leaf *universerpc.AssetLeaf) (*monCandidate, error) {
+
+	s := m.universe
+	assetId := leaf.GetAsset().GetAssetGenesis().GetAssetId()
+	if len(assetId) == 0 {
+		return nil, nil
+	}
+	needed, err := m.universeMonNeeded(ctx, assetId)
+	if err != nil || !needed {
+		return nil, err
+	}
+
+	decoded, err := m.tapClient.DecodeProof(
+		ctx, &taprpc.DecodeProofRequest{
+			RawProof:       leaf.Proof,
+			WithMetaReveal: true,
+		},
+	)
+	switch {
+	case err != nil && malformedProof(ctx, err):
+		log.Printf("Skipping asset %x with undecodable issuance proof: "+
+			"%v", assetId, err)
+		s.notMons[string(assetId)] = struct{}{}
+		return nil, nil
+
+	case err != nil:
+		return nil, fmt.Errorf("unable to decode issuance proof of "+
+			"asset %x: %w", assetId, err)
+	}
+
+	proof := decoded.DecodedProof
+	if proof == nil || proof.Asset == nil ||
+		proof.Asset.AssetGenesis == nil ||
+		proof.Asset.ChainAnchor == nil {
+
+		log.Printf("Skipping asset %x with incomplete issuance proof",
+			assetId)
+		s.notMons[string(assetId)] = struct{}{}
+		return nil, nil
+	}
+
+	meta := proof.MetaReveal
+	if meta == nil || meta.Type != taprpc.AssetMetaType_META_TYPE_JSON {
+		s.notMons[string(assetId)] = struct{}{}
+		return nil, nil
+	}
+	_, err = ParseMonMetadata(meta.Data)
+	if errors.Is(err, ErrInvalidMonMetadata) {
+		log.Printf("Rejecting asset %x: %v", assetId, err)
+	}
+	if err != nil {
+		s.notMons[string(assetId)] = struct{}{}
+		return nil, nil
+	}
+
+	return &monCandidate{
+		asset: proof.Asset,
+		meta:  meta,
+	}, nil
+}
+
+// malformedProof returns true if a proof failed to decode because tapd
+// rejected it, rather than because tapd couldn't be reached or the context
+// is done.
+func malformedProof(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unknown, codes.InvalidArgument:
+		return true
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,314 @@
+package mons
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/universerpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memUniverse is the universe of a tapd that syncs the issuance leaves of
+// the reachable universe servers.
+type memUniverse struct {
+	universerpc.UniverseClient
+
+	servers []*universerpc.UniverseFederationServer
+	global  []*universerpc.GlobalFederationSyncConfig
+	configs []*universerpc.SetFederationSyncConfigRequest
+	syncs   []*universerpc.SyncRequest
+
+	// remote are the issuance leaves of the reachable universe servers.
+	remote map[string][]*universerpc.AssetLeaf
+
+	// leaves are the synced issuance leaves by universe key.
+	leaves map[string][]*universerpc.AssetLeaf
+
+	// ids are the ids of the synced issuance universes by universe key.
+	ids map[string]*universerpc.ID
+
+	leafQueries int
+	rootQueries int
+}
+
+func (u *memUniverse) ListFederationServers(_ context.Context,
+	_ *universerpc.ListFederationServersRequest, _ ...grpc.CallOption) (
+	*universerpc.ListFederationServersResponse, error) {
+
+	return &universerpc.ListFederationServersResponse{
+		Servers: u.servers,
+	}, nil
+}
+
+func (u *memUniverse) AddFederationServer(_ context.Context,
+	req *universerpc.AddFederationServerRequest, _ ...grpc.CallOption) (
+	*universerpc.AddFederationServerResponse, error) {
+
+	u.servers = append(u.servers, req.Servers...)
+
+	return &universerpc.AddFederationServerResponse{}, nil
+}
+
+func (u *memUniverse) QueryFederationSyncConfig(_ context.Context,
+	_ *universerpc.QueryFederationSyncConfigRequest,
+	_ ...grpc.CallOption) (*universerpc.QueryFederationSyncConfigResponse,
+	error) {
+
+	return &universerpc.QueryFederationSyncConfigResponse{
+		GlobalSyncConfigs: u.global,
+	}, nil
+}
+
+func (u *memUniverse) SetFederationSyncConfig(_ context.Context,
+	req *universerpc.SetFederationSyncConfigRequest,
+	_ ...grpc.CallOption) (*universerpc.SetFederationSyncConfigResponse,
+	error) {
+
+	u.configs = append(u.configs, req)
+
+	return &universerpc.SetFederationSyncConfigResponse{}, nil
+}
+
+func (u *memUniverse) SyncUniverse(_ context.Context,
+	req *universerpc.SyncRequest, _ ...grpc.CallOption) (
+	*universerpc.SyncResponse, error) {
+
+	leaves, ok := u.remote[req.UniverseHost]
+	if !ok {
+		return nil, fmt.Errorf("universe %v unreachable",
+			req.UniverseHost)
+	}
+	u.syncs = append(u.syncs, req)
+
+	resp := &universerpc.SyncResponse{}
+	if req.SyncMode != universerpc.UniverseSyncMode_SYNC_ISSUANCE_ONLY {
+		return resp, nil
+	}
+	for _, leaf := range leaves {
+		id := issuanceUniverse(leaf.Asset)
+		key := universeKey(id)
+		if slices.Contains(u.leaves[key], leaf) {
+			continue
+		}
+		u.leaves[key] = append(u.leaves[key], leaf)
+		u.ids[key] = id
+
+		resp.SyncedUniverses = append(
+			resp.SyncedUniverses, &universerpc.SyncedUniverse{
+				NewAssetRoot: &universerpc.UniverseRoot{Id: id},
+			},
+		)
+	}
+
+	return resp, nil
+}
+
+// issuanceUniverse returns the id of the issuance universe of an asset,
+// which is the universe of its group if it has one.
+func issuanceUniverse(asset *taprpc.Asset) *universerpc.ID {
+	id := &universerpc.ID{
+		Id: &universerpc.ID_AssetId{
+			AssetId: asset.AssetGenesis.AssetId,
+		},
+		ProofType: universerpc.ProofType_PROOF_TYPE_ISSUANCE,
+	}
+	if asset.AssetGroup != nil {
+		id.Id = &universerpc.ID_GroupKey{
+			GroupKey: asset.AssetGroup.TweakedGroupKey,
+		}
+	}
+
+	return id
+}
+
+// AssetRoots returns the issuance roots in the order of their keys.
+func (u *memUniverse) AssetRoots(_ context.Context,
+	req *universerpc.AssetRootRequest, _ ...grpc.CallOption) (
+	*universerpc.AssetRootResponse, error) {
+
+	u.rootQueries++
+
+	keys := make([]string, 0, len(u.leaves))
+	for key := range u.leaves {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	roots := make(map[string]*universerpc.UniverseRoot)
+	for i := int(req.Offset); i < len(keys) &&
+		i < int(req.Offset+req.Limit); i++ {
+
+		roots[keys[i]] = &universerpc.UniverseRoot{Id: u.ids[keys[i]]}
+	}
+
+	return &universerpc.AssetRootResponse{UniverseRoots: roots}, nil
+}
+
+func (u *memUniverse) AssetLeaves(_ context.Context, id *universerpc.ID,
+	_ ...grpc.CallOption) (*universerpc.AssetLeafResponse, error) {
+
+	u.leafQueries++
+
+	return &universerpc.AssetLeafResponse{
+		Leaves: u.leaves[universeKey(id)],
+	}, nil
+}
+
+// TestUniverseSync tests that mons minted on other nodes are discovered from
+// the issuances synced from the universe servers and that only the transfers
+// of mons are synced.
+func TestUniverseSync(t *testing.T) {
+	ctx := context.Background()
+	tapd := &memTapd{
+		metas:  make(map[string]*taprpc.AssetMeta),
+		proofs: make(map[string]*taprpc.Asset),
+	}
+	universe := &memUniverse{
+		servers: []*universerpc.UniverseFederationServer{{
+			Host: "universe-a",
+		}},
+		global: []*universerpc.GlobalFederationSyncConfig{{
+			ProofType:       universerpc.ProofType_PROOF_TYPE_ISSUANCE,
+			AllowSyncExport: true,
+		}},
+		remote: make(map[string][]*universerpc.AssetLeaf),
+		leaves: make(map[string][]*universerpc.AssetLeaf),
+		ids:    make(map[string]*universerpc.ID),
+	}
+	store := newMemMonStore()
+	manager := NewManager(
+		tapd, nil, nil, nil, newMemChain(), store, testPolicy,
+		NewUniverseSyncer(
+			universe, []string{"universe-a", "universe-b"}, true,
+		),
+	)
+
+	// A mon of ours is bred with a mon minted on another node, both of
+	// them are only known to the universe server.
+	tapd.addMon(t, testMon(1, 100, 0))
+	remoteLeaf := func(mon *IndexedMon) *universerpc.AssetLeaf {
+		tapd.addMeta(t, mon)
+		asset := genesisAsset(mon)
+		proof := fmt.Sprintf("issuance-%x", mon.AssetId)
+		tapd.proofs[proof] = asset
+
+		return &universerpc.AssetLeaf{
+			Asset: asset,
+			Proof: []byte(proof),
+		}
+	}
+	notMon := &taprpc.Asset{
+		AssetGenesis: &taprpc.GenesisInfo{AssetId: []byte{20}},
+		ChainAnchor:  &taprpc.AnchorInfo{},
+	}
+	tapd.proofs["not a mon"] = notMon
+	tapd.metas[string([]byte{20})] = &taprpc.AssetMeta{
+		Type: taprpc.AssetMetaType_META_TYPE_OPAQUE,
+	}
+	// The mons of a collection share the issuance universe of their
+	// group.
+	collectionLeaf := func(mon *IndexedMon) *universerpc.AssetLeaf {
+		mon.GroupKey = []byte{2, 9}
+		leaf := remoteLeaf(mon)
+		leaf.Asset.AssetGroup = &taprpc.AssetGroup{
+			TweakedGroupKey: mon.GroupKey,
+		}
+
+		return leaf
+	}
+	broken := &taprpc.Asset{
+		AssetGenesis: &taprpc.GenesisInfo{AssetId: []byte{21}},
+	}
+	universe.remote["universe-a"] = []*universerpc.AssetLeaf{
+		remoteLeaf(testMon(10, 110+BaseBreedCooldown, 1, 1, 2)),
+		remoteLeaf(testMon(2, 110, 0)),
+		{Asset: notMon, Proof: []byte("not a mon")},
+		{Asset: broken, Proof: []byte("undecodable")},
+		collectionLeaf(testMon(30, 120, 0)),
+		collectionLeaf(testMon(31, 121, 0)),
+	}
+
+	require.NoError(t, manager.IndexMons(ctx))
+	require.Len(t, store.mons, 1)
+
+	// The missing server is added to the federation and the issuances
+	// are synced from the reachable one.
+	require.NoError(t, manager.SyncUniverses(ctx))
+	require.Len(t, universe.servers, 2)
+	require.Equal(t, "universe-b", universe.servers[1].Host)
+	require.Len(t, universe.syncs, 2)
+	require.Equal(
+		t, universerpc.UniverseSyncMode_SYNC_ISSUANCE_ONLY,
+		universe.syncs[0].SyncMode,
+	)
+	require.Len(t, universe.configs, 2)
+	global := universe.configs[0].GlobalSyncConfigs
+	require.Len(t, global, 1)
+	require.True(t, global[0].AllowSyncInsert)
+	require.True(t, global[0].AllowSyncExport)
+	require.Len(t, universe.configs[1].AssetSyncConfigs, 1)
+
+	// Proofs that can't be decoded because tapd is unreachable are
+	// decoded again on the next run.
+	tapd.decodeErr = status.Error(codes.Unavailable, "tapd restarting")
+	require.NoError(t, manager.IndexMons(ctx))
+	require.Len(t, store.mons, 1)
+	tapd.decodeErr = nil
+
+	// The remote mons are indexed, parents before their children, while
+	// the asset with an undecodable proof is skipped.
+	require.NoError(t, manager.IndexMons(ctx))
+	require.Len(t, store.mons, 5)
+	child, err := store.GetMon(ctx, []byte{10})
+	require.NoError(t, err)
+	require.NotNil(t, child)
+	require.NotNil(t, child.Mon)
+	notIndexed, err := store.GetMon(ctx, []byte{20})
+	require.NoError(t, err)
+	require.Nil(t, notIndexed)
+	collectionMon, err := store.GetMon(ctx, []byte{31})
+	require.NoError(t, err)
+	require.NotNil(t, collectionMon)
+	require.Equal(t, []byte{2, 9}, collectionMon.GroupKey)
+
+	// Indexed mons and assets that aren't mons aren't looked up again,
+	// and the universe roots are only listed by the first run.
+	queries := universe.leafQueries
+	require.NoError(t, manager.IndexMons(ctx))
+	require.Equal(t, queries, universe.leafQueries)
+	require.Equal(t, 1, universe.rootQueries)
+
+	// The transfers of all mons are synced, while only the new mons are
+	// configured.
+	require.NoError(t, manager.SyncUniverses(ctx))
+	require.Len(t, universe.configs, 3)
+	require.Len(t, universe.configs[2].AssetSyncConfigs, 4)
+
+	full := universe.syncs[len(universe.syncs)-1]
+	require.Equal(t, universerpc.UniverseSyncMode_SYNC_FULL, full.SyncMode)
+	require.Len(t, full.SyncTargets, 5)
+	require.Equal(
+		t, universerpc.ProofType_PROOF_TYPE_TRANSFER,
+		full.SyncTargets[0].Id.ProofType,
+	)
+
+	// Unless asked to, the issuance sync config of tapd is kept.
+	universe.configs = nil
+	kept := NewManager(
+		tapd, nil, nil, nil, newMemChain(), newMemMonStore(),
+		testPolicy, NewUniverseSyncer(
+			universe, []string{"universe-a"}, false,
+		),
+	)
+	require.NoError(t, kept.SyncUniverses(ctx))
+	for _, config := range universe.configs {
+		require.Empty(t, config.GlobalSyncConfigs)
+	}
+}
@@ -1,5 +1,16 @@
 package nostr
 
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/nbd-wtf/go-nostr/nip44"
+)
+
 const MessageStartRange = 51928
 
 const (
@@ -7,5 +18,144 @@ const (
 	KindFoundMonLevel
 )
 
+// Manager publishes and receives events on a set of nostr relays with a
+// single nostr key.
 type Manager struct {
+	privKey string
+	pubKey  string
+	relays  []string
+	pool    *nostr.SimplePool
+}
+
+// NewManager creates a new manager that uses the given private key and
+// relays. The relay connections live as long as the given context.
+func NewManager(ctx context.Context, privKey string,
+	relays []string) (*Manager, error) {
+
+	if len(relays) == 0 {
+		return nil, errors.New("no relays configured")
+	}
+
+	pubKey, err := nostr.GetPublicKey(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nostr private key: %w", err)
+	}
+
+	return &Manager{
+		privKey: privKey,
+		pubKey:  pubKey,
+		relays:  relays,
+		pool:    nostr.NewSimplePool(ctx),
+	}, nil
+}
+
+// PubKey returns the pubkey the manager publishes events with.
+func (m *Manager) PubKey() string {
+	return m.pubKey
+}
+
+// Sign signs the event with our key without publishing it.
+func (m *Manager) Sign(ev *nostr.Event) error {
+	ev.PubKey = m.pubKey
+	if ev.CreatedAt == 0 {
+		ev.CreatedAt = nostr.Now()
+	}
+
+	return ev.Sign(m.privKey)
+}
+
+// Encrypt encrypts the plaintext for the given pubkey with NIP-44.
+func (m *Manager) Encrypt(pubKey, plaintext string) (string, error) {
+	key, err := nip44.GenerateConversationKey(pubKey, m.privKey)
+	if err != nil {
+		return "", err
+	}
+
+	// The nonce is passed explicitly, as the nip44 version we use doesn't
+	// generate a random one by itself.
+	var nonce [32]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+
+	return nip44.Encrypt(plaintext, key, nip44.WithCustomNonce(nonce[:]))
+}
+
+// Decrypt decrypts a NIP-44 ciphertext that the given pubkey encrypted for
+// us.
+func (m *Manager) Decrypt(pubKey, ciphertext string) (string, error) {
+	key, err := nip44.GenerateConversationKey(pubKey, m.privKey)
+	if err != nil {
+		return "", err
+	}
+
+	return nip44.Decrypt(ciphertext, key)
+}
+
+// Publish signs the event and publishes it to all relays. It succeeds if at
+// least one relay accepted the event.
+func (m *Manager) Publish(ctx context.Context, ev *nostr.Event) error {
+	if err := m.Sign(ev); err != nil {
+		return err
+	}
+
+	var lastErr error
+	published := 0
+	for _, url := range m.relays {
+		relay, err := m.pool.EnsureRelay(url)
+		if err != nil {
+			log.Printf("Unable to connect to relay %v: %v", url, err)
+			lastErr = err
+			continue
+		}
+
+		if err := relay.Publish(ctx, *ev); err != nil {
+			log.Printf("Unable to publish event %v to %v: %v", ev.ID,
+				url, err)
+			lastErr = err
+			continue
+		}
+		published++
+	}
+
+	if published == 0 {
+		return fmt.Errorf("event %v not accepted by any relay: %w", ev.ID,
+			lastErr)
+	}
+
+	return nil
+}
+
+// Subscribe streams all events matching the filters until the context is
+// cancelled.
+func (m *Manager) Subscribe(ctx context.Context,
+	filters nostr.Filters) (<-chan *nostr.Event, error) {
+
+	events := make(chan *nostr.Event)
+	incoming := m.pool.SubMany(ctx, m.relays, filters)
+	go func() {
+		defer close(events)
+		for ev := range incoming {
+			select {
+			case events <- ev.Event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// Query returns the stored events matching the filters that the relays
+// returned before the context was done.
+func (m *Manager) Query(ctx context.Context,
+	filters nostr.Filters) ([]*nostr.Event, error) {
+
+	var events []*nostr.Event
+	for ev := range m.pool.SubManyEose(ctx, m.relays, filters) {
+		events = append(events, ev.Event)
+	}
+
+	return events, nil
 }
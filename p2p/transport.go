@@ -0,0 +1,473 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/tapmon/tapmond/fightmons"
+)
+
+const (
+	// FightProtocol is the libp2p protocol fightmon events are streamed
+	// with. Every message is a signed nostr event encoded as a single line
+	// of json, the same format the relays carry.
+	FightProtocol = protocol.ID("/tapmon/fight/1.0.0")
+
+	// connectTimeout is the time we wait for a direct connection to the
+	// peer of an opponent before we keep using the relays only.
+	connectTimeout = 10 * time.Second
+
+	// subscriptionBuffer is the number of events buffered per
+	// subscription. Direct events that don't fit are dropped, they still
+	// arrive through the relays.
+	subscriptionBuffer = 1000
+)
+
+// directStream is an outgoing stream to the peer of another player.
+type directStream struct {
+	sync.Mutex
+
+	stream network.Stream
+	enc    *json.Encoder
+}
+
+// subscription is a subscription of the transport that receives events from
+// the relays and from direct streams.
+type subscription struct {
+	sync.Mutex
+
+	filters  nostr.Filters
+	matchIds []string
+	events   chan *nostr.Event
+	closed   bool
+}
+
+// Transport is a fightmon transport that exchanges the events of a match over
+// direct libp2p streams with the other player. The relays are used to find
+// the peer of the other player and remain the public record of the match, so
+// every event is published to them as well. If no direct stream can be
+// established or it breaks, the events arrive through the relays only.
+type Transport struct {
+	relays fightmons.PrivateTransport
+	host   host.Host
+
+	ctx    context.Context
+	cancel func()
+
+	mu sync.Mutex
+
+	// streams are our direct streams by match id and pubkey of the
+	// player they reach.
+	streams map[string]map[string]*directStream
+
+	// announced are the matches we announced our peer for.
+	announced map[string]struct{}
+
+	subs map[*subscription]struct{}
+}
+
+// A compile time check to ensure Transport implements the
+// fightmons.PrivateTransport interface.
+var _ fightmons.PrivateTransport = (*Transport)(nil)
+
+// NewTransport creates a libp2p host with the given options and returns a
+// transport that streams events directly to other players and uses the given
+// transport for the relays.
+func NewTransport(relays fightmons.PrivateTransport,
+	opts ...libp2p.Option) (*Transport, error) {
+
+	h, err := libp2p.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Transport{
+		relays:    relays,
+		host:      h,
+		ctx:       ctx,
+		cancel:    cancel,
+		streams:   make(map[string]map[string]*directStream),
+		announced: make(map[string]struct{}),
+		subs:      make(map[*subscription]struct{}),
+	}
+	h.SetStreamHandler(FightProtocol, t.handleStream)
+
+	return t, nil
+}
+
+// Close closes all direct streams and the libp2p host.
+func (t *Transport) Close() error {
+	t.cancel()
+
+	return t.host.Close()
+}
+
+// PeerId returns the libp2p peer id of the transport.
+func (t *Transport) PeerId() peer.ID {
+	return t.host.ID()
+}
+
+// PubKey returns the pubkey the transport publishes events with.
+func (t *Transport) PubKey() string {
+	return t.relays.PubKey()
+}
+
+// Sign signs the event with the key of the transport without publishing it.
+func (t *Transport) Sign(ev *nostr.Event) error {
+	return t.relays.Sign(ev)
+}
+
+// Encrypt encrypts the plaintext for the given pubkey with NIP-44.
+func (t *Transport) Encrypt(pubKey, plaintext string) (string, error) {
+	return t.relays.Encrypt(pubKey, plaintext)
+}
+
+// Decrypt decrypts a NIP-44 ciphertext that the given pubkey encrypted for
+// us.
+func (t *Transport) Decrypt(pubKey, ciphertext string) (string, error) {
+	return t.relays.Decrypt(pubKey, ciphertext)
+}
+
+// Query returns the events matching the filters stored by the relays.
+func (t *Transport) Query(ctx context.Context,
+	filters nostr.Filters) ([]*nostr.Event, error) {
+
+	return t.relays.Query(ctx, filters)
+}
+
+// Publish signs the event and sends it over the direct streams of its match
+// before publishing it to the relays. Handshake events announce our peer for
+// the match, so the other player can connect to us. It only fails if the
+// event reached neither a direct stream nor the relays.
+func (t *Transport) Publish(ctx context.Context, ev *nostr.Event) error {
+	if err := t.relays.Sign(ev); err != nil {
+		return err
+	}
+
+	matchId := fightmons.GetMatchId(ev)
+	sent := matchId != "" && t.sendDirect(matchId, ev)
+
+	if matchId != "" && isHandshake(ev.Kind) {
+		if err := t.announce(ctx, matchId); err != nil {
+			log.Printf("Unable to announce peer for match %v: %v",
+				matchId, err)
+		}
+	}
+
+	err := t.relays.Publish(ctx, ev)
+	if err != nil && sent {
+		log.Printf("Unable to publish event %v to relays, only sent "+
+			"it directly: %v", ev.ID, err)
+
+		return nil
+	}
+
+	return err
+}
+
+// Subscribe streams all events matching the filters from the relays and the
+// direct streams until the context is cancelled. Following a match connects
+// to the peers the other players announced for it.
+func (t *Transport) Subscribe(ctx context.Context,
+	filters nostr.Filters) (<-chan *nostr.Event, error) {
+
+	relayEvents, err := t.relays.Subscribe(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &subscription{
+		filters: filters,
+		events:  make(chan *nostr.Event, subscriptionBuffer),
+	}
+	for _, filter := range filters {
+		sub.matchIds = append(
+			sub.matchIds, filter.Tags[fightmons.MatchIndexTag]...,
+		)
+	}
+
+	t.mu.Lock()
+	t.subs[sub] = struct{}{}
+	t.mu.Unlock()
+
+	go func() {
+		defer t.unsubscribe(sub)
+
+		for ev := range relayEvents {
+			if ev.Kind == fightmons.FightMonPeer {
+				go t.connect(ev)
+			}
+
+			sub.Lock()
+			select {
+			case sub.events <- ev:
+			case <-ctx.Done():
+			}
+			sub.Unlock()
+		}
+	}()
+
+	return sub.events, nil
+}
+
+// unsubscribe closes the subscription and the direct streams of the matches
+// nobody follows anymore.
+func (t *Transport) unsubscribe(sub *subscription) {
+	t.mu.Lock()
+	delete(t.subs, sub)
+
+	var closing []*directStream
+	for _, matchId := range sub.matchIds {
+		if t.followed(matchId) {
+			continue
+		}
+		for _, stream := range t.streams[matchId] {
+			closing = append(closing, stream)
+		}
+		delete(t.streams, matchId)
+		delete(t.announced, matchId)
+	}
+	t.mu.Unlock()
+
+	for _, stream := range closing {
+		stream.stream.Close()
+	}
+
+	sub.Lock()
+	sub.closed = true
+	close(sub.events)
+	sub.Unlock()
+}
+
+// followed returns true if a subscription follows the given match. The
+// caller must hold the lock.
+func (t *Transport) followed(matchId string) bool {
+	for sub := range t.subs {
+		for _, id := range sub.matchIds {
+			if id == matchId {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// announce publishes our peer for the match to the relays once.
+func (t *Transport) announce(ctx context.Context, matchId string) error {
+	t.mu.Lock()
+	_, ok := t.announced[matchId]
+	t.announced[matchId] = struct{}{}
+	t.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	addrs := t.host.Addrs()
+	if len(addrs) == 0 {
+		return fmt.Errorf("no listen addresses")
+	}
+	content := fightmons.FightMonPeerEvent{
+		PeerId: t.host.ID().String(),
+	}
+	for _, addr := range addrs {
+		content.Addrs = append(content.Addrs, addr.String())
+	}
+
+	ev, err := fightmons.GetFightMonPeerEvent(matchId, content)
+	if err != nil {
+		return err
+	}
+
+	return t.relays.Publish(ctx, ev)
+}
+
+// connect opens a direct stream to the peer announced by the given event if
+// we follow its match and aren't connected to the player yet.
+func (t *Transport) connect(ev *nostr.Event) {
+	if ev.PubKey == t.PubKey() {
+		return
+	}
+	if ok, err := ev.CheckSignature(); err != nil || !ok {
+		return
+	}
+	var announced fightmons.FightMonPeerEvent
+	if err := fightmons.ValidateEvent(ev); err != nil {
+		return
+	}
+	if err := json.Unmarshal([]byte(ev.Content), &announced); err != nil {
+		return
+	}
+
+	matchId := fightmons.GetMatchId(ev)
+	t.mu.Lock()
+	_, connected := t.streams[matchId][ev.PubKey]
+	followed := t.followed(matchId)
+	t.mu.Unlock()
+	if connected || !followed {
+		return
+	}
+
+	info, err := addrInfo(announced)
+	if err != nil {
+		log.Printf("Invalid peer announced by %v: %v", ev.PubKey, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(t.ctx, connectTimeout)
+	defer cancel()
+	if err := t.host.Connect(ctx, *info); err != nil {
+		log.Printf("Unable to connect to peer of %v, using relays: %v",
+			ev.PubKey, err)
+		return
+	}
+	stream, err := t.host.NewStream(ctx, info.ID, FightProtocol)
+	if err != nil {
+		log.Printf("Unable to open stream to peer of %v, using "+
+			"relays: %v", ev.PubKey, err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, connected = t.streams[matchId][ev.PubKey]
+	if connected || !t.followed(matchId) {
+		stream.Close()
+		return
+	}
+	if t.streams[matchId] == nil {
+		t.streams[matchId] = make(map[string]*directStream)
+	}
+	t.streams[matchId][ev.PubKey] = &directStream{
+		stream: stream,
+		enc:    json.NewEncoder(stream),
+	}
+}
+
+// sendDirect sends the event over all direct streams of its match and returns
+// true if it reached at least one of them. Broken streams are closed, their
+// players receive our events through the relays from then on.
+func (t *Transport) sendDirect(matchId string, ev *nostr.Event) bool {
+	t.mu.Lock()
+	streams := make(map[string]*directStream, len(t.streams[matchId]))
+	for pubKey, stream := range t.streams[matchId] {
+		streams[pubKey] = stream
+	}
+	t.mu.Unlock()
+
+	var sent bool
+	for pubKey, stream := range streams {
+		stream.Lock()
+		err := stream.enc.Encode(ev)
+		stream.Unlock()
+		if err == nil {
+			sent = true
+			continue
+		}
+
+		log.Printf("Direct stream to %v broken, using relays: %v",
+			pubKey, err)
+		stream.stream.Reset()
+
+		t.mu.Lock()
+		if t.streams[matchId][pubKey] == stream {
+			delete(t.streams[matchId], pubKey)
+		}
+		t.mu.Unlock()
+	}
+
+	return sent
+}
+
+// handleStream reads the events another player streams to us and hands them
+// to the subscriptions they match.
+func (t *Transport) handleStream(stream network.Stream) {
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var ev nostr.Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			log.Printf("Invalid event from peer %v: %v",
+				stream.Conn().RemotePeer(), err)
+			stream.Reset()
+			return
+		}
+		if ok, err := ev.CheckSignature(); err != nil || !ok {
+			continue
+		}
+
+		t.deliver(&ev)
+	}
+}
+
+// deliver hands a direct event to the subscriptions it matches.
+func (t *Transport) deliver(ev *nostr.Event) {
+	t.mu.Lock()
+	subs := make([]*subscription, 0, len(t.subs))
+	for sub := range t.subs {
+		subs = append(subs, sub)
+	}
+	t.mu.Unlock()
+
+	for _, sub := range subs {
+		if !sub.filters.Match(ev) {
+			continue
+		}
+
+		sub.Lock()
+		if !sub.closed {
+			select {
+			case sub.events <- ev:
+			default:
+			}
+		}
+		sub.Unlock()
+	}
+}
+
+// isHandshake returns true for the events a player takes part in a match
+// with.
+func isHandshake(kind int) bool {
+	switch kind {
+	case fightmons.FightMonLookingForMatch, fightmons.FightMonRequestMatch:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// addrInfo parses the announced peer.
+func addrInfo(announced fightmons.FightMonPeerEvent) (*peer.AddrInfo, error) {
+	id, err := peer.Decode(announced.PeerId)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &peer.AddrInfo{ID: id}
+	for _, addr := range announced.Addrs {
+		maddr, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return nil, err
+		}
+		info.Addrs = append(info.Addrs, maddr)
+	}
+
+	return info, nil
+}
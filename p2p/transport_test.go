@@ -0,0 +1,254 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/fightmons"
+)
+
+// testRelay is an in-memory relay that can be taken offline.
+type testRelay struct {
+	sync.Mutex
+
+	events  []*nostr.Event
+	subs    map[chan *nostr.Event]nostr.Filters
+	offline bool
+}
+
+// testRelayTransport is a transport that publishes to a testRelay.
+type testRelayTransport struct {
+	relay *testRelay
+	sk    string
+	pub   string
+}
+
+func newTestRelayTransport(t *testing.T,
+	relay *testRelay) *testRelayTransport {
+
+	sk := nostr.GeneratePrivateKey()
+	pub, err := nostr.GetPublicKey(sk)
+	require.NoError(t, err)
+
+	return &testRelayTransport{relay: relay, sk: sk, pub: pub}
+}
+
+func (r *testRelayTransport) PubKey() string {
+	return r.pub
+}
+
+func (r *testRelayTransport) Sign(ev *nostr.Event) error {
+	ev.PubKey = r.pub
+	if ev.CreatedAt == 0 {
+		ev.CreatedAt = nostr.Now()
+	}
+
+	return ev.Sign(r.sk)
+}
+
+func (r *testRelayTransport) Encrypt(string, string) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (r *testRelayTransport) Decrypt(string, string) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (r *testRelayTransport) Publish(_ context.Context,
+	ev *nostr.Event) error {
+
+	if err := r.Sign(ev); err != nil {
+		return err
+	}
+
+	r.relay.Lock()
+	defer r.relay.Unlock()
+
+	if r.relay.offline {
+		return errors.New("relay offline")
+	}
+	published := *ev
+	r.relay.events = append(r.relay.events, &published)
+	for sub, filters := range r.relay.subs {
+		if filters.Match(&published) {
+			sub <- &published
+		}
+	}
+
+	return nil
+}
+
+func (r *testRelayTransport) Subscribe(ctx context.Context,
+	filters nostr.Filters) (<-chan *nostr.Event, error) {
+
+	sub := make(chan *nostr.Event, 1000)
+
+	r.relay.Lock()
+	for _, ev := range r.relay.events {
+		if filters.Match(ev) {
+			sub <- ev
+		}
+	}
+	r.relay.subs[sub] = filters
+	r.relay.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		r.relay.Lock()
+		delete(r.relay.subs, sub)
+		close(sub)
+		r.relay.Unlock()
+	}()
+
+	return sub, nil
+}
+
+func (r *testRelayTransport) Query(_ context.Context,
+	filters nostr.Filters) ([]*nostr.Event, error) {
+
+	r.relay.Lock()
+	defer r.relay.Unlock()
+
+	var events []*nostr.Event
+	for _, ev := range r.relay.events {
+		if filters.Match(ev) {
+			events = append(events, ev)
+		}
+	}
+
+	return events, nil
+}
+
+// newTestTransport returns a transport listening on localhost that uses the
+// given relay.
+func newTestTransport(t *testing.T, relay *testRelay) *Transport {
+	transport, err := NewTransport(
+		newTestRelayTransport(t, relay),
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		transport.Close()
+	})
+
+	return transport
+}
+
+// directStreams returns the number of direct streams of the match.
+func (t *Transport) directStreams(matchId string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.streams[matchId])
+}
+
+// waitForEvent waits for the event with the given id.
+func waitForEvent(t *testing.T, events <-chan *nostr.Event, id string) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev := <-events:
+			if ev.ID == id {
+				return
+			}
+
+		case <-timeout:
+			t.Fatalf("timeout waiting for event %v", id)
+		}
+	}
+}
+
+// handshake opens a match hosted by the host and requested by the
+// challenger, who both follow it, and returns the match id and the events
+// both receive.
+func handshake(t *testing.T, host, challenger *Transport) (string,
+	<-chan *nostr.Event, <-chan *nostr.Event) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	matchId, matchNonce, err := fightmons.NewMatchId(host.PubKey())
+	require.NoError(t, err)
+	filters := nostr.Filters{fightmons.MatchFilter(matchId)}
+
+	hostEvents, err := host.Subscribe(ctx, filters)
+	require.NoError(t, err)
+	require.NoError(t, host.Publish(
+		ctx, fightmons.GetFightMonLookingForMatchEvent(
+			matchId, matchNonce,
+		),
+	))
+
+	challengerEvents, err := challenger.Subscribe(ctx, filters)
+	require.NoError(t, err)
+	request, err := fightmons.GetFightMonRequestMatchEvent(
+		matchId, fightmons.Party{{Id: matchId}},
+	)
+	require.NoError(t, err)
+	require.NoError(t, challenger.Publish(ctx, request))
+
+	return matchId, hostEvents, challengerEvents
+}
+
+// TestDirectTransport tests that the players of a match connect to each other
+// and exchange the events of the match directly.
+func TestDirectTransport(t *testing.T) {
+	ctx := context.Background()
+	relay := &testRelay{subs: make(map[chan *nostr.Event]nostr.Filters)}
+	host, challenger := newTestTransport(t, relay),
+		newTestTransport(t, relay)
+
+	matchId, hostEvents, challengerEvents := handshake(t, host, challenger)
+	require.Eventually(t, func() bool {
+		return host.directStreams(matchId) == 1 &&
+			challenger.directStreams(matchId) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// With the relays gone, the events still reach the other player.
+	relay.Lock()
+	relay.offline = true
+	relay.Unlock()
+
+	start := fightmons.GetFightMonStartMatchEvent(matchId)
+	require.NoError(t, host.Publish(ctx, start))
+	waitForEvent(t, challengerEvents, start.ID)
+
+	commit, err := fightmons.GetFightMonRoundCommitEvent(
+		matchId, fightmons.FightMonRoundCommitEvent{RoundID: 1},
+	)
+	require.NoError(t, err)
+	require.NoError(t, challenger.Publish(ctx, commit))
+	waitForEvent(t, hostEvents, commit.ID)
+
+	// Events of other matches aren't sent directly.
+	otherId, _, err := fightmons.NewMatchId(host.PubKey())
+	require.NoError(t, err)
+	require.Error(t, host.Publish(
+		ctx, fightmons.GetFightMonStartMatchEvent(otherId),
+	))
+}
+
+// TestDirectTransportFallback tests that the events of a match are exchanged
+// through the relays if the players can't connect to each other.
+func TestDirectTransportFallback(t *testing.T) {
+	ctx := context.Background()
+	relay := &testRelay{subs: make(map[chan *nostr.Event]nostr.Filters)}
+	host, challenger := newTestTransport(t, relay),
+		newTestTransport(t, relay)
+
+	// The peer of the challenger is gone before the host can connect.
+	require.NoError(t, challenger.host.Close())
+	matchId, _, challengerEvents := handshake(t, host, challenger)
+
+	start := fightmons.GetFightMonStartMatchEvent(matchId)
+	require.NoError(t, host.Publish(ctx, start))
+	waitForEvent(t, challengerEvents, start.ID)
+	require.Zero(t, host.directStreams(matchId))
+}
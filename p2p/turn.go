@@ -0,0 +1,357 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/pion/logging"
+	"github.com/pion/turn/v3"
+	"github.com/tapmon/tapmond/fightmons"
+)
+
+const (
+	// maxDatagramSize is the largest message we send over a TURN channel.
+	// TURN servers only relay datagrams that fit their MTU, larger events
+	// have to be sent through the relays.
+	maxDatagramSize = 1200
+
+	// probeInterval is the interval in which we probe the relay address of
+	// the opponent until both of us heard from each other.
+	probeInterval = 100 * time.Millisecond
+)
+
+var (
+	// ErrEventTooLarge is returned for events that don't fit into a single
+	// datagram of a TURN channel.
+	ErrEventTooLarge = errors.New("event too large for channel")
+)
+
+// TurnServer is a TURN server we can allocate relay addresses on.
+type TurnServer struct {
+	// Addr is the host and port of the server.
+	Addr string
+
+	Username string
+	Password string
+}
+
+// channelMessage is a datagram of a TURN channel. Messages without an event
+// are probes, which are sent until both players heard from each other.
+type channelMessage struct {
+	// Ack is true if the sender already heard from the receiver.
+	Ack bool `json:"ack,omitempty"`
+
+	Event *nostr.Event `json:"event,omitempty"`
+}
+
+// TurnChannel is a fight channel between the two players of a match whose
+// datagrams are relayed by TURN servers. It is used if the players can't
+// connect to each other directly. Datagrams can be lost, so the relays
+// remain the reliable way the events of the match are exchanged.
+type TurnChannel struct {
+	client *turn.Client
+	conn   net.PacketConn
+	peer   net.Addr
+
+	// pending are the events the opponent sent while we were still
+	// probing.
+	pending []*nostr.Event
+}
+
+// DialTurnChannel allocates a relay address on the first of the given TURN
+// servers that grants one, exchanges relay addresses with the opponent of
+// the match through signaling events published with the given transport and
+// returns the channel once both players heard from each other.
+func DialTurnChannel(ctx context.Context, servers []TurnServer,
+	signaling fightmons.Transport, matchId,
+	opponent string) (*TurnChannel, error) {
+
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no TURN servers configured")
+	}
+
+	var (
+		client *turn.Client
+		conn   net.PacketConn
+		err    error
+	)
+	for _, server := range servers {
+		client, conn, err = allocate(server)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to allocate relay: %w", err)
+	}
+
+	channel := &TurnChannel{
+		client: client,
+		conn:   conn,
+	}
+	err = channel.connect(ctx, signaling, matchId, opponent)
+	if err != nil {
+		channel.Close()
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// allocate connects to the TURN server and allocates a relay address.
+func allocate(server TurnServer) (*turn.Client, net.PacketConn, error) {
+	conn, err := net.ListenPacket("udp4", "0.0.0.0:0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := turn.NewClient(&turn.ClientConfig{
+		STUNServerAddr: server.Addr,
+		TURNServerAddr: server.Addr,
+		Conn:           conn,
+		Username:       server.Username,
+		Password:       server.Password,
+		LoggerFactory:  logging.NewDefaultLoggerFactory(),
+	})
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if err := client.Listen(); err != nil {
+		client.Close()
+		conn.Close()
+		return nil, nil, err
+	}
+
+	relayConn, err := client.Allocate()
+	if err != nil {
+		client.Close()
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return client, relayConn, nil
+}
+
+// RelayAddr returns the relay address the TURN server allocated for us.
+func (c *TurnChannel) RelayAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// PeerAddr returns the relay address of the opponent.
+func (c *TurnChannel) PeerAddr() net.Addr {
+	return c.peer
+}
+
+// connect announces our relay address, waits for the relay address of the
+// opponent and probes it until both of us heard from each other.
+func (c *TurnChannel) connect(ctx context.Context,
+	signaling fightmons.Transport, matchId, opponent string) error {
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := signaling.Subscribe(subCtx, nostr.Filters{{
+		Kinds:   []int{fightmons.FightMonTurnRelay},
+		Authors: []string{opponent},
+		Tags: nostr.TagMap{
+			fightmons.MatchIndexTag: []string{matchId},
+		},
+	}})
+	if err != nil {
+		return err
+	}
+
+	ev, err := fightmons.GetFightMonTurnRelayEvent(
+		matchId, fightmons.FightMonTurnRelayEvent{
+			RelayAddr: c.RelayAddr().String(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if err := signaling.Publish(ctx, ev); err != nil {
+		return err
+	}
+
+	for c.peer == nil {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return fmt.Errorf("signaling closed")
+			}
+			c.peer = relayAddr(ev, opponent)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := c.client.CreatePermission(c.peer); err != nil {
+		return err
+	}
+
+	return c.probe(ctx)
+}
+
+// relayAddr returns the relay address the event of the opponent announces,
+// or nil if it doesn't announce a valid one.
+func relayAddr(ev *nostr.Event, opponent string) net.Addr {
+	if ev.PubKey != opponent {
+		return nil
+	}
+	if ok, err := ev.CheckSignature(); err != nil || !ok {
+		return nil
+	}
+
+	var relay fightmons.FightMonTurnRelayEvent
+	if err := fightmons.ValidateEvent(ev); err != nil {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(ev.Content), &relay); err != nil {
+		return nil
+	}
+	addr, err := net.ResolveUDPAddr("udp", relay.RelayAddr)
+	if err != nil {
+		return nil
+	}
+
+	return addr
+}
+
+// probe sends probes to the opponent until both of us heard from each other.
+func (c *TurnChannel) probe(ctx context.Context) error {
+	var heard, acked bool
+	buf := make([]byte, maxDatagramSize)
+	for !heard || !acked {
+		if err := c.write(channelMessage{Ack: heard}); err != nil {
+			return err
+		}
+
+		deadline := time.Now().Add(probeInterval)
+		if err := c.conn.SetReadDeadline(deadline); err != nil {
+			return err
+		}
+		for {
+			msg, err := c.read(buf)
+			if err != nil {
+				break
+			}
+			// The opponent only sends events once it heard our
+			// acknowledgement.
+			heard = true
+			acked = acked || msg.Ack || msg.Event != nil
+			if msg.Event != nil {
+				c.pending = append(c.pending, msg.Event)
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	// Our last probe might not have told the opponent that we heard
+	// from it, so we acknowledge once more. If that is lost too, the
+	// opponent keeps probing and Receive answers.
+	if err := c.conn.SetReadDeadline(time.Time{}); err != nil {
+		return err
+	}
+
+	return c.write(channelMessage{Ack: true})
+}
+
+// Send sends the event to the opponent. Delivery isn't guaranteed.
+func (c *TurnChannel) Send(ev *nostr.Event) error {
+	return c.write(channelMessage{Event: ev})
+}
+
+// Receive blocks until the next event of the opponent arrives or the channel
+// is closed. Probes of the opponent are answered, datagrams of others are
+// dropped.
+func (c *TurnChannel) Receive() (*nostr.Event, error) {
+	if len(c.pending) > 0 {
+		ev := c.pending[0]
+		c.pending = c.pending[1:]
+
+		return ev, nil
+	}
+
+	buf := make([]byte, maxDatagramSize)
+	for {
+		msg, err := c.read(buf)
+		var netErr net.Error
+		switch {
+		case errors.As(err, &netErr):
+			return nil, err
+
+		// Invalid messages are dropped like lost ones.
+		case err != nil:
+			continue
+		}
+
+		if msg.Event == nil {
+			if err := c.write(channelMessage{Ack: true}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		return msg.Event, nil
+	}
+}
+
+// Close releases the relay address and closes the connection to the TURN
+// server.
+func (c *TurnChannel) Close() error {
+	err := c.conn.Close()
+	c.client.Close()
+
+	return err
+}
+
+// write sends a message to the opponent.
+func (c *TurnChannel) write(msg channelMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(data) > maxDatagramSize {
+		return ErrEventTooLarge
+	}
+
+	_, err = c.conn.WriteTo(data, c.peer)
+
+	return err
+}
+
+// read reads the next message of the opponent.
+func (c *TurnChannel) read(buf []byte) (*channelMessage, error) {
+	for {
+		n, from, err := c.conn.ReadFrom(buf)
+		if err != nil {
+			return nil, err
+		}
+		if from.String() != c.peer.String() {
+			continue
+		}
+
+		var msg channelMessage
+		if err := json.Unmarshal(buf[:n], &msg); err != nil {
+			return nil, fmt.Errorf("invalid message: %w", err)
+		}
+		if msg.Event != nil {
+			ok, err := msg.Event.CheckSignature()
+			if err != nil || !ok {
+				return nil, fmt.Errorf("invalid event signature")
+			}
+		}
+
+		return &msg, nil
+	}
+}
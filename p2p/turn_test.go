@@ -0,0 +1,134 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nbd-wtf/go-nostr"
+	"github.com/pion/turn/v3"
+	"github.com/stretchr/testify/require"
+	"github.com/tapmon/tapmond/fightmons"
+)
+
+// newTestTurnServer starts a TURN server on localhost and returns its
+// config.
+func newTestTurnServer(t *testing.T) TurnServer {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	const (
+		realm    = "tapmon"
+		username = "tapmon"
+		password = "secret"
+	)
+	key := turn.GenerateAuthKey(username, realm, password)
+	server, err := turn.NewServer(turn.ServerConfig{
+		Realm: realm,
+		AuthHandler: func(user, _ string, _ net.Addr) ([]byte, bool) {
+			return key, user == username
+		},
+		PacketConnConfigs: []turn.PacketConnConfig{{
+			PacketConn: conn,
+			RelayAddressGenerator: &turn.RelayAddressGeneratorStatic{
+				RelayAddress: net.ParseIP("127.0.0.1"),
+				Address:      "127.0.0.1",
+			},
+		}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return TurnServer{
+		Addr:     conn.LocalAddr().String(),
+		Username: username,
+		Password: password,
+	}
+}
+
+// TestTurnChannel tests that two players exchange their relay addresses over
+// nostr and send each other events through the TURN server.
+func TestTurnChannel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	server := newTestTurnServer(t)
+	relay := &testRelay{subs: make(map[chan *nostr.Event]nostr.Filters)}
+	host, challenger := newTestRelayTransport(t, relay),
+		newTestRelayTransport(t, relay)
+	matchId, _, err := fightmons.NewMatchId(host.pub)
+	require.NoError(t, err)
+
+	// The host is configured with a server it can't authenticate with
+	// first, so it has to fall back to the next one.
+	wrongPassword := server
+	wrongPassword.Password = "wrong"
+
+	type result struct {
+		channel *TurnChannel
+		err     error
+	}
+	dial := func(servers []TurnServer, signaling fightmons.Transport,
+		opponent string, results chan<- result) {
+
+		channel, err := DialTurnChannel(
+			ctx, servers, signaling, matchId, opponent,
+		)
+		results <- result{channel: channel, err: err}
+	}
+	hostResult, challengerResult := make(chan result, 1),
+		make(chan result, 1)
+	go dial(
+		[]TurnServer{wrongPassword, server}, host, challenger.pub,
+		hostResult,
+	)
+	go dial([]TurnServer{server}, challenger, host.pub, challengerResult)
+
+	hostDial, challengerDial := <-hostResult, <-challengerResult
+	require.NoError(t, hostDial.err)
+	require.NoError(t, challengerDial.err)
+	hostChannel, challengerChannel := hostDial.channel,
+		challengerDial.channel
+	defer hostChannel.Close()
+	defer challengerChannel.Close()
+
+	require.Equal(t, challengerChannel.RelayAddr().String(),
+		hostChannel.PeerAddr().String())
+	require.Equal(t, hostChannel.RelayAddr().String(),
+		challengerChannel.PeerAddr().String())
+
+	start := fightmons.GetFightMonStartMatchEvent(matchId)
+	require.NoError(t, host.Sign(start))
+	require.NoError(t, hostChannel.Send(start))
+	received, err := challengerChannel.Receive()
+	require.NoError(t, err)
+	require.Equal(t, start.ID, received.ID)
+
+	commit, err := fightmons.GetFightMonRoundCommitEvent(
+		matchId, fightmons.FightMonRoundCommitEvent{RoundID: 1},
+	)
+	require.NoError(t, err)
+	require.NoError(t, challenger.Sign(commit))
+	require.NoError(t, challengerChannel.Send(commit))
+	received, err = hostChannel.Receive()
+	require.NoError(t, err)
+	require.Equal(t, commit.ID, received.ID)
+
+	// Events that don't fit into a datagram have to go through the
+	// relays.
+	large := &nostr.Event{
+		Kind:    fightmons.FightMonMatchBundle,
+		Content: strings.Repeat("a", maxDatagramSize),
+	}
+	require.NoError(t, host.Sign(large))
+	require.ErrorIs(t, hostChannel.Send(large), ErrEventTooLarge)
+
+	// Closing the channel ends receiving.
+	require.NoError(t, hostChannel.Close())
+	_, err = hostChannel.Receive()
+	require.Error(t, err)
+}
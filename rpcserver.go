@@ -2,59 +2,1100 @@ package tapmond
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/tapmon/tapmond/fightmons"
 	"github.com/tapmon/tapmond/mons"
 	"github.com/tapmon/tapmond/tapmonrpc"
 )
 
+const (
+	// defaultLeaderboardLimit is the number of leaderboard entries
+	// returned if the request doesn't set a limit.
+	defaultLeaderboardLimit = 100
+
+	// maxLeaderboardLimit is the maximum number of leaderboard entries
+	// returned at once.
+	maxLeaderboardLimit = 1000
+)
+
 type TapmonRpcServer struct {
 	tapmonManager *mons.Manager
+	fightManager  *fightmons.Manager
+	tournaments   *fightmons.Tournaments
 
 	tapmonrpc.UnimplementedTapmonServer
 }
 
-func NewTapmonRpcServer(manager *mons.Manager) *TapmonRpcServer {
+func NewTapmonRpcServer(manager *mons.Manager,
+	fightManager *fightmons.Manager,
+	tournaments *fightmons.Tournaments) *TapmonRpcServer {
+
 	return &TapmonRpcServer{
 		tapmonManager: manager,
+		fightManager:  fightManager,
+		tournaments:   tournaments,
 	}
 }
 
+// GetMon returns an indexed mon by its id or name together with its status.
+// Looking up a contested name fails with all mons claiming it.
 func (t *TapmonRpcServer) GetMon(ctx context.Context,
 	req *tapmonrpc.GetMonRequest) (*tapmonrpc.GetMonResponse, error) {
-	panic("not implemented") // TODO: Implement
+
+	var (
+		info *mons.MonInfo
+		err  error
+	)
+	switch {
+	case len(req.Id) != 0:
+		info, err = t.tapmonManager.GetMon(ctx, req.Id)
+
+	case req.Name != "":
+		info, err = t.tapmonManager.GetMonByName(ctx, req.Name)
+
+	default:
+		return nil, fmt.Errorf("no mon id or name given")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.GetMonResponse{
+		Mon: monInfoToRpc(info),
+	}, nil
 }
 
+// ListOwnedMons lists the indexed mons we own with the requested statuses.
 func (t *TapmonRpcServer) ListOwnedMons(ctx context.Context,
 	req *tapmonrpc.ListOwnedMonsRequest) (*tapmonrpc.ListOwnedMonsResponse,
 	error) {
 
-	panic("not implemented") // TODO: Implement
+	infos, err := t.tapmonManager.ListMons(ctx, mons.MonFilter{
+		Statuses: monStatusesFromRpc(req.Statuses),
+		Owned:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.ListOwnedMonsResponse{
+		Mons: monInfosToRpc(infos),
+	}, nil
 }
 
+// ListAllMons lists a page of all indexed mons with the requested statuses,
+// optionally restricted to a collection.
 func (t *TapmonRpcServer) ListAllMons(ctx context.Context,
 	req *tapmonrpc.ListAllMonsRequest) (*tapmonrpc.ListAllMonsResponse, error) {
 
-	panic("not implemented") // TODO: Implement
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, fmt.Errorf("invalid limit %d or offset %d",
+			req.Limit, req.Offset)
+	}
+
+	groupKey := req.GroupKey
+	if req.Collection != "" {
+		if len(groupKey) != 0 {
+			return nil, fmt.Errorf("either a group key or a " +
+				"collection can be given")
+		}
+
+		collection, err := t.tapmonManager.GetCollection(
+			ctx, req.Collection,
+		)
+		if err != nil {
+			return nil, err
+		}
+		groupKey = collection.GroupKey
+	}
+
+	infos, err := t.tapmonManager.ListMons(ctx, mons.MonFilter{
+		Statuses: monStatusesFromRpc(req.Statuses),
+		GroupKey: groupKey,
+		Offset:   int(req.Offset),
+		Limit:    int(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.ListAllMonsResponse{
+		Mons: monInfosToRpc(infos),
+	}, nil
 }
 
 func (t *TapmonRpcServer) MintMon(ctx context.Context,
 	req *tapmonrpc.MintMonRequest) (*tapmonrpc.MintMonResponse, error) {
 
-	mon, err := t.tapmonManager.MintMon(ctx, req.Name)
+	mon, err := t.tapmonManager.MintMon(ctx, req.Name, req.Collection)
 	if err != nil {
 		return nil, err
 	}
 
-	return &tapmonrpc.MintMonResponse{
+	resp := &tapmonrpc.MintMonResponse{
+		Mon: monToRpc(mon),
+	}
+	if req.Collection != "" {
+		collection, err := t.tapmonManager.GetCollection(
+			ctx, req.Collection,
+		)
+		if err != nil {
+			return nil, err
+		}
+		resp.GroupKey = collection.GroupKey
+	}
+
+	return resp, nil
+}
+
+func (t *TapmonRpcServer) BreedMon(ctx context.Context,
+	req *tapmonrpc.BreedMonRequest) (*tapmonrpc.BreedMonResponse, error) {
+
+	mon, err := t.tapmonManager.BreedMon(
+		ctx, req.Name, req.ParentAAssetId, req.ParentBAssetId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.BreedMonResponse{
 		Mon: monToRpc(mon),
 	}, nil
+}
+
+// SubscribeMonChanges streams the changes of indexed mons whose anchor blocks
+// were reorged out of the main chain.
+func (t *TapmonRpcServer) SubscribeMonChanges(
+	req *tapmonrpc.SubscribeMonChangesRequest,
+	stream tapmonrpc.Tapmon_SubscribeMonChangesServer) error {
 
+	changes, cancel := t.tapmonManager.SubscribeMonChanges()
+	defer cancel()
+
+	for {
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				return nil
+			}
+
+			rpcChange := &tapmonrpc.MonChange{
+				AssetId:  change.AssetId,
+				Previous: monToRpc(change.Previous),
+				Reorged:  change.Reorged(),
+			}
+			if change.Mon != nil {
+				rpcChange.Mon = monToRpc(change.Mon)
+			}
+			if err := stream.Send(rpcChange); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
 }
 
 func (t *TapmonRpcServer) LevelMon(ctx context.Context,
 	req *tapmonrpc.LevelMonRequest) (*tapmonrpc.LevelMonResponse, error) {
 
-	panic("not implemented") // TODO: Implement
+	mon, nonce, err := t.tapmonManager.LevelMon(
+		ctx, req.Id, int(req.RequestedLevel), int(req.StartAtNonce),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.LevelMonResponse{
+		Nonce: int64(nonce),
+		Mon:   monToRpc(mon),
+	}, nil
+}
+
+// GetMonHistory returns the mint, transfers, level ups and rated fights of a
+// mon in chronological order together with its latest known owner.
+func (t *TapmonRpcServer) GetMonHistory(ctx context.Context,
+	req *tapmonrpc.GetMonHistoryRequest) (*tapmonrpc.GetMonHistoryResponse,
+	error) {
+
+	history, err := t.tapmonManager.MonHistory(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fights are only known from the ratings of the matches we played.
+	fights, err := t.fightManager.RatingHistory(
+		ctx, fightmons.RatingMon, hex.EncodeToString(req.Id),
+	)
+	if err != nil && !errors.Is(err, fightmons.ErrRatingsNotTracked) {
+		return nil, err
+	}
+	for _, fight := range fights {
+		history = append(history, &mons.MonEvent{
+			Type:      mons.MonEventFight,
+			Timestamp: fight.PlayedAt,
+			MatchId:   fight.MatchId,
+		})
+	}
+	mons.SortHistory(history)
+
+	owner, err := t.tapmonManager.MonOwner(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*tapmonrpc.MonEvent, 0, len(history))
+	for _, event := range history {
+		rpcEvent := &tapmonrpc.MonEvent{
+			Type:      tapmonrpc.MonEventType(event.Type),
+			Timestamp: event.Timestamp.Unix(),
+			Height:    event.Height,
+			MatchId:   event.MatchId,
+		}
+		if event.Owner != nil {
+			rpcEvent.Owner = monOwnerToRpc(event.Owner)
+		}
+		if event.LevelUp != nil {
+			rpcEvent.Level = int32(event.LevelUp.Level)
+			rpcEvent.Nonce = int64(event.LevelUp.Nonce)
+		}
+		events = append(events, rpcEvent)
+	}
+
+	resp := &tapmonrpc.GetMonHistoryResponse{
+		Events: events,
+	}
+	if owner != nil {
+		resp.Owner = monOwnerToRpc(owner)
+	}
+
+	return resp, nil
+}
+
+// NewMonAddress creates a tap address to receive an indexed mon.
+func (t *TapmonRpcServer) NewMonAddress(ctx context.Context,
+	req *tapmonrpc.NewMonAddressRequest) (*tapmonrpc.NewMonAddressResponse,
+	error) {
+
+	addr, err := t.tapmonManager.NewMonAddress(ctx, req.AssetId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.NewMonAddressResponse{
+		Address: addr,
+	}, nil
+}
+
+// SendMon sends a mon to a tap address and waits for the transfer to
+// confirm.
+func (t *TapmonRpcServer) SendMon(ctx context.Context,
+	req *tapmonrpc.SendMonRequest) (*tapmonrpc.SendMonResponse, error) {
+
+	owner, err := t.tapmonManager.SendMon(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.SendMonResponse{
+		Owner: monOwnerToRpc(owner),
+	}, nil
+}
+
+// SubscribeMonReceives streams the updates of the mons we receive on our
+// addresses.
+func (t *TapmonRpcServer) SubscribeMonReceives(
+	req *tapmonrpc.SubscribeMonReceivesRequest,
+	stream tapmonrpc.Tapmon_SubscribeMonReceivesServer) error {
+
+	receives, errChan, err := t.tapmonManager.SubscribeMonReceives(
+		stream.Context(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case receive := <-receives:
+			err := stream.Send(&tapmonrpc.MonReceive{
+				AssetId:  receive.AssetId,
+				Address:  receive.Address,
+				Outpoint: receive.Outpoint,
+				Status: tapmonrpc.MonReceiveStatus(
+					receive.Status,
+				),
+				ConfirmationHeight: receive.ConfirmationHeight,
+				Error:              receive.Error,
+			})
+			if err != nil {
+				return err
+			}
+
+		case err := <-errChan:
+			return err
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// ExportMonProof exports a self-contained proof of a mon we own, which can
+// be verified offline.
+func (t *TapmonRpcServer) ExportMonProof(ctx context.Context,
+	req *tapmonrpc.ExportMonProofRequest) (
+	*tapmonrpc.ExportMonProofResponse, error) {
+
+	if len(req.Id) == 0 {
+		return nil, fmt.Errorf("no mon id given")
+	}
+
+	monProof, err := t.tapmonManager.ExportMonProof(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(monProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.ExportMonProofResponse{
+		Proof: data,
+	}, nil
+}
+
+// FindMatch returns the open lobbies of other players and optionally opens
+// a lobby of our own, which is private if an opponent is given.
+func (t *TapmonRpcServer) FindMatch(ctx context.Context,
+	req *tapmonrpc.FindMatchRequest) (*tapmonrpc.FindMatchResponse, error) {
+
+	if req.PrivateOpponent != "" && !req.OpenLobby {
+		return nil, fmt.Errorf("private opponent requires open_lobby")
+	}
+
+	openLobby := req.OpenLobby && req.PrivateOpponent == ""
+	lobbies, matchId, err := t.fightManager.FindMatch(ctx, openLobby)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PrivateOpponent != "" {
+		matchId, err = t.fightManager.OpenPrivateMatch(
+			ctx, req.PrivateOpponent,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rpcLobbies := make([]*tapmonrpc.Lobby, 0, len(lobbies))
+	for _, lobby := range lobbies {
+		roundTimeout := lobby.RoundTimeout / time.Second
+		rpcLobbies = append(rpcLobbies, &tapmonrpc.Lobby{
+			MatchId:      lobby.MatchId,
+			Host:         lobby.Host,
+			CreatedAt:    lobby.CreatedAt.Unix(),
+			HostRating:   lobby.HostRating,
+			Version:      uint32(lobby.Version),
+			Private:      lobby.Private,
+			RoundTimeout: uint32(roundTimeout),
+		})
+	}
+
+	return &tapmonrpc.FindMatchResponse{
+		Lobbies: rpcLobbies,
+		MatchId: matchId,
+	}, nil
+}
+
+// ChallengeMon requests a match from the host of an open lobby.
+func (t *TapmonRpcServer) ChallengeMon(ctx context.Context,
+	req *tapmonrpc.ChallengeMonRequest) (*tapmonrpc.ChallengeMonResponse,
+	error) {
+
+	party, err := partyFromRpc(req.Mon, req.Party)
+	if err != nil {
+		return nil, err
+	}
+	t.publishOwnership(ctx, party)
+
+	err = t.fightManager.ChallengeMon(ctx, req.MatchId, party)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.ChallengeMonResponse{}, nil
+}
+
+// AcceptChallenge accepts a match request for our lobby and starts the
+// match, or offers a wager and starts the match once both stakes are locked.
+func (t *TapmonRpcServer) AcceptChallenge(ctx context.Context,
+	req *tapmonrpc.AcceptChallengeRequest) (
+	*tapmonrpc.AcceptChallengeResponse, error) {
+
+	party, err := partyFromRpc(req.Mon, req.Party)
+	if err != nil {
+		return nil, err
+	}
+	t.publishOwnership(ctx, party)
+
+	if req.WagerSat > 0 {
+		err = t.fightManager.AcceptWageredChallenge(
+			ctx, req.MatchId, req.Challenger, party,
+			btcutil.Amount(req.WagerSat),
+		)
+	} else {
+		err = t.fightManager.AcceptChallenge(
+			ctx, req.MatchId, req.Challenger, party,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.AcceptChallengeResponse{}, nil
+}
+
+// SubmitAction submits our action for the current round of a match.
+func (t *TapmonRpcServer) SubmitAction(ctx context.Context,
+	req *tapmonrpc.SubmitActionRequest) (*tapmonrpc.SubmitActionResponse,
+	error) {
+
+	var (
+		roundId int
+		err     error
+	)
+	if req.Action == tapmonrpc.FightAction_SWITCH {
+		roundId, err = t.fightManager.SwitchMon(
+			ctx, req.MatchId, int(req.Target),
+		)
+	} else {
+		roundId, err = t.fightManager.SubmitAction(
+			ctx, req.MatchId, fightmons.Action(req.Action),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.SubmitActionResponse{
+		RoundId: int32(roundId),
+	}, nil
+}
+
+// SubscribeMatch streams all past and future updates of a match until the
+// match is finished.
+func (t *TapmonRpcServer) SubscribeMatch(req *tapmonrpc.SubscribeMatchRequest,
+	stream tapmonrpc.Tapmon_SubscribeMatchServer) error {
+
+	updates, cancel, err := t.fightManager.SubscribeMatch(req.MatchId)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+
+			err := stream.Send(matchUpdateToRpc(req.MatchId, update))
+			if err != nil {
+				return err
+			}
+
+			if update.Type == fightmons.UpdateFinished {
+				return nil
+			}
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// WatchMatch streams all past and future updates of any public match until
+// the match is finished.
+func (t *TapmonRpcServer) WatchMatch(req *tapmonrpc.WatchMatchRequest,
+	stream tapmonrpc.Tapmon_WatchMatchServer) error {
+
+	updates, err := t.fightManager.WatchMatch(
+		stream.Context(), req.MatchId,
+	)
+	if err != nil {
+		return err
+	}
+
+	for update := range updates {
+		err := stream.Send(matchUpdateToRpc(req.MatchId, update))
+		if err != nil {
+			return err
+		}
+	}
+
+	return stream.Context().Err()
+}
+
+// DisputeMatch publishes all events of a match we took part in as a bundle,
+// so third parties can verify its result.
+func (t *TapmonRpcServer) DisputeMatch(ctx context.Context,
+	req *tapmonrpc.DisputeMatchRequest) (*tapmonrpc.DisputeMatchResponse,
+	error) {
+
+	bundleId, err := t.fightManager.DisputeMatch(ctx, req.MatchId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.DisputeMatchResponse{
+		BundleId: bundleId,
+	}, nil
+}
+
+// OfferWager offers a wager on a match we take part in.
+func (t *TapmonRpcServer) OfferWager(ctx context.Context,
+	req *tapmonrpc.OfferWagerRequest) (*tapmonrpc.OfferWagerResponse,
+	error) {
+
+	wager, err := t.fightManager.OfferWager(
+		ctx, req.MatchId, btcutil.Amount(req.AmountSat),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.OfferWagerResponse{
+		Wager: wagerToRpc(wager),
+	}, nil
+}
+
+// ListWagers lists all our wagers.
+func (t *TapmonRpcServer) ListWagers(ctx context.Context,
+	req *tapmonrpc.ListWagersRequest) (*tapmonrpc.ListWagersResponse,
+	error) {
+
+	wagers, err := t.fightManager.ListWagers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcWagers := make([]*tapmonrpc.Wager, 0, len(wagers))
+	for _, wager := range wagers {
+		rpcWagers = append(rpcWagers, wagerToRpc(wager))
+	}
+
+	return &tapmonrpc.ListWagersResponse{
+		Wagers: rpcWagers,
+	}, nil
+}
+
+// OfferAnte offers one of our mons as ante of a match we take part in.
+func (t *TapmonRpcServer) OfferAnte(ctx context.Context,
+	req *tapmonrpc.OfferAnteRequest) (*tapmonrpc.OfferAnteResponse,
+	error) {
+
+	ante, err := t.fightManager.OfferAnte(
+		ctx, req.MatchId, req.Escrow, req.AssetId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.OfferAnteResponse{
+		Ante: anteToRpc(ante),
+	}, nil
+}
+
+// ListAntes lists all our antes.
+func (t *TapmonRpcServer) ListAntes(ctx context.Context,
+	req *tapmonrpc.ListAntesRequest) (*tapmonrpc.ListAntesResponse,
+	error) {
+
+	antes, err := t.fightManager.ListAntes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcAntes := make([]*tapmonrpc.Ante, 0, len(antes))
+	for _, ante := range antes {
+		rpcAntes = append(rpcAntes, anteToRpc(ante))
+	}
+
+	return &tapmonrpc.ListAntesResponse{
+		Antes: rpcAntes,
+	}, nil
+}
+
+// GetLeaderboard returns a page of the players or mons with the highest
+// ratings.
+func (t *TapmonRpcServer) GetLeaderboard(ctx context.Context,
+	req *tapmonrpc.GetLeaderboardRequest) (
+	*tapmonrpc.GetLeaderboardResponse, error) {
+
+	limit := int(req.Limit)
+	switch {
+	case limit == 0:
+		limit = defaultLeaderboardLimit
+
+	case limit > maxLeaderboardLimit:
+		return nil, fmt.Errorf("limit %d exceeds maximum of %d", limit,
+			maxLeaderboardLimit)
+	}
+
+	var kind fightmons.RatingKind
+	switch req.Kind {
+	case tapmonrpc.RatingKind_RATING_PLAYER:
+		kind = fightmons.RatingPlayer
+
+	case tapmonrpc.RatingKind_RATING_MON:
+		kind = fightmons.RatingMon
+
+	default:
+		return nil, fmt.Errorf("unknown rating kind %v", req.Kind)
+	}
+
+	ratings, err := t.fightManager.Leaderboard(
+		ctx, kind, int(req.Offset), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*tapmonrpc.LeaderboardEntry, 0, len(ratings))
+	for i, rating := range ratings {
+		entries = append(entries, &tapmonrpc.LeaderboardEntry{
+			Rank:    req.Offset + uint32(i) + 1,
+			Subject: rating.Subject,
+			Rating:  rating.Rating,
+			Games:   uint32(rating.Games),
+			Wins:    uint32(rating.Wins),
+			Losses:  uint32(rating.Losses),
+		})
+	}
+
+	return &tapmonrpc.GetLeaderboardResponse{
+		Entries: entries,
+	}, nil
+}
+
+// CreateTournament announces a tournament organized by us.
+func (t *TapmonRpcServer) CreateTournament(ctx context.Context,
+	req *tapmonrpc.CreateTournamentRequest) (
+	*tapmonrpc.CreateTournamentResponse, error) {
+
+	rules, err := tournamentRulesFromRpc(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	tournamentId, err := t.tournaments.Create(ctx, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.CreateTournamentResponse{
+		TournamentId: tournamentId,
+	}, nil
+}
+
+// RegisterTournament registers our fightmon for a tournament. Our matches
+// are joined automatically once the rounds start.
+func (t *TapmonRpcServer) RegisterTournament(ctx context.Context,
+	req *tapmonrpc.RegisterTournamentRequest) (
+	*tapmonrpc.RegisterTournamentResponse, error) {
+
+	fightMon, err := fightMonFromRpc(req.Mon)
+	if err != nil {
+		return nil, err
+	}
+	t.publishOwnership(ctx, fightmons.Party{fightMon})
+
+	err = t.tournaments.Register(ctx, req.TournamentId, fightMon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapmonrpc.RegisterTournamentResponse{}, nil
+}
+
+// GetTournament returns the registrations, rounds and standings of a
+// tournament, optionally verified against the events of its matches.
+func (t *TapmonRpcServer) GetTournament(ctx context.Context,
+	req *tapmonrpc.GetTournamentRequest) (*tapmonrpc.GetTournamentResponse,
+	error) {
+
+	var (
+		tournament *fightmons.Tournament
+		err        error
+	)
+	if req.Verify {
+		tournament, err = t.tournaments.Verify(ctx, req.TournamentId)
+	} else {
+		tournament, err = t.tournaments.Get(ctx, req.TournamentId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rpcTournament, err := tournamentToRpc(tournament)
+	if err != nil {
+		return nil, err
+	}
+	rpcTournament.Verified = req.Verify
+
+	return &tapmonrpc.GetTournamentResponse{
+		Tournament: rpcTournament,
+	}, nil
+}
+
+func tournamentRulesFromRpc(
+	rules *tapmonrpc.TournamentRules) (fightmons.TournamentRules, error) {
+
+	if rules == nil {
+		return fightmons.TournamentRules{},
+			fmt.Errorf("no tournament rules given")
+	}
+
+	var format fightmons.TournamentFormat
+	switch rules.Format {
+	case tapmonrpc.TournamentFormat_SINGLE_ELIMINATION:
+		format = fightmons.TournamentSingleElimination
+
+	case tapmonrpc.TournamentFormat_SWISS:
+		format = fightmons.TournamentSwiss
+
+	default:
+		return fightmons.TournamentRules{}, fmt.Errorf("unknown "+
+			"tournament format %v", rules.Format)
+	}
+
+	return fightmons.TournamentRules{
+		Name:            rules.Name,
+		Format:          format,
+		Rounds:          int(rules.Rounds),
+		MinLevel:        int(rules.MinLevel),
+		MaxRarity:       rules.MaxRarity,
+		EntryFeeSat:     rules.EntryFeeSat,
+		MaxPlayers:      int(rules.MaxPlayers),
+		RegistrationEnd: rules.RegistrationEnd,
+		RoundDuration:   rules.RoundDuration,
+	}, nil
+}
+
+func tournamentToRpc(
+	tournament *fightmons.Tournament) (*tapmonrpc.Tournament, error) {
+
+	rules := tournament.Rules
+	format := tapmonrpc.TournamentFormat_SINGLE_ELIMINATION
+	if rules.Format == fightmons.TournamentSwiss {
+		format = tapmonrpc.TournamentFormat_SWISS
+	}
+
+	rpcTournament := &tapmonrpc.Tournament{
+		TournamentId: tournament.Id,
+		Organizer:    tournament.Organizer,
+		Rules: &tapmonrpc.TournamentRules{
+			Name:            rules.Name,
+			Format:          format,
+			Rounds:          uint32(rules.Rounds),
+			MinLevel:        int32(rules.MinLevel),
+			MaxRarity:       rules.MaxRarity,
+			EntryFeeSat:     rules.EntryFeeSat,
+			MaxPlayers:      uint32(rules.MaxPlayers),
+			RegistrationEnd: rules.RegistrationEnd,
+			RoundDuration:   rules.RoundDuration,
+		},
+		Entrants: tournament.Entrants,
+		Final:    tournament.Final,
+	}
+
+	for _, registration := range tournament.Registrations {
+		mon := registration.Mon
+		monId, err := hex.DecodeString(mon.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcTournament.Registrations = append(
+			rpcTournament.Registrations,
+			&tapmonrpc.TournamentRegistration{
+				Player: registration.Player,
+				Mon: &tapmonrpc.FightMon{
+					Id:         monId,
+					Level:      int32(mon.Level),
+					LevelNonce: int64(mon.LevelNonce),
+				},
+				RegisteredAt: registration.RegisteredAt.Unix(),
+			},
+		)
+	}
+
+	for _, round := range tournament.Rounds {
+		rpcRound := &tapmonrpc.TournamentRound{
+			Round:     uint32(round.Round),
+			Bye:       round.Bye,
+			StartedAt: round.StartedAt.Unix(),
+			Closed:    round.Closed,
+		}
+		for i, pairing := range round.Pairings {
+			rpcPairing := &tapmonrpc.TournamentPairing{
+				MatchId:    pairing.MatchId,
+				Host:       pairing.Host,
+				Challenger: pairing.Challenger,
+			}
+			if i < len(round.Results) {
+				rpcPairing.Winner = round.Results[i].Winner
+			}
+			rpcRound.Pairings = append(
+				rpcRound.Pairings, rpcPairing,
+			)
+		}
+		rpcTournament.Rounds = append(rpcTournament.Rounds, rpcRound)
+	}
+
+	for _, standing := range tournament.Standings() {
+		rpcTournament.Standings = append(
+			rpcTournament.Standings, &tapmonrpc.TournamentStanding{
+				Player:     standing.Player,
+				Points:     standing.Points,
+				Wins:       uint32(standing.Wins),
+				Draws:      uint32(standing.Draws),
+				Losses:     uint32(standing.Losses),
+				Byes:       uint32(standing.Byes),
+				Eliminated: standing.Eliminated,
+			},
+		)
+	}
+
+	return rpcTournament, nil
+}
+
+func anteToRpc(ante *fightmons.Ante) *tapmonrpc.Ante {
+	return &tapmonrpc.Ante{
+		MatchId:      ante.MatchId,
+		Escrow:       ante.Escrow,
+		AssetId:      ante.AssetId,
+		RefundAddr:   ante.RefundAddr,
+		DepositAddr:  ante.DepositAddr,
+		State:        tapmonrpc.AnteState(ante.State),
+		TransferTxid: ante.TransferTxid,
+		Expiry:       ante.Expiry.Unix(),
+	}
+}
+
+func wagerToRpc(wager *fightmons.Wager) *tapmonrpc.Wager {
+	return &tapmonrpc.Wager{
+		MatchId:         wager.MatchId,
+		AmountSat:       int64(wager.AmountSat),
+		Invoice:         wager.Invoice,
+		OpponentInvoice: wager.OpponentInvoice,
+		State:           tapmonrpc.WagerState(wager.State),
+		Expiry:          wager.Expiry.Unix(),
+	}
+}
+
+func fightMonFromRpc(mon *tapmonrpc.FightMon) (fightmons.FightMon, error) {
+	if mon == nil {
+		return fightmons.FightMon{}, fmt.Errorf("no fightmon given")
+	}
+
+	return fightmons.FightMon{
+		Id:         hex.EncodeToString(mon.Id),
+		Level:      int(mon.Level),
+		LevelNonce: int(mon.LevelNonce),
+	}, nil
+}
+
+// partyFromRpc returns the given party or, if no party is given, a party of
+// the given mon alone.
+func partyFromRpc(mon *tapmonrpc.FightMon,
+	rpcParty []*tapmonrpc.FightMon) (fightmons.Party, error) {
+
+	if len(rpcParty) == 0 {
+		rpcParty = []*tapmonrpc.FightMon{mon}
+	}
+
+	party := make(fightmons.Party, 0, len(rpcParty))
+	for _, rpcMon := range rpcParty {
+		fightMon, err := fightMonFromRpc(rpcMon)
+		if err != nil {
+			return nil, err
+		}
+		party = append(party, fightMon)
+	}
+
+	return party, nil
+}
+
+// publishOwnership publishes the proofs of the mons of the party, so that
+// our opponents can rate them. Mons we can't prove to own fight unrated.
+func (t *TapmonRpcServer) publishOwnership(ctx context.Context,
+	party fightmons.Party) {
+
+	for _, mon := range party {
+		id, err := hex.DecodeString(mon.Id)
+		if err != nil {
+			continue
+		}
+
+		monProof, err := t.tapmonManager.ExportMonProof(ctx, id)
+		if err == nil {
+			err = t.fightManager.PublishOwnership(ctx, monProof)
+		}
+		if err != nil {
+			log.Printf("Unable to publish ownership proof of mon %v: "+
+				"%v", mon.Id, err)
+		}
+	}
+}
+
+// partyToRpc returns the rpc representation of a validated party.
+func partyToRpc(party fightmons.Party) []*tapmonrpc.FightMon {
+	rpcParty := make([]*tapmonrpc.FightMon, 0, len(party))
+	for _, mon := range party {
+		id, _ := hex.DecodeString(mon.Id)
+		rpcParty = append(rpcParty, &tapmonrpc.FightMon{
+			Id:         id,
+			Level:      int32(mon.Level),
+			LevelNonce: int64(mon.LevelNonce),
+		})
+	}
+
+	return rpcParty
+}
+
+func sideToRpc(side fightmons.Side) tapmonrpc.FightSide {
+	switch side {
+	case fightmons.SideHost:
+		return tapmonrpc.FightSide_SIDE_HOST
+	case fightmons.SideChallenger:
+		return tapmonrpc.FightSide_SIDE_CHALLENGER
+	default:
+		return tapmonrpc.FightSide_SIDE_NONE
+	}
+}
+
+func matchUpdateToRpc(matchId string,
+	update *fightmons.MatchUpdate) *tapmonrpc.MatchUpdate {
+
+	rpcUpdate := &tapmonrpc.MatchUpdate{
+		Type:    tapmonrpc.MatchUpdateType(update.Type),
+		MatchId: matchId,
+		Side:    sideToRpc(update.Side),
+		RoundId: int32(update.RoundId),
+	}
+	switch update.Type {
+	case fightmons.UpdateRevealed:
+		rpcUpdate.Action = tapmonrpc.FightAction(update.Action)
+		rpcUpdate.Target = uint32(update.Target)
+
+	case fightmons.UpdateAccepted:
+		rpcUpdate.Challenger = update.Players[fightmons.SideChallenger]
+		rpcUpdate.HostParty = partyToRpc(
+			update.Parties[fightmons.SideHost],
+		)
+		rpcUpdate.ChallengerParty = partyToRpc(
+			update.Parties[fightmons.SideChallenger],
+		)
+
+	case fightmons.UpdateFinished:
+		rpcUpdate.Winner = sideToRpc(update.Winner)
+		rpcUpdate.TimedOut = update.TimedOut
+
+	case fightmons.UpdateResultSigned:
+		rpcUpdate.Winner = sideToRpc(update.Winner)
+	}
+	if update.Event != nil {
+		rpcUpdate.EventId = update.Event.ID
+		rpcUpdate.Pubkey = update.Event.PubKey
+	}
+
+	if update.Result != nil {
+		result := &tapmonrpc.RoundResult{
+			RoundId: int32(update.Result.RoundId),
+			Hp: []int32{
+				int32(update.Result.HP[fightmons.SideHost]),
+				int32(update.Result.HP[fightmons.SideChallenger]),
+			},
+			Active: []uint32{
+				uint32(update.Result.Active[fightmons.SideHost]),
+				uint32(update.Result.Active[fightmons.SideChallenger]),
+			},
+		}
+		partyHP := update.Result.PartyHP
+		for _, hp := range partyHP[fightmons.SideHost] {
+			result.HostPartyHp = append(result.HostPartyHp, int32(hp))
+		}
+		for _, hp := range partyHP[fightmons.SideChallenger] {
+			result.ChallengerPartyHp = append(
+				result.ChallengerPartyHp, int32(hp),
+			)
+		}
+		for _, move := range update.Result.Moves {
+			result.Moves = append(result.Moves, &tapmonrpc.FightMove{
+				Side:   sideToRpc(move.Side),
+				Action: tapmonrpc.FightAction(move.Action),
+				Damage: int32(move.Damage),
+				Missed: move.Missed,
+				Target: uint32(move.Target),
+				Forced: move.Forced,
+			})
+		}
+		rpcUpdate.Result = result
+	}
+
+	return rpcUpdate
+}
+
+func monInfoToRpc(info *mons.MonInfo) *tapmonrpc.Mon {
+	// Unconfirmed mints aren't derived yet.
+	rpcMon := &tapmonrpc.Mon{}
+	if info.Mon != nil {
+		rpcMon = monToRpc(info.Mon)
+	}
+	rpcMon.Name = info.Name
+	rpcMon.AssetId = info.AssetId
+	rpcMon.GroupKey = info.GroupKey
+	rpcMon.Status = tapmonrpc.MonStatus(info.Status)
+
+	return rpcMon
+}
+
+func monInfosToRpc(infos []*mons.MonInfo) []*tapmonrpc.Mon {
+	rpcMons := make([]*tapmonrpc.Mon, 0, len(infos))
+	for _, info := range infos {
+		rpcMons = append(rpcMons, monInfoToRpc(info))
+	}
+
+	return rpcMons
+}
+
+func monStatusesFromRpc(statuses []tapmonrpc.MonStatus) []mons.MonStatus {
+	monStatuses := make([]mons.MonStatus, 0, len(statuses))
+	for _, status := range statuses {
+		monStatuses = append(monStatuses, mons.MonStatus(status))
+	}
+
+	return monStatuses
+}
+
+func monOwnerToRpc(owner *mons.MonOwner) *tapmonrpc.MonOwner {
+	return &tapmonrpc.MonOwner{
+		ScriptKey:      owner.ScriptKey,
+		AnchorOutpoint: owner.AnchorOutpoint.String(),
+		Height:         owner.Height,
+	}
 }
 
 func monToRpc(mon *mons.Mon) *tapmonrpc.Mon {
@@ -63,9 +1104,19 @@ func monToRpc(mon *mons.Mon) *tapmonrpc.Mon {
 		attributes = append(attributes, int32(rarity))
 	}
 
-	return &tapmonrpc.Mon{
+	rpcMon := &tapmonrpc.Mon{
 		Id:          mon.Id,
 		RarityScore: mon.CalculateRarityScore(0),
 		Attributes:  attributes,
+		Types:       mon.Types,
 	}
+	if mon.Form != nil {
+		rpcMon.Form = &tapmonrpc.MonForm{
+			Stage: int32(mon.Form.Stage),
+			Name:  mon.Form.Name,
+			Boost: int32(mon.Form.Boost),
+		}
+	}
+
+	return rpcMon
 }
@@ -1,9 +1,318 @@
 package tapmond
 
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/mintrpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/universerpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	gonostr "github.com/nbd-wtf/go-nostr"
+	"github.com/tapmon/tapmond/fightmons"
+	"github.com/tapmon/tapmond/mondb"
+	"github.com/tapmon/tapmond/mons"
+	"github.com/tapmon/tapmond/nostr"
+	"github.com/tapmon/tapmond/p2p"
+	"github.com/tapmon/tapmond/tapmonrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/macaroon.v2"
+)
+
+// Tapmond is the tapmon daemon. It indexes the mons known to tapd, plays
+// fights on the configured nostr relays and serves both over its rpc server.
 type Tapmond struct {
-	rpcServer *TapmonRpcServer
+	cfg *Config
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+
+	db        *mondb.SqliteStore
+	tapConn   *grpc.ClientConn
+	lnd       *lndclient.GrpcLndServices
+	transport fightmons.PrivateTransport
+	p2p       *p2p.Transport
+
+	monManager   *mons.Manager
+	fightManager *fightmons.Manager
+	tournaments  *fightmons.Tournaments
+	escrow       *fightmons.Escrow
+
+	grpcServer *grpc.Server
+	rpcServer  *TapmonRpcServer
+}
+
+// InitTapmond validates the configuration and creates the daemon. Nothing
+// is connected to before Start is called.
+func InitTapmond(cfg *Config) (*Tapmond, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Tapmond{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+// Start connects to tapd, lnd and the nostr relays, opens the database,
+// starts the indexer and the fight handlers and serves the rpc server. If
+// starting fails, everything started so far is stopped again.
+func (t *Tapmond) Start() error {
+	if err := t.start(); err != nil {
+		t.Stop()
+		return err
+	}
+
+	return nil
+}
+
+func (t *Tapmond) start() error {
+	cfg := t.cfg
+	if err := os.MkdirAll(cfg.DataDir, 0700); err != nil {
+		return err
+	}
+
+	var err error
+	t.db, err = mondb.NewSqliteStore(cfg.dbPath())
+	if err != nil {
+		return fmt.Errorf("unable to open database: %w", err)
+	}
+
+	t.tapConn, err = connectTapd(&cfg.Tapd)
+	if err != nil {
+		return fmt.Errorf("unable to connect to tapd: %w", err)
+	}
+	tapClient := taprpc.NewTaprootAssetsClient(t.tapConn)
+
+	t.lnd, err = lndclient.NewLndServices(&lndclient.LndServicesConfig{
+		LndAddress:  cfg.Lnd.Host,
+		Network:     lndclient.Network(cfg.Network),
+		MacaroonDir: cfg.Lnd.MacaroonDir,
+		TLSPath:     cfg.Lnd.TLSPath,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to connect to lnd: %w", err)
+	}
+
+	policy, err := cfg.finalityPolicy()
+	if err != nil {
+		return err
+	}
+	var universe *mons.UniverseSyncer
+	if len(cfg.Universe.Servers) > 0 {
+		universe = mons.NewUniverseSyncer(
+			universerpc.NewUniverseClient(t.tapConn),
+			cfg.Universe.Servers, cfg.Universe.SyncIssuances,
+		)
+	}
+	t.monManager = mons.NewManager(
+		tapClient, mintrpc.NewMintClient(t.tapConn),
+		assetwalletrpc.NewAssetWalletClient(t.tapConn),
+		t.lnd.ChainNotifier, t.lnd.ChainKit,
+		mondb.NewMonStore(t.db), policy, universe,
+	)
+
+	if err := t.startFights(tapClient); err != nil {
+		return err
+	}
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		err := t.monManager.RunIndexer(t.ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Mon indexer stopped: %v", err)
+		}
+	}()
+
+	return t.serveRPC()
+}
+
+// startFights connects to the nostr relays and starts the fight manager,
+// the tournaments and, if enabled, the escrow.
+func (t *Tapmond) startFights(tapClient taprpc.TaprootAssetsClient) error {
+	cfg := t.cfg
+
+	privKey, err := loadNostrKey(cfg.Nostr.KeyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load nostr key: %w", err)
+	}
+	relays, err := nostr.NewManager(t.ctx, privKey, cfg.Nostr.Relays)
+	if err != nil {
+		return err
+	}
+	t.transport = relays
+
+	if len(cfg.Fight.P2PListen) > 0 {
+		t.p2p, err = p2p.NewTransport(
+			relays, libp2p.ListenAddrStrings(cfg.Fight.P2PListen...),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to start p2p transport: %w",
+				err)
+		}
+		t.transport = t.p2p
+	}
+
+	wagers := fightmons.NewWagers(
+		mondb.NewWagerStore(t.db), t.lnd.Invoices, t.lnd.Client,
+		cfg.Fight.WagerTimeout,
+	)
+	antes := fightmons.NewAntes(
+		mondb.NewAnteStore(t.db), tapClient, cfg.Fight.AnteTimeout,
+	)
+	// Mons are rated once their players published proofs of owning
+	// them, which are verified against the headers of our own node.
+	ratings := fightmons.NewRatings(
+		mondb.NewRatingStore(t.db),
+		fightmons.NewProofOwnership(t.transport, t.monManager),
+	)
+	t.fightManager = fightmons.NewManager(
+		t.transport, wagers, antes, ratings,
+	)
+	t.tournaments = fightmons.NewTournaments(t.fightManager, t.lnd.Client)
+
+	if !cfg.Fight.Escrow {
+		return nil
+	}
+	t.escrow = fightmons.NewEscrow(
+		t.transport, tapClient, mondb.NewAnteStore(t.db),
+		cfg.Fight.AnteTimeout,
+	)
+
+	return t.escrow.Start()
+}
+
+// serveRPC starts serving the rpc server.
+func (t *Tapmond) serveRPC() error {
+	listener, err := net.Listen("tcp", t.cfg.RPCListen)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %v: %w",
+			t.cfg.RPCListen, err)
+	}
+
+	t.rpcServer = NewTapmonRpcServer(
+		t.monManager, t.fightManager, t.tournaments,
+	)
+	t.grpcServer = grpc.NewServer()
+	tapmonrpc.RegisterTapmonServer(t.grpcServer, t.rpcServer)
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		log.Printf("RPC server listening on %v", listener.Addr())
+		if err := t.grpcServer.Serve(listener); err != nil {
+			log.Printf("RPC server stopped: %v", err)
+		}
+	}()
+
+	return nil
 }
 
-func InitTapmond() (*Tapmond, error) {
-	return &Tapmond{}, nil
+// Stop stops the rpc server and all handlers and closes the connections.
+func (t *Tapmond) Stop() {
+	if t.grpcServer != nil {
+		t.grpcServer.GracefulStop()
+	}
+	t.cancel()
+
+	if t.escrow != nil {
+		t.escrow.Stop()
+	}
+	if t.tournaments != nil {
+		t.tournaments.Stop()
+	}
+	if t.fightManager != nil {
+		t.fightManager.Stop()
+	}
+	if t.p2p != nil {
+		if err := t.p2p.Close(); err != nil {
+			log.Printf("Unable to close p2p transport: %v", err)
+		}
+	}
+	t.wg.Wait()
+
+	if t.lnd != nil {
+		t.lnd.Close()
+	}
+	if t.tapConn != nil {
+		t.tapConn.Close()
+	}
+	if t.db != nil {
+		t.db.Close()
+	}
+}
+
+// connectTapd opens a grpc connection to tapd that authenticates with the
+// configured macaroon.
+func connectTapd(cfg *TapdConfig) (*grpc.ClientConn, error) {
+	tlsCreds, err := credentials.NewClientTLSFromFile(cfg.TLSPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tls certificate: %w", err)
+	}
+
+	macBytes, err := os.ReadFile(cfg.MacaroonPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read macaroon: %w", err)
+	}
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return nil, fmt.Errorf("unable to decode macaroon: %w", err)
+	}
+	macCreds, err := macaroons.NewMacaroonCredential(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	return grpc.Dial(
+		cfg.Host, grpc.WithTransportCredentials(tlsCreds),
+		grpc.WithPerRPCCredentials(macCreds),
+	)
+}
+
+// loadNostrKey reads the hex encoded nostr private key from the given file.
+// If the file doesn't exist, a new key is created and written to it.
+func loadNostrKey(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		privKey := strings.TrimSpace(string(data))
+		if _, err := hex.DecodeString(privKey); err != nil {
+			return "", fmt.Errorf("invalid key in %v: %w", path, err)
+		}
+
+		return privKey, nil
+
+	case !errors.Is(err, os.ErrNotExist):
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	privKey := gonostr.GeneratePrivateKey()
+	if err := os.WriteFile(path, []byte(privKey+"\n"), 0600); err != nil {
+		return "", err
+	}
+
+	return privKey, nil
 }
@@ -20,6 +20,166 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MonEventType int32
+
+const (
+	MonEventType_MON_EVENT_MINT     MonEventType = 0
+	MonEventType_MON_EVENT_TRANSFER MonEventType = 1
+	MonEventType_MON_EVENT_LEVEL_UP MonEventType = 2
+	MonEventType_MON_EVENT_FIGHT    MonEventType = 3
+)
+
+// Enum value maps for MonEventType.
+var (
+	MonEventType_name = map[int32]string{
+		0: "MON_EVENT_MINT",
+		1: "MON_EVENT_TRANSFER",
+		2: "MON_EVENT_LEVEL_UP",
+		3: "MON_EVENT_FIGHT",
+	}
+	MonEventType_value = map[string]int32{
+		"MON_EVENT_MINT":     0,
+		"MON_EVENT_TRANSFER": 1,
+		"MON_EVENT_LEVEL_UP": 2,
+		"MON_EVENT_FIGHT":    3,
+	}
+)
+
+func (x MonEventType) Enum() *MonEventType {
+	p := new(MonEventType)
+	*p = x
+	return p
+}
+
+func (x MonEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MonEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[0].Descriptor()
+}
+
+func (MonEventType) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[0]
+}
+
+func (x MonEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MonEventType.Descriptor instead.
+func (MonEventType) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{0}
+}
+
+type MonReceiveStatus int32
+
+const (
+	MonReceiveStatus_RECEIVE_DETECTED       MonReceiveStatus = 0
+	MonReceiveStatus_RECEIVE_CONFIRMED      MonReceiveStatus = 1
+	MonReceiveStatus_RECEIVE_PROOF_RECEIVED MonReceiveStatus = 2
+	MonReceiveStatus_RECEIVE_COMPLETED      MonReceiveStatus = 3
+)
+
+// Enum value maps for MonReceiveStatus.
+var (
+	MonReceiveStatus_name = map[int32]string{
+		0: "RECEIVE_DETECTED",
+		1: "RECEIVE_CONFIRMED",
+		2: "RECEIVE_PROOF_RECEIVED",
+		3: "RECEIVE_COMPLETED",
+	}
+	MonReceiveStatus_value = map[string]int32{
+		"RECEIVE_DETECTED":       0,
+		"RECEIVE_CONFIRMED":      1,
+		"RECEIVE_PROOF_RECEIVED": 2,
+		"RECEIVE_COMPLETED":      3,
+	}
+)
+
+func (x MonReceiveStatus) Enum() *MonReceiveStatus {
+	p := new(MonReceiveStatus)
+	*p = x
+	return p
+}
+
+func (x MonReceiveStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MonReceiveStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[1].Descriptor()
+}
+
+func (MonReceiveStatus) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[1]
+}
+
+func (x MonReceiveStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MonReceiveStatus.Descriptor instead.
+func (MonReceiveStatus) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{1}
+}
+
+type MonStatus int32
+
+const (
+	// The mon isn't confirmed in the main chain, because its mint is
+	// unconfirmed or its anchor block was reorged out. Unconfirmed mints
+	// aren't derived yet and only carry their name and asset id.
+	MonStatus_MON_PENDING MonStatus = 0
+	// The mon is confirmed but doesn't have enough confirmations to be
+	// final. Its attributes may still change.
+	MonStatus_MON_TENTATIVE MonStatus = 1
+	// The mon has enough confirmations that its attributes are assumed to
+	// never change.
+	MonStatus_MON_FINAL MonStatus = 2
+)
+
+// Enum value maps for MonStatus.
+var (
+	MonStatus_name = map[int32]string{
+		0: "MON_PENDING",
+		1: "MON_TENTATIVE",
+		2: "MON_FINAL",
+	}
+	MonStatus_value = map[string]int32{
+		"MON_PENDING":   0,
+		"MON_TENTATIVE": 1,
+		"MON_FINAL":     2,
+	}
+)
+
+func (x MonStatus) Enum() *MonStatus {
+	p := new(MonStatus)
+	*p = x
+	return p
+}
+
+func (x MonStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MonStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[2].Descriptor()
+}
+
+func (MonStatus) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[2]
+}
+
+func (x MonStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MonStatus.Descriptor instead.
+func (MonStatus) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{2}
+}
+
 type Rarity int32
 
 const (
@@ -56,11 +216,11 @@ func (x Rarity) String() string {
 }
 
 func (Rarity) Descriptor() protoreflect.EnumDescriptor {
-	return file_tapmonrpc_proto_enumTypes[0].Descriptor()
+	return file_tapmonrpc_proto_enumTypes[3].Descriptor()
 }
 
 func (Rarity) Type() protoreflect.EnumType {
-	return &file_tapmonrpc_proto_enumTypes[0]
+	return &file_tapmonrpc_proto_enumTypes[3]
 }
 
 func (x Rarity) Number() protoreflect.EnumNumber {
@@ -69,222 +229,409 @@ func (x Rarity) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Rarity.Descriptor instead.
 func (Rarity) EnumDescriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{0}
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{3}
 }
 
-type GetMonRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type FightAction int32
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Id   []byte `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-}
+const (
+	FightAction_ATTACK  FightAction = 0
+	FightAction_SPECIAL FightAction = 1
+	FightAction_DEFEND  FightAction = 2
+	FightAction_SWITCH  FightAction = 3
+)
 
-func (x *GetMonRequest) Reset() {
-	*x = GetMonRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for FightAction.
+var (
+	FightAction_name = map[int32]string{
+		0: "ATTACK",
+		1: "SPECIAL",
+		2: "DEFEND",
+		3: "SWITCH",
+	}
+	FightAction_value = map[string]int32{
+		"ATTACK":  0,
+		"SPECIAL": 1,
+		"DEFEND":  2,
+		"SWITCH":  3,
 	}
+)
+
+func (x FightAction) Enum() *FightAction {
+	p := new(FightAction)
+	*p = x
+	return p
 }
 
-func (x *GetMonRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x FightAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*GetMonRequest) ProtoMessage() {}
+func (FightAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[4].Descriptor()
+}
 
-func (x *GetMonRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (FightAction) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[4]
 }
 
-// Deprecated: Use GetMonRequest.ProtoReflect.Descriptor instead.
-func (*GetMonRequest) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{0}
+func (x FightAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *GetMonRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use FightAction.Descriptor instead.
+func (FightAction) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetMonRequest) GetId() []byte {
-	if x != nil {
-		return x.Id
+type FightSide int32
+
+const (
+	FightSide_SIDE_NONE       FightSide = 0
+	FightSide_SIDE_HOST       FightSide = 1
+	FightSide_SIDE_CHALLENGER FightSide = 2
+)
+
+// Enum value maps for FightSide.
+var (
+	FightSide_name = map[int32]string{
+		0: "SIDE_NONE",
+		1: "SIDE_HOST",
+		2: "SIDE_CHALLENGER",
 	}
-	return nil
+	FightSide_value = map[string]int32{
+		"SIDE_NONE":       0,
+		"SIDE_HOST":       1,
+		"SIDE_CHALLENGER": 2,
+	}
+)
+
+func (x FightSide) Enum() *FightSide {
+	p := new(FightSide)
+	*p = x
+	return p
 }
 
-type GetMonResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x FightSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
 
-	Mon *Mon `protobuf:"bytes,1,opt,name=mon,proto3" json:"mon,omitempty"`
+func (FightSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[5].Descriptor()
 }
 
-func (x *GetMonResponse) Reset() {
-	*x = GetMonResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (FightSide) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[5]
 }
 
-func (x *GetMonResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x FightSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (*GetMonResponse) ProtoMessage() {}
+// Deprecated: Use FightSide.Descriptor instead.
+func (FightSide) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{5}
+}
 
-func (x *GetMonResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+type MatchUpdateType int32
+
+const (
+	MatchUpdateType_LOBBY_OPENED  MatchUpdateType = 0
+	MatchUpdateType_CHALLENGED    MatchUpdateType = 1
+	MatchUpdateType_ACCEPTED      MatchUpdateType = 2
+	MatchUpdateType_STARTED       MatchUpdateType = 3
+	MatchUpdateType_COMMITTED     MatchUpdateType = 4
+	MatchUpdateType_REVEALED      MatchUpdateType = 5
+	MatchUpdateType_ROUND_RESULT  MatchUpdateType = 6
+	MatchUpdateType_FINISHED      MatchUpdateType = 7
+	MatchUpdateType_RESULT_SIGNED MatchUpdateType = 8
+)
+
+// Enum value maps for MatchUpdateType.
+var (
+	MatchUpdateType_name = map[int32]string{
+		0: "LOBBY_OPENED",
+		1: "CHALLENGED",
+		2: "ACCEPTED",
+		3: "STARTED",
+		4: "COMMITTED",
+		5: "REVEALED",
+		6: "ROUND_RESULT",
+		7: "FINISHED",
+		8: "RESULT_SIGNED",
 	}
-	return mi.MessageOf(x)
+	MatchUpdateType_value = map[string]int32{
+		"LOBBY_OPENED":  0,
+		"CHALLENGED":    1,
+		"ACCEPTED":      2,
+		"STARTED":       3,
+		"COMMITTED":     4,
+		"REVEALED":      5,
+		"ROUND_RESULT":  6,
+		"FINISHED":      7,
+		"RESULT_SIGNED": 8,
+	}
+)
+
+func (x MatchUpdateType) Enum() *MatchUpdateType {
+	p := new(MatchUpdateType)
+	*p = x
+	return p
 }
 
-// Deprecated: Use GetMonResponse.ProtoReflect.Descriptor instead.
-func (*GetMonResponse) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{1}
+func (x MatchUpdateType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *GetMonResponse) GetMon() *Mon {
-	if x != nil {
-		return x.Mon
-	}
-	return nil
+func (MatchUpdateType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[6].Descriptor()
 }
 
-type ListOwnedMonsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (MatchUpdateType) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[6]
 }
 
-func (x *ListOwnedMonsRequest) Reset() {
-	*x = ListOwnedMonsRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x MatchUpdateType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *ListOwnedMonsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use MatchUpdateType.Descriptor instead.
+func (MatchUpdateType) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{6}
 }
 
-func (*ListOwnedMonsRequest) ProtoMessage() {}
+type WagerState int32
 
-func (x *ListOwnedMonsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	WagerState_WAGER_OFFERED  WagerState = 0
+	WagerState_WAGER_LOCKED   WagerState = 1
+	WagerState_WAGER_WON      WagerState = 2
+	WagerState_WAGER_LOST     WagerState = 3
+	WagerState_WAGER_CANCELED WagerState = 4
+)
+
+// Enum value maps for WagerState.
+var (
+	WagerState_name = map[int32]string{
+		0: "WAGER_OFFERED",
+		1: "WAGER_LOCKED",
+		2: "WAGER_WON",
+		3: "WAGER_LOST",
+		4: "WAGER_CANCELED",
 	}
-	return mi.MessageOf(x)
+	WagerState_value = map[string]int32{
+		"WAGER_OFFERED":  0,
+		"WAGER_LOCKED":   1,
+		"WAGER_WON":      2,
+		"WAGER_LOST":     3,
+		"WAGER_CANCELED": 4,
+	}
+)
+
+func (x WagerState) Enum() *WagerState {
+	p := new(WagerState)
+	*p = x
+	return p
 }
 
-// Deprecated: Use ListOwnedMonsRequest.ProtoReflect.Descriptor instead.
-func (*ListOwnedMonsRequest) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{2}
+func (x WagerState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type ListOwnedMonsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (WagerState) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[7].Descriptor()
+}
 
-	Mons []*Mon `protobuf:"bytes,1,rep,name=mons,proto3" json:"mons,omitempty"`
+func (WagerState) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[7]
 }
 
-func (x *ListOwnedMonsResponse) Reset() {
-	*x = ListOwnedMonsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x WagerState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *ListOwnedMonsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use WagerState.Descriptor instead.
+func (WagerState) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{7}
 }
 
-func (*ListOwnedMonsResponse) ProtoMessage() {}
+type AnteState int32
 
-func (x *ListOwnedMonsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+const (
+	AnteState_ANTE_OFFERED   AnteState = 0
+	AnteState_ANTE_DEPOSITED AnteState = 1
+	AnteState_ANTE_RETURNED  AnteState = 2
+	AnteState_ANTE_FORFEITED AnteState = 3
+	AnteState_ANTE_CANCELED  AnteState = 4
+)
+
+// Enum value maps for AnteState.
+var (
+	AnteState_name = map[int32]string{
+		0: "ANTE_OFFERED",
+		1: "ANTE_DEPOSITED",
+		2: "ANTE_RETURNED",
+		3: "ANTE_FORFEITED",
+		4: "ANTE_CANCELED",
+	}
+	AnteState_value = map[string]int32{
+		"ANTE_OFFERED":   0,
+		"ANTE_DEPOSITED": 1,
+		"ANTE_RETURNED":  2,
+		"ANTE_FORFEITED": 3,
+		"ANTE_CANCELED":  4,
+	}
+)
+
+func (x AnteState) Enum() *AnteState {
+	p := new(AnteState)
+	*p = x
+	return p
 }
 
-// Deprecated: Use ListOwnedMonsResponse.ProtoReflect.Descriptor instead.
-func (*ListOwnedMonsResponse) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{3}
+func (x AnteState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *ListOwnedMonsResponse) GetMons() []*Mon {
-	if x != nil {
-		return x.Mons
+func (AnteState) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[8].Descriptor()
+}
+
+func (AnteState) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[8]
+}
+
+func (x AnteState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnteState.Descriptor instead.
+func (AnteState) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{8}
+}
+
+type RatingKind int32
+
+const (
+	RatingKind_RATING_PLAYER RatingKind = 0
+	RatingKind_RATING_MON    RatingKind = 1
+)
+
+// Enum value maps for RatingKind.
+var (
+	RatingKind_name = map[int32]string{
+		0: "RATING_PLAYER",
+		1: "RATING_MON",
 	}
-	return nil
+	RatingKind_value = map[string]int32{
+		"RATING_PLAYER": 0,
+		"RATING_MON":    1,
+	}
+)
+
+func (x RatingKind) Enum() *RatingKind {
+	p := new(RatingKind)
+	*p = x
+	return p
 }
 
-type ListAllMonsRequest struct {
+func (x RatingKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RatingKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[9].Descriptor()
+}
+
+func (RatingKind) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[9]
+}
+
+func (x RatingKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RatingKind.Descriptor instead.
+func (RatingKind) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{9}
+}
+
+type TournamentFormat int32
+
+const (
+	TournamentFormat_SINGLE_ELIMINATION TournamentFormat = 0
+	TournamentFormat_SWISS              TournamentFormat = 1
+)
+
+// Enum value maps for TournamentFormat.
+var (
+	TournamentFormat_name = map[int32]string{
+		0: "SINGLE_ELIMINATION",
+		1: "SWISS",
+	}
+	TournamentFormat_value = map[string]int32{
+		"SINGLE_ELIMINATION": 0,
+		"SWISS":              1,
+	}
+)
+
+func (x TournamentFormat) Enum() *TournamentFormat {
+	p := new(TournamentFormat)
+	*p = x
+	return p
+}
+
+func (x TournamentFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TournamentFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_tapmonrpc_proto_enumTypes[10].Descriptor()
+}
+
+func (TournamentFormat) Type() protoreflect.EnumType {
+	return &file_tapmonrpc_proto_enumTypes[10]
+}
+
+func (x TournamentFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TournamentFormat.Descriptor instead.
+func (TournamentFormat) EnumDescriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{10}
+}
+
+type GetMonRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The name of the mon, looked up case insensitively if no id is given.
+	// A name is held by the mon that was minted first, looking it up fails
+	// with all mons claiming it while that mon isn't final.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The id of the mon.
+	Id []byte `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *ListAllMonsRequest) Reset() {
-	*x = ListAllMonsRequest{}
+func (x *GetMonRequest) Reset() {
+	*x = GetMonRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[4]
+		mi := &file_tapmonrpc_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListAllMonsRequest) String() string {
+func (x *GetMonRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAllMonsRequest) ProtoMessage() {}
+func (*GetMonRequest) ProtoMessage() {}
 
-func (x *ListAllMonsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[4]
+func (x *GetMonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,50 +642,50 @@ func (x *ListAllMonsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAllMonsRequest.ProtoReflect.Descriptor instead.
-func (*ListAllMonsRequest) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetMonRequest.ProtoReflect.Descriptor instead.
+func (*GetMonRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *ListAllMonsRequest) GetLimit() int32 {
+func (x *GetMonRequest) GetName() string {
 	if x != nil {
-		return x.Limit
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ListAllMonsRequest) GetOffset() int32 {
+func (x *GetMonRequest) GetId() []byte {
 	if x != nil {
-		return x.Offset
+		return x.Id
 	}
-	return 0
+	return nil
 }
 
-type ListAllMonsResponse struct {
+type GetMonResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Mons []*Mon `protobuf:"bytes,1,rep,name=mons,proto3" json:"mons,omitempty"`
+	Mon *Mon `protobuf:"bytes,1,opt,name=mon,proto3" json:"mon,omitempty"`
 }
 
-func (x *ListAllMonsResponse) Reset() {
-	*x = ListAllMonsResponse{}
+func (x *GetMonResponse) Reset() {
+	*x = GetMonResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[5]
+		mi := &file_tapmonrpc_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListAllMonsResponse) String() string {
+func (x *GetMonResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAllMonsResponse) ProtoMessage() {}
+func (*GetMonResponse) ProtoMessage() {}
 
-func (x *ListAllMonsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[5]
+func (x *GetMonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,43 +696,44 @@ func (x *ListAllMonsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAllMonsResponse.ProtoReflect.Descriptor instead.
-func (*ListAllMonsResponse) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetMonResponse.ProtoReflect.Descriptor instead.
+func (*GetMonResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *ListAllMonsResponse) GetMons() []*Mon {
+func (x *GetMonResponse) GetMon() *Mon {
 	if x != nil {
-		return x.Mons
+		return x.Mon
 	}
 	return nil
 }
 
-type MintMonRequest struct {
+type ListOwnedMonsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The statuses of the listed mons, all if empty.
+	Statuses []MonStatus `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=tapmonrpc.MonStatus" json:"statuses,omitempty"`
 }
 
-func (x *MintMonRequest) Reset() {
-	*x = MintMonRequest{}
+func (x *ListOwnedMonsRequest) Reset() {
+	*x = ListOwnedMonsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[6]
+		mi := &file_tapmonrpc_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MintMonRequest) String() string {
+func (x *ListOwnedMonsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MintMonRequest) ProtoMessage() {}
+func (*ListOwnedMonsRequest) ProtoMessage() {}
 
-func (x *MintMonRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[6]
+func (x *ListOwnedMonsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,43 +744,43 @@ func (x *MintMonRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MintMonRequest.ProtoReflect.Descriptor instead.
-func (*MintMonRequest) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListOwnedMonsRequest.ProtoReflect.Descriptor instead.
+func (*ListOwnedMonsRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *MintMonRequest) GetName() string {
+func (x *ListOwnedMonsRequest) GetStatuses() []MonStatus {
 	if x != nil {
-		return x.Name
+		return x.Statuses
 	}
-	return ""
+	return nil
 }
 
-type MintMonResponse struct {
+type ListOwnedMonsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Mon *Mon `protobuf:"bytes,1,opt,name=mon,proto3" json:"mon,omitempty"`
+	Mons []*Mon `protobuf:"bytes,1,rep,name=mons,proto3" json:"mons,omitempty"`
 }
 
-func (x *MintMonResponse) Reset() {
-	*x = MintMonResponse{}
+func (x *ListOwnedMonsResponse) Reset() {
+	*x = ListOwnedMonsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[7]
+		mi := &file_tapmonrpc_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MintMonResponse) String() string {
+func (x *ListOwnedMonsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MintMonResponse) ProtoMessage() {}
+func (*ListOwnedMonsResponse) ProtoMessage() {}
 
-func (x *MintMonResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[7]
+func (x *ListOwnedMonsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -443,45 +791,51 @@ func (x *MintMonResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MintMonResponse.ProtoReflect.Descriptor instead.
-func (*MintMonResponse) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListOwnedMonsResponse.ProtoReflect.Descriptor instead.
+func (*ListOwnedMonsResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *MintMonResponse) GetMon() *Mon {
+func (x *ListOwnedMonsResponse) GetMons() []*Mon {
 	if x != nil {
-		return x.Mon
+		return x.Mons
 	}
 	return nil
 }
 
-type LevelMonRequest struct {
+type ListAllMonsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id             []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	RequestedLevel int32  `protobuf:"varint,2,opt,name=requested_level,json=requestedLevel,proto3" json:"requested_level,omitempty"`
-	StartAtNonce   int64  `protobuf:"varint,3,opt,name=start_at_nonce,json=startAtNonce,proto3" json:"start_at_nonce,omitempty"`
+	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The statuses of the listed mons, all if empty.
+	Statuses []MonStatus `protobuf:"varint,3,rep,packed,name=statuses,proto3,enum=tapmonrpc.MonStatus" json:"statuses,omitempty"`
+	// The group key of the collection of the listed mons, all if empty.
+	GroupKey []byte `protobuf:"bytes,4,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+	// The name of a collection we issue to list the mons of, as an
+	// alternative to its group key.
+	Collection string `protobuf:"bytes,5,opt,name=collection,proto3" json:"collection,omitempty"`
 }
 
-func (x *LevelMonRequest) Reset() {
-	*x = LevelMonRequest{}
+func (x *ListAllMonsRequest) Reset() {
+	*x = ListAllMonsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[8]
+		mi := &file_tapmonrpc_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LevelMonRequest) String() string {
+func (x *ListAllMonsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LevelMonRequest) ProtoMessage() {}
+func (*ListAllMonsRequest) ProtoMessage() {}
 
-func (x *LevelMonRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[8]
+func (x *ListAllMonsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,43 +846,286 @@ func (x *LevelMonRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LevelMonRequest.ProtoReflect.Descriptor instead.
-func (*LevelMonRequest) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListAllMonsRequest.ProtoReflect.Descriptor instead.
+func (*ListAllMonsRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *LevelMonRequest) GetId() []byte {
+func (x *ListAllMonsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Id
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *LevelMonRequest) GetRequestedLevel() int32 {
+func (x *ListAllMonsRequest) GetOffset() int32 {
 	if x != nil {
-		return x.RequestedLevel
+		return x.Offset
 	}
 	return 0
 }
 
-func (x *LevelMonRequest) GetStartAtNonce() int64 {
+func (x *ListAllMonsRequest) GetStatuses() []MonStatus {
 	if x != nil {
-		return x.StartAtNonce
+		return x.Statuses
 	}
-	return 0
+	return nil
 }
 
-type LevelMonResponse struct {
+func (x *ListAllMonsRequest) GetGroupKey() []byte {
+	if x != nil {
+		return x.GroupKey
+	}
+	return nil
+}
+
+func (x *ListAllMonsRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type ListAllMonsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nonce int64 `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Mons []*Mon `protobuf:"bytes,1,rep,name=mons,proto3" json:"mons,omitempty"`
 }
 
-func (x *LevelMonResponse) Reset() {
-	*x = LevelMonResponse{}
-	if protoimpl.UnsafeEnabled {
+func (x *ListAllMonsResponse) Reset() {
+	*x = ListAllMonsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAllMonsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllMonsResponse) ProtoMessage() {}
+
+func (x *ListAllMonsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllMonsResponse.ProtoReflect.Descriptor instead.
+func (*ListAllMonsResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAllMonsResponse) GetMons() []*Mon {
+	if x != nil {
+		return x.Mons
+	}
+	return nil
+}
+
+type MintMonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the mon, 3 to 32 ASCII letters and digits that may be
+	// separated by single spaces or hyphens. It must not be claimed by
+	// another mon, regardless of case.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The name of the collection or season to mint the mon into, a
+	// standalone mon is minted if empty. The first mon of a collection
+	// creates its asset group, so that only we can mint into it.
+	Collection string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+}
+
+func (x *MintMonRequest) Reset() {
+	*x = MintMonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintMonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintMonRequest) ProtoMessage() {}
+
+func (x *MintMonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintMonRequest.ProtoReflect.Descriptor instead.
+func (*MintMonRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MintMonRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MintMonRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type MintMonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mon *Mon `protobuf:"bytes,1,opt,name=mon,proto3" json:"mon,omitempty"`
+	// The group key of the collection the mon was minted into.
+	GroupKey []byte `protobuf:"bytes,2,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+}
+
+func (x *MintMonResponse) Reset() {
+	*x = MintMonResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintMonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintMonResponse) ProtoMessage() {}
+
+func (x *MintMonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintMonResponse.ProtoReflect.Descriptor instead.
+func (*MintMonResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MintMonResponse) GetMon() *Mon {
+	if x != nil {
+		return x.Mon
+	}
+	return nil
+}
+
+func (x *MintMonResponse) GetGroupKey() []byte {
+	if x != nil {
+		return x.GroupKey
+	}
+	return nil
+}
+
+type LevelMonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestedLevel int32  `protobuf:"varint,2,opt,name=requested_level,json=requestedLevel,proto3" json:"requested_level,omitempty"`
+	StartAtNonce   int64  `protobuf:"varint,3,opt,name=start_at_nonce,json=startAtNonce,proto3" json:"start_at_nonce,omitempty"`
+}
+
+func (x *LevelMonRequest) Reset() {
+	*x = LevelMonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LevelMonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LevelMonRequest) ProtoMessage() {}
+
+func (x *LevelMonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LevelMonRequest.ProtoReflect.Descriptor instead.
+func (*LevelMonRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LevelMonRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *LevelMonRequest) GetRequestedLevel() int32 {
+	if x != nil {
+		return x.RequestedLevel
+	}
+	return 0
+}
+
+func (x *LevelMonRequest) GetStartAtNonce() int64 {
+	if x != nil {
+		return x.StartAtNonce
+	}
+	return 0
+}
+
+type LevelMonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nonce int64 `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// The mon at the requested level in the form it evolved into.
+	Mon *Mon `protobuf:"bytes,2,opt,name=mon,proto3" json:"mon,omitempty"`
+}
+
+func (x *LevelMonResponse) Reset() {
+	*x = LevelMonResponse{}
+	if protoimpl.UnsafeEnabled {
 		mi := &file_tapmonrpc_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
@@ -548,302 +1145,5582 @@ func (x *LevelMonResponse) ProtoReflect() protoreflect.Message {
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use LevelMonResponse.ProtoReflect.Descriptor instead.
-func (*LevelMonResponse) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *LevelMonResponse) GetNonce() int64 {
-	if x != nil {
-		return x.Nonce
-	}
-	return 0
-}
-
-type Mon struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id          []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Attributes  []int32   `protobuf:"varint,3,rep,packed,name=attributes,proto3" json:"attributes,omitempty"`
-	RarityScore float64   `protobuf:"fixed64,4,opt,name=rarity_score,json=rarityScore,proto3" json:"rarity_score,omitempty"`
-	Level       *MonLevel `protobuf:"bytes,5,opt,name=level,proto3" json:"level,omitempty"`
-}
-
-func (x *Mon) Reset() {
-	*x = Mon{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *Mon) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Mon) ProtoMessage() {}
-
-func (x *Mon) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LevelMonResponse.ProtoReflect.Descriptor instead.
+func (*LevelMonResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LevelMonResponse) GetNonce() int64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *LevelMonResponse) GetMon() *Mon {
+	if x != nil {
+		return x.Mon
+	}
+	return nil
+}
+
+type BreedMonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the child asset.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The asset ids of the parents. Both must be indexed, owned by us and
+	// off their breeding cooldowns.
+	ParentAAssetId []byte `protobuf:"bytes,2,opt,name=parent_a_asset_id,json=parentAAssetId,proto3" json:"parent_a_asset_id,omitempty"`
+	ParentBAssetId []byte `protobuf:"bytes,3,opt,name=parent_b_asset_id,json=parentBAssetId,proto3" json:"parent_b_asset_id,omitempty"`
+}
+
+func (x *BreedMonRequest) Reset() {
+	*x = BreedMonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BreedMonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreedMonRequest) ProtoMessage() {}
+
+func (x *BreedMonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreedMonRequest.ProtoReflect.Descriptor instead.
+func (*BreedMonRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BreedMonRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BreedMonRequest) GetParentAAssetId() []byte {
+	if x != nil {
+		return x.ParentAAssetId
+	}
+	return nil
+}
+
+func (x *BreedMonRequest) GetParentBAssetId() []byte {
+	if x != nil {
+		return x.ParentBAssetId
+	}
+	return nil
+}
+
+type BreedMonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The child with the attributes it inherited.
+	Mon *Mon `protobuf:"bytes,1,opt,name=mon,proto3" json:"mon,omitempty"`
+}
+
+func (x *BreedMonResponse) Reset() {
+	*x = BreedMonResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BreedMonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreedMonResponse) ProtoMessage() {}
+
+func (x *BreedMonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreedMonResponse.ProtoReflect.Descriptor instead.
+func (*BreedMonResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BreedMonResponse) GetMon() *Mon {
+	if x != nil {
+		return x.Mon
+	}
+	return nil
+}
+
+type SubscribeMonChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SubscribeMonChangesRequest) Reset() {
+	*x = SubscribeMonChangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeMonChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeMonChangesRequest) ProtoMessage() {}
+
+func (x *SubscribeMonChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeMonChangesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeMonChangesRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{12}
+}
+
+// A change of an indexed mon whose anchor block was reorged out of the main
+// chain.
+type MonChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The asset id of the mon.
+	AssetId []byte `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// The mon as it was derived before the change.
+	Previous *Mon `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"`
+	// The mon derived from its new anchor, unset if the mon was just
+	// reorged out and isn't derived again yet.
+	Mon *Mon `protobuf:"bytes,3,opt,name=mon,proto3" json:"mon,omitempty"`
+	// Set if the mon was reorged out and isn't derived again yet.
+	Reorged bool `protobuf:"varint,4,opt,name=reorged,proto3" json:"reorged,omitempty"`
+}
+
+func (x *MonChange) Reset() {
+	*x = MonChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonChange) ProtoMessage() {}
+
+func (x *MonChange) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonChange.ProtoReflect.Descriptor instead.
+func (*MonChange) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MonChange) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+func (x *MonChange) GetPrevious() *Mon {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *MonChange) GetMon() *Mon {
+	if x != nil {
+		return x.Mon
+	}
+	return nil
+}
+
+func (x *MonChange) GetReorged() bool {
+	if x != nil {
+		return x.Reorged
+	}
+	return false
+}
+
+type GetMonHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetMonHistoryRequest) Reset() {
+	*x = GetMonHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMonHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMonHistoryRequest) ProtoMessage() {}
+
+func (x *GetMonHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMonHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetMonHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetMonHistoryRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type GetMonHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The events in the history of the mon in chronological order.
+	Events []*MonEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// The latest known owner of the mon, unset if no owner is known.
+	Owner *MonOwner `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (x *GetMonHistoryResponse) Reset() {
+	*x = GetMonHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMonHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMonHistoryResponse) ProtoMessage() {}
+
+func (x *GetMonHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMonHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetMonHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetMonHistoryResponse) GetEvents() []*MonEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetMonHistoryResponse) GetOwner() *MonOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+// An owner of a mon, identified by the script key the mon was sent to.
+type MonOwner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScriptKey []byte `protobuf:"bytes,1,opt,name=script_key,json=scriptKey,proto3" json:"script_key,omitempty"`
+	// The outpoint that anchors the mon while it is owned by the owner.
+	AnchorOutpoint string `protobuf:"bytes,2,opt,name=anchor_outpoint,json=anchorOutpoint,proto3" json:"anchor_outpoint,omitempty"`
+	// The height of the block that confirmed the anchor.
+	Height uint32 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (x *MonOwner) Reset() {
+	*x = MonOwner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonOwner) ProtoMessage() {}
+
+func (x *MonOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonOwner.ProtoReflect.Descriptor instead.
+func (*MonOwner) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MonOwner) GetScriptKey() []byte {
+	if x != nil {
+		return x.ScriptKey
+	}
+	return nil
+}
+
+func (x *MonOwner) GetAnchorOutpoint() string {
+	if x != nil {
+		return x.AnchorOutpoint
+	}
+	return ""
+}
+
+func (x *MonOwner) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// An event in the history of a mon.
+type MonEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type MonEventType `protobuf:"varint,1,opt,name=type,proto3,enum=tapmonrpc.MonEventType" json:"type,omitempty"`
+	// The unix timestamp of the event. The time of mints and transfers is
+	// the timestamp of the block that confirmed them.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The height of the block that confirmed a mint or transfer, or the
+	// height of the tip of the chain at a level up. Zero for fights.
+	Height uint32 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// The new owner of a mint or transfer.
+	Owner *MonOwner `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The level reached by a level up and the nonce proving it.
+	Level int32 `protobuf:"varint,5,opt,name=level,proto3" json:"level,omitempty"`
+	Nonce int64 `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// The id of the match of a fight.
+	MatchId string `protobuf:"bytes,7,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *MonEvent) Reset() {
+	*x = MonEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonEvent) ProtoMessage() {}
+
+func (x *MonEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonEvent.ProtoReflect.Descriptor instead.
+func (*MonEvent) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MonEvent) GetType() MonEventType {
+	if x != nil {
+		return x.Type
+	}
+	return MonEventType_MON_EVENT_MINT
+}
+
+func (x *MonEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MonEvent) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *MonEvent) GetOwner() *MonOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *MonEvent) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *MonEvent) GetNonce() int64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *MonEvent) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type NewMonAddressRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The asset id of the indexed mon to receive.
+	AssetId []byte `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+}
+
+func (x *NewMonAddressRequest) Reset() {
+	*x = NewMonAddressRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewMonAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewMonAddressRequest) ProtoMessage() {}
+
+func (x *NewMonAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewMonAddressRequest.ProtoReflect.Descriptor instead.
+func (*NewMonAddressRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NewMonAddressRequest) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+type NewMonAddressResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The tap address to send the mon to.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *NewMonAddressResponse) Reset() {
+	*x = NewMonAddressResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewMonAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewMonAddressResponse) ProtoMessage() {}
+
+func (x *NewMonAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewMonAddressResponse.ProtoReflect.Descriptor instead.
+func (*NewMonAddressResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NewMonAddressResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type SendMonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The tap address of the recipient. It must request a single indexed
+	// mon we own.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *SendMonRequest) Reset() {
+	*x = SendMonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendMonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMonRequest) ProtoMessage() {}
+
+func (x *SendMonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMonRequest.ProtoReflect.Descriptor instead.
+func (*SendMonRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SendMonRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type SendMonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The new owner of the mon once the transfer confirmed.
+	Owner *MonOwner `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (x *SendMonResponse) Reset() {
+	*x = SendMonResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendMonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMonResponse) ProtoMessage() {}
+
+func (x *SendMonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMonResponse.ProtoReflect.Descriptor instead.
+func (*SendMonResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SendMonResponse) GetOwner() *MonOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+type SubscribeMonReceivesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SubscribeMonReceivesRequest) Reset() {
+	*x = SubscribeMonReceivesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeMonReceivesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeMonReceivesRequest) ProtoMessage() {}
+
+func (x *SubscribeMonReceivesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeMonReceivesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeMonReceivesRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{22}
+}
+
+// An update of a mon we receive on one of our addresses.
+type MonReceive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AssetId []byte `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// The outpoint that anchors the received mon.
+	Outpoint string           `protobuf:"bytes,3,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	Status   MonReceiveStatus `protobuf:"varint,4,opt,name=status,proto3,enum=tapmonrpc.MonReceiveStatus" json:"status,omitempty"`
+	// The height the transaction confirmed at, zero if it isn't confirmed
+	// yet.
+	ConfirmationHeight uint32 `protobuf:"varint,5,opt,name=confirmation_height,json=confirmationHeight,proto3" json:"confirmation_height,omitempty"`
+	// The error tapd ran into while receiving the mon, if any.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *MonReceive) Reset() {
+	*x = MonReceive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonReceive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonReceive) ProtoMessage() {}
+
+func (x *MonReceive) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonReceive.ProtoReflect.Descriptor instead.
+func (*MonReceive) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MonReceive) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+func (x *MonReceive) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *MonReceive) GetOutpoint() string {
+	if x != nil {
+		return x.Outpoint
+	}
+	return ""
+}
+
+func (x *MonReceive) GetStatus() MonReceiveStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MonReceiveStatus_RECEIVE_DETECTED
+}
+
+func (x *MonReceive) GetConfirmationHeight() uint32 {
+	if x != nil {
+		return x.ConfirmationHeight
+	}
+	return 0
+}
+
+func (x *MonReceive) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ExportMonProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The id of the mon to prove, which we must own.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ExportMonProofRequest) Reset() {
+	*x = ExportMonProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportMonProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMonProofRequest) ProtoMessage() {}
+
+func (x *ExportMonProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMonProofRequest.ProtoReflect.Descriptor instead.
+func (*ExportMonProofRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ExportMonProofRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type ExportMonProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The JSON encoded proof bundle of the mon. It can be verified offline
+	// with `moncli mon verify` given the headers of the blocks the mon and
+	// its ancestors were minted in.
+	Proof []byte `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *ExportMonProofResponse) Reset() {
+	*x = ExportMonProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportMonProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMonProofResponse) ProtoMessage() {}
+
+func (x *ExportMonProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMonProofResponse.ProtoReflect.Descriptor instead.
+func (*ExportMonProofResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportMonProofResponse) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type Mon struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Attributes  []int32   `protobuf:"varint,3,rep,packed,name=attributes,proto3" json:"attributes,omitempty"`
+	RarityScore float64   `protobuf:"fixed64,4,opt,name=rarity_score,json=rarityScore,proto3" json:"rarity_score,omitempty"`
+	Level       *MonLevel `protobuf:"bytes,5,opt,name=level,proto3" json:"level,omitempty"`
+	// The evolution form of the mon at its level.
+	Form *MonForm `protobuf:"bytes,6,opt,name=form,proto3" json:"form,omitempty"`
+	// The types the mon gained by evolving.
+	Types []string `protobuf:"bytes,7,rep,name=types,proto3" json:"types,omitempty"`
+	// The status of an indexed mon at the tip of the chain.
+	Status MonStatus `protobuf:"varint,8,opt,name=status,proto3,enum=tapmonrpc.MonStatus" json:"status,omitempty"`
+	// The asset id of an indexed mon.
+	AssetId []byte `protobuf:"bytes,9,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// The group key of the collection an indexed mon was minted into,
+	// unset for standalone mons.
+	GroupKey []byte `protobuf:"bytes,10,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+}
+
+func (x *Mon) Reset() {
+	*x = Mon{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Mon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mon) ProtoMessage() {}
+
+func (x *Mon) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mon.ProtoReflect.Descriptor instead.
+func (*Mon) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Mon) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Mon) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Mon) GetAttributes() []int32 {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Mon) GetRarityScore() float64 {
+	if x != nil {
+		return x.RarityScore
+	}
+	return 0
+}
+
+func (x *Mon) GetLevel() *MonLevel {
+	if x != nil {
+		return x.Level
+	}
+	return nil
+}
+
+func (x *Mon) GetForm() *MonForm {
+	if x != nil {
+		return x.Form
+	}
+	return nil
+}
+
+func (x *Mon) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Mon) GetStatus() MonStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MonStatus_MON_PENDING
+}
+
+func (x *Mon) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+func (x *Mon) GetGroupKey() []byte {
+	if x != nil {
+		return x.GroupKey
+	}
+	return nil
+}
+
+type MonForm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The evolution stage of the form, zero for the form a mon is minted
+	// with.
+	Stage int32 `protobuf:"varint,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// The name of the form.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The boost the form adds to every attribute of the mon.
+	Boost int32 `protobuf:"varint,3,opt,name=boost,proto3" json:"boost,omitempty"`
+}
+
+func (x *MonForm) Reset() {
+	*x = MonForm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonForm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonForm) ProtoMessage() {}
+
+func (x *MonForm) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonForm.ProtoReflect.Descriptor instead.
+func (*MonForm) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MonForm) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *MonForm) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MonForm) GetBoost() int32 {
+	if x != nil {
+		return x.Boost
+	}
+	return 0
+}
+
+type MonLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level int32  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Nonce []byte `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *MonLevel) Reset() {
+	*x = MonLevel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonLevel) ProtoMessage() {}
+
+func (x *MonLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonLevel.ProtoReflect.Descriptor instead.
+func (*MonLevel) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MonLevel) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *MonLevel) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+type FindMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If set, a lobby is opened for other players to challenge.
+	OpenLobby bool `protobuf:"varint,1,opt,name=open_lobby,json=openLobby,proto3" json:"open_lobby,omitempty"`
+	// If set together with open_lobby, the lobby is private and only the
+	// player with this nostr pubkey is invited. All events of the match are
+	// encrypted between both players with NIP-44 until it is finished, when
+	// they are published as a bundle for anyone to verify the result.
+	PrivateOpponent string `protobuf:"bytes,2,opt,name=private_opponent,json=privateOpponent,proto3" json:"private_opponent,omitempty"`
+}
+
+func (x *FindMatchRequest) Reset() {
+	*x = FindMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMatchRequest) ProtoMessage() {}
+
+func (x *FindMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMatchRequest.ProtoReflect.Descriptor instead.
+func (*FindMatchRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FindMatchRequest) GetOpenLobby() bool {
+	if x != nil {
+		return x.OpenLobby
+	}
+	return false
+}
+
+func (x *FindMatchRequest) GetPrivateOpponent() string {
+	if x != nil {
+		return x.PrivateOpponent
+	}
+	return ""
+}
+
+type FindMatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The open lobbies of other players.
+	Lobbies []*Lobby `protobuf:"bytes,1,rep,name=lobbies,proto3" json:"lobbies,omitempty"`
+	// The match id of our own lobby if one was opened.
+	MatchId string `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *FindMatchResponse) Reset() {
+	*x = FindMatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMatchResponse) ProtoMessage() {}
+
+func (x *FindMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMatchResponse.ProtoReflect.Descriptor instead.
+func (*FindMatchResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FindMatchResponse) GetLobbies() []*Lobby {
+	if x != nil {
+		return x.Lobbies
+	}
+	return nil
+}
+
+func (x *FindMatchResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type Lobby struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId   string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Host      string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	CreatedAt int64  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The rating of the host if ratings are tracked. Lobbies are sorted by
+	// the distance of the rating of their host to ours.
+	HostRating float64 `protobuf:"fixed64,4,opt,name=host_rating,json=hostRating,proto3" json:"host_rating,omitempty"`
+	// The protocol version a match with the host would be played with.
+	Version uint32 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// Whether the host invited us to a private match.
+	Private bool `protobuf:"varint,6,opt,name=private,proto3" json:"private,omitempty"`
+	// The time in seconds the players have to commit and to reveal in each
+	// round. A player that misses it loses the match once the opponent
+	// claims the timeout.
+	RoundTimeout uint32 `protobuf:"varint,7,opt,name=round_timeout,json=roundTimeout,proto3" json:"round_timeout,omitempty"`
+}
+
+func (x *Lobby) Reset() {
+	*x = Lobby{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lobby) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lobby) ProtoMessage() {}
+
+func (x *Lobby) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lobby.ProtoReflect.Descriptor instead.
+func (*Lobby) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Lobby) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *Lobby) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Lobby) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Lobby) GetHostRating() float64 {
+	if x != nil {
+		return x.HostRating
+	}
+	return 0
+}
+
+func (x *Lobby) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Lobby) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *Lobby) GetRoundTimeout() uint32 {
+	if x != nil {
+		return x.RoundTimeout
+	}
+	return 0
+}
+
+type FightMon struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Level      int32  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	LevelNonce int64  `protobuf:"varint,3,opt,name=level_nonce,json=levelNonce,proto3" json:"level_nonce,omitempty"`
+}
+
+func (x *FightMon) Reset() {
+	*x = FightMon{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FightMon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FightMon) ProtoMessage() {}
+
+func (x *FightMon) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FightMon.ProtoReflect.Descriptor instead.
+func (*FightMon) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FightMon) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *FightMon) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *FightMon) GetLevelNonce() int64 {
+	if x != nil {
+		return x.LevelNonce
+	}
+	return 0
+}
+
+type ChallengeMonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The mon to fight with, ignored if a party is set.
+	Mon *FightMon `protobuf:"bytes,2,opt,name=mon,proto3" json:"mon,omitempty"`
+	// The party to fight with, led by its first mon.
+	Party []*FightMon `protobuf:"bytes,3,rep,name=party,proto3" json:"party,omitempty"`
+}
+
+func (x *ChallengeMonRequest) Reset() {
+	*x = ChallengeMonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeMonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeMonRequest) ProtoMessage() {}
+
+func (x *ChallengeMonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeMonRequest.ProtoReflect.Descriptor instead.
+func (*ChallengeMonRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ChallengeMonRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *ChallengeMonRequest) GetMon() *FightMon {
+	if x != nil {
+		return x.Mon
+	}
+	return nil
+}
+
+func (x *ChallengeMonRequest) GetParty() []*FightMon {
+	if x != nil {
+		return x.Party
+	}
+	return nil
+}
+
+type ChallengeMonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChallengeMonResponse) Reset() {
+	*x = ChallengeMonResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeMonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeMonResponse) ProtoMessage() {}
+
+func (x *ChallengeMonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeMonResponse.ProtoReflect.Descriptor instead.
+func (*ChallengeMonResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{34}
+}
+
+type AcceptChallengeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId    string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Challenger string `protobuf:"bytes,2,opt,name=challenger,proto3" json:"challenger,omitempty"`
+	// The mon to fight with, ignored if a party is set.
+	Mon *FightMon `protobuf:"bytes,3,opt,name=mon,proto3" json:"mon,omitempty"`
+	// The party to fight with, led by its first mon.
+	Party []*FightMon `protobuf:"bytes,4,rep,name=party,proto3" json:"party,omitempty"`
+	// The stake of each player if the match is wagered. A wagered match
+	// is only started once the challenger offered the same stake and both
+	// stakes are locked.
+	WagerSat int64 `protobuf:"varint,5,opt,name=wager_sat,json=wagerSat,proto3" json:"wager_sat,omitempty"`
+}
+
+func (x *AcceptChallengeRequest) Reset() {
+	*x = AcceptChallengeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcceptChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptChallengeRequest) ProtoMessage() {}
+
+func (x *AcceptChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptChallengeRequest.ProtoReflect.Descriptor instead.
+func (*AcceptChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AcceptChallengeRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AcceptChallengeRequest) GetChallenger() string {
+	if x != nil {
+		return x.Challenger
+	}
+	return ""
+}
+
+func (x *AcceptChallengeRequest) GetMon() *FightMon {
+	if x != nil {
+		return x.Mon
+	}
+	return nil
+}
+
+func (x *AcceptChallengeRequest) GetParty() []*FightMon {
+	if x != nil {
+		return x.Party
+	}
+	return nil
+}
+
+func (x *AcceptChallengeRequest) GetWagerSat() int64 {
+	if x != nil {
+		return x.WagerSat
+	}
+	return 0
+}
+
+type AcceptChallengeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AcceptChallengeResponse) Reset() {
+	*x = AcceptChallengeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcceptChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptChallengeResponse) ProtoMessage() {}
+
+func (x *AcceptChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptChallengeResponse.ProtoReflect.Descriptor instead.
+func (*AcceptChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{36}
+}
+
+type SubmitActionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string      `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Action  FightAction `protobuf:"varint,2,opt,name=action,proto3,enum=tapmonrpc.FightAction" json:"action,omitempty"`
+	// The party index of the mon to switch in, only used for SWITCH.
+	Target uint32 `protobuf:"varint,3,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *SubmitActionRequest) Reset() {
+	*x = SubmitActionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitActionRequest) ProtoMessage() {}
+
+func (x *SubmitActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitActionRequest.ProtoReflect.Descriptor instead.
+func (*SubmitActionRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubmitActionRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *SubmitActionRequest) GetAction() FightAction {
+	if x != nil {
+		return x.Action
+	}
+	return FightAction_ATTACK
+}
+
+func (x *SubmitActionRequest) GetTarget() uint32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+type SubmitActionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoundId int32 `protobuf:"varint,1,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+}
+
+func (x *SubmitActionResponse) Reset() {
+	*x = SubmitActionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitActionResponse) ProtoMessage() {}
+
+func (x *SubmitActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitActionResponse.ProtoReflect.Descriptor instead.
+func (*SubmitActionResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SubmitActionResponse) GetRoundId() int32 {
+	if x != nil {
+		return x.RoundId
+	}
+	return 0
+}
+
+type SubscribeMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *SubscribeMatchRequest) Reset() {
+	*x = SubscribeMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeMatchRequest) ProtoMessage() {}
+
+func (x *SubscribeMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeMatchRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeMatchRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SubscribeMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type WatchMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *WatchMatchRequest) Reset() {
+	*x = WatchMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMatchRequest) ProtoMessage() {}
+
+func (x *WatchMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchMatchRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *WatchMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type MatchUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    MatchUpdateType `protobuf:"varint,1,opt,name=type,proto3,enum=tapmonrpc.MatchUpdateType" json:"type,omitempty"`
+	MatchId string          `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	EventId string          `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Pubkey  string          `protobuf:"bytes,4,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Side    FightSide       `protobuf:"varint,5,opt,name=side,proto3,enum=tapmonrpc.FightSide" json:"side,omitempty"`
+	RoundId int32           `protobuf:"varint,6,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	// The revealed action, only set for REVEALED updates.
+	Action FightAction `protobuf:"varint,7,opt,name=action,proto3,enum=tapmonrpc.FightAction" json:"action,omitempty"`
+	// The party index of the mon switched in, only set for revealed
+	// SWITCH actions.
+	Target uint32 `protobuf:"varint,10,opt,name=target,proto3" json:"target,omitempty"`
+	// The result of the round, only set for ROUND_RESULT updates.
+	Result *RoundResult `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	// The winner of the match, only set for FINISHED updates.
+	Winner FightSide `protobuf:"varint,9,opt,name=winner,proto3,enum=tapmonrpc.FightSide" json:"winner,omitempty"`
+	// The pubkey of the accepted challenger, only set for ACCEPTED
+	// updates.
+	Challenger string `protobuf:"bytes,11,opt,name=challenger,proto3" json:"challenger,omitempty"`
+	// The parties of the host and the challenger, only set for ACCEPTED
+	// updates.
+	HostParty       []*FightMon `protobuf:"bytes,12,rep,name=host_party,json=hostParty,proto3" json:"host_party,omitempty"`
+	ChallengerParty []*FightMon `protobuf:"bytes,13,rep,name=challenger_party,json=challengerParty,proto3" json:"challenger_party,omitempty"`
+	// Whether the match finished because the loser missed a deadline, only
+	// set for FINISHED updates.
+	TimedOut bool `protobuf:"varint,14,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+}
+
+func (x *MatchUpdate) Reset() {
+	*x = MatchUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchUpdate) ProtoMessage() {}
+
+func (x *MatchUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchUpdate.ProtoReflect.Descriptor instead.
+func (*MatchUpdate) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MatchUpdate) GetType() MatchUpdateType {
+	if x != nil {
+		return x.Type
+	}
+	return MatchUpdateType_LOBBY_OPENED
+}
+
+func (x *MatchUpdate) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetPubkey() string {
+	if x != nil {
+		return x.Pubkey
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetSide() FightSide {
+	if x != nil {
+		return x.Side
+	}
+	return FightSide_SIDE_NONE
+}
+
+func (x *MatchUpdate) GetRoundId() int32 {
+	if x != nil {
+		return x.RoundId
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetAction() FightAction {
+	if x != nil {
+		return x.Action
+	}
+	return FightAction_ATTACK
+}
+
+func (x *MatchUpdate) GetTarget() uint32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetResult() *RoundResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *MatchUpdate) GetWinner() FightSide {
+	if x != nil {
+		return x.Winner
+	}
+	return FightSide_SIDE_NONE
+}
+
+func (x *MatchUpdate) GetChallenger() string {
+	if x != nil {
+		return x.Challenger
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetHostParty() []*FightMon {
+	if x != nil {
+		return x.HostParty
+	}
+	return nil
+}
+
+func (x *MatchUpdate) GetChallengerParty() []*FightMon {
+	if x != nil {
+		return x.ChallengerParty
+	}
+	return nil
+}
+
+func (x *MatchUpdate) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+type DisputeMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *DisputeMatchRequest) Reset() {
+	*x = DisputeMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisputeMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeMatchRequest) ProtoMessage() {}
+
+func (x *DisputeMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeMatchRequest.ProtoReflect.Descriptor instead.
+func (*DisputeMatchRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DisputeMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type DisputeMatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The id of the event that published all events of the match as a
+	// bundle for third parties to verify the result from.
+	BundleId string `protobuf:"bytes,1,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+}
+
+func (x *DisputeMatchResponse) Reset() {
+	*x = DisputeMatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisputeMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeMatchResponse) ProtoMessage() {}
+
+func (x *DisputeMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeMatchResponse.ProtoReflect.Descriptor instead.
+func (*DisputeMatchResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DisputeMatchResponse) GetBundleId() string {
+	if x != nil {
+		return x.BundleId
+	}
+	return ""
+}
+
+type RoundResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoundId int32        `protobuf:"varint,1,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Moves   []*FightMove `protobuf:"bytes,2,rep,name=moves,proto3" json:"moves,omitempty"`
+	// The hp of the active mons of the host and the challenger after the
+	// round.
+	Hp []int32 `protobuf:"varint,3,rep,packed,name=hp,proto3" json:"hp,omitempty"`
+	// The hp of every mon of the host's party after the round.
+	HostPartyHp []int32 `protobuf:"varint,4,rep,packed,name=host_party_hp,json=hostPartyHp,proto3" json:"host_party_hp,omitempty"`
+	// The hp of every mon of the challenger's party after the round.
+	ChallengerPartyHp []int32 `protobuf:"varint,5,rep,packed,name=challenger_party_hp,json=challengerPartyHp,proto3" json:"challenger_party_hp,omitempty"`
+	// The party indexes of the active mons of the host and the challenger
+	// after the round.
+	Active []uint32 `protobuf:"varint,6,rep,packed,name=active,proto3" json:"active,omitempty"`
+}
+
+func (x *RoundResult) Reset() {
+	*x = RoundResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoundResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundResult) ProtoMessage() {}
+
+func (x *RoundResult) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundResult.ProtoReflect.Descriptor instead.
+func (*RoundResult) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RoundResult) GetRoundId() int32 {
+	if x != nil {
+		return x.RoundId
+	}
+	return 0
+}
+
+func (x *RoundResult) GetMoves() []*FightMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *RoundResult) GetHp() []int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return nil
+}
+
+func (x *RoundResult) GetHostPartyHp() []int32 {
+	if x != nil {
+		return x.HostPartyHp
+	}
+	return nil
+}
+
+func (x *RoundResult) GetChallengerPartyHp() []int32 {
+	if x != nil {
+		return x.ChallengerPartyHp
+	}
+	return nil
+}
+
+func (x *RoundResult) GetActive() []uint32 {
+	if x != nil {
+		return x.Active
+	}
+	return nil
+}
+
+type FightMove struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Side   FightSide   `protobuf:"varint,1,opt,name=side,proto3,enum=tapmonrpc.FightSide" json:"side,omitempty"`
+	Action FightAction `protobuf:"varint,2,opt,name=action,proto3,enum=tapmonrpc.FightAction" json:"action,omitempty"`
+	Damage int32       `protobuf:"varint,3,opt,name=damage,proto3" json:"damage,omitempty"`
+	Missed bool        `protobuf:"varint,4,opt,name=missed,proto3" json:"missed,omitempty"`
+	// The party index of the mon switched in, only set for switches.
+	Target uint32 `protobuf:"varint,5,opt,name=target,proto3" json:"target,omitempty"`
+	// Whether the mon was switched in because the active mon was knocked
+	// out.
+	Forced bool `protobuf:"varint,6,opt,name=forced,proto3" json:"forced,omitempty"`
+}
+
+func (x *FightMove) Reset() {
+	*x = FightMove{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FightMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FightMove) ProtoMessage() {}
+
+func (x *FightMove) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FightMove.ProtoReflect.Descriptor instead.
+func (*FightMove) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *FightMove) GetSide() FightSide {
+	if x != nil {
+		return x.Side
+	}
+	return FightSide_SIDE_NONE
+}
+
+func (x *FightMove) GetAction() FightAction {
+	if x != nil {
+		return x.Action
+	}
+	return FightAction_ATTACK
+}
+
+func (x *FightMove) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *FightMove) GetMissed() bool {
+	if x != nil {
+		return x.Missed
+	}
+	return false
+}
+
+func (x *FightMove) GetTarget() uint32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *FightMove) GetForced() bool {
+	if x != nil {
+		return x.Forced
+	}
+	return false
+}
+
+// Wagers are trust-based: each player holds the preimage of their own hold
+// invoice, so a losing opponent can settle our payment instead of canceling
+// it. Only wager against opponents that are trusted with the stake.
+type OfferWagerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The stake of each player.
+	AmountSat int64 `protobuf:"varint,2,opt,name=amount_sat,json=amountSat,proto3" json:"amount_sat,omitempty"`
+}
+
+func (x *OfferWagerRequest) Reset() {
+	*x = OfferWagerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferWagerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferWagerRequest) ProtoMessage() {}
+
+func (x *OfferWagerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferWagerRequest.ProtoReflect.Descriptor instead.
+func (*OfferWagerRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *OfferWagerRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *OfferWagerRequest) GetAmountSat() int64 {
+	if x != nil {
+		return x.AmountSat
+	}
+	return 0
+}
+
+type OfferWagerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Wager *Wager `protobuf:"bytes,1,opt,name=wager,proto3" json:"wager,omitempty"`
+}
+
+func (x *OfferWagerResponse) Reset() {
+	*x = OfferWagerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferWagerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferWagerResponse) ProtoMessage() {}
+
+func (x *OfferWagerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferWagerResponse.ProtoReflect.Descriptor instead.
+func (*OfferWagerResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *OfferWagerResponse) GetWager() *Wager {
+	if x != nil {
+		return x.Wager
+	}
+	return nil
+}
+
+type ListWagersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListWagersRequest) Reset() {
+	*x = ListWagersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWagersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWagersRequest) ProtoMessage() {}
+
+func (x *ListWagersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWagersRequest.ProtoReflect.Descriptor instead.
+func (*ListWagersRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{48}
+}
+
+type ListWagersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Wagers []*Wager `protobuf:"bytes,1,rep,name=wagers,proto3" json:"wagers,omitempty"`
+}
+
+func (x *ListWagersResponse) Reset() {
+	*x = ListWagersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWagersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWagersResponse) ProtoMessage() {}
+
+func (x *ListWagersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWagersResponse.ProtoReflect.Descriptor instead.
+func (*ListWagersResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListWagersResponse) GetWagers() []*Wager {
+	if x != nil {
+		return x.Wagers
+	}
+	return nil
+}
+
+type Wager struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId   string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	AmountSat int64  `protobuf:"varint,2,opt,name=amount_sat,json=amountSat,proto3" json:"amount_sat,omitempty"`
+	// Our hold invoice the opponent pays to lock their stake.
+	Invoice string `protobuf:"bytes,3,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	// The hold invoice of the opponent we paid.
+	OpponentInvoice string     `protobuf:"bytes,4,opt,name=opponent_invoice,json=opponentInvoice,proto3" json:"opponent_invoice,omitempty"`
+	State           WagerState `protobuf:"varint,5,opt,name=state,proto3,enum=tapmonrpc.WagerState" json:"state,omitempty"`
+	// The unix time after which the wager is canceled if the match isn't
+	// finished.
+	Expiry int64 `protobuf:"varint,6,opt,name=expiry,proto3" json:"expiry,omitempty"`
+}
+
+func (x *Wager) Reset() {
+	*x = Wager{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Wager) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wager) ProtoMessage() {}
+
+func (x *Wager) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wager.ProtoReflect.Descriptor instead.
+func (*Wager) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Wager) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *Wager) GetAmountSat() int64 {
+	if x != nil {
+		return x.AmountSat
+	}
+	return 0
+}
+
+func (x *Wager) GetInvoice() string {
+	if x != nil {
+		return x.Invoice
+	}
+	return ""
+}
+
+func (x *Wager) GetOpponentInvoice() string {
+	if x != nil {
+		return x.OpponentInvoice
+	}
+	return ""
+}
+
+func (x *Wager) GetState() WagerState {
+	if x != nil {
+		return x.State
+	}
+	return WagerState_WAGER_OFFERED
+}
+
+func (x *Wager) GetExpiry() int64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+type OfferAnteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The nostr pubkey of the escrow that holds the mons of both players.
+	Escrow string `protobuf:"bytes,2,opt,name=escrow,proto3" json:"escrow,omitempty"`
+	// The asset id of the mon to put at stake.
+	AssetId []byte `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+}
+
+func (x *OfferAnteRequest) Reset() {
+	*x = OfferAnteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferAnteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferAnteRequest) ProtoMessage() {}
+
+func (x *OfferAnteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferAnteRequest.ProtoReflect.Descriptor instead.
+func (*OfferAnteRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *OfferAnteRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *OfferAnteRequest) GetEscrow() string {
+	if x != nil {
+		return x.Escrow
+	}
+	return ""
+}
+
+func (x *OfferAnteRequest) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+type OfferAnteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ante *Ante `protobuf:"bytes,1,opt,name=ante,proto3" json:"ante,omitempty"`
+}
+
+func (x *OfferAnteResponse) Reset() {
+	*x = OfferAnteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferAnteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferAnteResponse) ProtoMessage() {}
+
+func (x *OfferAnteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferAnteResponse.ProtoReflect.Descriptor instead.
+func (*OfferAnteResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *OfferAnteResponse) GetAnte() *Ante {
+	if x != nil {
+		return x.Ante
+	}
+	return nil
+}
+
+type ListAntesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListAntesRequest) Reset() {
+	*x = ListAntesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAntesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAntesRequest) ProtoMessage() {}
+
+func (x *ListAntesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAntesRequest.ProtoReflect.Descriptor instead.
+func (*ListAntesRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{53}
+}
+
+type ListAntesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Antes []*Ante `protobuf:"bytes,1,rep,name=antes,proto3" json:"antes,omitempty"`
+}
+
+func (x *ListAntesResponse) Reset() {
+	*x = ListAntesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAntesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAntesResponse) ProtoMessage() {}
+
+func (x *ListAntesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAntesResponse.ProtoReflect.Descriptor instead.
+func (*ListAntesResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListAntesResponse) GetAntes() []*Ante {
+	if x != nil {
+		return x.Antes
+	}
+	return nil
+}
+
+type Ante struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Escrow  string `protobuf:"bytes,2,opt,name=escrow,proto3" json:"escrow,omitempty"`
+	AssetId []byte `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// Our address the escrow returns the mon to.
+	RefundAddr string `protobuf:"bytes,4,opt,name=refund_addr,json=refundAddr,proto3" json:"refund_addr,omitempty"`
+	// The address of the escrow we deposited the mon to.
+	DepositAddr string    `protobuf:"bytes,5,opt,name=deposit_addr,json=depositAddr,proto3" json:"deposit_addr,omitempty"`
+	State       AnteState `protobuf:"varint,6,opt,name=state,proto3,enum=tapmonrpc.AnteState" json:"state,omitempty"`
+	// The anchor txid of the last transfer of the mon.
+	TransferTxid string `protobuf:"bytes,7,opt,name=transfer_txid,json=transferTxid,proto3" json:"transfer_txid,omitempty"`
+	// The unix time after which the escrow returns the mon if the match
+	// isn't finished.
+	Expiry int64 `protobuf:"varint,8,opt,name=expiry,proto3" json:"expiry,omitempty"`
+}
+
+func (x *Ante) Reset() {
+	*x = Ante{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ante) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ante) ProtoMessage() {}
+
+func (x *Ante) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ante.ProtoReflect.Descriptor instead.
+func (*Ante) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Ante) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *Ante) GetEscrow() string {
+	if x != nil {
+		return x.Escrow
+	}
+	return ""
+}
+
+func (x *Ante) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+func (x *Ante) GetRefundAddr() string {
+	if x != nil {
+		return x.RefundAddr
+	}
+	return ""
+}
+
+func (x *Ante) GetDepositAddr() string {
+	if x != nil {
+		return x.DepositAddr
+	}
+	return ""
+}
+
+func (x *Ante) GetState() AnteState {
+	if x != nil {
+		return x.State
+	}
+	return AnteState_ANTE_OFFERED
+}
+
+func (x *Ante) GetTransferTxid() string {
+	if x != nil {
+		return x.TransferTxid
+	}
+	return ""
+}
+
+func (x *Ante) GetExpiry() int64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+type GetLeaderboardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind RatingKind `protobuf:"varint,1,opt,name=kind,proto3,enum=tapmonrpc.RatingKind" json:"kind,omitempty"`
+	// The number of entries to skip.
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The maximum number of entries to return. Defaults to 100.
+	Limit uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetLeaderboardRequest) GetKind() RatingKind {
+	if x != nil {
+		return x.Kind
+	}
+	return RatingKind_RATING_PLAYER
+}
+
+func (x *GetLeaderboardRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetLeaderboardRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetLeaderboardResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*LeaderboardEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GetLeaderboardResponse) Reset() {
+	*x = GetLeaderboardResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank uint32 `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	// The nostr pubkey of a player or the hex encoded id of a mon.
+	Subject string  `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Rating  float64 `protobuf:"fixed64,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Games   uint32  `protobuf:"varint,4,opt,name=games,proto3" json:"games,omitempty"`
+	Wins    uint32  `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses  uint32  `protobuf:"varint,6,opt,name=losses,proto3" json:"losses,omitempty"`
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *LeaderboardEntry) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetGames() uint32 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetWins() uint32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetLosses() uint32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+type CreateTournamentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules *TournamentRules `protobuf:"bytes,1,opt,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *CreateTournamentRequest) Reset() {
+	*x = CreateTournamentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentRequest) ProtoMessage() {}
+
+func (x *CreateTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentRequest.ProtoReflect.Descriptor instead.
+func (*CreateTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateTournamentRequest) GetRules() *TournamentRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type CreateTournamentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TournamentId string `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+}
+
+func (x *CreateTournamentResponse) Reset() {
+	*x = CreateTournamentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentResponse) ProtoMessage() {}
+
+func (x *CreateTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentResponse.ProtoReflect.Descriptor instead.
+func (*CreateTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CreateTournamentResponse) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+type RegisterTournamentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TournamentId string    `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	Mon          *FightMon `protobuf:"bytes,2,opt,name=mon,proto3" json:"mon,omitempty"`
+}
+
+func (x *RegisterTournamentRequest) Reset() {
+	*x = RegisterTournamentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterTournamentRequest) ProtoMessage() {}
+
+func (x *RegisterTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterTournamentRequest.ProtoReflect.Descriptor instead.
+func (*RegisterTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RegisterTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *RegisterTournamentRequest) GetMon() *FightMon {
+	if x != nil {
+		return x.Mon
+	}
+	return nil
+}
+
+type RegisterTournamentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegisterTournamentResponse) Reset() {
+	*x = RegisterTournamentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterTournamentResponse) ProtoMessage() {}
+
+func (x *RegisterTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterTournamentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{62}
+}
+
+type GetTournamentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TournamentId string `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	// If set, the tournament is verified against the events of its
+	// matches.
+	Verify bool `protobuf:"varint,2,opt,name=verify,proto3" json:"verify,omitempty"`
+}
+
+func (x *GetTournamentRequest) Reset() {
+	*x = GetTournamentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTournamentRequest) ProtoMessage() {}
+
+func (x *GetTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTournamentRequest.ProtoReflect.Descriptor instead.
+func (*GetTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *GetTournamentRequest) GetVerify() bool {
+	if x != nil {
+		return x.Verify
+	}
+	return false
+}
+
+type GetTournamentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tournament *Tournament `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+}
+
+func (x *GetTournamentResponse) Reset() {
+	*x = GetTournamentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTournamentResponse) ProtoMessage() {}
+
+func (x *GetTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTournamentResponse.ProtoReflect.Descriptor instead.
+func (*GetTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetTournamentResponse) GetTournament() *Tournament {
+	if x != nil {
+		return x.Tournament
+	}
+	return nil
+}
+
+type TournamentRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Format TournamentFormat `protobuf:"varint,2,opt,name=format,proto3,enum=tapmonrpc.TournamentFormat" json:"format,omitempty"`
+	// The number of rounds of a swiss tournament. If zero, the number of
+	// rounds needed to find a single undefeated player is played.
+	Rounds   uint32 `protobuf:"varint,3,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	MinLevel int32  `protobuf:"varint,4,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	// The highest rarity score of a registered mon between 0 and 1. Zero
+	// means mons of any rarity can enter.
+	MaxRarity   float64 `protobuf:"fixed64,5,opt,name=max_rarity,json=maxRarity,proto3" json:"max_rarity,omitempty"`
+	EntryFeeSat int64   `protobuf:"varint,6,opt,name=entry_fee_sat,json=entryFeeSat,proto3" json:"entry_fee_sat,omitempty"`
+	// The maximum number of entrants, zero means no limit.
+	MaxPlayers uint32 `protobuf:"varint,7,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	// The unix time at which the registration closes and the first round
+	// starts.
+	RegistrationEnd int64 `protobuf:"varint,8,opt,name=registration_end,json=registrationEnd,proto3" json:"registration_end,omitempty"`
+	// The time in seconds the players of a round have to finish their
+	// matches.
+	RoundDuration int64 `protobuf:"varint,9,opt,name=round_duration,json=roundDuration,proto3" json:"round_duration,omitempty"`
+}
+
+func (x *TournamentRules) Reset() {
+	*x = TournamentRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TournamentRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentRules) ProtoMessage() {}
+
+func (x *TournamentRules) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentRules.ProtoReflect.Descriptor instead.
+func (*TournamentRules) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *TournamentRules) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TournamentRules) GetFormat() TournamentFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TournamentFormat_SINGLE_ELIMINATION
+}
+
+func (x *TournamentRules) GetRounds() uint32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *TournamentRules) GetMinLevel() int32 {
+	if x != nil {
+		return x.MinLevel
+	}
+	return 0
+}
+
+func (x *TournamentRules) GetMaxRarity() float64 {
+	if x != nil {
+		return x.MaxRarity
+	}
+	return 0
+}
+
+func (x *TournamentRules) GetEntryFeeSat() int64 {
+	if x != nil {
+		return x.EntryFeeSat
+	}
+	return 0
+}
+
+func (x *TournamentRules) GetMaxPlayers() uint32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *TournamentRules) GetRegistrationEnd() int64 {
+	if x != nil {
+		return x.RegistrationEnd
+	}
+	return 0
+}
+
+func (x *TournamentRules) GetRoundDuration() int64 {
+	if x != nil {
+		return x.RoundDuration
+	}
+	return 0
+}
+
+type Tournament struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TournamentId  string                    `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	Organizer     string                    `protobuf:"bytes,2,opt,name=organizer,proto3" json:"organizer,omitempty"`
+	Rules         *TournamentRules          `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`
+	Registrations []*TournamentRegistration `protobuf:"bytes,4,rep,name=registrations,proto3" json:"registrations,omitempty"`
+	// The entrants in the order of their seeds.
+	Entrants []string           `protobuf:"bytes,5,rep,name=entrants,proto3" json:"entrants,omitempty"`
+	Rounds   []*TournamentRound `protobuf:"bytes,6,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	// The standings after the last closed round.
+	Standings []*TournamentStanding `protobuf:"bytes,7,rep,name=standings,proto3" json:"standings,omitempty"`
+	// Set once the final standings were published.
+	Final bool `protobuf:"varint,8,opt,name=final,proto3" json:"final,omitempty"`
+	// Set if the tournament was verified against the events of its
+	// matches.
+	Verified bool `protobuf:"varint,9,opt,name=verified,proto3" json:"verified,omitempty"`
+}
+
+func (x *Tournament) Reset() {
+	*x = Tournament{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tournament) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tournament) ProtoMessage() {}
+
+func (x *Tournament) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tournament.ProtoReflect.Descriptor instead.
+func (*Tournament) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *Tournament) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *Tournament) GetOrganizer() string {
+	if x != nil {
+		return x.Organizer
+	}
+	return ""
+}
+
+func (x *Tournament) GetRules() *TournamentRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Tournament) GetRegistrations() []*TournamentRegistration {
+	if x != nil {
+		return x.Registrations
+	}
+	return nil
+}
+
+func (x *Tournament) GetEntrants() []string {
+	if x != nil {
+		return x.Entrants
+	}
+	return nil
+}
+
+func (x *Tournament) GetRounds() []*TournamentRound {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *Tournament) GetStandings() []*TournamentStanding {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+func (x *Tournament) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+func (x *Tournament) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+type TournamentRegistration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Player       string    `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Mon          *FightMon `protobuf:"bytes,2,opt,name=mon,proto3" json:"mon,omitempty"`
+	RegisteredAt int64     `protobuf:"varint,3,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+}
+
+func (x *TournamentRegistration) Reset() {
+	*x = TournamentRegistration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TournamentRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentRegistration) ProtoMessage() {}
+
+func (x *TournamentRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentRegistration.ProtoReflect.Descriptor instead.
+func (*TournamentRegistration) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *TournamentRegistration) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *TournamentRegistration) GetMon() *FightMon {
+	if x != nil {
+		return x.Mon
+	}
+	return nil
+}
+
+func (x *TournamentRegistration) GetRegisteredAt() int64 {
+	if x != nil {
+		return x.RegisteredAt
+	}
+	return 0
+}
+
+type TournamentRound struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round     uint32               `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Pairings  []*TournamentPairing `protobuf:"bytes,2,rep,name=pairings,proto3" json:"pairings,omitempty"`
+	Bye       string               `protobuf:"bytes,3,opt,name=bye,proto3" json:"bye,omitempty"`
+	StartedAt int64                `protobuf:"varint,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Set once the results of the round were published.
+	Closed bool `protobuf:"varint,5,opt,name=closed,proto3" json:"closed,omitempty"`
+}
+
+func (x *TournamentRound) Reset() {
+	*x = TournamentRound{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TournamentRound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentRound) ProtoMessage() {}
+
+func (x *TournamentRound) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentRound.ProtoReflect.Descriptor instead.
+func (*TournamentRound) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *TournamentRound) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *TournamentRound) GetPairings() []*TournamentPairing {
+	if x != nil {
+		return x.Pairings
+	}
+	return nil
+}
+
+func (x *TournamentRound) GetBye() string {
+	if x != nil {
+		return x.Bye
+	}
+	return ""
+}
+
+func (x *TournamentRound) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *TournamentRound) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+type TournamentPairing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId    string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Host       string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Challenger string `protobuf:"bytes,3,opt,name=challenger,proto3" json:"challenger,omitempty"`
+	// The pubkey of the winner once the round was closed. Empty on a draw
+	// or a match that wasn't finished in time.
+	Winner string `protobuf:"bytes,4,opt,name=winner,proto3" json:"winner,omitempty"`
+}
+
+func (x *TournamentPairing) Reset() {
+	*x = TournamentPairing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TournamentPairing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentPairing) ProtoMessage() {}
+
+func (x *TournamentPairing) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentPairing.ProtoReflect.Descriptor instead.
+func (*TournamentPairing) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *TournamentPairing) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *TournamentPairing) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *TournamentPairing) GetChallenger() string {
+	if x != nil {
+		return x.Challenger
+	}
+	return ""
+}
+
+func (x *TournamentPairing) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+type TournamentStanding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Player     string  `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Points     float64 `protobuf:"fixed64,2,opt,name=points,proto3" json:"points,omitempty"`
+	Wins       uint32  `protobuf:"varint,3,opt,name=wins,proto3" json:"wins,omitempty"`
+	Draws      uint32  `protobuf:"varint,4,opt,name=draws,proto3" json:"draws,omitempty"`
+	Losses     uint32  `protobuf:"varint,5,opt,name=losses,proto3" json:"losses,omitempty"`
+	Byes       uint32  `protobuf:"varint,6,opt,name=byes,proto3" json:"byes,omitempty"`
+	Eliminated bool    `protobuf:"varint,7,opt,name=eliminated,proto3" json:"eliminated,omitempty"`
+}
+
+func (x *TournamentStanding) Reset() {
+	*x = TournamentStanding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapmonrpc_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TournamentStanding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentStanding) ProtoMessage() {}
+
+func (x *TournamentStanding) ProtoReflect() protoreflect.Message {
+	mi := &file_tapmonrpc_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentStanding.ProtoReflect.Descriptor instead.
+func (*TournamentStanding) Descriptor() ([]byte, []int) {
+	return file_tapmonrpc_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *TournamentStanding) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *TournamentStanding) GetPoints() float64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetWins() uint32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetDraws() uint32 {
+	if x != nil {
+		return x.Draws
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetLosses() uint32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetByes() uint32 {
+	if x != nil {
+		return x.Byes
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetEliminated() bool {
+	if x != nil {
+		return x.Eliminated
+	}
+	return false
+}
+
+var File_tapmonrpc_proto protoreflect.FileDescriptor
+
+var file_tapmonrpc_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x22, 0x33, 0x0a, 0x0d,
+	0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x32, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e,
+	0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x22, 0x48, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e,
+	0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a,
+	0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x22,
+	0x3b, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x6f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x52, 0x04, 0x6d, 0x6f, 0x6e, 0x73, 0x22, 0xb1, 0x01, 0x0a,
+	0x12, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x30, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4b, 0x65, 0x79,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x39, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x52, 0x04, 0x6d, 0x6f, 0x6e, 0x73, 0x22, 0x44, 0x0a, 0x0e, 0x4d,
+	0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x50, 0x0a, 0x0f, 0x4d, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f,
+	0x6e, 0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x4b, 0x65, 0x79, 0x22, 0x70, 0x0a, 0x0f, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12,
+	0x24, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x5f, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74,
+	0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x4a, 0x0a, 0x10, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12,
+	0x20, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x6f,
+	0x6e, 0x22, 0x7b, 0x0a, 0x0f, 0x42, 0x72, 0x65, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x11, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x5f,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x41, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x22, 0x34,
+	0x0a, 0x10, 0x42, 0x72, 0x65, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x20, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x52,
+	0x03, 0x6d, 0x6f, 0x6e, 0x22, 0x1c, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x4d, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x8e, 0x01, 0x0a, 0x09, 0x4d, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x08, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x52, 0x08, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6f,
+	0x72, 0x67, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x6f, 0x72,
+	0x67, 0x65, 0x64, 0x22, 0x26, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0x6f, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x29, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e,
+	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x6a, 0x0a, 0x08,
+	0x4d, 0x6f, 0x6e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xdf, 0x01, 0x0a, 0x08, 0x4d, 0x6f, 0x6e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x14, 0x4e, 0x65,
+	0x77, 0x4d, 0x6f, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x22, 0x31, 0x0a,
+	0x15, 0x4e, 0x65, 0x77, 0x4d, 0x6f, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0x2a, 0x0a, 0x0e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x3c, 0x0a, 0x0f,
+	0x53, 0x65, 0x6e, 0x64, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x29, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xd9, 0x01, 0x0a, 0x0a, 0x4d, 0x6f,
+	0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x61, 0x70, 0x6d,
+	0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f,
+	0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x27, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d,
+	0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2e,
+	0x0a, 0x16, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0xbb,
+	0x02, 0x0a, 0x03, 0x4d, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a,
+	0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x61,
+	0x72, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x72, 0x61, 0x72, 0x69, 0x74, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x29, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x26, 0x0a, 0x04, 0x66, 0x6f, 0x72, 0x6d,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x6d, 0x52, 0x04, 0x66, 0x6f, 0x72, 0x6d,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4b, 0x65, 0x79, 0x22, 0x49, 0x0a, 0x07,
+	0x4d, 0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x62, 0x6f, 0x6f, 0x73, 0x74, 0x22, 0x36, 0x0a, 0x08, 0x4d, 0x6f, 0x6e, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22,
+	0x5c, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x6c, 0x6f, 0x62, 0x62,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x4c, 0x6f, 0x62,
+	0x62, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x70,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x22, 0x5a, 0x0a,
+	0x11, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6c, 0x6f, 0x62, 0x62, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x4c, 0x6f, 0x62, 0x62, 0x79, 0x52, 0x07, 0x6c, 0x6f, 0x62, 0x62, 0x69, 0x65, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0xcf, 0x01, 0x0a, 0x05, 0x4c, 0x6f,
+	0x62, 0x62, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x52, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x51, 0x0a, 0x08, 0x46,
+	0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x5f, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x82,
+	0x01, 0x0a, 0x13, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x64, 0x12, 0x25, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74,
+	0x4d, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74,
+	0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x05, 0x70, 0x61,
+	0x72, 0x74, 0x79, 0x22, 0x16, 0x0a, 0x14, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc2, 0x01, 0x0a, 0x16,
+	0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x72, 0x12, 0x25, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74,
+	0x4d, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74,
+	0x79, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x05, 0x70, 0x61,
+	0x72, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x61, 0x67, 0x65, 0x72, 0x5f, 0x73, 0x61, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x77, 0x61, 0x67, 0x65, 0x72, 0x53, 0x61, 0x74,
+	0x22, 0x19, 0x0a, 0x17, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x78, 0x0a, 0x13, 0x53,
+	0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x2e, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x31, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x15, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x11,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0xa7, 0x04, 0x0a,
+	0x0b, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2e, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x61, 0x70,
+	0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x69,
+	0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f,
+	0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04,
+	0x73, 0x69, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x69, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x12,
+	0x2e, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x16, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68,
+	0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65,
+	0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x53, 0x69, 0x64, 0x65, 0x52, 0x06, 0x77,
+	0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x61,
+	0x72, 0x74, 0x79, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d,
+	0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x09,
+	0x68, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x72, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x10, 0x63, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x46, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x0f, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x72, 0x50, 0x61, 0x72, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x22, 0x30, 0x0a, 0x13, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74,
+	0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x14, 0x44, 0x69, 0x73, 0x70,
+	0x75, 0x74, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x64, 0x22, 0xd0, 0x01,
+	0x0a, 0x0b, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x05, 0x6d,
+	0x6f, 0x76, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x70, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x02, 0x68, 0x70, 0x12, 0x22, 0x0a, 0x0d, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x72,
+	0x74, 0x79, 0x5f, 0x68, 0x70, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x68, 0x6f, 0x73,
+	0x74, 0x50, 0x61, 0x72, 0x74, 0x79, 0x48, 0x70, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x5f, 0x68, 0x70, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x05, 0x52, 0x11, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x72, 0x50, 0x61, 0x72, 0x74, 0x79, 0x48, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x22, 0xc5, 0x01, 0x0a, 0x09, 0x46, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x28,
+	0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x53, 0x69,
+	0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f,
+	0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61, 0x6d, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x22, 0x4d, 0x0a, 0x11, 0x4f, 0x66, 0x66, 0x65,
+	0x72, 0x57, 0x61, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x22, 0x3c, 0x0a, 0x12, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x57, 0x61, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a,
+	0x05, 0x77, 0x61, 0x67, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x57, 0x61, 0x67, 0x65, 0x72, 0x52, 0x05,
+	0x77, 0x61, 0x67, 0x65, 0x72, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x67,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3e, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x57, 0x61, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x28, 0x0a, 0x06, 0x77, 0x61, 0x67, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x57, 0x61, 0x67,
+	0x65, 0x72, 0x52, 0x06, 0x77, 0x61, 0x67, 0x65, 0x72, 0x73, 0x22, 0xcb, 0x01, 0x0a, 0x05, 0x57,
+	0x61, 0x67, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x70, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x57,
+	0x61, 0x67, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x60, 0x0a, 0x10, 0x4f, 0x66, 0x66, 0x65,
+	0x72, 0x41, 0x6e, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x73, 0x63, 0x72, 0x6f,
+	0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x12,
+	0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x22, 0x38, 0x0a, 0x11, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x41, 0x6e, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x23, 0x0a, 0x04, 0x61, 0x6e, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x6e, 0x74, 0x65, 0x52, 0x04,
+	0x61, 0x6e, 0x74, 0x65, 0x22, 0x12, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6e, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3a, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x6e, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a,
+	0x05, 0x61, 0x6e, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x6e, 0x74, 0x65, 0x52, 0x05, 0x61,
+	0x6e, 0x74, 0x65, 0x73, 0x22, 0x81, 0x02, 0x0a, 0x04, 0x41, 0x6e, 0x74, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x73, 0x63, 0x72,
+	0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x73, 0x63, 0x72, 0x6f, 0x77,
+	0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
+	0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x41, 0x64, 0x64, 0x72, 0x12, 0x21, 0x0a, 0x0c,
+	0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x12,
+	0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x6e, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x74, 0x78, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x54, 0x78, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x70, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x29, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x15, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4f, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70,
+	0x63, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x10,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x77, 0x69, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x22, 0x4b, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x54,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x05,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x3f, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x6f, 0x75, 0x72, 0x6e, 0x61,
+	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x67, 0x0a, 0x19, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70,
+	0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x22,
+	0x1c, 0x0a, 0x1a, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x54, 0x6f, 0x75, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x53, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x6f,
+	0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x76, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x22, 0x4e, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x74,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x74, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x6e, 0x74, 0x22, 0xc5, 0x02, 0x0a, 0x0f, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x61, 0x70,
+	0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e,
+	0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x61, 0x72, 0x69,
+	0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x52, 0x61, 0x72,
+	0x69, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x0d, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x66, 0x65, 0x65,
+	0x5f, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x65, 0x6e, 0x74, 0x72,
+	0x79, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61,
+	0x78, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x89, 0x03, 0x0a, 0x0a, 0x54,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x75,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x74, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x05,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x61,
+	0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x47,
+	0x0a, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70,
+	0x63, 0x2e, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x61,
+	0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x61,
+	0x6e, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52,
+	0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x3b, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x70,
+	0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e,
+	0x74, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x73, 0x74, 0x61, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x22, 0x7c, 0x0a, 0x16, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70,
+	0x63, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x12,
+	0x23, 0x0a, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x0f, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x38,
+	0x0a, 0x08, 0x70, 0x61, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x75,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x08,
+	0x70, 0x61, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x79, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x79, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x6f,
+	0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65,
+	0x64, 0x22, 0x7a, 0x0a, 0x11, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x50,
+	0x61, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0xba, 0x01,
+	0x0a, 0x12, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x72, 0x61, 0x77,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x64, 0x72, 0x61, 0x77, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x79, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x79, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x6c,
+	0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x2a, 0x67, 0x0a, 0x0c, 0x4d, 0x6f,
+	0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x4f,
+	0x4e, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4d, 0x49, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x16,
+	0x0a, 0x12, 0x4d, 0x4f, 0x4e, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x52, 0x41, 0x4e,
+	0x53, 0x46, 0x45, 0x52, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x4f, 0x4e, 0x5f, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x50, 0x10, 0x02, 0x12, 0x13,
+	0x0a, 0x0f, 0x4d, 0x4f, 0x4e, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x46, 0x49, 0x47, 0x48,
+	0x54, 0x10, 0x03, 0x2a, 0x72, 0x0a, 0x10, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x43, 0x45, 0x49,
+	0x56, 0x45, 0x5f, 0x44, 0x45, 0x54, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a,
+	0x11, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x5f,
+	0x50, 0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x15, 0x0a, 0x11, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x50,
+	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x3e, 0x0a, 0x09, 0x4d, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x4f, 0x4e, 0x5f, 0x50, 0x45, 0x4e, 0x44,
+	0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x4f, 0x4e, 0x5f, 0x54, 0x45, 0x4e,
+	0x54, 0x41, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x4f, 0x4e, 0x5f,
+	0x46, 0x49, 0x4e, 0x41, 0x4c, 0x10, 0x02, 0x2a, 0x37, 0x0a, 0x06, 0x52, 0x61, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4f, 0x4d, 0x4d, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x52, 0x41, 0x52, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x50, 0x49, 0x43, 0x10,
+	0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4c, 0x45, 0x47, 0x45, 0x4e, 0x44, 0x41, 0x52, 0x59, 0x10, 0x03,
+	0x2a, 0x3e, 0x0a, 0x0b, 0x46, 0x69, 0x67, 0x68, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0a, 0x0a, 0x06, 0x41, 0x54, 0x54, 0x41, 0x43, 0x4b, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x46, 0x45,
+	0x4e, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x57, 0x49, 0x54, 0x43, 0x48, 0x10, 0x03,
+	0x2a, 0x3e, 0x0a, 0x09, 0x46, 0x69, 0x67, 0x68, 0x74, 0x53, 0x69, 0x64, 0x65, 0x12, 0x0d, 0x0a,
+	0x09, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
+	0x53, 0x49, 0x44, 0x45, 0x5f, 0x48, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x53,
+	0x49, 0x44, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45, 0x52, 0x10, 0x02,
+	0x2a, 0x9e, 0x01, 0x0a, 0x0f, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x4f, 0x42, 0x42, 0x59, 0x5f, 0x4f, 0x50,
+	0x45, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45,
+	0x4e, 0x47, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x43, 0x43, 0x45, 0x50, 0x54,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10,
+	0x03, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x54, 0x45, 0x44, 0x10, 0x04,
+	0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x56, 0x45, 0x41, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x10,
+	0x0a, 0x0c, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x10, 0x06,
+	0x12, 0x0c, 0x0a, 0x08, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x07, 0x12, 0x11,
+	0x0a, 0x0d, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x45, 0x44, 0x10,
+	0x08, 0x2a, 0x64, 0x0a, 0x0a, 0x57, 0x61, 0x67, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x11, 0x0a, 0x0d, 0x57, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x4f, 0x46, 0x46, 0x45, 0x52, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x4c, 0x4f, 0x43, 0x4b,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x57, 0x4f,
+	0x4e, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x4c, 0x4f, 0x53,
+	0x54, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x43, 0x41, 0x4e,
+	0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x6b, 0x0a, 0x09, 0x41, 0x6e, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x4e, 0x54, 0x45, 0x5f, 0x4f, 0x46, 0x46,
+	0x45, 0x52, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4e, 0x54, 0x45, 0x5f, 0x44,
+	0x45, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x4e,
+	0x54, 0x45, 0x5f, 0x52, 0x45, 0x54, 0x55, 0x52, 0x4e, 0x45, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a,
+	0x0e, 0x41, 0x4e, 0x54, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x46, 0x45, 0x49, 0x54, 0x45, 0x44, 0x10,
+	0x03, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x4e, 0x54, 0x45, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c,
+	0x45, 0x44, 0x10, 0x04, 0x2a, 0x2f, 0x0a, 0x0a, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4b, 0x69,
+	0x6e, 0x64, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x50, 0x4c, 0x41,
+	0x59, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f,
+	0x4d, 0x4f, 0x4e, 0x10, 0x01, 0x2a, 0x35, 0x0a, 0x10, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x49, 0x4e,
+	0x47, 0x4c, 0x45, 0x5f, 0x45, 0x4c, 0x49, 0x4d, 0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10,
+	0x00, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x57, 0x49, 0x53, 0x53, 0x10, 0x01, 0x32, 0xdf, 0x10, 0x0a,
+	0x06, 0x54, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4d, 0x6f,
+	0x6e, 0x12, 0x18, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65,
+	0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x61,
+	0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77,
+	0x6e, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4d, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f,
+	0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4d, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x6d,
+	0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f,
+	0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x07, 0x4d, 0x69, 0x6e, 0x74,
+	0x4d, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x4d,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x4d, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72,
+	0x70, 0x63, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x43, 0x0a, 0x08, 0x42, 0x72, 0x65, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x74, 0x61,
+	0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x72, 0x65, 0x65, 0x64, 0x4d, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x42, 0x72, 0x65, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x4d, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x74, 0x61,
+	0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x4d, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x30, 0x01, 0x12, 0x52, 0x0a, 0x0d, 0x47, 0x65,
+	0x74, 0x4d, 0x6f, 0x6e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1f, 0x2e, 0x74, 0x61,
+	0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52,
+	0x0a, 0x0d, 0x4e, 0x65, 0x77, 0x4d, 0x6f, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1f, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4e, 0x65, 0x77, 0x4d,
+	0x6f, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4e, 0x65, 0x77,
+	0x4d, 0x6f, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x40, 0x0a, 0x07, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x6f, 0x6e, 0x12, 0x19, 0x2e,
+	0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f,
+	0x6e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x30, 0x01, 0x12, 0x55, 0x0a,
+	0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12,
+	0x20, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x4d, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x4d, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x1b, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69,
+	0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c,
+	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a,
+	0x0f, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x12, 0x21, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x20, 0x2e, 0x74, 0x61, 0x70,
+	0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x1c, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x4f, 0x0a, 0x0c,
+	0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1e, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x75, 0x74, 0x65,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a,
+	0x0a, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x57, 0x61, 0x67, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x74, 0x61,
+	0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x57, 0x61, 0x67,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x6d,
+	0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x57, 0x61, 0x67, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74,
+	0x57, 0x61, 0x67, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72,
+	0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x41, 0x6e, 0x74, 0x65,
+	0x12, 0x1b, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x41, 0x6e, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x41,
+	0x6e, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x6e, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f,
+	0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6e, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70,
+	0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6e, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70,
+	0x63, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
+	0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x10, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22,
+	0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x12, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x2e,
+	0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0d, 0x47, 0x65,
+	0x74, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x74, 0x61,
+	0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x75, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74,
+	0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x25,
+	0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x61, 0x70,
+	0x6d, 0x6f, 0x6e, 0x2f, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x64, 0x2f, 0x74, 0x61, 0x70, 0x6d,
+	0x6f, 0x6e, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tapmonrpc_proto_rawDescOnce sync.Once
+	file_tapmonrpc_proto_rawDescData = file_tapmonrpc_proto_rawDesc
+)
+
+func file_tapmonrpc_proto_rawDescGZIP() []byte {
+	file_tapmonrpc_proto_rawDescOnce.Do(func() {
+		file_tapmonrpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_tapmonrpc_proto_rawDescData)
+	})
+	return file_tapmonrpc_proto_rawDescData
+}
+
+var file_tapmonrpc_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_tapmonrpc_proto_msgTypes = make([]protoimpl.MessageInfo, 71)
+var file_tapmonrpc_proto_goTypes = []any{
+	(MonEventType)(0),                   // 0: tapmonrpc.MonEventType
+	(MonReceiveStatus)(0),               // 1: tapmonrpc.MonReceiveStatus
+	(MonStatus)(0),                      // 2: tapmonrpc.MonStatus
+	(Rarity)(0),                         // 3: tapmonrpc.Rarity
+	(FightAction)(0),                    // 4: tapmonrpc.FightAction
+	(FightSide)(0),                      // 5: tapmonrpc.FightSide
+	(MatchUpdateType)(0),                // 6: tapmonrpc.MatchUpdateType
+	(WagerState)(0),                     // 7: tapmonrpc.WagerState
+	(AnteState)(0),                      // 8: tapmonrpc.AnteState
+	(RatingKind)(0),                     // 9: tapmonrpc.RatingKind
+	(TournamentFormat)(0),               // 10: tapmonrpc.TournamentFormat
+	(*GetMonRequest)(nil),               // 11: tapmonrpc.GetMonRequest
+	(*GetMonResponse)(nil),              // 12: tapmonrpc.GetMonResponse
+	(*ListOwnedMonsRequest)(nil),        // 13: tapmonrpc.ListOwnedMonsRequest
+	(*ListOwnedMonsResponse)(nil),       // 14: tapmonrpc.ListOwnedMonsResponse
+	(*ListAllMonsRequest)(nil),          // 15: tapmonrpc.ListAllMonsRequest
+	(*ListAllMonsResponse)(nil),         // 16: tapmonrpc.ListAllMonsResponse
+	(*MintMonRequest)(nil),              // 17: tapmonrpc.MintMonRequest
+	(*MintMonResponse)(nil),             // 18: tapmonrpc.MintMonResponse
+	(*LevelMonRequest)(nil),             // 19: tapmonrpc.LevelMonRequest
+	(*LevelMonResponse)(nil),            // 20: tapmonrpc.LevelMonResponse
+	(*BreedMonRequest)(nil),             // 21: tapmonrpc.BreedMonRequest
+	(*BreedMonResponse)(nil),            // 22: tapmonrpc.BreedMonResponse
+	(*SubscribeMonChangesRequest)(nil),  // 23: tapmonrpc.SubscribeMonChangesRequest
+	(*MonChange)(nil),                   // 24: tapmonrpc.MonChange
+	(*GetMonHistoryRequest)(nil),        // 25: tapmonrpc.GetMonHistoryRequest
+	(*GetMonHistoryResponse)(nil),       // 26: tapmonrpc.GetMonHistoryResponse
+	(*MonOwner)(nil),                    // 27: tapmonrpc.MonOwner
+	(*MonEvent)(nil),                    // 28: tapmonrpc.MonEvent
+	(*NewMonAddressRequest)(nil),        // 29: tapmonrpc.NewMonAddressRequest
+	(*NewMonAddressResponse)(nil),       // 30: tapmonrpc.NewMonAddressResponse
+	(*SendMonRequest)(nil),              // 31: tapmonrpc.SendMonRequest
+	(*SendMonResponse)(nil),             // 32: tapmonrpc.SendMonResponse
+	(*SubscribeMonReceivesRequest)(nil), // 33: tapmonrpc.SubscribeMonReceivesRequest
+	(*MonReceive)(nil),                  // 34: tapmonrpc.MonReceive
+	(*ExportMonProofRequest)(nil),       // 35: tapmonrpc.ExportMonProofRequest
+	(*ExportMonProofResponse)(nil),      // 36: tapmonrpc.ExportMonProofResponse
+	(*Mon)(nil),                         // 37: tapmonrpc.Mon
+	(*MonForm)(nil),                     // 38: tapmonrpc.MonForm
+	(*MonLevel)(nil),                    // 39: tapmonrpc.MonLevel
+	(*FindMatchRequest)(nil),            // 40: tapmonrpc.FindMatchRequest
+	(*FindMatchResponse)(nil),           // 41: tapmonrpc.FindMatchResponse
+	(*Lobby)(nil),                       // 42: tapmonrpc.Lobby
+	(*FightMon)(nil),                    // 43: tapmonrpc.FightMon
+	(*ChallengeMonRequest)(nil),         // 44: tapmonrpc.ChallengeMonRequest
+	(*ChallengeMonResponse)(nil),        // 45: tapmonrpc.ChallengeMonResponse
+	(*AcceptChallengeRequest)(nil),      // 46: tapmonrpc.AcceptChallengeRequest
+	(*AcceptChallengeResponse)(nil),     // 47: tapmonrpc.AcceptChallengeResponse
+	(*SubmitActionRequest)(nil),         // 48: tapmonrpc.SubmitActionRequest
+	(*SubmitActionResponse)(nil),        // 49: tapmonrpc.SubmitActionResponse
+	(*SubscribeMatchRequest)(nil),       // 50: tapmonrpc.SubscribeMatchRequest
+	(*WatchMatchRequest)(nil),           // 51: tapmonrpc.WatchMatchRequest
+	(*MatchUpdate)(nil),                 // 52: tapmonrpc.MatchUpdate
+	(*DisputeMatchRequest)(nil),         // 53: tapmonrpc.DisputeMatchRequest
+	(*DisputeMatchResponse)(nil),        // 54: tapmonrpc.DisputeMatchResponse
+	(*RoundResult)(nil),                 // 55: tapmonrpc.RoundResult
+	(*FightMove)(nil),                   // 56: tapmonrpc.FightMove
+	(*OfferWagerRequest)(nil),           // 57: tapmonrpc.OfferWagerRequest
+	(*OfferWagerResponse)(nil),          // 58: tapmonrpc.OfferWagerResponse
+	(*ListWagersRequest)(nil),           // 59: tapmonrpc.ListWagersRequest
+	(*ListWagersResponse)(nil),          // 60: tapmonrpc.ListWagersResponse
+	(*Wager)(nil),                       // 61: tapmonrpc.Wager
+	(*OfferAnteRequest)(nil),            // 62: tapmonrpc.OfferAnteRequest
+	(*OfferAnteResponse)(nil),           // 63: tapmonrpc.OfferAnteResponse
+	(*ListAntesRequest)(nil),            // 64: tapmonrpc.ListAntesRequest
+	(*ListAntesResponse)(nil),           // 65: tapmonrpc.ListAntesResponse
+	(*Ante)(nil),                        // 66: tapmonrpc.Ante
+	(*GetLeaderboardRequest)(nil),       // 67: tapmonrpc.GetLeaderboardRequest
+	(*GetLeaderboardResponse)(nil),      // 68: tapmonrpc.GetLeaderboardResponse
+	(*LeaderboardEntry)(nil),            // 69: tapmonrpc.LeaderboardEntry
+	(*CreateTournamentRequest)(nil),     // 70: tapmonrpc.CreateTournamentRequest
+	(*CreateTournamentResponse)(nil),    // 71: tapmonrpc.CreateTournamentResponse
+	(*RegisterTournamentRequest)(nil),   // 72: tapmonrpc.RegisterTournamentRequest
+	(*RegisterTournamentResponse)(nil),  // 73: tapmonrpc.RegisterTournamentResponse
+	(*GetTournamentRequest)(nil),        // 74: tapmonrpc.GetTournamentRequest
+	(*GetTournamentResponse)(nil),       // 75: tapmonrpc.GetTournamentResponse
+	(*TournamentRules)(nil),             // 76: tapmonrpc.TournamentRules
+	(*Tournament)(nil),                  // 77: tapmonrpc.Tournament
+	(*TournamentRegistration)(nil),      // 78: tapmonrpc.TournamentRegistration
+	(*TournamentRound)(nil),             // 79: tapmonrpc.TournamentRound
+	(*TournamentPairing)(nil),           // 80: tapmonrpc.TournamentPairing
+	(*TournamentStanding)(nil),          // 81: tapmonrpc.TournamentStanding
+}
+var file_tapmonrpc_proto_depIdxs = []int32{
+	37, // 0: tapmonrpc.GetMonResponse.mon:type_name -> tapmonrpc.Mon
+	2,  // 1: tapmonrpc.ListOwnedMonsRequest.statuses:type_name -> tapmonrpc.MonStatus
+	37, // 2: tapmonrpc.ListOwnedMonsResponse.mons:type_name -> tapmonrpc.Mon
+	2,  // 3: tapmonrpc.ListAllMonsRequest.statuses:type_name -> tapmonrpc.MonStatus
+	37, // 4: tapmonrpc.ListAllMonsResponse.mons:type_name -> tapmonrpc.Mon
+	37, // 5: tapmonrpc.MintMonResponse.mon:type_name -> tapmonrpc.Mon
+	37, // 6: tapmonrpc.LevelMonResponse.mon:type_name -> tapmonrpc.Mon
+	37, // 7: tapmonrpc.BreedMonResponse.mon:type_name -> tapmonrpc.Mon
+	37, // 8: tapmonrpc.MonChange.previous:type_name -> tapmonrpc.Mon
+	37, // 9: tapmonrpc.MonChange.mon:type_name -> tapmonrpc.Mon
+	28, // 10: tapmonrpc.GetMonHistoryResponse.events:type_name -> tapmonrpc.MonEvent
+	27, // 11: tapmonrpc.GetMonHistoryResponse.owner:type_name -> tapmonrpc.MonOwner
+	0,  // 12: tapmonrpc.MonEvent.type:type_name -> tapmonrpc.MonEventType
+	27, // 13: tapmonrpc.MonEvent.owner:type_name -> tapmonrpc.MonOwner
+	27, // 14: tapmonrpc.SendMonResponse.owner:type_name -> tapmonrpc.MonOwner
+	1,  // 15: tapmonrpc.MonReceive.status:type_name -> tapmonrpc.MonReceiveStatus
+	39, // 16: tapmonrpc.Mon.level:type_name -> tapmonrpc.MonLevel
+	38, // 17: tapmonrpc.Mon.form:type_name -> tapmonrpc.MonForm
+	2,  // 18: tapmonrpc.Mon.status:type_name -> tapmonrpc.MonStatus
+	42, // 19: tapmonrpc.FindMatchResponse.lobbies:type_name -> tapmonrpc.Lobby
+	43, // 20: tapmonrpc.ChallengeMonRequest.mon:type_name -> tapmonrpc.FightMon
+	43, // 21: tapmonrpc.ChallengeMonRequest.party:type_name -> tapmonrpc.FightMon
+	43, // 22: tapmonrpc.AcceptChallengeRequest.mon:type_name -> tapmonrpc.FightMon
+	43, // 23: tapmonrpc.AcceptChallengeRequest.party:type_name -> tapmonrpc.FightMon
+	4,  // 24: tapmonrpc.SubmitActionRequest.action:type_name -> tapmonrpc.FightAction
+	6,  // 25: tapmonrpc.MatchUpdate.type:type_name -> tapmonrpc.MatchUpdateType
+	5,  // 26: tapmonrpc.MatchUpdate.side:type_name -> tapmonrpc.FightSide
+	4,  // 27: tapmonrpc.MatchUpdate.action:type_name -> tapmonrpc.FightAction
+	55, // 28: tapmonrpc.MatchUpdate.result:type_name -> tapmonrpc.RoundResult
+	5,  // 29: tapmonrpc.MatchUpdate.winner:type_name -> tapmonrpc.FightSide
+	43, // 30: tapmonrpc.MatchUpdate.host_party:type_name -> tapmonrpc.FightMon
+	43, // 31: tapmonrpc.MatchUpdate.challenger_party:type_name -> tapmonrpc.FightMon
+	56, // 32: tapmonrpc.RoundResult.moves:type_name -> tapmonrpc.FightMove
+	5,  // 33: tapmonrpc.FightMove.side:type_name -> tapmonrpc.FightSide
+	4,  // 34: tapmonrpc.FightMove.action:type_name -> tapmonrpc.FightAction
+	61, // 35: tapmonrpc.OfferWagerResponse.wager:type_name -> tapmonrpc.Wager
+	61, // 36: tapmonrpc.ListWagersResponse.wagers:type_name -> tapmonrpc.Wager
+	7,  // 37: tapmonrpc.Wager.state:type_name -> tapmonrpc.WagerState
+	66, // 38: tapmonrpc.OfferAnteResponse.ante:type_name -> tapmonrpc.Ante
+	66, // 39: tapmonrpc.ListAntesResponse.antes:type_name -> tapmonrpc.Ante
+	8,  // 40: tapmonrpc.Ante.state:type_name -> tapmonrpc.AnteState
+	9,  // 41: tapmonrpc.GetLeaderboardRequest.kind:type_name -> tapmonrpc.RatingKind
+	69, // 42: tapmonrpc.GetLeaderboardResponse.entries:type_name -> tapmonrpc.LeaderboardEntry
+	76, // 43: tapmonrpc.CreateTournamentRequest.rules:type_name -> tapmonrpc.TournamentRules
+	43, // 44: tapmonrpc.RegisterTournamentRequest.mon:type_name -> tapmonrpc.FightMon
+	77, // 45: tapmonrpc.GetTournamentResponse.tournament:type_name -> tapmonrpc.Tournament
+	10, // 46: tapmonrpc.TournamentRules.format:type_name -> tapmonrpc.TournamentFormat
+	76, // 47: tapmonrpc.Tournament.rules:type_name -> tapmonrpc.TournamentRules
+	78, // 48: tapmonrpc.Tournament.registrations:type_name -> tapmonrpc.TournamentRegistration
+	79, // 49: tapmonrpc.Tournament.rounds:type_name -> tapmonrpc.TournamentRound
+	81, // 50: tapmonrpc.Tournament.standings:type_name -> tapmonrpc.TournamentStanding
+	43, // 51: tapmonrpc.TournamentRegistration.mon:type_name -> tapmonrpc.FightMon
+	80, // 52: tapmonrpc.TournamentRound.pairings:type_name -> tapmonrpc.TournamentPairing
+	11, // 53: tapmonrpc.Tapmon.GetMon:input_type -> tapmonrpc.GetMonRequest
+	13, // 54: tapmonrpc.Tapmon.ListOwnedMons:input_type -> tapmonrpc.ListOwnedMonsRequest
+	15, // 55: tapmonrpc.Tapmon.ListAllMons:input_type -> tapmonrpc.ListAllMonsRequest
+	17, // 56: tapmonrpc.Tapmon.MintMon:input_type -> tapmonrpc.MintMonRequest
+	19, // 57: tapmonrpc.Tapmon.LevelMon:input_type -> tapmonrpc.LevelMonRequest
+	21, // 58: tapmonrpc.Tapmon.BreedMon:input_type -> tapmonrpc.BreedMonRequest
+	23, // 59: tapmonrpc.Tapmon.SubscribeMonChanges:input_type -> tapmonrpc.SubscribeMonChangesRequest
+	25, // 60: tapmonrpc.Tapmon.GetMonHistory:input_type -> tapmonrpc.GetMonHistoryRequest
+	29, // 61: tapmonrpc.Tapmon.NewMonAddress:input_type -> tapmonrpc.NewMonAddressRequest
+	31, // 62: tapmonrpc.Tapmon.SendMon:input_type -> tapmonrpc.SendMonRequest
+	33, // 63: tapmonrpc.Tapmon.SubscribeMonReceives:input_type -> tapmonrpc.SubscribeMonReceivesRequest
+	35, // 64: tapmonrpc.Tapmon.ExportMonProof:input_type -> tapmonrpc.ExportMonProofRequest
+	40, // 65: tapmonrpc.Tapmon.FindMatch:input_type -> tapmonrpc.FindMatchRequest
+	44, // 66: tapmonrpc.Tapmon.ChallengeMon:input_type -> tapmonrpc.ChallengeMonRequest
+	46, // 67: tapmonrpc.Tapmon.AcceptChallenge:input_type -> tapmonrpc.AcceptChallengeRequest
+	48, // 68: tapmonrpc.Tapmon.SubmitAction:input_type -> tapmonrpc.SubmitActionRequest
+	50, // 69: tapmonrpc.Tapmon.SubscribeMatch:input_type -> tapmonrpc.SubscribeMatchRequest
+	51, // 70: tapmonrpc.Tapmon.WatchMatch:input_type -> tapmonrpc.WatchMatchRequest
+	53, // 71: tapmonrpc.Tapmon.DisputeMatch:input_type -> tapmonrpc.DisputeMatchRequest
+	57, // 72: tapmonrpc.Tapmon.OfferWager:input_type -> tapmonrpc.OfferWagerRequest
+	59, // 73: tapmonrpc.Tapmon.ListWagers:input_type -> tapmonrpc.ListWagersRequest
+	62, // 74: tapmonrpc.Tapmon.OfferAnte:input_type -> tapmonrpc.OfferAnteRequest
+	64, // 75: tapmonrpc.Tapmon.ListAntes:input_type -> tapmonrpc.ListAntesRequest
+	67, // 76: tapmonrpc.Tapmon.GetLeaderboard:input_type -> tapmonrpc.GetLeaderboardRequest
+	70, // 77: tapmonrpc.Tapmon.CreateTournament:input_type -> tapmonrpc.CreateTournamentRequest
+	72, // 78: tapmonrpc.Tapmon.RegisterTournament:input_type -> tapmonrpc.RegisterTournamentRequest
+	74, // 79: tapmonrpc.Tapmon.GetTournament:input_type -> tapmonrpc.GetTournamentRequest
+	12, // 80: tapmonrpc.Tapmon.GetMon:output_type -> tapmonrpc.GetMonResponse
+	14, // 81: tapmonrpc.Tapmon.ListOwnedMons:output_type -> tapmonrpc.ListOwnedMonsResponse
+	16, // 82: tapmonrpc.Tapmon.ListAllMons:output_type -> tapmonrpc.ListAllMonsResponse
+	18, // 83: tapmonrpc.Tapmon.MintMon:output_type -> tapmonrpc.MintMonResponse
+	20, // 84: tapmonrpc.Tapmon.LevelMon:output_type -> tapmonrpc.LevelMonResponse
+	22, // 85: tapmonrpc.Tapmon.BreedMon:output_type -> tapmonrpc.BreedMonResponse
+	24, // 86: tapmonrpc.Tapmon.SubscribeMonChanges:output_type -> tapmonrpc.MonChange
+	26, // 87: tapmonrpc.Tapmon.GetMonHistory:output_type -> tapmonrpc.GetMonHistoryResponse
+	30, // 88: tapmonrpc.Tapmon.NewMonAddress:output_type -> tapmonrpc.NewMonAddressResponse
+	32, // 89: tapmonrpc.Tapmon.SendMon:output_type -> tapmonrpc.SendMonResponse
+	34, // 90: tapmonrpc.Tapmon.SubscribeMonReceives:output_type -> tapmonrpc.MonReceive
+	36, // 91: tapmonrpc.Tapmon.ExportMonProof:output_type -> tapmonrpc.ExportMonProofResponse
+	41, // 92: tapmonrpc.Tapmon.FindMatch:output_type -> tapmonrpc.FindMatchResponse
+	45, // 93: tapmonrpc.Tapmon.ChallengeMon:output_type -> tapmonrpc.ChallengeMonResponse
+	47, // 94: tapmonrpc.Tapmon.AcceptChallenge:output_type -> tapmonrpc.AcceptChallengeResponse
+	49, // 95: tapmonrpc.Tapmon.SubmitAction:output_type -> tapmonrpc.SubmitActionResponse
+	52, // 96: tapmonrpc.Tapmon.SubscribeMatch:output_type -> tapmonrpc.MatchUpdate
+	52, // 97: tapmonrpc.Tapmon.WatchMatch:output_type -> tapmonrpc.MatchUpdate
+	54, // 98: tapmonrpc.Tapmon.DisputeMatch:output_type -> tapmonrpc.DisputeMatchResponse
+	58, // 99: tapmonrpc.Tapmon.OfferWager:output_type -> tapmonrpc.OfferWagerResponse
+	60, // 100: tapmonrpc.Tapmon.ListWagers:output_type -> tapmonrpc.ListWagersResponse
+	63, // 101: tapmonrpc.Tapmon.OfferAnte:output_type -> tapmonrpc.OfferAnteResponse
+	65, // 102: tapmonrpc.Tapmon.ListAntes:output_type -> tapmonrpc.ListAntesResponse
+	68, // 103: tapmonrpc.Tapmon.GetLeaderboard:output_type -> tapmonrpc.GetLeaderboardResponse
+	71, // 104: tapmonrpc.Tapmon.CreateTournament:output_type -> tapmonrpc.CreateTournamentResponse
+	73, // 105: tapmonrpc.Tapmon.RegisterTournament:output_type -> tapmonrpc.RegisterTournamentResponse
+	75, // 106: tapmonrpc.Tapmon.GetTournament:output_type -> tapmonrpc.GetTournamentResponse
+	80, // [80:107] is the sub-list for method output_type
+	53, // [53:80] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
+}
+
+func init() { file_tapmonrpc_proto_init() }
+func file_tapmonrpc_proto_init() {
+	if File_tapmonrpc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tapmonrpc_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetMonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetMonResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListOwnedMonsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListOwnedMonsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ListAllMonsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ListAllMonsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*MintMonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*MintMonResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*LevelMonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*LevelMonResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*BreedMonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*BreedMonResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeMonChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*MonChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*GetMonHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*GetMonHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*MonOwner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*MonEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*NewMonAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*NewMonAddressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*SendMonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*SendMonResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeMonReceivesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*MonReceive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*ExportMonProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*ExportMonProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*Mon); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*MonForm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*MonLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*FindMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*FindMatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*Lobby); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*FightMon); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*ChallengeMonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*ChallengeMonResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*AcceptChallengeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*AcceptChallengeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[37].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitActionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[38].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitActionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[39].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[40].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[41].Exporter = func(v any, i int) any {
+			switch v := v.(*MatchUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[42].Exporter = func(v any, i int) any {
+			switch v := v.(*DisputeMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[43].Exporter = func(v any, i int) any {
+			switch v := v.(*DisputeMatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[44].Exporter = func(v any, i int) any {
+			switch v := v.(*RoundResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[45].Exporter = func(v any, i int) any {
+			switch v := v.(*FightMove); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[46].Exporter = func(v any, i int) any {
+			switch v := v.(*OfferWagerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[47].Exporter = func(v any, i int) any {
+			switch v := v.(*OfferWagerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[48].Exporter = func(v any, i int) any {
+			switch v := v.(*ListWagersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[49].Exporter = func(v any, i int) any {
+			switch v := v.(*ListWagersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[50].Exporter = func(v any, i int) any {
+			switch v := v.(*Wager); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[51].Exporter = func(v any, i int) any {
+			switch v := v.(*OfferAnteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[52].Exporter = func(v any, i int) any {
+			switch v := v.(*OfferAnteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[53].Exporter = func(v any, i int) any {
+			switch v := v.(*ListAntesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[54].Exporter = func(v any, i int) any {
+			switch v := v.(*ListAntesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[55].Exporter = func(v any, i int) any {
+			switch v := v.(*Ante); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Mon.ProtoReflect.Descriptor instead.
-func (*Mon) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *Mon) GetId() []byte {
-	if x != nil {
-		return x.Id
-	}
-	return nil
-}
-
-func (x *Mon) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Mon) GetAttributes() []int32 {
-	if x != nil {
-		return x.Attributes
-	}
-	return nil
-}
-
-func (x *Mon) GetRarityScore() float64 {
-	if x != nil {
-		return x.RarityScore
-	}
-	return 0
-}
-
-func (x *Mon) GetLevel() *MonLevel {
-	if x != nil {
-		return x.Level
-	}
-	return nil
-}
-
-type MonLevel struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Level int32  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
-	Nonce []byte `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
-}
-
-func (x *MonLevel) Reset() {
-	*x = MonLevel{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_tapmonrpc_proto_msgTypes[11]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *MonLevel) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*MonLevel) ProtoMessage() {}
-
-func (x *MonLevel) ProtoReflect() protoreflect.Message {
-	mi := &file_tapmonrpc_proto_msgTypes[11]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		file_tapmonrpc_proto_msgTypes[56].Exporter = func(v any, i int) any {
+			switch v := v.(*GetLeaderboardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use MonLevel.ProtoReflect.Descriptor instead.
-func (*MonLevel) Descriptor() ([]byte, []int) {
-	return file_tapmonrpc_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *MonLevel) GetLevel() int32 {
-	if x != nil {
-		return x.Level
-	}
-	return 0
-}
-
-func (x *MonLevel) GetNonce() []byte {
-	if x != nil {
-		return x.Nonce
-	}
-	return nil
-}
-
-var File_tapmonrpc_proto protoreflect.FileDescriptor
-
-var file_tapmonrpc_proto_rawDesc = []byte{
-	0x0a, 0x0f, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x09, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x22, 0x33, 0x0a, 0x0d,
-	0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69,
-	0x64, 0x22, 0x32, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e,
-	0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e,
-	0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3b, 0x0a,
-	0x15, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x6f, 0x6e, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63,
-	0x2e, 0x4d, 0x6f, 0x6e, 0x52, 0x04, 0x6d, 0x6f, 0x6e, 0x73, 0x22, 0x42, 0x0a, 0x12, 0x4c, 0x69,
-	0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x39,
-	0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e,
-	0x4d, 0x6f, 0x6e, 0x52, 0x04, 0x6d, 0x6f, 0x6e, 0x73, 0x22, 0x24, 0x0a, 0x0e, 0x4d, 0x69, 0x6e,
-	0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
-	0x33, 0x0a, 0x0f, 0x4d, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x20, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x52,
-	0x03, 0x6d, 0x6f, 0x6e, 0x22, 0x70, 0x0a, 0x0f, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c,
-	0x12, 0x24, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x5f, 0x6e, 0x6f, 0x6e,
-	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41,
-	0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x28, 0x0a, 0x10, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4d,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f,
-	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
-	0x22, 0x97, 0x01, 0x0a, 0x03, 0x4d, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
-	0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05,
-	0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c,
-	0x72, 0x61, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x0b, 0x72, 0x61, 0x72, 0x69, 0x74, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12,
-	0x29, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x4c, 0x65,
-	0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x36, 0x0a, 0x08, 0x4d, 0x6f,
-	0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05,
-	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e,
-	0x63, 0x65, 0x2a, 0x37, 0x0a, 0x06, 0x52, 0x61, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0a, 0x0a, 0x06,
-	0x43, 0x4f, 0x4d, 0x4d, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x41, 0x52, 0x45,
-	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x50, 0x49, 0x43, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09,
-	0x4c, 0x45, 0x47, 0x45, 0x4e, 0x44, 0x41, 0x52, 0x59, 0x10, 0x03, 0x32, 0xf0, 0x02, 0x0a, 0x06,
-	0x54, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e,
-	0x12, 0x18, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74,
-	0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x61, 0x70,
-	0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e,
-	0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72,
-	0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
-	0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4d, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x4c, 0x69, 0x73,
-	0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f,
-	0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e,
-	0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4d, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x07, 0x4d, 0x69, 0x6e, 0x74, 0x4d,
-	0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
-	0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
-	0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x4d, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x4c, 0x65, 0x76,
-	0x65, 0x6c, 0x4d, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70,
-	0x63, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x65,
-	0x76, 0x65, 0x6c, 0x4d, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x27,
-	0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x75,
-	0x74, 0x6e, 0x31, 0x63, 0x6b, 0x2f, 0x74, 0x61, 0x70, 0x6d, 0x6f, 0x6e, 0x64, 0x2f, 0x74, 0x61,
-	0x70, 0x6d, 0x6f, 0x6e, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_tapmonrpc_proto_rawDescOnce sync.Once
-	file_tapmonrpc_proto_rawDescData = file_tapmonrpc_proto_rawDesc
-)
-
-func file_tapmonrpc_proto_rawDescGZIP() []byte {
-	file_tapmonrpc_proto_rawDescOnce.Do(func() {
-		file_tapmonrpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_tapmonrpc_proto_rawDescData)
-	})
-	return file_tapmonrpc_proto_rawDescData
-}
-
-var file_tapmonrpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_tapmonrpc_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
-var file_tapmonrpc_proto_goTypes = []any{
-	(Rarity)(0),                   // 0: tapmonrpc.Rarity
-	(*GetMonRequest)(nil),         // 1: tapmonrpc.GetMonRequest
-	(*GetMonResponse)(nil),        // 2: tapmonrpc.GetMonResponse
-	(*ListOwnedMonsRequest)(nil),  // 3: tapmonrpc.ListOwnedMonsRequest
-	(*ListOwnedMonsResponse)(nil), // 4: tapmonrpc.ListOwnedMonsResponse
-	(*ListAllMonsRequest)(nil),    // 5: tapmonrpc.ListAllMonsRequest
-	(*ListAllMonsResponse)(nil),   // 6: tapmonrpc.ListAllMonsResponse
-	(*MintMonRequest)(nil),        // 7: tapmonrpc.MintMonRequest
-	(*MintMonResponse)(nil),       // 8: tapmonrpc.MintMonResponse
-	(*LevelMonRequest)(nil),       // 9: tapmonrpc.LevelMonRequest
-	(*LevelMonResponse)(nil),      // 10: tapmonrpc.LevelMonResponse
-	(*Mon)(nil),                   // 11: tapmonrpc.Mon
-	(*MonLevel)(nil),              // 12: tapmonrpc.MonLevel
-}
-var file_tapmonrpc_proto_depIdxs = []int32{
-	11, // 0: tapmonrpc.GetMonResponse.mon:type_name -> tapmonrpc.Mon
-	11, // 1: tapmonrpc.ListOwnedMonsResponse.mons:type_name -> tapmonrpc.Mon
-	11, // 2: tapmonrpc.ListAllMonsResponse.mons:type_name -> tapmonrpc.Mon
-	11, // 3: tapmonrpc.MintMonResponse.mon:type_name -> tapmonrpc.Mon
-	12, // 4: tapmonrpc.Mon.level:type_name -> tapmonrpc.MonLevel
-	1,  // 5: tapmonrpc.Tapmon.GetMon:input_type -> tapmonrpc.GetMonRequest
-	3,  // 6: tapmonrpc.Tapmon.ListOwnedMons:input_type -> tapmonrpc.ListOwnedMonsRequest
-	5,  // 7: tapmonrpc.Tapmon.ListAllMons:input_type -> tapmonrpc.ListAllMonsRequest
-	7,  // 8: tapmonrpc.Tapmon.MintMon:input_type -> tapmonrpc.MintMonRequest
-	9,  // 9: tapmonrpc.Tapmon.LevelMon:input_type -> tapmonrpc.LevelMonRequest
-	2,  // 10: tapmonrpc.Tapmon.GetMon:output_type -> tapmonrpc.GetMonResponse
-	4,  // 11: tapmonrpc.Tapmon.ListOwnedMons:output_type -> tapmonrpc.ListOwnedMonsResponse
-	6,  // 12: tapmonrpc.Tapmon.ListAllMons:output_type -> tapmonrpc.ListAllMonsResponse
-	8,  // 13: tapmonrpc.Tapmon.MintMon:output_type -> tapmonrpc.MintMonResponse
-	10, // 14: tapmonrpc.Tapmon.LevelMon:output_type -> tapmonrpc.LevelMonResponse
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
-}
-
-func init() { file_tapmonrpc_proto_init() }
-func file_tapmonrpc_proto_init() {
-	if File_tapmonrpc_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_tapmonrpc_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*GetMonRequest); i {
+		file_tapmonrpc_proto_msgTypes[57].Exporter = func(v any, i int) any {
+			switch v := v.(*GetLeaderboardResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -854,8 +6731,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*GetMonResponse); i {
+		file_tapmonrpc_proto_msgTypes[58].Exporter = func(v any, i int) any {
+			switch v := v.(*LeaderboardEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -866,8 +6743,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*ListOwnedMonsRequest); i {
+		file_tapmonrpc_proto_msgTypes[59].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateTournamentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -878,8 +6755,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*ListOwnedMonsResponse); i {
+		file_tapmonrpc_proto_msgTypes[60].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateTournamentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -890,8 +6767,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*ListAllMonsRequest); i {
+		file_tapmonrpc_proto_msgTypes[61].Exporter = func(v any, i int) any {
+			switch v := v.(*RegisterTournamentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -902,8 +6779,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*ListAllMonsResponse); i {
+		file_tapmonrpc_proto_msgTypes[62].Exporter = func(v any, i int) any {
+			switch v := v.(*RegisterTournamentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -914,8 +6791,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*MintMonRequest); i {
+		file_tapmonrpc_proto_msgTypes[63].Exporter = func(v any, i int) any {
+			switch v := v.(*GetTournamentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -926,8 +6803,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*MintMonResponse); i {
+		file_tapmonrpc_proto_msgTypes[64].Exporter = func(v any, i int) any {
+			switch v := v.(*GetTournamentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -938,8 +6815,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*LevelMonRequest); i {
+		file_tapmonrpc_proto_msgTypes[65].Exporter = func(v any, i int) any {
+			switch v := v.(*TournamentRules); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -950,8 +6827,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*LevelMonResponse); i {
+		file_tapmonrpc_proto_msgTypes[66].Exporter = func(v any, i int) any {
+			switch v := v.(*Tournament); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -962,8 +6839,8 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*Mon); i {
+		file_tapmonrpc_proto_msgTypes[67].Exporter = func(v any, i int) any {
+			switch v := v.(*TournamentRegistration); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -974,8 +6851,32 @@ func file_tapmonrpc_proto_init() {
 				return nil
 			}
 		}
-		file_tapmonrpc_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*MonLevel); i {
+		file_tapmonrpc_proto_msgTypes[68].Exporter = func(v any, i int) any {
+			switch v := v.(*TournamentRound); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[69].Exporter = func(v any, i int) any {
+			switch v := v.(*TournamentPairing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapmonrpc_proto_msgTypes[70].Exporter = func(v any, i int) any {
+			switch v := v.(*TournamentStanding); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -992,8 +6893,8 @@ func file_tapmonrpc_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tapmonrpc_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   12,
+			NumEnums:      11,
+			NumMessages:   71,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,28 @@ type TapmonClient interface {
 	ListAllMons(ctx context.Context, in *ListAllMonsRequest, opts ...grpc.CallOption) (*ListAllMonsResponse, error)
 	MintMon(ctx context.Context, in *MintMonRequest, opts ...grpc.CallOption) (*MintMonResponse, error)
 	LevelMon(ctx context.Context, in *LevelMonRequest, opts ...grpc.CallOption) (*LevelMonResponse, error)
+	BreedMon(ctx context.Context, in *BreedMonRequest, opts ...grpc.CallOption) (*BreedMonResponse, error)
+	SubscribeMonChanges(ctx context.Context, in *SubscribeMonChangesRequest, opts ...grpc.CallOption) (Tapmon_SubscribeMonChangesClient, error)
+	GetMonHistory(ctx context.Context, in *GetMonHistoryRequest, opts ...grpc.CallOption) (*GetMonHistoryResponse, error)
+	NewMonAddress(ctx context.Context, in *NewMonAddressRequest, opts ...grpc.CallOption) (*NewMonAddressResponse, error)
+	SendMon(ctx context.Context, in *SendMonRequest, opts ...grpc.CallOption) (*SendMonResponse, error)
+	SubscribeMonReceives(ctx context.Context, in *SubscribeMonReceivesRequest, opts ...grpc.CallOption) (Tapmon_SubscribeMonReceivesClient, error)
+	ExportMonProof(ctx context.Context, in *ExportMonProofRequest, opts ...grpc.CallOption) (*ExportMonProofResponse, error)
+	FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (*FindMatchResponse, error)
+	ChallengeMon(ctx context.Context, in *ChallengeMonRequest, opts ...grpc.CallOption) (*ChallengeMonResponse, error)
+	AcceptChallenge(ctx context.Context, in *AcceptChallengeRequest, opts ...grpc.CallOption) (*AcceptChallengeResponse, error)
+	SubmitAction(ctx context.Context, in *SubmitActionRequest, opts ...grpc.CallOption) (*SubmitActionResponse, error)
+	SubscribeMatch(ctx context.Context, in *SubscribeMatchRequest, opts ...grpc.CallOption) (Tapmon_SubscribeMatchClient, error)
+	WatchMatch(ctx context.Context, in *WatchMatchRequest, opts ...grpc.CallOption) (Tapmon_WatchMatchClient, error)
+	DisputeMatch(ctx context.Context, in *DisputeMatchRequest, opts ...grpc.CallOption) (*DisputeMatchResponse, error)
+	OfferWager(ctx context.Context, in *OfferWagerRequest, opts ...grpc.CallOption) (*OfferWagerResponse, error)
+	ListWagers(ctx context.Context, in *ListWagersRequest, opts ...grpc.CallOption) (*ListWagersResponse, error)
+	OfferAnte(ctx context.Context, in *OfferAnteRequest, opts ...grpc.CallOption) (*OfferAnteResponse, error)
+	ListAntes(ctx context.Context, in *ListAntesRequest, opts ...grpc.CallOption) (*ListAntesResponse, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*CreateTournamentResponse, error)
+	RegisterTournament(ctx context.Context, in *RegisterTournamentRequest, opts ...grpc.CallOption) (*RegisterTournamentResponse, error)
+	GetTournament(ctx context.Context, in *GetTournamentRequest, opts ...grpc.CallOption) (*GetTournamentResponse, error)
 }
 
 type tapmonClient struct {
@@ -78,6 +100,296 @@ func (c *tapmonClient) LevelMon(ctx context.Context, in *LevelMonRequest, opts .
 	return out, nil
 }
 
+func (c *tapmonClient) BreedMon(ctx context.Context, in *BreedMonRequest, opts ...grpc.CallOption) (*BreedMonResponse, error) {
+	out := new(BreedMonResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/BreedMon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) SubscribeMonChanges(ctx context.Context, in *SubscribeMonChangesRequest, opts ...grpc.CallOption) (Tapmon_SubscribeMonChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Tapmon_ServiceDesc.Streams[0], "/tapmonrpc.Tapmon/SubscribeMonChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tapmonSubscribeMonChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Tapmon_SubscribeMonChangesClient interface {
+	Recv() (*MonChange, error)
+	grpc.ClientStream
+}
+
+type tapmonSubscribeMonChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *tapmonSubscribeMonChangesClient) Recv() (*MonChange, error) {
+	m := new(MonChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tapmonClient) GetMonHistory(ctx context.Context, in *GetMonHistoryRequest, opts ...grpc.CallOption) (*GetMonHistoryResponse, error) {
+	out := new(GetMonHistoryResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/GetMonHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) NewMonAddress(ctx context.Context, in *NewMonAddressRequest, opts ...grpc.CallOption) (*NewMonAddressResponse, error) {
+	out := new(NewMonAddressResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/NewMonAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) SendMon(ctx context.Context, in *SendMonRequest, opts ...grpc.CallOption) (*SendMonResponse, error) {
+	out := new(SendMonResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/SendMon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) SubscribeMonReceives(ctx context.Context, in *SubscribeMonReceivesRequest, opts ...grpc.CallOption) (Tapmon_SubscribeMonReceivesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Tapmon_ServiceDesc.Streams[1], "/tapmonrpc.Tapmon/SubscribeMonReceives", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tapmonSubscribeMonReceivesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Tapmon_SubscribeMonReceivesClient interface {
+	Recv() (*MonReceive, error)
+	grpc.ClientStream
+}
+
+type tapmonSubscribeMonReceivesClient struct {
+	grpc.ClientStream
+}
+
+func (x *tapmonSubscribeMonReceivesClient) Recv() (*MonReceive, error) {
+	m := new(MonReceive)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tapmonClient) ExportMonProof(ctx context.Context, in *ExportMonProofRequest, opts ...grpc.CallOption) (*ExportMonProofResponse, error) {
+	out := new(ExportMonProofResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/ExportMonProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (*FindMatchResponse, error) {
+	out := new(FindMatchResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/FindMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) ChallengeMon(ctx context.Context, in *ChallengeMonRequest, opts ...grpc.CallOption) (*ChallengeMonResponse, error) {
+	out := new(ChallengeMonResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/ChallengeMon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) AcceptChallenge(ctx context.Context, in *AcceptChallengeRequest, opts ...grpc.CallOption) (*AcceptChallengeResponse, error) {
+	out := new(AcceptChallengeResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/AcceptChallenge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) SubmitAction(ctx context.Context, in *SubmitActionRequest, opts ...grpc.CallOption) (*SubmitActionResponse, error) {
+	out := new(SubmitActionResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/SubmitAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) SubscribeMatch(ctx context.Context, in *SubscribeMatchRequest, opts ...grpc.CallOption) (Tapmon_SubscribeMatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Tapmon_ServiceDesc.Streams[2], "/tapmonrpc.Tapmon/SubscribeMatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tapmonSubscribeMatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Tapmon_SubscribeMatchClient interface {
+	Recv() (*MatchUpdate, error)
+	grpc.ClientStream
+}
+
+type tapmonSubscribeMatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *tapmonSubscribeMatchClient) Recv() (*MatchUpdate, error) {
+	m := new(MatchUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tapmonClient) WatchMatch(ctx context.Context, in *WatchMatchRequest, opts ...grpc.CallOption) (Tapmon_WatchMatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Tapmon_ServiceDesc.Streams[3], "/tapmonrpc.Tapmon/WatchMatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tapmonWatchMatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Tapmon_WatchMatchClient interface {
+	Recv() (*MatchUpdate, error)
+	grpc.ClientStream
+}
+
+type tapmonWatchMatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *tapmonWatchMatchClient) Recv() (*MatchUpdate, error) {
+	m := new(MatchUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tapmonClient) DisputeMatch(ctx context.Context, in *DisputeMatchRequest, opts ...grpc.CallOption) (*DisputeMatchResponse, error) {
+	out := new(DisputeMatchResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/DisputeMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) OfferWager(ctx context.Context, in *OfferWagerRequest, opts ...grpc.CallOption) (*OfferWagerResponse, error) {
+	out := new(OfferWagerResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/OfferWager", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) ListWagers(ctx context.Context, in *ListWagersRequest, opts ...grpc.CallOption) (*ListWagersResponse, error) {
+	out := new(ListWagersResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/ListWagers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) OfferAnte(ctx context.Context, in *OfferAnteRequest, opts ...grpc.CallOption) (*OfferAnteResponse, error) {
+	out := new(OfferAnteResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/OfferAnte", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) ListAntes(ctx context.Context, in *ListAntesRequest, opts ...grpc.CallOption) (*ListAntesResponse, error) {
+	out := new(ListAntesResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/ListAntes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/GetLeaderboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*CreateTournamentResponse, error) {
+	out := new(CreateTournamentResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/CreateTournament", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) RegisterTournament(ctx context.Context, in *RegisterTournamentRequest, opts ...grpc.CallOption) (*RegisterTournamentResponse, error) {
+	out := new(RegisterTournamentResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/RegisterTournament", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tapmonClient) GetTournament(ctx context.Context, in *GetTournamentRequest, opts ...grpc.CallOption) (*GetTournamentResponse, error) {
+	out := new(GetTournamentResponse)
+	err := c.cc.Invoke(ctx, "/tapmonrpc.Tapmon/GetTournament", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TapmonServer is the server API for Tapmon service.
 // All implementations must embed UnimplementedTapmonServer
 // for forward compatibility
@@ -87,6 +399,28 @@ type TapmonServer interface {
 	ListAllMons(context.Context, *ListAllMonsRequest) (*ListAllMonsResponse, error)
 	MintMon(context.Context, *MintMonRequest) (*MintMonResponse, error)
 	LevelMon(context.Context, *LevelMonRequest) (*LevelMonResponse, error)
+	BreedMon(context.Context, *BreedMonRequest) (*BreedMonResponse, error)
+	SubscribeMonChanges(*SubscribeMonChangesRequest, Tapmon_SubscribeMonChangesServer) error
+	GetMonHistory(context.Context, *GetMonHistoryRequest) (*GetMonHistoryResponse, error)
+	NewMonAddress(context.Context, *NewMonAddressRequest) (*NewMonAddressResponse, error)
+	SendMon(context.Context, *SendMonRequest) (*SendMonResponse, error)
+	SubscribeMonReceives(*SubscribeMonReceivesRequest, Tapmon_SubscribeMonReceivesServer) error
+	ExportMonProof(context.Context, *ExportMonProofRequest) (*ExportMonProofResponse, error)
+	FindMatch(context.Context, *FindMatchRequest) (*FindMatchResponse, error)
+	ChallengeMon(context.Context, *ChallengeMonRequest) (*ChallengeMonResponse, error)
+	AcceptChallenge(context.Context, *AcceptChallengeRequest) (*AcceptChallengeResponse, error)
+	SubmitAction(context.Context, *SubmitActionRequest) (*SubmitActionResponse, error)
+	SubscribeMatch(*SubscribeMatchRequest, Tapmon_SubscribeMatchServer) error
+	WatchMatch(*WatchMatchRequest, Tapmon_WatchMatchServer) error
+	DisputeMatch(context.Context, *DisputeMatchRequest) (*DisputeMatchResponse, error)
+	OfferWager(context.Context, *OfferWagerRequest) (*OfferWagerResponse, error)
+	ListWagers(context.Context, *ListWagersRequest) (*ListWagersResponse, error)
+	OfferAnte(context.Context, *OfferAnteRequest) (*OfferAnteResponse, error)
+	ListAntes(context.Context, *ListAntesRequest) (*ListAntesResponse, error)
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	CreateTournament(context.Context, *CreateTournamentRequest) (*CreateTournamentResponse, error)
+	RegisterTournament(context.Context, *RegisterTournamentRequest) (*RegisterTournamentResponse, error)
+	GetTournament(context.Context, *GetTournamentRequest) (*GetTournamentResponse, error)
 	mustEmbedUnimplementedTapmonServer()
 }
 
@@ -109,6 +443,72 @@ func (UnimplementedTapmonServer) MintMon(context.Context, *MintMonRequest) (*Min
 func (UnimplementedTapmonServer) LevelMon(context.Context, *LevelMonRequest) (*LevelMonResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LevelMon not implemented")
 }
+func (UnimplementedTapmonServer) BreedMon(context.Context, *BreedMonRequest) (*BreedMonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BreedMon not implemented")
+}
+func (UnimplementedTapmonServer) SubscribeMonChanges(*SubscribeMonChangesRequest, Tapmon_SubscribeMonChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMonChanges not implemented")
+}
+func (UnimplementedTapmonServer) GetMonHistory(context.Context, *GetMonHistoryRequest) (*GetMonHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMonHistory not implemented")
+}
+func (UnimplementedTapmonServer) NewMonAddress(context.Context, *NewMonAddressRequest) (*NewMonAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewMonAddress not implemented")
+}
+func (UnimplementedTapmonServer) SendMon(context.Context, *SendMonRequest) (*SendMonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMon not implemented")
+}
+func (UnimplementedTapmonServer) SubscribeMonReceives(*SubscribeMonReceivesRequest, Tapmon_SubscribeMonReceivesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMonReceives not implemented")
+}
+func (UnimplementedTapmonServer) ExportMonProof(context.Context, *ExportMonProofRequest) (*ExportMonProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMonProof not implemented")
+}
+func (UnimplementedTapmonServer) FindMatch(context.Context, *FindMatchRequest) (*FindMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMatch not implemented")
+}
+func (UnimplementedTapmonServer) ChallengeMon(context.Context, *ChallengeMonRequest) (*ChallengeMonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChallengeMon not implemented")
+}
+func (UnimplementedTapmonServer) AcceptChallenge(context.Context, *AcceptChallengeRequest) (*AcceptChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptChallenge not implemented")
+}
+func (UnimplementedTapmonServer) SubmitAction(context.Context, *SubmitActionRequest) (*SubmitActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitAction not implemented")
+}
+func (UnimplementedTapmonServer) SubscribeMatch(*SubscribeMatchRequest, Tapmon_SubscribeMatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMatch not implemented")
+}
+func (UnimplementedTapmonServer) WatchMatch(*WatchMatchRequest, Tapmon_WatchMatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMatch not implemented")
+}
+func (UnimplementedTapmonServer) DisputeMatch(context.Context, *DisputeMatchRequest) (*DisputeMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisputeMatch not implemented")
+}
+func (UnimplementedTapmonServer) OfferWager(context.Context, *OfferWagerRequest) (*OfferWagerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferWager not implemented")
+}
+func (UnimplementedTapmonServer) ListWagers(context.Context, *ListWagersRequest) (*ListWagersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWagers not implemented")
+}
+func (UnimplementedTapmonServer) OfferAnte(context.Context, *OfferAnteRequest) (*OfferAnteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferAnte not implemented")
+}
+func (UnimplementedTapmonServer) ListAntes(context.Context, *ListAntesRequest) (*ListAntesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAntes not implemented")
+}
+func (UnimplementedTapmonServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedTapmonServer) CreateTournament(context.Context, *CreateTournamentRequest) (*CreateTournamentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTournament not implemented")
+}
+func (UnimplementedTapmonServer) RegisterTournament(context.Context, *RegisterTournamentRequest) (*RegisterTournamentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterTournament not implemented")
+}
+func (UnimplementedTapmonServer) GetTournament(context.Context, *GetTournamentRequest) (*GetTournamentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTournament not implemented")
+}
 func (UnimplementedTapmonServer) mustEmbedUnimplementedTapmonServer() {}
 
 // UnsafeTapmonServer may be embedded to opt out of forward compatibility for this service.
@@ -212,34 +612,535 @@ func _Tapmon_LevelMon_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
-// Tapmon_ServiceDesc is the grpc.ServiceDesc for Tapmon service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Tapmon_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "tapmonrpc.Tapmon",
-	HandlerType: (*TapmonServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetMon",
-			Handler:    _Tapmon_GetMon_Handler,
-		},
-		{
-			MethodName: "ListOwnedMons",
-			Handler:    _Tapmon_ListOwnedMons_Handler,
-		},
-		{
-			MethodName: "ListAllMons",
-			Handler:    _Tapmon_ListAllMons_Handler,
-		},
-		{
-			MethodName: "MintMon",
-			Handler:    _Tapmon_MintMon_Handler,
-		},
-		{
-			MethodName: "LevelMon",
-			Handler:    _Tapmon_LevelMon_Handler,
+func _Tapmon_BreedMon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BreedMonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).BreedMon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/BreedMon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).BreedMon(ctx, req.(*BreedMonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_SubscribeMonChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMonChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TapmonServer).SubscribeMonChanges(m, &tapmonSubscribeMonChangesServer{stream})
+}
+
+type Tapmon_SubscribeMonChangesServer interface {
+	Send(*MonChange) error
+	grpc.ServerStream
+}
+
+type tapmonSubscribeMonChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *tapmonSubscribeMonChangesServer) Send(m *MonChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Tapmon_GetMonHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMonHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).GetMonHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/GetMonHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).GetMonHistory(ctx, req.(*GetMonHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_NewMonAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewMonAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).NewMonAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/NewMonAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).NewMonAddress(ctx, req.(*NewMonAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_SendMon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).SendMon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/SendMon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).SendMon(ctx, req.(*SendMonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_SubscribeMonReceives_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMonReceivesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TapmonServer).SubscribeMonReceives(m, &tapmonSubscribeMonReceivesServer{stream})
+}
+
+type Tapmon_SubscribeMonReceivesServer interface {
+	Send(*MonReceive) error
+	grpc.ServerStream
+}
+
+type tapmonSubscribeMonReceivesServer struct {
+	grpc.ServerStream
+}
+
+func (x *tapmonSubscribeMonReceivesServer) Send(m *MonReceive) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Tapmon_ExportMonProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMonProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).ExportMonProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/ExportMonProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).ExportMonProof(ctx, req.(*ExportMonProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_FindMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).FindMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/FindMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).FindMatch(ctx, req.(*FindMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_ChallengeMon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChallengeMonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).ChallengeMon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/ChallengeMon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).ChallengeMon(ctx, req.(*ChallengeMonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_AcceptChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).AcceptChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/AcceptChallenge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).AcceptChallenge(ctx, req.(*AcceptChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_SubmitAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).SubmitAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/SubmitAction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).SubmitAction(ctx, req.(*SubmitActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_SubscribeMatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TapmonServer).SubscribeMatch(m, &tapmonSubscribeMatchServer{stream})
+}
+
+type Tapmon_SubscribeMatchServer interface {
+	Send(*MatchUpdate) error
+	grpc.ServerStream
+}
+
+type tapmonSubscribeMatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *tapmonSubscribeMatchServer) Send(m *MatchUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Tapmon_WatchMatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TapmonServer).WatchMatch(m, &tapmonWatchMatchServer{stream})
+}
+
+type Tapmon_WatchMatchServer interface {
+	Send(*MatchUpdate) error
+	grpc.ServerStream
+}
+
+type tapmonWatchMatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *tapmonWatchMatchServer) Send(m *MatchUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Tapmon_DisputeMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisputeMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).DisputeMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/DisputeMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).DisputeMatch(ctx, req.(*DisputeMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_OfferWager_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferWagerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).OfferWager(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/OfferWager",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).OfferWager(ctx, req.(*OfferWagerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_ListWagers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWagersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).ListWagers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/ListWagers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).ListWagers(ctx, req.(*ListWagersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_OfferAnte_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferAnteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).OfferAnte(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/OfferAnte",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).OfferAnte(ctx, req.(*OfferAnteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_ListAntes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAntesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).ListAntes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/ListAntes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).ListAntes(ctx, req.(*ListAntesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/GetLeaderboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_CreateTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).CreateTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/CreateTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).CreateTournament(ctx, req.(*CreateTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_RegisterTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).RegisterTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/RegisterTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).RegisterTournament(ctx, req.(*RegisterTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tapmon_GetTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapmonServer).GetTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapmonrpc.Tapmon/GetTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapmonServer).GetTournament(ctx, req.(*GetTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Tapmon_ServiceDesc is the grpc.ServiceDesc for Tapmon service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tapmon_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tapmonrpc.Tapmon",
+	HandlerType: (*TapmonServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMon",
+			Handler:    _Tapmon_GetMon_Handler,
+		},
+		{
+			MethodName: "ListOwnedMons",
+			Handler:    _Tapmon_ListOwnedMons_Handler,
+		},
+		{
+			MethodName: "ListAllMons",
+			Handler:    _Tapmon_ListAllMons_Handler,
+		},
+		{
+			MethodName: "MintMon",
+			Handler:    _Tapmon_MintMon_Handler,
+		},
+		{
+			MethodName: "LevelMon",
+			Handler:    _Tapmon_LevelMon_Handler,
+		},
+		{
+			MethodName: "BreedMon",
+			Handler:    _Tapmon_BreedMon_Handler,
+		},
+		{
+			MethodName: "GetMonHistory",
+			Handler:    _Tapmon_GetMonHistory_Handler,
+		},
+		{
+			MethodName: "NewMonAddress",
+			Handler:    _Tapmon_NewMonAddress_Handler,
+		},
+		{
+			MethodName: "SendMon",
+			Handler:    _Tapmon_SendMon_Handler,
+		},
+		{
+			MethodName: "ExportMonProof",
+			Handler:    _Tapmon_ExportMonProof_Handler,
+		},
+		{
+			MethodName: "FindMatch",
+			Handler:    _Tapmon_FindMatch_Handler,
+		},
+		{
+			MethodName: "ChallengeMon",
+			Handler:    _Tapmon_ChallengeMon_Handler,
+		},
+		{
+			MethodName: "AcceptChallenge",
+			Handler:    _Tapmon_AcceptChallenge_Handler,
+		},
+		{
+			MethodName: "SubmitAction",
+			Handler:    _Tapmon_SubmitAction_Handler,
+		},
+		{
+			MethodName: "DisputeMatch",
+			Handler:    _Tapmon_DisputeMatch_Handler,
+		},
+		{
+			MethodName: "OfferWager",
+			Handler:    _Tapmon_OfferWager_Handler,
+		},
+		{
+			MethodName: "ListWagers",
+			Handler:    _Tapmon_ListWagers_Handler,
+		},
+		{
+			MethodName: "OfferAnte",
+			Handler:    _Tapmon_OfferAnte_Handler,
+		},
+		{
+			MethodName: "ListAntes",
+			Handler:    _Tapmon_ListAntes_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _Tapmon_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "CreateTournament",
+			Handler:    _Tapmon_CreateTournament_Handler,
+		},
+		{
+			MethodName: "RegisterTournament",
+			Handler:    _Tapmon_RegisterTournament_Handler,
+		},
+		{
+			MethodName: "GetTournament",
+			Handler:    _Tapmon_GetTournament_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeMonChanges",
+			Handler:       _Tapmon_SubscribeMonChanges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMonReceives",
+			Handler:       _Tapmon_SubscribeMonReceives_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMatch",
+			Handler:       _Tapmon_SubscribeMatch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchMatch",
+			Handler:       _Tapmon_WatchMatch_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "tapmonrpc.proto",
 }